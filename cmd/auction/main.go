@@ -135,7 +135,14 @@ func main() {
 	var commands application.CommandUseCase = svc
 	var queries application.QueryUseCase = svc
 
-	handler := auctionHTTP.NewHandler(commands, queries)
+	identityKeys, err := middleware.ParseIdentityKeys(config.AppConfig.InternalIdentityKeys)
+	if err != nil {
+		slog.Error("failed to load internal identity keys", "error", err)
+		os.Exit(1)
+	}
+	identity := middleware.NewIdentityVerifier(identityKeys, config.AppConfig.InternalIdentityTolerance)
+
+	handler := auctionHTTP.NewHandler(commands, queries, identity)
 
 	mux := server.NewRouter()
 
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -15,9 +16,14 @@ import (
 	"github.com/in-jun/go-structure-example/internal/auth/application"
 	"github.com/in-jun/go-structure-example/internal/auth/application/command"
 	"github.com/in-jun/go-structure-example/internal/auth/application/query"
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	domainService "github.com/in-jun/go-structure-example/internal/auth/domain/service"
+	authevent "github.com/in-jun/go-structure-example/internal/auth/infrastructure/event"
 	authjwt "github.com/in-jun/go-structure-example/internal/auth/infrastructure/jwt"
+	authmail "github.com/in-jun/go-structure-example/internal/auth/infrastructure/mail"
 	authpg "github.com/in-jun/go-structure-example/internal/auth/infrastructure/pg"
 	authredis "github.com/in-jun/go-structure-example/internal/auth/infrastructure/redis"
+	authworker "github.com/in-jun/go-structure-example/internal/auth/infrastructure/worker"
 	authhttp "github.com/in-jun/go-structure-example/internal/auth/interfaces/http"
 	"github.com/in-jun/go-structure-example/internal/shared/config"
 	"github.com/in-jun/go-structure-example/internal/shared/crypto"
@@ -25,7 +31,9 @@ import (
 	"github.com/in-jun/go-structure-example/internal/shared/health"
 	"github.com/in-jun/go-structure-example/internal/shared/logging"
 	"github.com/in-jun/go-structure-example/internal/shared/middleware"
+	sharedNats "github.com/in-jun/go-structure-example/internal/shared/nats"
 	"github.com/in-jun/go-structure-example/internal/shared/observability"
+	"github.com/in-jun/go-structure-example/internal/shared/outbox"
 	"github.com/in-jun/go-structure-example/internal/shared/server"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 
@@ -81,6 +89,17 @@ func main() {
 		}
 	}()
 
+	nc, err := sharedNats.NewConnection()
+	if err != nil {
+		slog.Error("failed to connect to NATS", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := nc.Drain(); err != nil {
+			slog.Warn("failed to drain NATS connection", "error", err)
+		}
+	}()
+
 	redisClient := goredis.NewClient(&goredis.Options{
 		Addr: config.AppConfig.RedisURL,
 	})
@@ -93,8 +112,14 @@ func main() {
 	dbGetter := transaction.NewDBGetter(db)
 	transactor := transaction.NewTransactor(db)
 
+	signingKeys, err := loadSigningKeys()
+	if err != nil {
+		slog.Error("failed to load JWT signing keys", "error", err)
+		os.Exit(1)
+	}
+
 	tokenGen, err := authjwt.NewProvider(
-		config.AppConfig.JWTSecret,
+		signingKeys,
 		config.AppConfig.JWTAccessExpiry,
 		config.AppConfig.JWTRefreshExpiry,
 	)
@@ -106,14 +131,41 @@ func main() {
 	hasher := crypto.NewBcryptPasswordHasher()
 	userRepo := authpg.NewUserRepository(dbGetter)
 	tokenRepo := authredis.NewTokenRepository(redisClient)
+	verificationRepo := authpg.NewEmailVerificationRepository(dbGetter)
+	emailOutbox := authpg.NewEmailOutbox(dbGetter)
+	verificationPolicy := domainService.NewVerificationPolicy(
+		config.AppConfig.EmailVerificationTTL,
+		config.AppConfig.EmailResendCooldown,
+		config.AppConfig.EmailVerifyURL,
+	)
+
+	pgPublisher := authevent.NewPublisher(dbGetter)
+	compositePublisher := authevent.NewCompositePublisher(pgPublisher, nc)
+
+	relay := outbox.NewRelay(db, nc, "auth")
+	go relay.Start(ctx)
+
+	mailer, err := newMailer()
+	if err != nil {
+		slog.Error("failed to create mailer", "error", err)
+		os.Exit(1)
+	}
+	mailDispatcher := authworker.NewMailDispatcher(
+		command.NewDispatchEmailsHandler(emailOutbox, mailer),
+		config.AppConfig.MailDispatchInterval,
+	)
+	go mailDispatcher.Start(ctx)
 
 	svc := application.NewService(
-		command.NewRegisterHandler(userRepo, hasher, transactor),
+		command.NewRegisterHandler(userRepo, verificationRepo, emailOutbox, hasher, verificationPolicy, compositePublisher, transactor),
+		command.NewVerifyEmailHandler(userRepo, verificationRepo, compositePublisher, transactor),
+		command.NewResendVerificationHandler(userRepo, verificationRepo, emailOutbox, verificationPolicy, transactor),
 		command.NewLoginHandler(userRepo, tokenRepo, tokenGen, hasher),
-		command.NewRefreshHandler(tokenRepo, tokenGen),
+		command.NewRefreshHandler(userRepo, tokenRepo, tokenGen),
 		command.NewLogoutHandler(tokenRepo, tokenGen),
 		command.NewLogoutAllHandler(tokenRepo, tokenGen),
 		query.NewValidateHandler(tokenRepo, tokenGen),
+		query.NewGetUserHandler(userRepo),
 	)
 
 	var commands application.CommandUseCase = svc
@@ -124,10 +176,19 @@ func main() {
 		if err != nil {
 			return nil, err
 		}
-		return &middleware.ValidateTokenResult{UserID: result.UserID, JTI: result.JTI, IssuedAt: result.IssuedAt}, nil
+		return &middleware.ValidateTokenResult{
+			UserID: result.UserID, JTI: result.JTI, IssuedAt: result.IssuedAt, Roles: result.Roles,
+		}, nil
 	})
 
-	handler := authhttp.NewHandler(commands, queries, tokenValidator)
+	identityKeys, err := middleware.ParseIdentityKeys(config.AppConfig.InternalIdentityKeys)
+	if err != nil {
+		slog.Error("failed to load internal identity keys", "error", err)
+		os.Exit(1)
+	}
+	identity := middleware.NewIdentityVerifier(identityKeys, config.AppConfig.InternalIdentityTolerance)
+
+	handler := authhttp.NewHandler(commands, queries, tokenValidator, identity)
 
 	mux := server.NewRouter()
 
@@ -145,10 +206,11 @@ func main() {
 
 	mux.Handle("GET /metrics", observability.MetricsHandler())
 
-	healthChecker := health.NewChecker(db, nil).WithRedis(redisClient).WithBuildInfo(Version, BuildTime, GitCommit)
+	healthChecker := health.NewChecker(db, nc).WithRedis(redisClient).WithBuildInfo(Version, BuildTime, GitCommit)
 	healthChecker.RegisterRoutes(mux)
 
 	handler.RegisterRoutes(mux, stack)
+	signingKeys.RegisterRoutes(mux, stack)
 
 	srv := &http.Server{
 		Addr:         ":" + config.AppConfig.AppPort,
@@ -178,3 +240,29 @@ func main() {
 
 	slog.Info("service stopped")
 }
+
+// loadSigningKeys falls back to a throwaway key when none are configured so
+// the service runs locally without key files.
+func loadSigningKeys() (*authjwt.KeySet, error) {
+	if config.AppConfig.JWTSigningKeys == "" {
+		slog.Warn("JWT_SIGNING_KEYS is not set; signing with an ephemeral key")
+		return authjwt.GenerateKeySet()
+	}
+	return authjwt.LoadKeySet(config.AppConfig.JWTSigningKeys)
+}
+
+func newMailer() (domain.Mailer, error) {
+	switch config.AppConfig.Mailer {
+	case "smtp":
+		return authmail.NewSMTPMailer(
+			config.AppConfig.SMTPAddr,
+			config.AppConfig.SMTPUsername,
+			config.AppConfig.SMTPPassword,
+			config.AppConfig.MailFrom,
+		), nil
+	case "log":
+		return authmail.NewLogMailer(config.AppConfig.MailDir, config.AppConfig.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("unknown MAILER %q", config.AppConfig.Mailer)
+	}
+}
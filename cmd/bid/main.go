@@ -12,6 +12,8 @@ import (
 
 	_ "go.uber.org/automaxprocs"
 
+	goredis "github.com/go-redis/redis/v8"
+
 	"github.com/in-jun/go-structure-example/internal/shared/config"
 	"github.com/in-jun/go-structure-example/internal/shared/database"
 	"github.com/in-jun/go-structure-example/internal/shared/health"
@@ -30,6 +32,8 @@ import (
 	auctionGRPC "github.com/in-jun/go-structure-example/internal/bid/infrastructure/grpc"
 	bidNats "github.com/in-jun/go-structure-example/internal/bid/infrastructure/nats"
 	"github.com/in-jun/go-structure-example/internal/bid/infrastructure/pg"
+	bidRedis "github.com/in-jun/go-structure-example/internal/bid/infrastructure/redis"
+	"github.com/in-jun/go-structure-example/internal/bid/infrastructure/worker"
 	"github.com/in-jun/go-structure-example/internal/shared/outbox"
 	bidHTTP "github.com/in-jun/go-structure-example/internal/bid/interfaces/http"
 )
@@ -93,10 +97,24 @@ func main() {
 		}
 	}()
 
+	redisClient := goredis.NewClient(&goredis.Options{
+		Addr: config.AppConfig.RedisURL,
+	})
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			slog.Warn("failed to close Redis client", "error", err)
+		}
+	}()
+
 	dbGetter := transaction.NewDBGetter(pgDB)
 	transactor := transaction.NewTransactor(pgDB)
 
 	bidRepo := pg.NewBidRepository(dbGetter)
+	offerRepo := pg.NewOfferRepository(dbGetter)
+	flagRepo := pg.NewFlagRepository(dbGetter)
+	signalRepo := pg.NewFraudSignalRepository(dbGetter)
+	highestCache := bidRedis.NewHighestBidCache(redisClient, config.AppConfig.HighestBidCacheTTL)
+	statsCache := bidRedis.NewBidStatsCache(redisClient, 24*time.Hour)
 	eventReader := event.NewReader(dbGetter)
 	auctionClient, err := auctionGRPC.NewAuctionClient(config.AppConfig.AuctionGRPCAddress)
 	if err != nil {
@@ -104,17 +122,34 @@ func main() {
 		os.Exit(1)
 	}
 	bidPolicy := &service.BidPolicy{}
+	fraudPolicy := &service.FraudPolicy{}
+	secondChancePolicy := &service.SecondChancePolicy{MaxOffers: config.AppConfig.SecondChanceOffers}
+	if config.AppConfig.BidPseudonymSecret == "" {
+		slog.Error("BID_PSEUDONYM_SECRET is required")
+		os.Exit(1)
+	}
+	pseudonymizer := service.NewPseudonymizer([]byte(config.AppConfig.BidPseudonymSecret))
 
 	pgPublisher := event.NewPublisher(dbGetter)
 	compositePublisher := event.NewCompositePublisher(pgPublisher, nc)
 
-	placeBidHandler := command.NewPlaceBidHandler(bidRepo, auctionClient, bidPolicy, compositePublisher, transactor)
-	determineWinnerHandler := command.NewDetermineWinnerHandler(bidRepo, compositePublisher, transactor)
-	getHighestHandler := query.NewGetHighestHandler(bidRepo)
-	listBidsHandler := query.NewListBidsHandler(bidRepo)
-	eventHistoryHandler := query.NewEventHistoryHandler(eventReader)
-
-	consumer := bidNats.NewConsumer(nc, determineWinnerHandler, dbGetter, transactor)
+	placeBidHandler := command.NewPlaceBidHandler(bidRepo, auctionClient, bidPolicy, compositePublisher, highestCache, transactor)
+	determineWinnerHandler := command.NewDetermineWinnerHandler(bidRepo, offerRepo, compositePublisher, transactor)
+	offerSecondChanceHandler := command.NewOfferSecondChanceHandler(bidRepo, offerRepo, secondChancePolicy, compositePublisher, transactor)
+	detectFraudHandler := command.NewDetectFraudHandler(signalRepo, flagRepo, fraudPolicy, compositePublisher, transactor)
+	recordAuctionSellerHandler := command.NewRecordAuctionSellerHandler(signalRepo)
+	recordBidderAccountHandler := command.NewRecordBidderAccountHandler(signalRepo)
+	getHighestHandler := query.NewGetHighestHandler(bidRepo, highestCache, auctionClient, pseudonymizer)
+	listBidsHandler := query.NewListBidsHandler(bidRepo, auctionClient, pseudonymizer)
+	eventHistoryHandler := query.NewEventHistoryHandler(eventReader, bidRepo, auctionClient, pseudonymizer)
+	getStatsHandler := query.NewGetStatsHandler(bidRepo, statsCache, auctionClient)
+	listFlaggedHandler := query.NewListFlaggedAuctionsHandler(flagRepo)
+
+	consumer := bidNats.NewConsumer(
+		nc, determineWinnerHandler, offerSecondChanceHandler,
+		recordAuctionSellerHandler, recordBidderAccountHandler,
+		dbGetter, transactor,
+	)
 	if err := consumer.Start(ctx); err != nil {
 		slog.Error("failed to start NATS consumer", "error", err)
 		os.Exit(1)
@@ -133,15 +168,25 @@ func main() {
 	relay := outbox.NewRelay(pgDB, nc, "bid")
 	go relay.Start(ctx)
 
+	fraudScanner := worker.NewFraudScanner(detectFraudHandler, config.AppConfig.FraudScanInterval)
+	go fraudScanner.Start(ctx)
+
 	svc := application.NewService(
-		placeBidHandler, determineWinnerHandler,
-		getHighestHandler, listBidsHandler, eventHistoryHandler,
+		placeBidHandler, determineWinnerHandler, offerSecondChanceHandler, detectFraudHandler,
+		getHighestHandler, listBidsHandler, eventHistoryHandler, getStatsHandler, listFlaggedHandler,
 	)
 
 	var commands application.CommandUseCase = svc
 	var queries application.QueryUseCase = svc
 
-	handler := bidHTTP.NewHandler(commands, queries)
+	identityKeys, err := middleware.ParseIdentityKeys(config.AppConfig.InternalIdentityKeys)
+	if err != nil {
+		slog.Error("failed to load internal identity keys", "error", err)
+		os.Exit(1)
+	}
+	identity := middleware.NewIdentityVerifier(identityKeys, config.AppConfig.InternalIdentityTolerance)
+
+	handler := bidHTTP.NewHandler(commands, queries, identity)
 
 	mux := server.NewRouter()
 
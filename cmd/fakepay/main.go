@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/infrastructure/gateway"
+	"github.com/in-jun/go-structure-example/internal/shared/config"
+	"github.com/in-jun/go-structure-example/internal/shared/logging"
+)
+
+func main() {
+	config.Load()
+	logging.Init("fakepay")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	provider := gateway.NewFakeProvider(gateway.FakeProviderConfig{
+		APIKey:        config.AppConfig.PaymentProviderAPIKey,
+		WebhookSecret: config.AppConfig.PaymentWebhookSecret,
+		WebhookURL:    config.AppConfig.FakePayWebhookURL,
+		WebhookDelay:  config.AppConfig.FakePayWebhookDelay,
+		DeclineAbove:  int64(config.AppConfig.FakePayDeclineAbove),
+	})
+
+	srv := &http.Server{
+		Addr:         ":" + config.AppConfig.AppPort,
+		Handler:      provider.Handler(),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	go func() {
+		slog.Info("fake payment provider starting", "port", config.AppConfig.AppPort, "webhook_url", config.AppConfig.FakePayWebhookURL)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	slog.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.AppConfig.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("server forced to shutdown", "error", err)
+	}
+}
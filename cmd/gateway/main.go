@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/in-jun/go-structure-example/internal/shared/config"
 	"github.com/in-jun/go-structure-example/internal/shared/health"
+	"github.com/in-jun/go-structure-example/internal/shared/jwks"
 	"github.com/in-jun/go-structure-example/internal/shared/logging"
 	"github.com/in-jun/go-structure-example/internal/shared/middleware"
 	"github.com/in-jun/go-structure-example/internal/shared/observability"
@@ -37,7 +39,7 @@ type serviceProxy struct {
 	name  string
 }
 
-func newServiceProxy(name string, rawURL string, timeout time.Duration, maxRetries int) *serviceProxy {
+func newServiceProxy(name string, rawURL string, timeout time.Duration, maxRetries int, signer *middleware.IdentitySigner) *serviceProxy {
 	target, _ := url.Parse(rawURL)
 
 	base := &http.Transport{
@@ -52,7 +54,7 @@ func newServiceProxy(name string, rawURL string, timeout time.Duration, maxRetri
 		TLSHandshakeTimeout:   5 * time.Second,
 	}
 
-	retry := middleware.NewRetryTransport(base, maxRetries)
+	retry := middleware.NewRetryTransport(signer.Transport(base), maxRetries)
 	cb := middleware.NewCircuitBreakerTransport(retry, name)
 
 	proxy := httputil.NewSingleHostReverseProxy(target)
@@ -96,7 +98,8 @@ func isTokenBlacklisted(ctx context.Context, redisClient *goredis.Client, jti st
 }
 
 type jwtClaims struct {
-	UserID string `json:"user_id"`
+	UserID string   `json:"user_id"`
+	Roles  []string `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -135,13 +138,21 @@ func main() {
 		}
 	}()
 
-	secretKey := []byte(config.AppConfig.JWTSecret)
+	// The gateway only verifies tokens: it holds the auth service's public
+	// keys, never anything that could mint one.
+	jwksURL := config.AppConfig.JWKSURL
+	if jwksURL == "" {
+		jwksURL = strings.TrimSuffix(config.AppConfig.AuthServiceURL, "/") + "/.well-known/jwks.json"
+	}
+	keys := jwks.NewCache(jwksURL, config.AppConfig.JWKSCacheTTL, &http.Client{Timeout: 5 * time.Second})
+
 	tokenValidator := middleware.TokenValidator(func(ctx context.Context, tokenString string) (*middleware.ValidateTokenResult, error) {
 		token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return secretKey, nil
+			kid, _ := token.Header["kid"].(string)
+			return keys.Key(ctx, kid)
 		})
 		if err != nil {
 			return nil, err
@@ -164,13 +175,21 @@ func main() {
 			UserID:   c.UserID,
 			JTI:      c.ID,
 			IssuedAt: issuedAt,
+			Roles:    c.Roles,
 		}, nil
 	})
 
-	authSvc := newServiceProxy("auth", config.AppConfig.AuthServiceURL, 5*time.Second, 2)
-	auctionSvc := newServiceProxy("auction", config.AppConfig.AuctionServiceURL, 10*time.Second, 3)
-	bidSvc := newServiceProxy("bid", config.AppConfig.BidServiceURL, 10*time.Second, 3)
-	paymentSvc := newServiceProxy("payment", config.AppConfig.PaymentServiceURL, 30*time.Second, 2)
+	identityKeys, err := middleware.ParseIdentityKeys(config.AppConfig.InternalIdentityKeys)
+	if err != nil {
+		slog.Error("failed to load internal identity keys", "error", err)
+		os.Exit(1)
+	}
+	identitySigner := middleware.NewIdentitySigner(identityKeys)
+
+	authSvc := newServiceProxy("auth", config.AppConfig.AuthServiceURL, 5*time.Second, 2, identitySigner)
+	auctionSvc := newServiceProxy("auction", config.AppConfig.AuctionServiceURL, 10*time.Second, 3, identitySigner)
+	bidSvc := newServiceProxy("bid", config.AppConfig.BidServiceURL, 10*time.Second, 3, identitySigner)
+	paymentSvc := newServiceProxy("payment", config.AppConfig.PaymentServiceURL, 30*time.Second, 2, identitySigner)
 
 	mux := server.NewRouter()
 
@@ -200,10 +219,14 @@ func main() {
 	idempotencyMw := middleware.Idempotency(redisClient)
 	injectUserID := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Header.Set("X-User-ID", server.UserID(r))
-			r.Header.Set("X-Token-JTI", server.TokenJTI(r))
-			r.Header.Set("X-Token-IAT", strconv.FormatInt(server.TokenIssuedAt(r), 10))
-			next.ServeHTTP(w, r)
+			ctx := middleware.ContextWithIdentity(r.Context(), middleware.Identity{
+				UserID:    server.UserID(r),
+				JTI:       server.TokenJTI(r),
+				IssuedAt:  server.TokenIssuedAt(r),
+				Roles:     server.Roles(r),
+				RequestID: logging.RequestIDFromContext(r.Context()),
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 
@@ -215,12 +238,22 @@ func main() {
 		return stack(authMw(injectUserID(sp.proxy)))
 	}
 
+	// Public reads that personalize the response when a token is present
+	optionalAuthedProxy := func(sp *serviceProxy) http.Handler {
+		return stack(middleware.OptionalAuth(tokenValidator)(injectUserID(sp.proxy)))
+	}
+
+	bidRateLimit := middleware.BidRateLimit(redisClient, config.AppConfig.BidRateLimit, config.AppConfig.BidRateLimitWindow)
+
 	// Auth routes
 	mux.Handle("POST /api/v1/auth/register", publicProxy(authSvc))
+	mux.Handle("POST /api/v1/auth/verify-email", publicProxy(authSvc))
+	mux.Handle("POST /api/v1/auth/verify-email/resend", authedNoIdempotency(authSvc))
 	mux.Handle("POST /api/v1/auth/login", publicProxy(authSvc))
 	mux.Handle("POST /api/v1/auth/refresh", publicProxy(authSvc))
 	mux.Handle("POST /api/v1/auth/logout", authedNoIdempotency(authSvc))
 	mux.Handle("POST /api/v1/auth/logout/all", authedNoIdempotency(authSvc))
+	mux.Handle("GET /.well-known/jwks.json", publicProxy(authSvc))
 
 	// Auction routes (authed write, public read)
 	mux.Handle("POST /api/v1/auctions", authedProxy(auctionSvc))
@@ -230,18 +263,41 @@ func main() {
 	mux.Handle("GET /api/v1/auctions", publicProxy(auctionSvc))
 	mux.Handle("GET /api/v1/auctions/{id}", publicProxy(auctionSvc))
 	mux.Handle("GET /api/v1/auctions/{id}/events", publicProxy(auctionSvc))
+	mux.Handle("POST /api/v1/admin/auctions/{id}/cancel", authedProxy(auctionSvc))
 
 	// Bid routes
-	mux.Handle("POST /api/v1/auctions/{id}/bids", authedProxy(bidSvc))
-	mux.Handle("GET /api/v1/auctions/{id}/bids", publicProxy(bidSvc))
-	mux.Handle("GET /api/v1/auctions/{id}/bids/highest", publicProxy(bidSvc))
-	mux.Handle("GET /api/v1/auctions/{id}/bids/events", publicProxy(bidSvc))
+	// The limiter sits inside the idempotency middleware so cached replays of a
+	// bid do not count against the bidder's limit.
+	mux.Handle("POST /api/v1/auctions/{id}/bids", stack(authMw(idempotencyMw(bidRateLimit(injectUserID(bidSvc.proxy))))))
+	mux.Handle("GET /api/v1/auctions/{id}/bids", optionalAuthedProxy(bidSvc))
+	mux.Handle("GET /api/v1/auctions/{id}/bids/highest", optionalAuthedProxy(bidSvc))
+	mux.Handle("GET /api/v1/auctions/{id}/bids/events", optionalAuthedProxy(bidSvc))
+	mux.Handle("GET /api/v1/auctions/{id}/bids/stats", publicProxy(bidSvc))
+	mux.Handle("GET /api/v1/admin/flagged-auctions", authedNoIdempotency(bidSvc))
 
 	// Payment routes
 	mux.Handle("POST /api/v1/payments/{id}/confirm", authedProxy(paymentSvc))
+	mux.Handle("POST /api/v1/payments/{id}/confirm-delivery", authedProxy(paymentSvc))
 	mux.Handle("POST /api/v1/payments/{id}/refund", authedProxy(paymentSvc))
 	mux.Handle("GET /api/v1/payments/{id}", authedNoIdempotency(paymentSvc))
 	mux.Handle("GET /api/v1/payments/{id}/events", authedNoIdempotency(paymentSvc))
+	mux.Handle("GET /api/v1/payments/{id}/invoice", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/payments/webhooks/{provider}", publicProxy(paymentSvc))
+	mux.Handle("GET /api/v1/me/payments", authedNoIdempotency(paymentSvc))
+	mux.Handle("GET /api/v1/auctions/{id}/payment", authedNoIdempotency(paymentSvc))
+	mux.Handle("GET /api/v1/me/balance", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/me/payouts", authedProxy(paymentSvc))
+	mux.Handle("POST /api/v1/payments/{id}/disputes", authedProxy(paymentSvc))
+	mux.Handle("GET /api/v1/disputes/{id}", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/disputes/{id}/respond", authedProxy(paymentSvc))
+	mux.Handle("POST /api/v1/me/payment-methods", authedProxy(paymentSvc))
+	mux.Handle("GET /api/v1/me/payment-methods", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/me/payment-methods/{id}/default", authedProxy(paymentSvc))
+	mux.Handle("DELETE /api/v1/me/payment-methods/{id}", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/admin/payments/{id}/refund", authedProxy(paymentSvc))
+	mux.Handle("GET /api/v1/admin/reconciliation-issues", authedNoIdempotency(paymentSvc))
+	mux.Handle("GET /api/v1/admin/disputes", authedNoIdempotency(paymentSvc))
+	mux.Handle("POST /api/v1/admin/disputes/{id}/resolve", authedProxy(paymentSvc))
 
 	srv := &http.Server{
 		Addr:         ":" + config.AppConfig.AppPort,
@@ -26,11 +26,15 @@ import (
 	"github.com/in-jun/go-structure-example/internal/payment/application"
 	"github.com/in-jun/go-structure-example/internal/payment/application/command"
 	"github.com/in-jun/go-structure-example/internal/payment/application/query"
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
 	"github.com/in-jun/go-structure-example/internal/payment/infrastructure/event"
 	"github.com/in-jun/go-structure-example/internal/payment/infrastructure/gateway"
+	auctionGRPC "github.com/in-jun/go-structure-example/internal/payment/infrastructure/grpc"
+	authHTTP "github.com/in-jun/go-structure-example/internal/payment/infrastructure/http"
 	paymentNats "github.com/in-jun/go-structure-example/internal/payment/infrastructure/nats"
 	"github.com/in-jun/go-structure-example/internal/payment/infrastructure/pg"
+	"github.com/in-jun/go-structure-example/internal/payment/infrastructure/worker"
 	paymentHTTP "github.com/in-jun/go-structure-example/internal/payment/interfaces/http"
 )
 
@@ -98,20 +102,138 @@ func main() {
 	transactor := transaction.NewTransactor(pgDB)
 
 	paymentRepo := pg.NewPaymentRepository(dbGetter)
+	refundRepo := pg.NewRefundRepository(dbGetter)
+	ledgerRepo := pg.NewLedgerRepository(dbGetter)
+	payoutRepo := pg.NewPayoutRepository(dbGetter)
+	issueRepo := pg.NewReconciliationRepository(dbGetter)
+	disputeRepo := pg.NewDisputeRepository(dbGetter)
+	methodRepo := pg.NewPaymentMethodRepository(dbGetter)
+	invoiceRepo := pg.NewInvoiceRepository(dbGetter)
 	eventReader := event.NewReader(dbGetter)
-	mockGW := gateway.NewMockGateway()
-	processor := service.NewPaymentProcessor(mockGW)
+	auctionClient, err := auctionGRPC.NewAuctionClient(config.AppConfig.AuctionGRPCAddress)
+	if err != nil {
+		slog.Error("failed to create auction gRPC client", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := auctionClient.Close(); err != nil {
+			slog.Warn("failed to close auction client", "error", err)
+		}
+	}()
+	identityKeys, err := middleware.ParseIdentityKeys(config.AppConfig.InternalIdentityKeys)
+	if err != nil {
+		slog.Error("failed to load internal identity keys", "error", err)
+		os.Exit(1)
+	}
+	userClient := authHTTP.NewUserClient(config.AppConfig.AuthServiceURL, middleware.NewIdentitySigner(identityKeys))
+	var paymentGW domain.PaymentGateway
+	var methodGW domain.PaymentMethodGateway
+	var fakeGW *gateway.FakeGateway
+	verifiers := map[string]domain.WebhookVerifier{}
+	switch config.AppConfig.PaymentGateway {
+	case "http":
+		if config.AppConfig.PaymentWebhookSecret == "" {
+			slog.Error("PAYMENT_WEBHOOK_SECRET is required for the http payment gateway")
+			os.Exit(1)
+		}
+		httpGW := gateway.NewHTTPGateway(gateway.HTTPGatewayConfig{
+			BaseURL:    config.AppConfig.PaymentProviderURL,
+			APIKey:     config.AppConfig.PaymentProviderAPIKey,
+			Timeout:    config.AppConfig.PaymentProviderTimeout,
+			MaxRetries: config.AppConfig.PaymentProviderRetries,
+		})
+		paymentGW, methodGW = httpGW, httpGW
+		verifiers[config.AppConfig.PaymentProvider] = gateway.NewHMACWebhookVerifier(
+			config.AppConfig.PaymentWebhookSecret, config.AppConfig.PaymentWebhookTolerance,
+		)
+	default:
+		scenarios, err := gateway.ParseFakeScenarios(config.AppConfig.FakeGatewayScenarios)
+		if err != nil {
+			slog.Error("invalid FAKE_GATEWAY_SCENARIOS", "error", err)
+			os.Exit(1)
+		}
+		fakeGW = gateway.NewFakeGateway(gateway.FakeGatewayConfig{
+			Scenarios:    scenarios,
+			TimeoutAfter: config.AppConfig.FakeGatewayTimeout,
+			SettleAfter:  config.AppConfig.FakeGatewaySettleDelay,
+		})
+		paymentGW, methodGW = fakeGW, fakeGW
+		verifiers[gateway.FakeProviderName] = fakeGW.Verifier()
+	}
+	processor := service.NewPaymentProcessor(paymentGW)
+	payoutGW := gateway.NewFakePayoutGateway()
+	ledgerPolicy := service.NewLedgerPolicy()
+	feeRule, err := service.ParseFeeRule(config.AppConfig.PlatformFee)
+	if err != nil {
+		slog.Error("invalid PLATFORM_FEE", "error", err)
+		os.Exit(1)
+	}
+	feeCategories, err := service.ParseFeeCategories(config.AppConfig.PlatformFeeCategories)
+	if err != nil {
+		slog.Error("invalid PLATFORM_FEE_CATEGORIES", "error", err)
+		os.Exit(1)
+	}
+	feePolicy := service.NewFeePolicy(feeRule, feeCategories)
+	deadlinePolicy := service.NewDeadlinePolicy(config.AppConfig.PaymentDueAfter, config.AppConfig.PaymentReminderOffsets)
+	refundRetryPolicy := service.NewRefundRetryPolicy(
+		config.AppConfig.PaymentRefundRetryBaseDelay, config.AppConfig.PaymentRefundRetryMaxDelay,
+		config.AppConfig.PaymentRefundMaxAttempts,
+	)
+
+	disputePolicy := service.NewDisputePolicy(
+		config.AppConfig.DisputeOpenWindow, config.AppConfig.DisputeResponseWindow, config.AppConfig.DisputeReviewWindow,
+	)
 
 	pgPublisher := event.NewPublisher(dbGetter)
 	compositePublisher := event.NewCompositePublisher(pgPublisher, nc)
 
-	createPaymentHandler := command.NewCreatePaymentHandler(paymentRepo, compositePublisher, transactor)
-	confirmPaymentHandler := command.NewConfirmPaymentHandler(paymentRepo, processor, compositePublisher, transactor)
-	refundPaymentHandler := command.NewRefundPaymentHandler(paymentRepo, processor, compositePublisher, transactor)
+	createPaymentHandler := command.NewCreatePaymentHandler(
+		paymentRepo, auctionClient, deadlinePolicy, feePolicy, config.AppConfig.PaymentEscrowHold, compositePublisher, transactor,
+	)
+	confirmPaymentHandler := command.NewConfirmPaymentHandler(paymentRepo, methodRepo, processor, compositePublisher, transactor)
+	refundPaymentHandler := command.NewRefundPaymentHandler(paymentRepo, refundRepo, processor, refundRetryPolicy, compositePublisher, transactor)
+	retryRefundsHandler := command.NewRetryRefundsHandler(paymentRepo, refundRepo, processor, refundRetryPolicy, compositePublisher, transactor)
+	enforceDeadlinesHandler := command.NewEnforceDeadlinesHandler(paymentRepo, deadlinePolicy, compositePublisher, transactor)
+	confirmDeliveryHandler := command.NewConfirmDeliveryHandler(paymentRepo, disputeRepo, compositePublisher, transactor)
+	releaseEscrowsHandler := command.NewReleaseEscrowsHandler(paymentRepo, disputeRepo, compositePublisher, transactor)
+	recoverPaymentsHandler := command.NewRecoverPaymentsHandler(paymentRepo, methodRepo, processor, compositePublisher, transactor)
+	handleWebhookHandler := command.NewHandleWebhookHandler(paymentRepo, processor, verifiers, compositePublisher, transactor)
+	recordSaleHandler := command.NewRecordSaleHandler(paymentRepo, ledgerRepo, auctionClient, ledgerPolicy, transactor)
+	recordRefundHandler := command.NewRecordRefundHandler(paymentRepo, refundRepo, ledgerRepo, auctionClient, ledgerPolicy, transactor)
+	reconcilePaymentsHandler := command.NewReconcilePaymentsHandler(
+		paymentRepo, refundRepo, issueRepo, paymentGW, service.NewReconciliationPolicy(), compositePublisher, transactor,
+	)
+	issueInvoiceHandler := command.NewIssueInvoiceHandler(
+		paymentRepo, invoiceRepo, auctionClient, userClient, int64(config.AppConfig.InvoiceTaxBasisPoints),
+		compositePublisher, transactor,
+	)
+	payoutSellerHandler := command.NewPayoutSellerHandler(
+		payoutRepo, ledgerRepo, disputeRepo, payoutGW, ledgerPolicy, compositePublisher, transactor,
+	)
+	openDisputeHandler := command.NewOpenDisputeHandler(
+		paymentRepo, disputeRepo, ledgerRepo, auctionClient, disputePolicy, compositePublisher, transactor,
+	)
+	respondDisputeHandler := command.NewRespondDisputeHandler(disputeRepo, disputePolicy, compositePublisher, transactor)
+	resolveDisputeHandler := command.NewResolveDisputeHandler(
+		disputeRepo, paymentRepo, refundRepo, processor, refundRetryPolicy, compositePublisher, transactor,
+	)
+	enforceDisputeDeadlinesHandler := command.NewEnforceDisputeDeadlinesHandler(disputeRepo, disputePolicy, compositePublisher, transactor)
 	getPaymentHandler := query.NewGetPaymentHandler(paymentRepo)
 	eventHistoryHandler := query.NewEventHistoryHandler(eventReader)
-
-	consumer := paymentNats.NewConsumer(nc, createPaymentHandler, dbGetter, transactor)
+	listPaymentsHandler := query.NewListPaymentsHandler(paymentRepo)
+	auctionPaymentHandler := query.NewGetAuctionPaymentHandler(paymentRepo, auctionClient)
+	getBalanceHandler := query.NewGetBalanceHandler(ledgerRepo)
+	listIssuesHandler := query.NewListReconciliationIssuesHandler(issueRepo)
+	getInvoiceHandler := query.NewGetInvoiceHandler(invoiceRepo)
+	getDisputeHandler := query.NewGetDisputeHandler(disputeRepo)
+	listDisputesHandler := query.NewListDisputesHandler(disputeRepo)
+	addMethodHandler := command.NewAddPaymentMethodHandler(methodRepo, methodGW, transactor)
+	setDefaultMethodHandler := command.NewSetDefaultPaymentMethodHandler(methodRepo, transactor)
+	removeMethodHandler := command.NewRemovePaymentMethodHandler(methodRepo, transactor)
+	listMethodsHandler := query.NewListPaymentMethodsHandler(methodRepo)
+
+	consumer := paymentNats.NewConsumer(nc, createPaymentHandler, recordSaleHandler, recordRefundHandler, issueInvoiceHandler,
+		dbGetter, transactor)
 	if err := consumer.Start(ctx); err != nil {
 		slog.Error("failed to start NATS consumer", "error", err)
 		os.Exit(1)
@@ -125,12 +247,54 @@ func main() {
 	relay := outbox.NewRelay(pgDB, nc, "payment")
 	go relay.Start(ctx)
 
-	svc := application.NewService(createPaymentHandler, confirmPaymentHandler, refundPaymentHandler, getPaymentHandler, eventHistoryHandler)
+	recovery := worker.NewPaymentRecovery(
+		recoverPaymentsHandler, config.AppConfig.PaymentRecoveryInterval,
+		config.AppConfig.PaymentRecoveryStaleAfter, config.AppConfig.PaymentRecoveryGiveUpAfter,
+	)
+	go recovery.Start(ctx)
+
+	deadlineEnforcer := worker.NewDeadlineEnforcer(enforceDeadlinesHandler, config.AppConfig.PaymentDeadlineInterval)
+	go deadlineEnforcer.Start(ctx)
+
+	refundRetrier := worker.NewRefundRetrier(retryRefundsHandler, config.AppConfig.PaymentRefundRetryInterval)
+	go refundRetrier.Start(ctx)
+
+	reconciler := worker.NewReconciler(
+		reconcilePaymentsHandler, config.AppConfig.PaymentReconcileInterval,
+		config.AppConfig.PaymentReconcileWindow, config.AppConfig.PaymentReconcileLag,
+	)
+	go reconciler.Start(ctx)
+
+	escrowReleaser := worker.NewEscrowReleaser(releaseEscrowsHandler, config.AppConfig.PaymentEscrowReleaseInterval)
+	go escrowReleaser.Start(ctx)
+
+	disputeDeadlines := worker.NewDisputeDeadlineEnforcer(enforceDisputeDeadlinesHandler, config.AppConfig.DisputeDeadlineInterval)
+	go disputeDeadlines.Start(ctx)
+
+	svc := application.NewService(
+		createPaymentHandler, confirmPaymentHandler, refundPaymentHandler, handleWebhookHandler, payoutSellerHandler,
+		getPaymentHandler, eventHistoryHandler, listPaymentsHandler, auctionPaymentHandler, getBalanceHandler,
+		listIssuesHandler, getInvoiceHandler, confirmDeliveryHandler,
+		openDisputeHandler, respondDisputeHandler, resolveDisputeHandler, getDisputeHandler, listDisputesHandler,
+		addMethodHandler, setDefaultMethodHandler, removeMethodHandler, listMethodsHandler,
+	)
 
 	var commands application.CommandUseCase = svc
 	var queries application.QueryUseCase = svc
 
-	handler := paymentHTTP.NewHandler(commands, queries)
+	identity := middleware.NewIdentityVerifier(identityKeys, config.AppConfig.InternalIdentityTolerance)
+
+	handler := paymentHTTP.NewHandler(commands, queries, identity)
+
+	if fakeGW != nil {
+		fakeGW.OnWebhook(func(ctx context.Context, payload []byte, signature string) error {
+			return commands.HandleWebhook(ctx, command.HandleWebhook{
+				Provider:  gateway.FakeProviderName,
+				Payload:   payload,
+				Signature: signature,
+			})
+		})
+	}
 
 	mux := server.NewRouter()
 
@@ -152,6 +316,9 @@ func main() {
 	healthChecker.RegisterRoutes(mux)
 
 	handler.RegisterRoutes(mux, stack)
+	if fakeGW != nil {
+		gateway.NewFakeGatewayAdmin(fakeGW, identity).RegisterRoutes(mux, stack)
+	}
 
 	srv := &http.Server{
 		Addr:         ":" + config.AppConfig.AppPort,
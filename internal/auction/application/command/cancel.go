@@ -12,6 +12,7 @@ import (
 
 type Cancel struct {
 	UserID    string // empty = system-initiated (NATS), non-empty = user-initiated (requires ownership)
+	Admin     bool   // admins may cancel auctions they do not own
 	AuctionID string
 }
 
@@ -39,7 +40,7 @@ func (h *CancelHandler) Handle(ctx context.Context, cmd Cancel) error {
 		if auction == nil {
 			return errors.NotFound("Auction not found")
 		}
-		if cmd.UserID != "" && !auction.IsOwnedBy(cmd.UserID) {
+		if cmd.UserID != "" && !cmd.Admin && !auction.IsOwnedBy(cmd.UserID) {
 			return errors.Forbidden("Not authorized")
 		}
 
@@ -16,6 +16,7 @@ type Create struct {
 	UserID      string
 	Title       string
 	Description string
+	Category    string
 	StartPrice  int64
 	EndTime     time.Time
 }
@@ -25,6 +26,7 @@ type CreateResult struct {
 	SellerID    string
 	Title       string
 	Description string
+	Category    string
 	StartPrice  int64
 	Status      string
 	EndTime     time.Time
@@ -49,7 +51,7 @@ func (h *CreateHandler) Handle(ctx context.Context, cmd Create) (*CreateResult,
 		return nil, errors.BadRequest(err.Error())
 	}
 
-	cv, err := vo.NewCreateVO(cmd.Title, cmd.Description, cmd.StartPrice, cmd.EndTime)
+	cv, err := vo.NewCreateVO(cmd.Title, cmd.Description, cmd.Category, cmd.StartPrice, cmd.EndTime)
 	if err != nil {
 		return nil, errors.BadRequest(err.Error())
 	}
@@ -58,7 +60,7 @@ func (h *CreateHandler) Handle(ctx context.Context, cmd Create) (*CreateResult,
 		return nil, errors.BadRequest(err.Error())
 	}
 
-	auction, err := entity.NewAuction(sv.ID, cv.Title, cv.Description, cv.StartPrice, cv.EndTime)
+	auction, err := entity.NewAuction(sv.ID, cv.Title, cv.Description, cv.Category, cv.StartPrice, cv.EndTime)
 	if err != nil {
 		return nil, errors.BadRequest(err.Error())
 	}
@@ -75,7 +77,7 @@ func (h *CreateHandler) Handle(ctx context.Context, cmd Create) (*CreateResult,
 
 		result = &CreateResult{
 			ID: auction.ID(), SellerID: auction.SellerID(), Title: auction.Title(), Description: auction.Description(),
-			StartPrice: auction.StartPrice(), Status: auction.Status(), EndTime: auction.EndTime(),
+			Category: auction.Category(), StartPrice: auction.StartPrice(), Status: auction.Status(), EndTime: auction.EndTime(),
 			CreatedAt: auction.CreatedAt(), UpdatedAt: auction.UpdatedAt(),
 		}
 		return nil
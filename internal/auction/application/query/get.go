@@ -18,6 +18,7 @@ type Result struct {
 	SellerID    string
 	Title       string
 	Description string
+	Category    string
 	StartPrice  int64
 	Status      string
 	EndTime     time.Time
@@ -49,7 +50,7 @@ func (h *GetHandler) Handle(ctx context.Context, qry Get) (*Result, error) {
 
 	return &Result{
 		ID: auction.ID(), SellerID: auction.SellerID(), Title: auction.Title(),
-		Description: auction.Description(), StartPrice: auction.StartPrice(),
+		Description: auction.Description(), Category: auction.Category(), StartPrice: auction.StartPrice(),
 		Status: auction.Status(), EndTime: auction.EndTime(),
 		CreatedAt: auction.CreatedAt(), UpdatedAt: auction.UpdatedAt(),
 	}, nil
@@ -34,7 +34,7 @@ func (h *ListHandler) Handle(ctx context.Context, qry List) (*ListResult, error)
 	for i, a := range auctions {
 		results[i] = Result{
 			ID: a.ID(), SellerID: a.SellerID(), Title: a.Title(),
-			Description: a.Description(), StartPrice: a.StartPrice(),
+			Description: a.Description(), Category: a.Category(), StartPrice: a.StartPrice(),
 			Status: a.Status(), EndTime: a.EndTime(),
 			CreatedAt: a.CreatedAt(), UpdatedAt: a.UpdatedAt(),
 		}
@@ -87,6 +87,35 @@ func TestAuctionService_Create(t *testing.T) {
 	}
 }
 
+func TestAuctionService_Create_Category(t *testing.T) {
+	svc := newTestService(&mockAuctionRepo{})
+
+	result, err := svc.Create(context.Background(), command.Create{
+		UserID:     uuid.New().String(),
+		Title:      "Test Auction",
+		Category:   " Electronics ",
+		StartPrice: 1000,
+		EndTime:    time.Now().Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if result.Category != "electronics" {
+		t.Errorf("Category = %q, want %q", result.Category, "electronics")
+	}
+
+	_, err = svc.Create(context.Background(), command.Create{
+		UserID:     uuid.New().String(),
+		Title:      "Test Auction",
+		Category:   "arts & crafts",
+		StartPrice: 1000,
+		EndTime:    time.Now().Add(2 * time.Hour),
+	})
+	if err == nil {
+		t.Error("expected error for an invalid category")
+	}
+}
+
 func TestAuctionService_Create_InvalidInput(t *testing.T) {
 	svc := newTestService(&mockAuctionRepo{})
 
@@ -101,7 +130,7 @@ func TestAuctionService_Create_InvalidInput(t *testing.T) {
 
 func TestAuctionService_Open(t *testing.T) {
 	userID := uuid.New().String()
-	auction, _ := entity.NewAuction(userID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(userID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	err := svc.Open(context.Background(), command.Open{
@@ -116,7 +145,7 @@ func TestAuctionService_Open(t *testing.T) {
 func TestAuctionService_Open_NotOwner(t *testing.T) {
 	ownerID := uuid.New().String()
 	otherID := uuid.New().String()
-	auction, _ := entity.NewAuction(ownerID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(ownerID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	err := svc.Open(context.Background(), command.Open{
@@ -130,7 +159,7 @@ func TestAuctionService_Open_NotOwner(t *testing.T) {
 
 func TestAuctionService_Close(t *testing.T) {
 	userID := uuid.New().String()
-	auction, _ := entity.NewAuction(userID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(userID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -149,7 +178,7 @@ func TestAuctionService_Close(t *testing.T) {
 func TestAuctionService_GetByID(t *testing.T) {
 	userID := uuid.New().String()
 	now := time.Now()
-	auction := entity.ReconstructAuction(uuid.New().String(), userID, "Test", "Desc", 500, entity.StatusOpen, now.Add(24*time.Hour), now, now)
+	auction := entity.ReconstructAuction(uuid.New().String(), userID, "Test", "Desc", "", 500, entity.StatusOpen, now.Add(24*time.Hour), now, now)
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	result, err := svc.GetByID(context.Background(), query.Get{
@@ -169,8 +198,8 @@ func TestAuctionService_GetByID(t *testing.T) {
 func TestAuctionService_GetList(t *testing.T) {
 	userID := uuid.New().String()
 	now := time.Now()
-	a1 := entity.ReconstructAuction(uuid.New().String(), userID, "Auction 1", "", 100, entity.StatusOpen, now.Add(24*time.Hour), now, now)
-	a2 := entity.ReconstructAuction(uuid.New().String(), userID, "Auction 2", "", 200, entity.StatusDraft, now.Add(48*time.Hour), now, now)
+	a1 := entity.ReconstructAuction(uuid.New().String(), userID, "Auction 1", "", "", 100, entity.StatusOpen, now.Add(24*time.Hour), now, now)
+	a2 := entity.ReconstructAuction(uuid.New().String(), userID, "Auction 2", "", "", 200, entity.StatusDraft, now.Add(48*time.Hour), now, now)
 	repo := &mockAuctionRepo{auctions: []*entity.Auction{a1, a2}, total: 2}
 	svc := newTestService(repo)
 
@@ -188,7 +217,7 @@ func TestAuctionService_GetList(t *testing.T) {
 
 func TestAuctionService_Settle(t *testing.T) {
 	userID := uuid.New().String()
-	auction, _ := entity.NewAuction(userID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(userID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -206,7 +235,7 @@ func TestAuctionService_Settle(t *testing.T) {
 
 func TestAuctionService_Cancel(t *testing.T) {
 	userID := uuid.New().String()
-	auction, _ := entity.NewAuction(userID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(userID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	err := svc.Cancel(context.Background(), command.Cancel{AuctionID: auction.ID()})
@@ -217,7 +246,7 @@ func TestAuctionService_Cancel(t *testing.T) {
 
 func TestAuctionService_Cancel_ByOwner(t *testing.T) {
 	ownerID := uuid.New().String()
-	auction, _ := entity.NewAuction(ownerID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(ownerID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	err := svc.Cancel(context.Background(), command.Cancel{
@@ -232,7 +261,7 @@ func TestAuctionService_Cancel_ByOwner(t *testing.T) {
 func TestAuctionService_Cancel_NotOwner(t *testing.T) {
 	ownerID := uuid.New().String()
 	otherID := uuid.New().String()
-	auction, _ := entity.NewAuction(ownerID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(ownerID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	svc := newTestService(&mockAuctionRepo{auction: auction})
 
 	err := svc.Cancel(context.Background(), command.Cancel{
@@ -244,6 +273,20 @@ func TestAuctionService_Cancel_NotOwner(t *testing.T) {
 	}
 }
 
+func TestAuctionService_Cancel_ByAdmin(t *testing.T) {
+	auction, _ := entity.NewAuction(uuid.New().String(), "Test", "", "", 100, time.Now().Add(2*time.Hour))
+	svc := newTestService(&mockAuctionRepo{auction: auction})
+
+	err := svc.Cancel(context.Background(), command.Cancel{
+		UserID:    uuid.New().String(),
+		Admin:     true,
+		AuctionID: auction.ID(),
+	})
+	if err != nil {
+		t.Fatalf("Cancel() by admin error = %v", err)
+	}
+}
+
 func TestAuctionService_GetEvents(t *testing.T) {
 	svc := newTestService(&mockAuctionRepo{})
 
@@ -292,7 +335,7 @@ func TestAuctionService_Close_NotFound(t *testing.T) {
 func TestAuctionService_Close_NotOwner(t *testing.T) {
 	ownerID := uuid.New().String()
 	otherID := uuid.New().String()
-	auction, _ := entity.NewAuction(ownerID, "Test", "", 100, time.Now().Add(2*time.Hour))
+	auction, _ := entity.NewAuction(ownerID, "Test", "", "", 100, time.Now().Add(2*time.Hour))
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -33,6 +33,7 @@ type Auction struct {
 	sellerID    string
 	title       string
 	description string
+	category    string
 	startPrice  int64
 	status      string
 	endTime     time.Time
@@ -42,7 +43,7 @@ type Auction struct {
 	events []event.Event
 }
 
-func NewAuction(sellerID, title, description string, startPrice int64, endTime time.Time) (*Auction, error) {
+func NewAuction(sellerID, title, description, category string, startPrice int64, endTime time.Time) (*Auction, error) {
 	if sellerID == "" || title == "" {
 		return nil, errInvalidInput
 	}
@@ -58,19 +59,20 @@ func NewAuction(sellerID, title, description string, startPrice int64, endTime t
 		sellerID:    sellerID,
 		title:       title,
 		description: description,
+		category:    category,
 		startPrice:  startPrice,
 		status:      StatusDraft,
 		endTime:     endTime,
 		createdAt:   now,
 		updatedAt:   now,
 	}
-	a.record(event.NewAuctionCreated(a.id, sellerID, title, startPrice, endTime))
+	a.record(event.NewAuctionCreated(a.id, sellerID, title, category, startPrice, endTime))
 	return a, nil
 }
 
-func ReconstructAuction(id, sellerID, title, description string, startPrice int64, status string, endTime, createdAt, updatedAt time.Time) *Auction {
+func ReconstructAuction(id, sellerID, title, description, category string, startPrice int64, status string, endTime, createdAt, updatedAt time.Time) *Auction {
 	return &Auction{
-		id: id, sellerID: sellerID, title: title, description: description,
+		id: id, sellerID: sellerID, title: title, description: description, category: category,
 		startPrice: startPrice, status: status, endTime: endTime,
 		createdAt: createdAt, updatedAt: updatedAt,
 	}
@@ -80,6 +82,7 @@ func (a *Auction) ID() string          { return a.id }
 func (a *Auction) SellerID() string     { return a.sellerID }
 func (a *Auction) Title() string        { return a.title }
 func (a *Auction) Description() string  { return a.description }
+func (a *Auction) Category() string     { return a.category }
 func (a *Auction) StartPrice() int64    { return a.startPrice }
 func (a *Auction) Status() string       { return a.status }
 func (a *Auction) EndTime() time.Time   { return a.endTime }
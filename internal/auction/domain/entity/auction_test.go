@@ -15,7 +15,7 @@ func futureTime() time.Time {
 }
 
 func TestNewAuction(t *testing.T) {
-	auction, err := NewAuction(testSellerID, "Test Auction", "Description", 1000, futureTime())
+	auction, err := NewAuction(testSellerID, "Test Auction", "", "Description", 1000, futureTime())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -55,7 +55,7 @@ func TestNewAuction_Invariants(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewAuction(tt.sellerID, tt.title, "", tt.startPrice, tt.endTime)
+			_, err := NewAuction(tt.sellerID, tt.title, "", "", tt.startPrice, tt.endTime)
 			if err == nil {
 				t.Errorf("expected error for %s", tt.name)
 			}
@@ -64,7 +64,7 @@ func TestNewAuction_Invariants(t *testing.T) {
 }
 
 func TestAuction_IsOwnedBy(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 
 	if !auction.IsOwnedBy(testSellerID) {
 		t.Error("expected IsOwnedBy to return true for owner")
@@ -75,7 +75,7 @@ func TestAuction_IsOwnedBy(t *testing.T) {
 }
 
 func TestAuction_Open(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 	auction.ClearEvents()
 
 	if err := auction.Open(); err != nil {
@@ -95,7 +95,7 @@ func TestAuction_Open(t *testing.T) {
 
 func TestAuction_Open_ExpiredEndTime(t *testing.T) {
 	pastTime := time.Now().Add(-1 * time.Hour)
-	auction := ReconstructAuction(uuid.New().String(), testSellerID, "Test", "", 100, StatusDraft, pastTime, time.Now(), time.Now())
+	auction := ReconstructAuction(uuid.New().String(), testSellerID, "Test", "", "", 100, StatusDraft, pastTime, time.Now(), time.Now())
 
 	if err := auction.Open(); !errors.Is(err, ErrEndTimeExpired) {
 		t.Errorf("expected ErrEndTimeExpired, got %v", err)
@@ -103,7 +103,7 @@ func TestAuction_Open_ExpiredEndTime(t *testing.T) {
 }
 
 func TestAuction_Close(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 
 	if err := auction.Close(); err == nil {
 		t.Error("expected error when closing draft auction")
@@ -126,7 +126,7 @@ func TestAuction_Close(t *testing.T) {
 }
 
 func TestAuction_Settle(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 
 	if err := auction.Settle(); err == nil {
 		t.Error("expected error when settling draft auction")
@@ -149,7 +149,7 @@ func TestAuction_Settle(t *testing.T) {
 }
 
 func TestAuction_Cancel(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 
 	if err := auction.Cancel(); err != nil {
 		t.Fatalf("unexpected error cancelling draft: %v", err)
@@ -160,7 +160,7 @@ func TestAuction_Cancel(t *testing.T) {
 }
 
 func TestAuction_Cancel_FromClosed(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -178,7 +178,7 @@ func TestAuction_Cancel_FromClosed(t *testing.T) {
 }
 
 func TestAuction_Cancel_FromOpen_Fails(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -189,7 +189,7 @@ func TestAuction_Cancel_FromOpen_Fails(t *testing.T) {
 }
 
 func TestAuction_ClearEvents(t *testing.T) {
-	auction, _ := NewAuction(testSellerID, "Test", "", 100, futureTime())
+	auction, _ := NewAuction(testSellerID, "Test", "", "", 100, futureTime())
 	if err := auction.Open(); err != nil {
 		t.Fatal(err)
 	}
@@ -209,7 +209,7 @@ func TestReconstructAuction(t *testing.T) {
 	now := time.Now()
 	endTime := now.Add(24 * time.Hour)
 
-	auction := ReconstructAuction(id, testSellerID, "Title", "Desc", 500, StatusOpen, endTime, now, now)
+	auction := ReconstructAuction(id, testSellerID, "Title", "Desc", "", 500, StatusOpen, endTime, now, now)
 
 	if auction.ID() != id {
 		t.Errorf("expected ID '%s', got '%s'", id, auction.ID())
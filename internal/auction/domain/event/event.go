@@ -13,14 +13,15 @@ type AuctionCreated struct {
 	AuctionID   string    `json:"auction_id"`
 	SellerID    string    `json:"seller_id"`
 	Title       string    `json:"title"`
+	Category    string    `json:"category,omitempty"`
 	StartPrice  int64     `json:"start_price"`
 	EndTime     time.Time `json:"end_time"`
 	Timestamp   time.Time `json:"occurred_at"`
 }
 
-func NewAuctionCreated(auctionID, sellerID, title string, startPrice int64, endTime time.Time) AuctionCreated {
+func NewAuctionCreated(auctionID, sellerID, title, category string, startPrice int64, endTime time.Time) AuctionCreated {
 	return AuctionCreated{
-		AuctionID: auctionID, SellerID: sellerID, Title: title,
+		AuctionID: auctionID, SellerID: sellerID, Title: title, Category: category,
 		StartPrice: startPrice, EndTime: endTime,
 		Timestamp: time.Now(),
 	}
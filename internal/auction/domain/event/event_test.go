@@ -9,7 +9,7 @@ const testID = "test-auction-id"
 const testSellerID = "test-seller-id"
 
 func TestAuctionCreated_EventName(t *testing.T) {
-	e := NewAuctionCreated(testID, testSellerID, "Title", 1000, time.Now().Add(time.Hour))
+	e := NewAuctionCreated(testID, testSellerID, "Title", "", 1000, time.Now().Add(time.Hour))
 	if e.EventName() != "auction.created" {
 		t.Errorf("EventName = %q, want auction.created", e.EventName())
 	}
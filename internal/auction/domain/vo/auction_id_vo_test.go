@@ -38,19 +38,19 @@ func TestNewSellerIDVO(t *testing.T) {
 }
 
 func TestNewCreateVO(t *testing.T) {
-	if _, err := NewCreateVO("Title", "Desc", 100, futureTime()); err != nil {
+	if _, err := NewCreateVO("Title", "Desc", "", 100, futureTime()); err != nil {
 		t.Errorf("expected no error for valid input, got %v", err)
 	}
 
-	if _, err := NewCreateVO("", "Desc", 100, futureTime()); err == nil {
+	if _, err := NewCreateVO("", "Desc", "", 100, futureTime()); err == nil {
 		t.Error("expected error for empty title")
 	}
 
-	if _, err := NewCreateVO("Title", "Desc", 0, futureTime()); err == nil {
+	if _, err := NewCreateVO("Title", "Desc", "", 0, futureTime()); err == nil {
 		t.Error("expected error for zero price")
 	}
 
-	if _, err := NewCreateVO("Title", "Desc", -1, futureTime()); err == nil {
+	if _, err := NewCreateVO("Title", "Desc", "", -1, futureTime()); err == nil {
 		t.Error("expected error for negative price")
 	}
 }
@@ -2,21 +2,46 @@ package vo
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
-var errInvalidCreate = errors.New("title and positive start price are required")
+const maxCategoryLength = 50
+
+var (
+	errInvalidCreate   = errors.New("title and positive start price are required")
+	errInvalidCategory = errors.New("category may only contain letters, digits, '-' and '_' and be at most 50 characters")
+)
 
 type CreateVO struct {
 	Title       string
 	Description string
+	Category    string
 	StartPrice  int64
 	EndTime     time.Time
 }
 
-func NewCreateVO(title, description string, startPrice int64, endTime time.Time) (*CreateVO, error) {
+// NewCreateVO lowercases the optional category so it matches the fee
+// overrides configured in the payment service.
+func NewCreateVO(title, description, category string, startPrice int64, endTime time.Time) (*CreateVO, error) {
 	if title == "" || startPrice <= 0 {
 		return nil, errInvalidCreate
 	}
-	return &CreateVO{Title: title, Description: description, StartPrice: startPrice, EndTime: endTime}, nil
+	category = strings.ToLower(strings.TrimSpace(category))
+	if !validCategory(category) {
+		return nil, errInvalidCategory
+	}
+	return &CreateVO{Title: title, Description: description, Category: category, StartPrice: startPrice, EndTime: endTime}, nil
+}
+
+func validCategory(category string) bool {
+	if len(category) > maxCategoryLength {
+		return false
+	}
+	for _, r := range category {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
 }
@@ -14,6 +14,7 @@ import (
 
 type paymentEvent struct {
 	AuctionID string `json:"auction_id"`
+	Escrow    bool   `json:"escrow"`
 }
 
 type Consumer struct {
@@ -45,7 +46,10 @@ func (c *Consumer) Start(_ context.Context) error {
 			if err := json.Unmarshal(env.Payload, &pe); err != nil {
 				return err
 			}
-			slog.Info("received payment.completed", "service", "auction", "auction_id", pe.AuctionID)
+			slog.Info("received payment.completed", "service", "auction", "auction_id", pe.AuctionID, "escrow", pe.Escrow)
+			if pe.Escrow {
+				return nil
+			}
 			return c.settleHandler.Handle(ctx, command.Settle{AuctionID: pe.AuctionID})
 		})
 	if err != nil {
@@ -53,21 +57,31 @@ func (c *Consumer) Start(_ context.Context) error {
 	}
 	c.subs = append(c.subs, sub1)
 
-	sub2, err := sharedNats.SubscribeIdempotent(c.nc, "payment.failed", "auction", c.dbGetter, c.transactor,
+	sub, err := sharedNats.SubscribeIdempotent(c.nc, "payment.escrow_released", "auction", c.dbGetter, c.transactor,
 		func(ctx context.Context, env *sharedEvent.Envelope) error {
 			var pe paymentEvent
 			if err := json.Unmarshal(env.Payload, &pe); err != nil {
 				return err
 			}
-			slog.Info("received payment.failed", "service", "auction", "auction_id", pe.AuctionID)
-			return c.cancelHandler.Handle(ctx, command.Cancel{AuctionID: pe.AuctionID})
+			slog.Info("received payment.escrow_released", "service", "auction", "auction_id", pe.AuctionID)
+			return c.settleHandler.Handle(ctx, command.Settle{AuctionID: pe.AuctionID})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	sub2, err := sharedNats.SubscribeIdempotent(c.nc, "bid.candidates_exhausted", "auction", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			slog.Info("received bid.candidates_exhausted", "service", "auction", "auction_id", env.AggregateID)
+			return c.cancelHandler.Handle(ctx, command.Cancel{AuctionID: env.AggregateID})
 		})
 	if err != nil {
 		return err
 	}
 	c.subs = append(c.subs, sub2)
 
-	slog.Info("NATS consumers started", "service", "auction", "subjects", "payment.completed, payment.failed")
+	slog.Info("NATS consumers started", "service", "auction", "subjects", "payment.completed, payment.escrow_released, bid.candidates_exhausted")
 	return nil
 }
 
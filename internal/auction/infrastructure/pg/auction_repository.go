@@ -27,8 +27,8 @@ func NewAuctionRepository(dbGetter func(ctx context.Context) transaction.DBTX) d
 func (r *auctionRepository) Save(ctx context.Context, auction *entity.Auction) error {
 	db := r.dbGetter(ctx)
 	_, err := db.ExecContext(ctx,
-		"INSERT INTO auctions (id, seller_id, title, description, start_price, status, end_time) VALUES ($1, $2, $3, $4, $5, $6, $7)",
-		auction.ID(), auction.SellerID(), auction.Title(), auction.Description(), auction.StartPrice(), auction.Status(), auction.EndTime(),
+		"INSERT INTO auctions (id, seller_id, title, description, category, start_price, status, end_time) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		auction.ID(), auction.SellerID(), auction.Title(), auction.Description(), auction.Category(), auction.StartPrice(), auction.Status(), auction.EndTime(),
 	)
 	if err != nil {
 		return errors.Internal("Failed to create auction")
@@ -39,16 +39,16 @@ func (r *auctionRepository) Save(ctx context.Context, auction *entity.Auction) e
 func (r *auctionRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Auction, error) {
 	cfg := query.ApplyOptions(opts)
 	db := r.dbGetter(ctx)
-	var aid, sellerID, title, description, status string
+	var aid, sellerID, title, description, category, status string
 	var startPrice int64
 	var endTime, createdAt, updatedAt time.Time
 
-	q := "SELECT id, seller_id, title, description, start_price, status, end_time, created_at, updated_at FROM auctions WHERE id = $1"
+	q := "SELECT id, seller_id, title, description, category, start_price, status, end_time, created_at, updated_at FROM auctions WHERE id = $1"
 	if cfg.ForUpdate {
 		q += " FOR UPDATE"
 	}
 
-	err := db.QueryRowContext(ctx, q, id).Scan(&aid, &sellerID, &title, &description, &startPrice, &status, &endTime, &createdAt, &updatedAt)
+	err := db.QueryRowContext(ctx, q, id).Scan(&aid, &sellerID, &title, &description, &category, &startPrice, &status, &endTime, &createdAt, &updatedAt)
 	if stderrors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -56,7 +56,7 @@ func (r *auctionRepository) FindByID(ctx context.Context, id string, opts ...que
 		return nil, errors.Internal("Failed to get auction")
 	}
 
-	return entity.ReconstructAuction(aid, sellerID, title, description, startPrice, status, endTime, createdAt, updatedAt), nil
+	return entity.ReconstructAuction(aid, sellerID, title, description, category, startPrice, status, endTime, createdAt, updatedAt), nil
 }
 
 func (r *auctionRepository) FindAll(ctx context.Context, page, limit int) ([]*entity.Auction, int64, error) {
@@ -64,7 +64,7 @@ func (r *auctionRepository) FindAll(ctx context.Context, page, limit int) ([]*en
 	offset := (page - 1) * limit
 
 	rows, err := db.QueryContext(ctx,
-		"SELECT id, seller_id, title, description, start_price, status, end_time, created_at, updated_at, COUNT(*) OVER() FROM auctions ORDER BY created_at DESC LIMIT $1 OFFSET $2",
+		"SELECT id, seller_id, title, description, category, start_price, status, end_time, created_at, updated_at, COUNT(*) OVER() FROM auctions ORDER BY created_at DESC LIMIT $1 OFFSET $2",
 		limit, offset,
 	)
 	if err != nil {
@@ -79,13 +79,13 @@ func (r *auctionRepository) FindAll(ctx context.Context, page, limit int) ([]*en
 	var auctions []*entity.Auction
 	var total int64
 	for rows.Next() {
-		var aid, sellerID, title, description, status string
+		var aid, sellerID, title, description, category, status string
 		var startPrice int64
 		var endTime, createdAt, updatedAt time.Time
-		if err := rows.Scan(&aid, &sellerID, &title, &description, &startPrice, &status, &endTime, &createdAt, &updatedAt, &total); err != nil {
+		if err := rows.Scan(&aid, &sellerID, &title, &description, &category, &startPrice, &status, &endTime, &createdAt, &updatedAt, &total); err != nil {
 			return nil, 0, errors.Internal("Failed to scan auction")
 		}
-		auctions = append(auctions, entity.ReconstructAuction(aid, sellerID, title, description, startPrice, status, endTime, createdAt, updatedAt))
+		auctions = append(auctions, entity.ReconstructAuction(aid, sellerID, title, description, category, startPrice, status, endTime, createdAt, updatedAt))
 	}
 	if err := rows.Err(); err != nil {
 		return nil, 0, errors.Internal("Error iterating auctions")
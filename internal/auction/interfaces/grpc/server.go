@@ -38,6 +38,7 @@ func (s *server) GetAuction(ctx context.Context, req *auctionv1.GetAuctionReques
 		SellerId:   result.SellerID,
 		StartPrice: result.StartPrice,
 		Status:     result.Status,
+		Category:   result.Category,
 	}, nil
 }
 
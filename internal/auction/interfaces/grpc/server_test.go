@@ -8,9 +8,34 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/in-jun/go-structure-example/internal/auction/application"
+	"github.com/in-jun/go-structure-example/internal/auction/application/query"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	auctionv1 "github.com/in-jun/go-structure-example/proto/auction/v1"
 )
 
+type stubQueries struct {
+	application.QueryUseCase
+	result *query.Result
+}
+
+func (s *stubQueries) GetByID(_ context.Context, _ query.Get) (*query.Result, error) {
+	return s.result, nil
+}
+
+func TestServer_GetAuction(t *testing.T) {
+	s := &server{queries: &stubQueries{result: &query.Result{
+		ID: "auction-1", SellerID: "seller-1", StartPrice: 1000, Status: "closed", Category: "electronics",
+	}}}
+
+	resp, err := s.GetAuction(context.Background(), &auctionv1.GetAuctionRequest{AuctionId: "auction-1"})
+	if err != nil {
+		t.Fatalf("GetAuction() error = %v", err)
+	}
+	if resp.SellerId != "seller-1" || resp.Status != "closed" || resp.Category != "electronics" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
 
 func TestToGRPCError_NotFound(t *testing.T) {
 	err := toGRPCError(errors.NotFound("resource not found"))
@@ -15,22 +15,26 @@ import (
 type Handler struct {
 	commands application.CommandUseCase
 	queries  application.QueryUseCase
+	identity *middleware.IdentityVerifier
 }
 
-func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase) *Handler {
-	return &Handler{commands: commands, queries: queries}
+func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase, identity *middleware.IdentityVerifier) *Handler {
+	return &Handler{commands: commands, queries: queries, identity: identity}
 }
 
 func (h *Handler) RegisterRoutes(mux *server.Router, mw server.Middleware) {
-	gatewayAuth := middleware.GatewayAuth()
+	gatewayAuth := middleware.GatewayAuth(h.identity)
+	seller := middleware.RequireRole(middleware.RoleSeller)
+	admin := middleware.RequireRole(middleware.RoleAdmin)
 
 	mux.Handle("GET /api/v1/auctions", mw(http.HandlerFunc(h.GetList)))
 	mux.Handle("GET /api/v1/auctions/{id}", mw(http.HandlerFunc(h.GetByID)))
 	mux.Handle("GET /api/v1/auctions/{id}/events", mw(http.HandlerFunc(h.GetEvents)))
-	mux.Handle("POST /api/v1/auctions", mw(gatewayAuth(http.HandlerFunc(h.Create))))
+	mux.Handle("POST /api/v1/auctions", mw(gatewayAuth(seller(http.HandlerFunc(h.Create)))))
 	mux.Handle("POST /api/v1/auctions/{id}/open", mw(gatewayAuth(http.HandlerFunc(h.Open))))
 	mux.Handle("POST /api/v1/auctions/{id}/close", mw(gatewayAuth(http.HandlerFunc(h.Close))))
 	mux.Handle("POST /api/v1/auctions/{id}/cancel", mw(gatewayAuth(http.HandlerFunc(h.Cancel))))
+	mux.Handle("POST /api/v1/admin/auctions/{id}/cancel", mw(gatewayAuth(admin(http.HandlerFunc(h.AdminCancel)))))
 }
 
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
@@ -45,6 +49,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		UserID:      userID,
 		Title:       req.Title,
 		Description: req.Description,
+		Category:    req.Category,
 		StartPrice:  req.StartPrice,
 		EndTime:     req.EndTime,
 	})
@@ -140,11 +145,21 @@ func (h *Handler) Close(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.cancel(w, r, false)
+}
+
+// AdminCancel cancels any auction, not only the caller's own.
+func (h *Handler) AdminCancel(w http.ResponseWriter, r *http.Request) {
+	h.cancel(w, r, true)
+}
+
+func (h *Handler) cancel(w http.ResponseWriter, r *http.Request, admin bool) {
 	id := r.PathValue("id")
 	userID := server.UserID(r)
 
 	if err := h.commands.Cancel(r.Context(), command.Cancel{
 		UserID:    userID,
+		Admin:     admin,
 		AuctionID: id,
 	}); err != nil {
 		middleware.HandleError(w, err)
@@ -52,7 +52,7 @@ const testUserID = "550e8400-e29b-41d4-a716-446655440000"
 
 func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server.Router {
 	mux := server.NewRouter()
-	h := NewHandler(cmdMock, qryMock)
+	h := NewHandler(cmdMock, qryMock, nil)
 	noopMw := server.Middleware(func(next http.Handler) http.Handler { return next })
 
 	injectUser := func(next http.Handler) http.Handler {
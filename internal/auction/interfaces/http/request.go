@@ -5,6 +5,7 @@ import "time"
 type CreateRequest struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
+	Category    string    `json:"category"`
 	StartPrice  int64     `json:"start_price"`
 	EndTime     time.Time `json:"end_time"`
 }
@@ -13,6 +13,7 @@ type Response struct {
 	SellerID    string    `json:"seller_id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
+	Category    string    `json:"category"`
 	StartPrice  int64     `json:"start_price"`
 	Status      string    `json:"status"`
 	EndTime     time.Time `json:"end_time"`
@@ -42,6 +43,7 @@ func toCreateResponse(r *command.CreateResult) *Response {
 		SellerID:    r.SellerID,
 		Title:       r.Title,
 		Description: r.Description,
+		Category:    r.Category,
 		StartPrice:  r.StartPrice,
 		Status:      r.Status,
 		EndTime:     r.EndTime,
@@ -56,6 +58,7 @@ func toGetResponse(r *query.Result) *Response {
 		SellerID:    r.SellerID,
 		Title:       r.Title,
 		Description: r.Description,
+		Category:    r.Category,
 		StartPrice:  r.StartPrice,
 		Status:      r.Status,
 		EndTime:     r.EndTime,
@@ -72,6 +75,7 @@ func toListResponse(r *query.ListResult) *ListResponse {
 			SellerID:    a.SellerID,
 			Title:       a.Title,
 			Description: a.Description,
+			Category:    a.Category,
 			StartPrice:  a.StartPrice,
 			Status:      a.Status,
 			EndTime:     a.EndTime,
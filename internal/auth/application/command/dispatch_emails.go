@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+)
+
+type DispatchEmails struct {
+	Now   time.Time
+	Limit int
+}
+
+type DispatchEmailsResult struct {
+	Sent    int
+	Failed  int
+	Pending int
+}
+
+type DispatchEmailsHandler struct {
+	outbox domain.EmailOutbox
+	mailer domain.Mailer
+}
+
+func NewDispatchEmailsHandler(outbox domain.EmailOutbox, mailer domain.Mailer) *DispatchEmailsHandler {
+	return &DispatchEmailsHandler{outbox: outbox, mailer: mailer}
+}
+
+// Handle hands every due outbox email to the mailer, rescheduling the ones
+// it rejects.
+func (h *DispatchEmailsHandler) Handle(ctx context.Context, cmd DispatchEmails) (*DispatchEmailsResult, error) {
+	emails, err := h.outbox.FindDue(ctx, cmd.Now, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DispatchEmailsResult{}
+	for _, email := range emails {
+		sendErr := h.mailer.Send(ctx, domain.Email{To: email.To(), Subject: email.Subject(), Body: email.Body()})
+		if sendErr == nil {
+			err = email.MarkSent(cmd.Now)
+		} else {
+			slog.Warn("failed to send email", "email_id", email.ID(), "attempt", email.Attempts()+1, "error", sendErr)
+			err = email.MarkFailed(sendErr, cmd.Now)
+		}
+		if err != nil {
+			slog.Error("failed to record email delivery", "email_id", email.ID(), "error", err)
+			continue
+		}
+		if err := h.outbox.Update(ctx, email); err != nil {
+			slog.Error("failed to update outbox email", "email_id", email.ID(), "error", err)
+			continue
+		}
+		switch email.Status() {
+		case entity.OutboundEmailStatusSent:
+			result.Sent++
+		case entity.OutboundEmailStatusFailed:
+			result.Failed++
+		default:
+			result.Pending++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+)
+
+type mockMailer struct {
+	sent []domain.Email
+	fail map[string]error
+}
+
+func (m *mockMailer) Send(_ context.Context, email domain.Email) error {
+	if err := m.fail[email.To]; err != nil {
+		return err
+	}
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+var _ domain.Mailer = (*mockMailer)(nil)
+
+func TestDispatchEmailsHandler(t *testing.T) {
+	ok, _ := entity.NewOutboundEmail("ok@example.com", "Subject", "Body")
+	bounced, _ := entity.NewOutboundEmail("down@example.com", "Subject", "Body")
+	outbox := &mockOutbox{due: []*entity.OutboundEmail{ok, bounced}}
+	mailer := &mockMailer{fail: map[string]error{"down@example.com": stderrors.New("connection refused")}}
+	h := NewDispatchEmailsHandler(outbox, mailer)
+
+	result, err := h.Handle(context.Background(), DispatchEmails{Now: time.Now(), Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Sent != 1 || result.Pending != 1 || result.Failed != 0 {
+		t.Errorf("result = %+v, want 1 sent and 1 pending", result)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != "ok@example.com" {
+		t.Errorf("sent = %v, want only ok@example.com", mailer.sent)
+	}
+	if len(outbox.updated) != 2 {
+		t.Errorf("updated %d emails, want 2", len(outbox.updated))
+	}
+	if bounced.LastError() != "connection refused" || !bounced.NextAttemptAt().After(ok.NextAttemptAt()) {
+		t.Errorf("expected the bounced email to be rescheduled, last error %q", bounced.LastError())
+	}
+}
@@ -60,7 +60,7 @@ func (h *LoginHandler) Handle(ctx context.Context, cmd Login) (*LoginResult, err
 		return nil, errors.Unauthorized("Invalid credentials")
 	}
 
-	accessToken, err := h.tokenGen.GenerateAccessToken(user.ID())
+	accessToken, err := h.tokenGen.GenerateAccessToken(user.ID(), user.ActiveRoles())
 	if err != nil {
 		return nil, errors.Internal("Failed to generate access token")
 	}
@@ -39,9 +39,14 @@ func (m *mockTokenRepo) IsRevokedForUser(_ context.Context, _ string, _ int64) (
 	return false, m.err
 }
 
-type mockTokenGen struct{}
+type mockTokenGen struct {
+	roles []string
+}
 
-func (m *mockTokenGen) GenerateAccessToken(_ string) (string, error) { return "access-token", nil }
+func (m *mockTokenGen) GenerateAccessToken(_ string, roles []string) (string, error) {
+	m.roles = roles
+	return "access-token", nil
+}
 func (m *mockTokenGen) ValidateToken(_ string) (*domain.TokenClaims, error) {
 	return &domain.TokenClaims{UserID: testUUID}, nil
 }
@@ -110,3 +115,15 @@ func TestLoginHandler_RepositoryError(t *testing.T) {
 		t.Fatal("expected error for repository failure, got nil")
 	}
 }
+
+func TestLoginHandler_UnverifiedUserCannotBidOrSell(t *testing.T) {
+	tokenGen := &mockTokenGen{}
+	h := NewLoginHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{}, tokenGen, &mockHasher{})
+
+	if _, err := h.Handle(context.Background(), Login{Email: "test@example.com", Password: "password123"}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(tokenGen.roles) != 0 {
+		t.Errorf("access token roles = %v, want none before verification", tokenGen.roles)
+	}
+}
@@ -22,12 +22,17 @@ type RefreshResult struct {
 }
 
 type RefreshHandler struct {
+	userRepo  domain.UserRepository
 	tokenRepo domain.TokenRepository
 	tokenGen  domain.TokenGenerator
 }
 
-func NewRefreshHandler(tokenRepo domain.TokenRepository, tokenGen domain.TokenGenerator) *RefreshHandler {
-	return &RefreshHandler{tokenRepo: tokenRepo, tokenGen: tokenGen}
+func NewRefreshHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.TokenRepository,
+	tokenGen domain.TokenGenerator,
+) *RefreshHandler {
+	return &RefreshHandler{userRepo: userRepo, tokenRepo: tokenRepo, tokenGen: tokenGen}
 }
 
 func (h *RefreshHandler) Handle(ctx context.Context, cmd Refresh) (*RefreshResult, error) {
@@ -61,6 +66,16 @@ func (h *RefreshHandler) Handle(ctx context.Context, cmd Refresh) (*RefreshResul
 		return nil, errors.Unauthorized("Refresh token expired")
 	}
 
+	// Roles are read fresh so a refreshed token reflects grants,
+	// revocations and email verification since login.
+	user, err := h.userRepo.FindByID(ctx, old.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.Unauthorized("Invalid refresh token")
+	}
+
 	if err := h.tokenRepo.DeleteByToken(ctx, v.Token); err != nil {
 		return nil, err
 	}
@@ -68,7 +83,7 @@ func (h *RefreshHandler) Handle(ctx context.Context, cmd Refresh) (*RefreshResul
 		return nil, err
 	}
 
-	accessToken, err := h.tokenGen.GenerateAccessToken(old.UserID())
+	accessToken, err := h.tokenGen.GenerateAccessToken(user.ID(), user.ActiveRoles())
 	if err != nil {
 		return nil, errors.Internal("Failed to generate access token")
 	}
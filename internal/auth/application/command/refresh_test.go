@@ -21,7 +21,7 @@ func makeExpiredRefreshToken() *entity.RefreshToken {
 
 func TestRefreshHandler_Success(t *testing.T) {
 	rt := makeValidRefreshToken()
-	h := NewRefreshHandler(&mockTokenRepo{token: rt}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{token: rt}, &mockTokenGen{})
 
 	result, err := h.Handle(context.Background(), Refresh{RefreshToken: rt.Token()})
 	if err != nil {
@@ -35,8 +35,34 @@ func TestRefreshHandler_Success(t *testing.T) {
 	}
 }
 
+func TestRefreshHandler_CarriesCurrentRoles(t *testing.T) {
+	rt := makeValidRefreshToken()
+	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Test",
+		[]string{entity.RoleBidder, entity.RoleAdmin}, time.Now(), time.Now(), time.Now())
+	tokenGen := &mockTokenGen{}
+	h := NewRefreshHandler(&mockUserRepo{user: user}, &mockTokenRepo{token: rt}, tokenGen)
+
+	if _, err := h.Handle(context.Background(), Refresh{RefreshToken: rt.Token()}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(tokenGen.roles) != 2 || tokenGen.roles[1] != entity.RoleAdmin {
+		t.Errorf("access token roles = %v, want the user's current roles", tokenGen.roles)
+	}
+}
+
+func TestRefreshHandler_UserGone(t *testing.T) {
+	rt := makeValidRefreshToken()
+	h := NewRefreshHandler(&mockUserRepo{}, &mockTokenRepo{token: rt}, &mockTokenGen{})
+
+	_, err := h.Handle(context.Background(), Refresh{RefreshToken: rt.Token()})
+	var ce errors.CustomError
+	if !asCustomError(err, &ce) || ce.Status != 401 {
+		t.Errorf("expected 401 Unauthorized, got %v", err)
+	}
+}
+
 func TestRefreshHandler_InvalidToken(t *testing.T) {
-	h := NewRefreshHandler(&mockTokenRepo{}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{}, &mockTokenGen{})
 
 	_, err := h.Handle(context.Background(), Refresh{RefreshToken: ""})
 	if err == nil {
@@ -45,7 +71,7 @@ func TestRefreshHandler_InvalidToken(t *testing.T) {
 }
 
 func TestRefreshHandler_TokenNotFound(t *testing.T) {
-	h := NewRefreshHandler(&mockTokenRepo{token: nil}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{token: nil}, &mockTokenGen{})
 
 	_, err := h.Handle(context.Background(), Refresh{RefreshToken: "nonexistent-token"})
 	if err == nil {
@@ -59,7 +85,7 @@ func TestRefreshHandler_TokenNotFound(t *testing.T) {
 
 func TestRefreshHandler_ExpiredToken(t *testing.T) {
 	rt := makeExpiredRefreshToken()
-	h := NewRefreshHandler(&mockTokenRepo{token: rt}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{token: rt}, &mockTokenGen{})
 
 	_, err := h.Handle(context.Background(), Refresh{RefreshToken: rt.Token()})
 	if err == nil {
@@ -72,7 +98,7 @@ func TestRefreshHandler_ExpiredToken(t *testing.T) {
 }
 
 func TestRefreshHandler_TokenReuseDetected(t *testing.T) {
-	h := NewRefreshHandler(&mockTokenRepo{token: nil, userID: testUUID}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{token: nil, userID: testUUID}, &mockTokenGen{})
 
 	_, err := h.Handle(context.Background(), Refresh{RefreshToken: "used-token"})
 	if err == nil {
@@ -85,7 +111,7 @@ func TestRefreshHandler_TokenReuseDetected(t *testing.T) {
 }
 
 func TestRefreshHandler_RepositoryError(t *testing.T) {
-	h := NewRefreshHandler(&mockTokenRepo{err: errors.Internal("db error")}, &mockTokenGen{})
+	h := NewRefreshHandler(&mockUserRepo{user: makeAuthUser()}, &mockTokenRepo{err: errors.Internal("db error")}, &mockTokenGen{})
 
 	_, err := h.Handle(context.Background(), Refresh{RefreshToken: "some-token"})
 	if err == nil {
@@ -2,9 +2,11 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/auth/domain"
 	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/service"
 	"github.com/in-jun/go-structure-example/internal/auth/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
@@ -19,11 +21,27 @@ type Register struct {
 type RegisterHandler struct {
 	userRepo       domain.UserRepository
 	passwordHasher domain.PasswordHasher
+	issuer         *verificationIssuer
+	eventPublisher domain.EventPublisher
 	transactor     transaction.Transactor
 }
 
-func NewRegisterHandler(userRepo domain.UserRepository, passwordHasher domain.PasswordHasher, transactor transaction.Transactor) *RegisterHandler {
-	return &RegisterHandler{userRepo: userRepo, passwordHasher: passwordHasher, transactor: transactor}
+func NewRegisterHandler(
+	userRepo domain.UserRepository,
+	verificationRepo domain.EmailVerificationRepository,
+	outbox domain.EmailOutbox,
+	passwordHasher domain.PasswordHasher,
+	policy *service.VerificationPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *RegisterHandler {
+	return &RegisterHandler{
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
+		issuer:         &verificationIssuer{verificationRepo: verificationRepo, outbox: outbox, policy: policy},
+		eventPublisher: eventPublisher,
+		transactor:     transactor,
+	}
 }
 
 func (h *RegisterHandler) Handle(ctx context.Context, cmd Register) error {
@@ -51,6 +69,17 @@ func (h *RegisterHandler) Handle(ctx context.Context, cmd Register) error {
 			return errors.Internal("Failed to create user")
 		}
 
-		return h.userRepo.Save(txCtx, user)
+		if err := h.userRepo.Save(txCtx, user); err != nil {
+			return err
+		}
+		if err := h.issuer.issue(txCtx, user, time.Now()); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, user.Events()...); err != nil {
+			return err
+		}
+		user.ClearEvents()
+		return nil
 	})
 }
@@ -3,33 +3,103 @@ package command
 import (
 	"context"
 	stderrors "errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/auth/domain"
 	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/event"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/service"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 )
 
 const testUUID = "550e8400-e29b-41d4-a716-446655440000"
 
+var testVerificationPolicy = service.NewVerificationPolicy(24*time.Hour, time.Minute, "https://example.com/verify")
+
 type mockUserRepo struct {
-	user *entity.User
-	err  error
+	user    *entity.User
+	updated *entity.User
+	err     error
 }
 
 func (m *mockUserRepo) Save(_ context.Context, _ *entity.User) error {
 	return m.err
 }
+func (m *mockUserRepo) Update(_ context.Context, user *entity.User) error {
+	m.updated = user
+	return m.err
+}
 func (m *mockUserRepo) FindByEmail(_ context.Context, _ string) (*entity.User, error) {
 	return m.user, m.err
 }
+func (m *mockUserRepo) FindByID(_ context.Context, _ string) (*entity.User, error) {
+	return m.user, m.err
+}
+
+type mockVerificationRepo struct {
+	verification *entity.EmailVerification
+	latest       *entity.EmailVerification
+	saved        []*entity.EmailVerification
+	deleted      int
+	updateErr    error
+}
+
+func (m *mockVerificationRepo) Save(_ context.Context, v *entity.EmailVerification) error {
+	m.saved = append(m.saved, v)
+	return nil
+}
+func (m *mockVerificationRepo) FindByTokenHash(_ context.Context, hash string) (*entity.EmailVerification, error) {
+	if m.verification == nil || m.verification.TokenHash() != hash {
+		return nil, nil
+	}
+	return m.verification, nil
+}
+func (m *mockVerificationRepo) FindLatestByUserID(_ context.Context, _ string) (*entity.EmailVerification, error) {
+	return m.latest, nil
+}
+func (m *mockVerificationRepo) Update(_ context.Context, _ *entity.EmailVerification) error {
+	return m.updateErr
+}
+func (m *mockVerificationRepo) DeleteUnusedByUserID(_ context.Context, _ string) error {
+	m.deleted++
+	return nil
+}
+
+type mockOutbox struct {
+	saved   []*entity.OutboundEmail
+	due     []*entity.OutboundEmail
+	updated []*entity.OutboundEmail
+}
+
+func (m *mockOutbox) Save(_ context.Context, email *entity.OutboundEmail) error {
+	m.saved = append(m.saved, email)
+	return nil
+}
+func (m *mockOutbox) FindDue(_ context.Context, _ time.Time, _ int) ([]*entity.OutboundEmail, error) {
+	return m.due, nil
+}
+func (m *mockOutbox) Update(_ context.Context, email *entity.OutboundEmail) error {
+	m.updated = append(m.updated, email)
+	return nil
+}
 
 type mockHasher struct{}
 
 func (m *mockHasher) Hash(password string) (string, error) { return "hashed_" + password, nil }
 func (m *mockHasher) Compare(hashed, plain string) bool    { return hashed == "hashed_"+plain }
 
+type mockPublisher struct {
+	published []event.Event
+}
+
+func (m *mockPublisher) Publish(_ context.Context, events ...event.Event) error {
+	m.published = append(m.published, events...)
+	return nil
+}
+
 type noopTransactor struct{}
 
 func (n *noopTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error, _ ...transaction.TxOption) error {
@@ -37,11 +107,19 @@ func (n *noopTransactor) WithinTransaction(ctx context.Context, fn func(context.
 }
 
 var _ domain.UserRepository = (*mockUserRepo)(nil)
+var _ domain.EmailVerificationRepository = (*mockVerificationRepo)(nil)
+var _ domain.EmailOutbox = (*mockOutbox)(nil)
 var _ domain.PasswordHasher = (*mockHasher)(nil)
+var _ domain.EventPublisher = (*mockPublisher)(nil)
 var _ transaction.Transactor = (*noopTransactor)(nil)
 
+func newTestRegisterHandler(userRepo *mockUserRepo, pub *mockPublisher) *RegisterHandler {
+	return NewRegisterHandler(userRepo, &mockVerificationRepo{}, &mockOutbox{}, &mockHasher{},
+		testVerificationPolicy, pub, &noopTransactor{})
+}
+
 func TestRegisterHandler_Success(t *testing.T) {
-	h := NewRegisterHandler(&mockUserRepo{}, &mockHasher{}, &noopTransactor{})
+	h := newTestRegisterHandler(&mockUserRepo{}, &mockPublisher{})
 	err := h.Handle(context.Background(), Register{
 		Email:    "test@example.com",
 		Password: "password123",
@@ -52,8 +130,49 @@ func TestRegisterHandler_Success(t *testing.T) {
 	}
 }
 
+func TestRegisterHandler_PublishesUserRegistered(t *testing.T) {
+	pub := &mockPublisher{}
+	h := newTestRegisterHandler(&mockUserRepo{}, pub)
+	err := h.Handle(context.Background(), Register{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Test User",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pub.published) != 1 || pub.published[0].EventName() != "user.registered" {
+		t.Errorf("expected user.registered to be published, got %v", pub.published)
+	}
+}
+
+func TestRegisterHandler_QueuesVerificationEmail(t *testing.T) {
+	verifications := &mockVerificationRepo{}
+	outbox := &mockOutbox{}
+	h := NewRegisterHandler(&mockUserRepo{}, verifications, outbox, &mockHasher{},
+		testVerificationPolicy, &mockPublisher{}, &noopTransactor{})
+	err := h.Handle(context.Background(), Register{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Test User",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(verifications.saved) != 1 || len(outbox.saved) != 1 {
+		t.Fatalf("saved %d verifications and %d emails, want 1 each", len(verifications.saved), len(outbox.saved))
+	}
+	email := outbox.saved[0]
+	if email.To() != "test@example.com" || !strings.Contains(email.Body(), "https://example.com/verify?token=") {
+		t.Errorf("unexpected verification email to %q: %q", email.To(), email.Body())
+	}
+	if strings.Contains(email.Body(), verifications.saved[0].TokenHash()) {
+		t.Error("email must carry the raw token, not its hash")
+	}
+}
+
 func TestRegisterHandler_InvalidEmail(t *testing.T) {
-	h := NewRegisterHandler(&mockUserRepo{}, &mockHasher{}, &noopTransactor{})
+	h := newTestRegisterHandler(&mockUserRepo{}, &mockPublisher{})
 	err := h.Handle(context.Background(), Register{
 		Email:    "not-an-email",
 		Password: "password123",
@@ -66,7 +185,7 @@ func TestRegisterHandler_InvalidEmail(t *testing.T) {
 
 func TestRegisterHandler_DuplicateEmail(t *testing.T) {
 	existing, _ := entity.NewUser("test@example.com", "hashed", "Existing")
-	h := NewRegisterHandler(&mockUserRepo{user: existing}, &mockHasher{}, &noopTransactor{})
+	h := newTestRegisterHandler(&mockUserRepo{user: existing}, &mockPublisher{})
 	err := h.Handle(context.Background(), Register{
 		Email:    "test@example.com",
 		Password: "password123",
@@ -86,7 +205,7 @@ func asCustomError(err error, ce *errors.CustomError) bool {
 }
 
 func TestRegisterHandler_RepositoryError(t *testing.T) {
-	h := NewRegisterHandler(&mockUserRepo{err: errors.Internal("db error")}, &mockHasher{}, &noopTransactor{})
+	h := newTestRegisterHandler(&mockUserRepo{err: errors.Internal("db error")}, &mockPublisher{})
 	err := h.Handle(context.Background(), Register{
 		Email:    "test@example.com",
 		Password: "password123",
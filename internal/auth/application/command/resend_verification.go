@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/service"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type ResendVerification struct {
+	UserID string
+}
+
+type ResendVerificationHandler struct {
+	userRepo         domain.UserRepository
+	verificationRepo domain.EmailVerificationRepository
+	policy           *service.VerificationPolicy
+	issuer           *verificationIssuer
+	transactor       transaction.Transactor
+}
+
+func NewResendVerificationHandler(
+	userRepo domain.UserRepository,
+	verificationRepo domain.EmailVerificationRepository,
+	outbox domain.EmailOutbox,
+	policy *service.VerificationPolicy,
+	transactor transaction.Transactor,
+) *ResendVerificationHandler {
+	return &ResendVerificationHandler{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		policy:           policy,
+		issuer:           &verificationIssuer{verificationRepo: verificationRepo, outbox: outbox, policy: policy},
+		transactor:       transactor,
+	}
+}
+
+// Handle invalidates any link still outstanding and mails a new one.
+func (h *ResendVerificationHandler) Handle(ctx context.Context, cmd ResendVerification) error {
+	v, err := vo.NewUserIDVO(cmd.UserID)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	now := time.Now()
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		user, err := h.userRepo.FindByID(txCtx, v.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return errors.NotFound("User not found")
+		}
+		if user.IsVerified() {
+			return errors.Conflict("Email already verified")
+		}
+
+		latest, err := h.verificationRepo.FindLatestByUserID(txCtx, user.ID())
+		if err != nil {
+			return err
+		}
+		if !h.policy.CanResend(latest, now) {
+			return errors.TooManyRequests("Verification email was sent recently")
+		}
+
+		return h.issuer.issue(txCtx, user, now)
+	})
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+func TestResendVerificationHandler_Success(t *testing.T) {
+	latest, _ := entity.ReconstructEmailVerification("id", testUUID, "hash",
+		time.Now().Add(time.Hour), time.Time{}, time.Now().Add(-2*time.Minute))
+	verifications := &mockVerificationRepo{latest: latest}
+	outbox := &mockOutbox{}
+	h := NewResendVerificationHandler(&mockUserRepo{user: makeAuthUser()}, verifications, outbox, testVerificationPolicy, &noopTransactor{})
+
+	if err := h.Handle(context.Background(), ResendVerification{UserID: testUUID}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if verifications.deleted != 1 {
+		t.Error("expected outstanding tokens to be invalidated")
+	}
+	if len(verifications.saved) != 1 || len(outbox.saved) != 1 {
+		t.Errorf("saved %d verifications and %d emails, want 1 each", len(verifications.saved), len(outbox.saved))
+	}
+}
+
+func TestResendVerificationHandler_Rejects(t *testing.T) {
+	verified, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Test",
+		nil, time.Now(), time.Now(), time.Now())
+	recent, _ := entity.ReconstructEmailVerification("id", testUUID, "hash",
+		time.Now().Add(time.Hour), time.Time{}, time.Now())
+
+	tests := []struct {
+		name       string
+		userID     string
+		user       *entity.User
+		latest     *entity.EmailVerification
+		wantStatus int
+	}{
+		{"invalid user id", "bad", makeAuthUser(), nil, 400},
+		{"unknown user", testUUID, nil, nil, 404},
+		{"already verified", testUUID, verified, nil, 409},
+		{"cooldown", testUUID, makeAuthUser(), recent, 429},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &mockOutbox{}
+			h := NewResendVerificationHandler(&mockUserRepo{user: tt.user}, &mockVerificationRepo{latest: tt.latest},
+				outbox, testVerificationPolicy, &noopTransactor{})
+
+			err := h.Handle(context.Background(), ResendVerification{UserID: tt.userID})
+			var ce errors.CustomError
+			if !asCustomError(err, &ce) || ce.Status != tt.wantStatus {
+				t.Errorf("expected %d, got %v", tt.wantStatus, err)
+			}
+			if len(outbox.saved) != 0 {
+				t.Error("no email should be queued")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+const verificationEmailSubject = "Verify your email address"
+
+// verificationIssuer replaces a user's outstanding verification token with
+// a fresh one and queues the mail carrying it. It must run inside the
+// caller's transaction.
+type verificationIssuer struct {
+	verificationRepo domain.EmailVerificationRepository
+	outbox           domain.EmailOutbox
+	policy           *service.VerificationPolicy
+}
+
+func (i *verificationIssuer) issue(ctx context.Context, user *entity.User, now time.Time) error {
+	if err := i.verificationRepo.DeleteUnusedByUserID(ctx, user.ID()); err != nil {
+		return err
+	}
+
+	verification, token, err := entity.NewEmailVerification(user.ID(), i.policy.ExpiresAt(now))
+	if err != nil {
+		return errors.Internal("Failed to create email verification")
+	}
+	if err := i.verificationRepo.Save(ctx, verification); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nConfirm your email address to start bidding and selling:\n\n%s\n\nThe link expires at %s.\n",
+		user.Name(), i.policy.Link(token), verification.ExpiresAt().UTC().Format(time.RFC1123))
+	email, err := entity.NewOutboundEmail(user.Email(), verificationEmailSubject, body)
+	if err != nil {
+		return errors.Internal("Failed to create verification email")
+	}
+	return i.outbox.Save(ctx, email)
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type VerifyEmail struct {
+	Token string
+}
+
+type VerifyEmailHandler struct {
+	userRepo         domain.UserRepository
+	verificationRepo domain.EmailVerificationRepository
+	eventPublisher   domain.EventPublisher
+	transactor       transaction.Transactor
+}
+
+func NewVerifyEmailHandler(
+	userRepo domain.UserRepository,
+	verificationRepo domain.EmailVerificationRepository,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *VerifyEmailHandler {
+	return &VerifyEmailHandler{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		eventPublisher:   eventPublisher,
+		transactor:       transactor,
+	}
+}
+
+func (h *VerifyEmailHandler) Handle(ctx context.Context, cmd VerifyEmail) error {
+	v, err := vo.NewTokenStringVO(cmd.Token)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	now := time.Now()
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		verification, err := h.verificationRepo.FindByTokenHash(txCtx, entity.HashVerificationToken(v.Token))
+		if err != nil {
+			return err
+		}
+		if verification == nil {
+			return errors.BadRequest("Invalid verification token")
+		}
+
+		if err := verification.Use(now); err != nil {
+			if stderrors.Is(err, entity.ErrVerificationExpired) {
+				return errors.BadRequest("Verification token expired")
+			}
+			return errors.BadRequest("Invalid verification token")
+		}
+		if err := h.verificationRepo.Update(txCtx, verification); err != nil {
+			return err
+		}
+
+		user, err := h.userRepo.FindByID(txCtx, verification.UserID())
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return errors.BadRequest("Invalid verification token")
+		}
+		if err := user.Verify(now); err != nil {
+			return errors.Conflict("Email already verified")
+		}
+		if err := h.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, user.Events()...); err != nil {
+			return err
+		}
+		user.ClearEvents()
+		return nil
+	})
+}
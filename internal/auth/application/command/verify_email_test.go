@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+func newPendingVerification(t *testing.T, expiresAt time.Time) (*entity.EmailVerification, string) {
+	t.Helper()
+	v, token, err := entity.NewEmailVerification(testUUID, expiresAt)
+	if err != nil {
+		t.Fatalf("NewEmailVerification() error = %v", err)
+	}
+	return v, token
+}
+
+func TestVerifyEmailHandler_Success(t *testing.T) {
+	verification, token := newPendingVerification(t, time.Now().Add(time.Hour))
+	user := makeAuthUser()
+	user.ClearEvents()
+	userRepo := &mockUserRepo{user: user}
+	pub := &mockPublisher{}
+	h := NewVerifyEmailHandler(userRepo, &mockVerificationRepo{verification: verification}, pub, &noopTransactor{})
+
+	if err := h.Handle(context.Background(), VerifyEmail{Token: token}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if userRepo.updated == nil || !userRepo.updated.IsVerified() {
+		t.Error("expected the user to be stored as verified")
+	}
+	if !verification.IsUsed() {
+		t.Error("expected the token to be consumed")
+	}
+	if len(pub.published) != 1 || pub.published[0].EventName() != "user.email_verified" {
+		t.Errorf("expected user.email_verified to be published, got %v", pub.published)
+	}
+}
+
+func TestVerifyEmailHandler_Rejects(t *testing.T) {
+	expired, expiredToken := newPendingVerification(t, time.Now().Add(-time.Minute))
+	used, usedToken := newPendingVerification(t, time.Now().Add(time.Hour))
+	_ = used.Use(time.Now())
+
+	tests := []struct {
+		name         string
+		verification *entity.EmailVerification
+		token        string
+		wantStatus   int
+	}{
+		{"empty token", nil, "", 400},
+		{"unknown token", nil, "nope", 400},
+		{"expired token", expired, expiredToken, 400},
+		{"used token", used, usedToken, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mockUserRepo{user: makeAuthUser()}
+			h := NewVerifyEmailHandler(userRepo, &mockVerificationRepo{verification: tt.verification}, &mockPublisher{}, &noopTransactor{})
+
+			err := h.Handle(context.Background(), VerifyEmail{Token: tt.token})
+			var ce errors.CustomError
+			if !asCustomError(err, &ce) || ce.Status != tt.wantStatus {
+				t.Errorf("expected %d, got %v", tt.wantStatus, err)
+			}
+			if userRepo.updated != nil {
+				t.Error("user must not be verified")
+			}
+		})
+	}
+}
+
+func TestVerifyEmailHandler_ConcurrentUse(t *testing.T) {
+	verification, token := newPendingVerification(t, time.Now().Add(time.Hour))
+	repo := &mockVerificationRepo{verification: verification, updateErr: errors.BadRequest("Invalid verification token")}
+	userRepo := &mockUserRepo{user: makeAuthUser()}
+	h := NewVerifyEmailHandler(userRepo, repo, &mockPublisher{}, &noopTransactor{})
+
+	if err := h.Handle(context.Background(), VerifyEmail{Token: token}); err == nil {
+		t.Fatal("expected error when the token was consumed concurrently")
+	}
+	if userRepo.updated != nil {
+		t.Error("user must not be verified")
+	}
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type GetUser struct {
+	UserID string
+}
+
+type UserResult struct {
+	ID       string
+	Email    string
+	Name     string
+	Verified bool
+}
+
+type GetUserHandler struct {
+	userRepo domain.UserRepository
+}
+
+func NewGetUserHandler(userRepo domain.UserRepository) *GetUserHandler {
+	return &GetUserHandler{userRepo: userRepo}
+}
+
+func (h *GetUserHandler) Handle(ctx context.Context, qry GetUser) (*UserResult, error) {
+	v, err := vo.NewUserIDVO(qry.UserID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	user, err := h.userRepo.FindByID(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.NotFound("User not found")
+	}
+	return &UserResult{ID: user.ID(), Email: user.Email(), Name: user.Name(), Verified: user.IsVerified()}, nil
+}
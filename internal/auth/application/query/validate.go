@@ -16,6 +16,7 @@ type Result struct {
 	UserID   string
 	JTI      string
 	IssuedAt int64
+	Roles    []string
 }
 
 type ValidateHandler struct {
@@ -54,5 +55,5 @@ func (h *ValidateHandler) Handle(ctx context.Context, qry Validate) (*Result, er
 		return nil, errors.Unauthorized("Token has been revoked")
 	}
 
-	return &Result{UserID: claims.UserID, JTI: claims.JTI, IssuedAt: claims.IssuedAt}, nil
+	return &Result{UserID: claims.UserID, JTI: claims.JTI, IssuedAt: claims.IssuedAt, Roles: claims.Roles}, nil
 }
@@ -49,7 +49,7 @@ type mockTokenGen struct {
 	err    error
 }
 
-func (m *mockTokenGen) GenerateAccessToken(_ string) (string, error) { return "", nil }
+func (m *mockTokenGen) GenerateAccessToken(_ string, _ []string) (string, error) { return "", nil }
 func (m *mockTokenGen) ValidateToken(_ string) (*domain.TokenClaims, error) {
 	return m.claims, m.err
 }
@@ -57,7 +57,7 @@ func (m *mockTokenGen) AccessExpirySeconds() int     { return 3600 }
 func (m *mockTokenGen) RefreshExpiry() time.Duration { return 24 * time.Hour }
 
 func TestValidateHandler_ValidToken(t *testing.T) {
-	claims := &domain.TokenClaims{UserID: testUUID, JTI: "jti-1", IssuedAt: time.Now().Unix()}
+	claims := &domain.TokenClaims{UserID: testUUID, JTI: "jti-1", IssuedAt: time.Now().Unix(), Roles: []string{"admin"}}
 	h := NewValidateHandler(&mockTokenRepo{}, &mockTokenGen{claims: claims})
 
 	result, err := h.Handle(context.Background(), Validate{TokenString: "valid-token"})
@@ -70,6 +70,9 @@ func TestValidateHandler_ValidToken(t *testing.T) {
 	if result.JTI != "jti-1" {
 		t.Errorf("expected JTI 'jti-1', got %q", result.JTI)
 	}
+	if len(result.Roles) != 1 || result.Roles[0] != "admin" {
+		t.Errorf("expected roles [admin], got %v", result.Roles)
+	}
 }
 
 func TestValidateHandler_EmptyToken(t *testing.T) {
@@ -9,6 +9,8 @@ import (
 
 type CommandUseCase interface {
 	Register(ctx context.Context, cmd command.Register) error
+	VerifyEmail(ctx context.Context, cmd command.VerifyEmail) error
+	ResendVerification(ctx context.Context, cmd command.ResendVerification) error
 	Login(ctx context.Context, cmd command.Login) (*command.LoginResult, error)
 	Refresh(ctx context.Context, cmd command.Refresh) (*command.RefreshResult, error)
 	Logout(ctx context.Context, cmd command.Logout) error
@@ -17,6 +19,7 @@ type CommandUseCase interface {
 
 type QueryUseCase interface {
 	ValidateToken(ctx context.Context, qry query.Validate) (*query.Result, error)
+	GetUser(ctx context.Context, qry query.GetUser) (*query.UserResult, error)
 }
 
 var (
@@ -25,29 +28,38 @@ var (
 )
 
 type service struct {
-	register  *command.RegisterHandler
-	login     *command.LoginHandler
-	refresh   *command.RefreshHandler
-	logout    *command.LogoutHandler
-	logoutAll *command.LogoutAllHandler
-	validate  *query.ValidateHandler
+	register           *command.RegisterHandler
+	verifyEmail        *command.VerifyEmailHandler
+	resendVerification *command.ResendVerificationHandler
+	login              *command.LoginHandler
+	refresh            *command.RefreshHandler
+	logout             *command.LogoutHandler
+	logoutAll          *command.LogoutAllHandler
+	validate           *query.ValidateHandler
+	getUser            *query.GetUserHandler
 }
 
 func NewService(
 	register *command.RegisterHandler,
+	verifyEmail *command.VerifyEmailHandler,
+	resendVerification *command.ResendVerificationHandler,
 	login *command.LoginHandler,
 	refresh *command.RefreshHandler,
 	logout *command.LogoutHandler,
 	logoutAll *command.LogoutAllHandler,
 	validate *query.ValidateHandler,
+	getUser *query.GetUserHandler,
 ) *service {
 	return &service{
-		register:  register,
-		login:     login,
-		refresh:   refresh,
-		logout:    logout,
-		logoutAll: logoutAll,
-		validate:  validate,
+		register:           register,
+		verifyEmail:        verifyEmail,
+		resendVerification: resendVerification,
+		login:              login,
+		refresh:            refresh,
+		logout:             logout,
+		logoutAll:          logoutAll,
+		validate:           validate,
+		getUser:            getUser,
 	}
 }
 
@@ -55,6 +67,14 @@ func (s *service) Register(ctx context.Context, cmd command.Register) error {
 	return s.register.Handle(ctx, cmd)
 }
 
+func (s *service) VerifyEmail(ctx context.Context, cmd command.VerifyEmail) error {
+	return s.verifyEmail.Handle(ctx, cmd)
+}
+
+func (s *service) ResendVerification(ctx context.Context, cmd command.ResendVerification) error {
+	return s.resendVerification.Handle(ctx, cmd)
+}
+
 func (s *service) Login(ctx context.Context, cmd command.Login) (*command.LoginResult, error) {
 	return s.login.Handle(ctx, cmd)
 }
@@ -74,3 +94,7 @@ func (s *service) LogoutAll(ctx context.Context, cmd command.LogoutAll) error {
 func (s *service) ValidateToken(ctx context.Context, qry query.Validate) (*query.Result, error) {
 	return s.validate.Handle(ctx, qry)
 }
+
+func (s *service) GetUser(ctx context.Context, qry query.GetUser) (*query.UserResult, error) {
+	return s.getUser.Handle(ctx, qry)
+}
@@ -9,12 +9,16 @@ import (
 	"github.com/in-jun/go-structure-example/internal/auth/application/query"
 	"github.com/in-jun/go-structure-example/internal/auth/domain"
 	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/event"
+	domainService "github.com/in-jun/go-structure-example/internal/auth/domain/service"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 )
 
 const testUUID = "550e8400-e29b-41d4-a716-446655440000"
 
+var testVerificationPolicy = domainService.NewVerificationPolicy(24*time.Hour, time.Minute, "http://localhost/verify")
+
 type noopTransactor struct{}
 
 func (n *noopTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error, _ ...transaction.TxOption) error {
@@ -30,6 +34,34 @@ func (m *mockUserRepo) Save(_ context.Context, _ *entity.User) error { return m.
 func (m *mockUserRepo) FindByEmail(_ context.Context, _ string) (*entity.User, error) {
 	return m.user, m.err
 }
+func (m *mockUserRepo) FindByID(_ context.Context, _ string) (*entity.User, error) {
+	return m.user, m.err
+}
+func (m *mockUserRepo) Update(_ context.Context, _ *entity.User) error { return m.err }
+
+type mockVerificationRepo struct{}
+
+func (m *mockVerificationRepo) Save(_ context.Context, _ *entity.EmailVerification) error {
+	return nil
+}
+func (m *mockVerificationRepo) FindByTokenHash(_ context.Context, _ string) (*entity.EmailVerification, error) {
+	return nil, nil
+}
+func (m *mockVerificationRepo) FindLatestByUserID(_ context.Context, _ string) (*entity.EmailVerification, error) {
+	return nil, nil
+}
+func (m *mockVerificationRepo) Update(_ context.Context, _ *entity.EmailVerification) error {
+	return nil
+}
+func (m *mockVerificationRepo) DeleteUnusedByUserID(_ context.Context, _ string) error { return nil }
+
+type mockOutbox struct{}
+
+func (m *mockOutbox) Save(_ context.Context, _ *entity.OutboundEmail) error { return nil }
+func (m *mockOutbox) FindDue(_ context.Context, _ time.Time, _ int) ([]*entity.OutboundEmail, error) {
+	return nil, nil
+}
+func (m *mockOutbox) Update(_ context.Context, _ *entity.OutboundEmail) error { return nil }
 
 type mockTokenRepo struct {
 	token *entity.RefreshToken
@@ -61,6 +93,8 @@ func (m *mockTokenRepo) IsRevokedForUser(_ context.Context, _ string, _ int64) (
 
 var _ domain.TokenRepository = (*mockTokenRepo)(nil)
 var _ domain.UserRepository = (*mockUserRepo)(nil)
+var _ domain.EmailVerificationRepository = (*mockVerificationRepo)(nil)
+var _ domain.EmailOutbox = (*mockOutbox)(nil)
 var _ domain.TokenGenerator = (*mockTokenGen)(nil)
 var _ domain.PasswordHasher = (*mockHasher)(nil)
 var _ transaction.Transactor = (*noopTransactor)(nil)
@@ -71,7 +105,7 @@ type mockTokenGen struct {
 	err         error
 }
 
-func (m *mockTokenGen) GenerateAccessToken(_ string) (string, error) {
+func (m *mockTokenGen) GenerateAccessToken(_ string, _ []string) (string, error) {
 	return m.accessToken, m.err
 }
 func (m *mockTokenGen) ValidateToken(_ string) (*domain.TokenClaims, error) {
@@ -80,6 +114,10 @@ func (m *mockTokenGen) ValidateToken(_ string) (*domain.TokenClaims, error) {
 func (m *mockTokenGen) AccessExpirySeconds() int     { return 3600 }
 func (m *mockTokenGen) RefreshExpiry() time.Duration { return 24 * time.Hour }
 
+type mockPublisher struct{}
+
+func (m *mockPublisher) Publish(_ context.Context, _ ...event.Event) error { return nil }
+
 type mockHasher struct{}
 
 func (m *mockHasher) Hash(password string) (string, error) { return "hashed_" + password, nil }
@@ -88,12 +126,15 @@ func (m *mockHasher) Compare(hashed, plain string) bool    { return hashed == "h
 func newTestService(userRepo *mockUserRepo, tokenRepo *mockTokenRepo, tokenGen *mockTokenGen) *service {
 	hasher := &mockHasher{}
 	return NewService(
-		command.NewRegisterHandler(userRepo, hasher, &noopTransactor{}),
+		command.NewRegisterHandler(userRepo, &mockVerificationRepo{}, &mockOutbox{}, hasher, testVerificationPolicy, &mockPublisher{}, &noopTransactor{}),
+		command.NewVerifyEmailHandler(userRepo, &mockVerificationRepo{}, &mockPublisher{}, &noopTransactor{}),
+		command.NewResendVerificationHandler(userRepo, &mockVerificationRepo{}, &mockOutbox{}, testVerificationPolicy, &noopTransactor{}),
 		command.NewLoginHandler(userRepo, tokenRepo, tokenGen, hasher),
-		command.NewRefreshHandler(tokenRepo, tokenGen),
+		command.NewRefreshHandler(userRepo, tokenRepo, tokenGen),
 		command.NewLogoutHandler(tokenRepo, tokenGen),
 		command.NewLogoutAllHandler(tokenRepo, tokenGen),
 		query.NewValidateHandler(tokenRepo, tokenGen),
+		query.NewGetUserHandler(userRepo),
 	)
 }
 
@@ -111,7 +152,7 @@ func TestAuthService_Register(t *testing.T) {
 }
 
 func TestAuthService_Register_DuplicateEmail(t *testing.T) {
-	existingUser, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Existing", time.Now(), time.Now())
+	existingUser, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Existing", nil, time.Now(), time.Now(), time.Now())
 	svc := newTestService(&mockUserRepo{user: existingUser}, &mockTokenRepo{}, &mockTokenGen{})
 
 	err := svc.Register(context.Background(), command.Register{
@@ -125,7 +166,7 @@ func TestAuthService_Register_DuplicateEmail(t *testing.T) {
 }
 
 func TestAuthService_Login(t *testing.T) {
-	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed_password123", "Test", time.Now(), time.Now())
+	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed_password123", "Test", nil, time.Now(), time.Now(), time.Now())
 	tokenGen := &mockTokenGen{accessToken: "access-token"}
 	svc := newTestService(&mockUserRepo{user: user}, &mockTokenRepo{}, tokenGen)
 
@@ -142,7 +183,7 @@ func TestAuthService_Login(t *testing.T) {
 }
 
 func TestAuthService_Login_InvalidPassword(t *testing.T) {
-	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed_correct", "Test", time.Now(), time.Now())
+	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed_correct", "Test", nil, time.Now(), time.Now(), time.Now())
 	svc := newTestService(&mockUserRepo{user: user}, &mockTokenRepo{}, &mockTokenGen{})
 
 	_, err := svc.Login(context.Background(), command.Login{
@@ -177,19 +218,22 @@ func (m *reuseDetectTokenRepo) FindUsedToken(_ context.Context, _ string) (strin
 func newServiceWithRepo(tokenRepo domain.TokenRepository) *service {
 	tokenGen := &mockTokenGen{}
 	return &service{
-		register:  command.NewRegisterHandler(&mockUserRepo{}, &mockHasher{}, &noopTransactor{}),
+		register: command.NewRegisterHandler(&mockUserRepo{}, &mockVerificationRepo{}, &mockOutbox{}, &mockHasher{},
+			testVerificationPolicy, &mockPublisher{}, &noopTransactor{}),
 		login:     command.NewLoginHandler(&mockUserRepo{}, tokenRepo, tokenGen, &mockHasher{}),
-		refresh:   command.NewRefreshHandler(tokenRepo, tokenGen),
+		refresh:   command.NewRefreshHandler(&mockUserRepo{}, tokenRepo, tokenGen),
 		logout:    command.NewLogoutHandler(tokenRepo, tokenGen),
 		logoutAll: command.NewLogoutAllHandler(tokenRepo, tokenGen),
 		validate:  query.NewValidateHandler(tokenRepo, tokenGen),
+		getUser:   query.NewGetUserHandler(&mockUserRepo{}),
 	}
 }
 
 func TestAuthService_Refresh(t *testing.T) {
 	validToken, _ := entity.ReconstructRefreshToken("valid-token", testUUID, time.Now().Add(time.Hour))
+	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Test", nil, time.Now(), time.Now(), time.Now())
 	tokenGen := &mockTokenGen{accessToken: "new-access-token"}
-	svc := newTestService(&mockUserRepo{}, &mockTokenRepo{token: validToken}, tokenGen)
+	svc := newTestService(&mockUserRepo{user: user}, &mockTokenRepo{token: validToken}, tokenGen)
 
 	result, err := svc.Refresh(context.Background(), command.Refresh{RefreshToken: "valid-token"})
 	if err != nil {
@@ -276,3 +320,25 @@ func TestAuthService_ValidateToken_Invalid(t *testing.T) {
 		t.Fatal("expected error for invalid token, got nil")
 	}
 }
+
+func TestAuthService_GetUser(t *testing.T) {
+	user, _ := entity.ReconstructUser(testUUID, "test@example.com", "hashed", "Test", nil, time.Now(), time.Now(), time.Now())
+	svc := newTestService(&mockUserRepo{user: user}, &mockTokenRepo{}, &mockTokenGen{})
+
+	result, err := svc.GetUser(context.Background(), query.GetUser{UserID: testUUID})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if result.Email != "test@example.com" || result.Name != "Test" {
+		t.Errorf("unexpected user %+v", result)
+	}
+}
+
+func TestAuthService_GetUser_NotFound(t *testing.T) {
+	svc := newTestService(&mockUserRepo{}, &mockTokenRepo{}, &mockTokenGen{})
+
+	_, err := svc.GetUser(context.Background(), query.GetUser{UserID: testUUID})
+	if err == nil {
+		t.Fatal("expected error for missing user, got nil")
+	}
+}
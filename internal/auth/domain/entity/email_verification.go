@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVerificationExpired = errors.New("verification token has expired")
+	ErrVerificationUsed    = errors.New("verification token has already been used")
+
+	errInvalidEmailVerification            = errors.New("user ID and expiration time are required")
+	errInvalidReconstructEmailVerification = errors.New("id, user ID, token hash, and expiration time are required")
+)
+
+// EmailVerification is a single-use proof that a user can read mail sent to
+// their address. Only the token's hash is kept; the raw token exists in the
+// email alone.
+type EmailVerification struct {
+	id        string
+	userID    string
+	tokenHash string
+	expiresAt time.Time
+	usedAt    time.Time
+	createdAt time.Time
+}
+
+// NewEmailVerification returns the verification and the raw token to mail.
+func NewEmailVerification(userID string, expiresAt time.Time) (*EmailVerification, string, error) {
+	if userID == "" || expiresAt.IsZero() {
+		return nil, "", errInvalidEmailVerification
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return &EmailVerification{
+		id:        uuid.New().String(),
+		userID:    userID,
+		tokenHash: HashVerificationToken(token),
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, token, nil
+}
+
+func ReconstructEmailVerification(id, userID, tokenHash string, expiresAt, usedAt, createdAt time.Time) (*EmailVerification, error) {
+	if id == "" || userID == "" || tokenHash == "" || expiresAt.IsZero() {
+		return nil, errInvalidReconstructEmailVerification
+	}
+	return &EmailVerification{
+		id: id, userID: userID, tokenHash: tokenHash,
+		expiresAt: expiresAt, usedAt: usedAt, createdAt: createdAt,
+	}, nil
+}
+
+func HashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (v *EmailVerification) ID() string           { return v.id }
+func (v *EmailVerification) UserID() string       { return v.userID }
+func (v *EmailVerification) TokenHash() string    { return v.tokenHash }
+func (v *EmailVerification) ExpiresAt() time.Time { return v.expiresAt }
+func (v *EmailVerification) UsedAt() time.Time    { return v.usedAt }
+func (v *EmailVerification) CreatedAt() time.Time { return v.createdAt }
+
+func (v *EmailVerification) IsUsed() bool { return !v.usedAt.IsZero() }
+
+func (v *EmailVerification) Use(now time.Time) error {
+	if v.IsUsed() {
+		return ErrVerificationUsed
+	}
+	if !now.Before(v.expiresAt) {
+		return ErrVerificationExpired
+	}
+	v.usedAt = now
+	return nil
+}
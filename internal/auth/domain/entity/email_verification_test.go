@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEmailVerification(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	v, token, err := NewEmailVerification(testUUID, expiresAt)
+	if err != nil {
+		t.Fatalf("NewEmailVerification() error = %v", err)
+	}
+	if token == "" {
+		t.Fatal("expected a raw token")
+	}
+	if v.TokenHash() == token || v.TokenHash() != HashVerificationToken(token) {
+		t.Errorf("TokenHash() = %q, want the hash of the raw token", v.TokenHash())
+	}
+
+	if _, _, err := NewEmailVerification("", expiresAt); err == nil {
+		t.Error("expected error for empty user ID")
+	}
+	if _, _, err := NewEmailVerification(testUUID, time.Time{}); err == nil {
+		t.Error("expected error for zero expiry")
+	}
+}
+
+func TestEmailVerification_Use(t *testing.T) {
+	now := time.Now()
+	v, _, _ := NewEmailVerification(testUUID, now.Add(time.Hour))
+
+	if err := v.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if !v.IsUsed() {
+		t.Error("expected verification to be used")
+	}
+	if err := v.Use(now); err != ErrVerificationUsed {
+		t.Errorf("second Use() error = %v, want %v", err, ErrVerificationUsed)
+	}
+}
+
+func TestEmailVerification_UseExpired(t *testing.T) {
+	now := time.Now()
+	v, _, _ := NewEmailVerification(testUUID, now)
+
+	if err := v.Use(now); err != ErrVerificationExpired {
+		t.Errorf("Use() error = %v, want %v", err, ErrVerificationExpired)
+	}
+	if v.IsUsed() {
+		t.Error("expired verification must not be consumed")
+	}
+}
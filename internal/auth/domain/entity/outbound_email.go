@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutboundEmailStatusPending = "pending"
+	OutboundEmailStatusSent    = "sent"
+	OutboundEmailStatusFailed  = "failed"
+)
+
+const (
+	outboundEmailMaxAttempts = 8
+	outboundEmailBaseDelay   = 30 * time.Second
+	outboundEmailMaxDelay    = time.Hour
+)
+
+var (
+	ErrOutboundEmailNotPending = errors.New("email is not in pending status")
+
+	errInvalidOutboundEmail = errors.New("recipient, subject, and body are required")
+)
+
+// OutboundEmail is a message queued in the same transaction as the change
+// that triggered it and handed to the mailer afterwards.
+type OutboundEmail struct {
+	id            string
+	to            string
+	subject       string
+	body          string
+	status        string
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func NewOutboundEmail(to, subject, body string) (*OutboundEmail, error) {
+	if to == "" || subject == "" || body == "" {
+		return nil, errInvalidOutboundEmail
+	}
+	now := time.Now()
+	return &OutboundEmail{
+		id:            uuid.New().String(),
+		to:            to,
+		subject:       subject,
+		body:          body,
+		status:        OutboundEmailStatusPending,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+func ReconstructOutboundEmail(
+	id, to, subject, body, status string,
+	attempts int, lastError string, nextAttemptAt, createdAt, updatedAt time.Time,
+) *OutboundEmail {
+	return &OutboundEmail{
+		id: id, to: to, subject: subject, body: body, status: status,
+		attempts: attempts, lastError: lastError, nextAttemptAt: nextAttemptAt,
+		createdAt: createdAt, updatedAt: updatedAt,
+	}
+}
+
+func (e *OutboundEmail) ID() string               { return e.id }
+func (e *OutboundEmail) To() string               { return e.to }
+func (e *OutboundEmail) Subject() string          { return e.subject }
+func (e *OutboundEmail) Body() string             { return e.body }
+func (e *OutboundEmail) Status() string           { return e.status }
+func (e *OutboundEmail) Attempts() int            { return e.attempts }
+func (e *OutboundEmail) LastError() string        { return e.lastError }
+func (e *OutboundEmail) NextAttemptAt() time.Time { return e.nextAttemptAt }
+func (e *OutboundEmail) CreatedAt() time.Time     { return e.createdAt }
+func (e *OutboundEmail) UpdatedAt() time.Time     { return e.updatedAt }
+
+func (e *OutboundEmail) IsPending() bool { return e.status == OutboundEmailStatusPending }
+
+func (e *OutboundEmail) MarkSent(now time.Time) error {
+	if !e.IsPending() {
+		return ErrOutboundEmailNotPending
+	}
+	e.attempts++
+	e.lastError = ""
+	e.status = OutboundEmailStatusSent
+	e.updatedAt = now
+	return nil
+}
+
+// MarkFailed schedules another attempt with exponential backoff, giving up
+// after outboundEmailMaxAttempts.
+func (e *OutboundEmail) MarkFailed(err error, now time.Time) error {
+	if !e.IsPending() {
+		return ErrOutboundEmailNotPending
+	}
+	e.attempts++
+	e.lastError = err.Error()
+	e.updatedAt = now
+	if e.attempts >= outboundEmailMaxAttempts {
+		e.status = OutboundEmailStatusFailed
+		return nil
+	}
+	delay := outboundEmailBaseDelay
+	for i := 1; i < e.attempts && delay < outboundEmailMaxDelay; i++ {
+		delay *= 2
+	}
+	e.nextAttemptAt = now.Add(min(delay, outboundEmailMaxDelay))
+	return nil
+}
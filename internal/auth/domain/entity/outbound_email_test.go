@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewOutboundEmail(t *testing.T) {
+	e, err := NewOutboundEmail("test@example.com", "Subject", "Body")
+	if err != nil {
+		t.Fatalf("NewOutboundEmail() error = %v", err)
+	}
+	if !e.IsPending() || e.NextAttemptAt().IsZero() {
+		t.Errorf("expected a pending email due now, got status %q", e.Status())
+	}
+	if _, err := NewOutboundEmail("", "Subject", "Body"); err == nil {
+		t.Error("expected error for empty recipient")
+	}
+}
+
+func TestOutboundEmail_MarkSent(t *testing.T) {
+	e, _ := NewOutboundEmail("test@example.com", "Subject", "Body")
+	if err := e.MarkSent(time.Now()); err != nil {
+		t.Fatalf("MarkSent() error = %v", err)
+	}
+	if e.Status() != OutboundEmailStatusSent || e.Attempts() != 1 {
+		t.Errorf("status = %q attempts = %d, want sent after 1", e.Status(), e.Attempts())
+	}
+	if err := e.MarkSent(time.Now()); err != ErrOutboundEmailNotPending {
+		t.Errorf("MarkSent() error = %v, want %v", err, ErrOutboundEmailNotPending)
+	}
+}
+
+func TestOutboundEmail_MarkFailedBacksOff(t *testing.T) {
+	e, _ := NewOutboundEmail("test@example.com", "Subject", "Body")
+	now := time.Now()
+	sendErr := errors.New("connection refused")
+
+	_ = e.MarkFailed(sendErr, now)
+	if got := e.NextAttemptAt().Sub(now); got != outboundEmailBaseDelay {
+		t.Errorf("first retry after %v, want %v", got, outboundEmailBaseDelay)
+	}
+	_ = e.MarkFailed(sendErr, now)
+	if got := e.NextAttemptAt().Sub(now); got != 2*outboundEmailBaseDelay {
+		t.Errorf("second retry after %v, want %v", got, 2*outboundEmailBaseDelay)
+	}
+	if e.LastError() != sendErr.Error() || !e.IsPending() {
+		t.Errorf("status = %q last error = %q", e.Status(), e.LastError())
+	}
+
+	for e.IsPending() {
+		_ = e.MarkFailed(sendErr, now)
+	}
+	if e.Status() != OutboundEmailStatusFailed || e.Attempts() != outboundEmailMaxAttempts {
+		t.Errorf("status = %q attempts = %d, want failed after %d", e.Status(), e.Attempts(), outboundEmailMaxAttempts)
+	}
+}
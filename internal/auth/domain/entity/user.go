@@ -5,48 +5,82 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/event"
+)
+
+const (
+	RoleAdmin  = "admin"
+	RoleSeller = "seller"
+	RoleBidder = "bidder"
 )
 
 var (
+	ErrUserAlreadyVerified = errors.New("email is already verified")
+
+	errUnknownRole            = errors.New("unknown role")
 	errInvalidUser            = errors.New("email, hashed password, and name are required")
 	errInvalidReconstructUser = errors.New("id, email, hashed password, and name are required")
 )
 
 type User struct {
-	id        string
-	email     string
-	password  string
-	name      string
-	createdAt time.Time
-	updatedAt time.Time
+	id         string
+	email      string
+	password   string
+	name       string
+	roles      []string
+	verifiedAt time.Time
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	events []event.Event
+}
+
+func IsKnownRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleSeller, RoleBidder:
+		return true
+	}
+	return false
 }
 
+// NewUser registers a user who may both bid and sell once their email is
+// verified. Admins are granted the role out of band.
 func NewUser(email, hashedPassword, name string) (*User, error) {
 	if email == "" || hashedPassword == "" || name == "" {
 		return nil, errInvalidUser
 	}
 	now := time.Now()
-	return &User{
+	u := &User{
 		id:        uuid.New().String(),
 		email:     email,
 		password:  hashedPassword,
 		name:      name,
+		roles:     []string{RoleBidder, RoleSeller},
 		createdAt: now,
 		updatedAt: now,
-	}, nil
+	}
+	u.record(event.NewUserRegistered(u.id, email, name, now))
+	return u, nil
 }
 
-func ReconstructUser(id, email, password, name string, createdAt, updatedAt time.Time) (*User, error) {
+func ReconstructUser(id, email, password, name string, roles []string, verifiedAt, createdAt, updatedAt time.Time) (*User, error) {
 	if id == "" || email == "" || password == "" || name == "" {
 		return nil, errInvalidReconstructUser
 	}
+	for _, role := range roles {
+		if !IsKnownRole(role) {
+			return nil, errUnknownRole
+		}
+	}
 	return &User{
-		id:        id,
-		email:     email,
-		password:  password,
-		name:      name,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:         id,
+		email:      email,
+		password:   password,
+		name:       name,
+		roles:      roles,
+		verifiedAt: verifiedAt,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
 	}, nil
 }
 
@@ -54,5 +88,47 @@ func (u *User) ID() string             { return u.id }
 func (u *User) Email() string          { return u.email }
 func (u *User) HashedPassword() string { return u.password }
 func (u *User) Name() string           { return u.name }
+func (u *User) Roles() []string        { return append([]string(nil), u.roles...) }
+func (u *User) VerifiedAt() time.Time  { return u.verifiedAt }
 func (u *User) CreatedAt() time.Time   { return u.createdAt }
 func (u *User) UpdatedAt() time.Time   { return u.updatedAt }
+
+func (u *User) IsVerified() bool { return !u.verifiedAt.IsZero() }
+
+// ActiveRoles are the roles to put in an access token. Bidding and selling
+// stay locked until the email address is verified.
+func (u *User) ActiveRoles() []string {
+	if u.IsVerified() {
+		return u.Roles()
+	}
+	var roles []string
+	for _, r := range u.roles {
+		if r != RoleBidder && r != RoleSeller {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+func (u *User) Verify(now time.Time) error {
+	if u.IsVerified() {
+		return ErrUserAlreadyVerified
+	}
+	u.verifiedAt = now
+	u.updatedAt = now
+	u.record(event.NewUserEmailVerified(u.id, u.email, now))
+	return nil
+}
+
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *User) Events() []event.Event { return u.events }
+func (u *User) ClearEvents()          { u.events = nil }
+func (u *User) record(e event.Event)  { u.events = append(u.events, e) }
@@ -47,17 +47,20 @@ func TestReconstructUser(t *testing.T) {
 		email     string
 		password  string
 		userName  string
+		roles     []string
 		wantError bool
 	}{
-		{"valid", testUUID, "test@example.com", "hashed", "Test", false},
-		{"empty id", "", "test@example.com", "hashed", "Test", true},
-		{"empty email", testUUID, "", "hashed", "Test", true},
-		{"empty password", testUUID, "test@example.com", "", "Test", true},
+		{"valid", testUUID, "test@example.com", "hashed", "Test", []string{RoleBidder}, false},
+		{"no roles", testUUID, "test@example.com", "hashed", "Test", nil, false},
+		{"empty id", "", "test@example.com", "hashed", "Test", nil, true},
+		{"empty email", testUUID, "", "hashed", "Test", nil, true},
+		{"empty password", testUUID, "test@example.com", "", "Test", nil, true},
+		{"unknown role", testUUID, "test@example.com", "hashed", "Test", []string{"root"}, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			user, err := ReconstructUser(tt.id, tt.email, tt.password, tt.userName, now, now)
+			user, err := ReconstructUser(tt.id, tt.email, tt.password, tt.userName, tt.roles, time.Time{}, now, now)
 			if tt.wantError && err == nil {
 				t.Errorf("expected error, got %+v", user)
 			}
@@ -67,3 +70,79 @@ func TestReconstructUser(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUser_DefaultRoles(t *testing.T) {
+	user, err := NewUser("test@example.com", "hashed_password", "Test User")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if !user.HasRole(RoleBidder) || !user.HasRole(RoleSeller) {
+		t.Errorf("Roles() = %v, want bidder and seller", user.Roles())
+	}
+	if user.HasRole(RoleAdmin) {
+		t.Error("new users must not be admins")
+	}
+}
+
+func TestNewUser_RecordsRegisteredEvent(t *testing.T) {
+	user, err := NewUser("test@example.com", "hashed_password", "Test User")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	events := user.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].EventName() != "user.registered" {
+		t.Errorf("EventName = %q, want user.registered", events[0].EventName())
+	}
+	if events[0].AggregateID() != user.ID() {
+		t.Errorf("AggregateID = %q, want %q", events[0].AggregateID(), user.ID())
+	}
+
+	user.ClearEvents()
+	if len(user.Events()) != 0 {
+		t.Error("expected events to be cleared")
+	}
+}
+
+func TestUser_UnverifiedCannotBidOrSell(t *testing.T) {
+	user, err := NewUser("test@example.com", "hashed_password", "Test User")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if user.IsVerified() {
+		t.Fatal("new users must start unverified")
+	}
+	if roles := user.ActiveRoles(); len(roles) != 0 {
+		t.Errorf("ActiveRoles() = %v, want none before verification", roles)
+	}
+
+	admin, _ := ReconstructUser(testUUID, "admin@example.com", "hashed", "Admin",
+		[]string{RoleAdmin, RoleBidder}, time.Time{}, time.Now(), time.Now())
+	if roles := admin.ActiveRoles(); len(roles) != 1 || roles[0] != RoleAdmin {
+		t.Errorf("ActiveRoles() = %v, want [admin]", roles)
+	}
+}
+
+func TestUser_Verify(t *testing.T) {
+	user, _ := NewUser("test@example.com", "hashed_password", "Test User")
+	user.ClearEvents()
+	now := time.Now()
+
+	if err := user.Verify(now); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !user.IsVerified() || !user.VerifiedAt().Equal(now) {
+		t.Errorf("VerifiedAt() = %v, want %v", user.VerifiedAt(), now)
+	}
+	if !user.HasRole(RoleBidder) || len(user.ActiveRoles()) != 2 {
+		t.Errorf("ActiveRoles() = %v, want bidder and seller", user.ActiveRoles())
+	}
+	if events := user.Events(); len(events) != 1 || events[0].EventName() != "user.email_verified" {
+		t.Errorf("expected user.email_verified, got %v", events)
+	}
+	if err := user.Verify(now); err != ErrUserAlreadyVerified {
+		t.Errorf("second Verify() error = %v, want %v", err, ErrUserAlreadyVerified)
+	}
+}
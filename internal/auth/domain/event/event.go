@@ -0,0 +1,38 @@
+package event
+
+import (
+	"time"
+
+	sharedevent "github.com/in-jun/go-structure-example/internal/shared/event"
+)
+
+type Event = sharedevent.Event
+
+type UserRegistered struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewUserRegistered(userID, email, name string, registeredAt time.Time) UserRegistered {
+	return UserRegistered{UserID: userID, Email: email, Name: name, Timestamp: registeredAt}
+}
+
+func (e UserRegistered) EventName() string     { return "user.registered" }
+func (e UserRegistered) AggregateID() string   { return e.UserID }
+func (e UserRegistered) OccurredAt() time.Time { return e.Timestamp }
+
+type UserEmailVerified struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewUserEmailVerified(userID, email string, verifiedAt time.Time) UserEmailVerified {
+	return UserEmailVerified{UserID: userID, Email: email, Timestamp: verifiedAt}
+}
+
+func (e UserEmailVerified) EventName() string     { return "user.email_verified" }
+func (e UserEmailVerified) AggregateID() string   { return e.UserID }
+func (e UserEmailVerified) OccurredAt() time.Time { return e.Timestamp }
@@ -0,0 +1,31 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserRegistered_EventName(t *testing.T) {
+	now := time.Now()
+	e := NewUserRegistered("user-id", "test@example.com", "Test", now)
+	if e.EventName() != "user.registered" {
+		t.Errorf("EventName = %q, want user.registered", e.EventName())
+	}
+	if e.AggregateID() != "user-id" {
+		t.Errorf("AggregateID = %q, want user-id", e.AggregateID())
+	}
+	if !e.OccurredAt().Equal(now) {
+		t.Errorf("OccurredAt = %v, want %v", e.OccurredAt(), now)
+	}
+}
+
+func TestUserEmailVerified_EventName(t *testing.T) {
+	now := time.Now()
+	e := NewUserEmailVerified("user-id", "test@example.com", now)
+	if e.EventName() != "user.email_verified" {
+		t.Errorf("EventName = %q, want user.email_verified", e.EventName())
+	}
+	if e.AggregateID() != "user-id" {
+		t.Errorf("AggregateID = %q, want user-id", e.AggregateID())
+	}
+}
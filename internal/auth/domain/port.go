@@ -5,11 +5,30 @@ import (
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/event"
 )
 
 type UserRepository interface {
 	Save(ctx context.Context, user *entity.User) error
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindByID(ctx context.Context, id string) (*entity.User, error)
+	Update(ctx context.Context, user *entity.User) error
+}
+
+type EmailVerificationRepository interface {
+	Save(ctx context.Context, verification *entity.EmailVerification) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.EmailVerification, error)
+	FindLatestByUserID(ctx context.Context, userID string) (*entity.EmailVerification, error)
+	Update(ctx context.Context, verification *entity.EmailVerification) error
+	DeleteUnusedByUserID(ctx context.Context, userID string) error
+}
+
+// EmailOutbox holds mail written alongside the state change that caused
+// it, so a rolled back transaction never sends and a crash never loses one.
+type EmailOutbox interface {
+	Save(ctx context.Context, email *entity.OutboundEmail) error
+	FindDue(ctx context.Context, before time.Time, limit int) ([]*entity.OutboundEmail, error)
+	Update(ctx context.Context, email *entity.OutboundEmail) error
 }
 
 type TokenRepository interface {
@@ -29,10 +48,11 @@ type TokenClaims struct {
 	UserID   string
 	JTI      string
 	IssuedAt int64
+	Roles    []string
 }
 
 type TokenGenerator interface {
-	GenerateAccessToken(userID string) (string, error)
+	GenerateAccessToken(userID string, roles []string) (string, error)
 	ValidateToken(tokenString string) (*TokenClaims, error)
 	AccessExpirySeconds() int
 	RefreshExpiry() time.Duration
@@ -42,3 +62,17 @@ type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Compare(hashedPassword, password string) bool
 }
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...event.Event) error
+}
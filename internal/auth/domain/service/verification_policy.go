@@ -0,0 +1,37 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+)
+
+// VerificationPolicy decides how long an email verification link stays
+// valid, how often a user may ask for a new one, and where it points.
+type VerificationPolicy struct {
+	ttl            time.Duration
+	resendCooldown time.Duration
+	linkURL        string
+}
+
+func NewVerificationPolicy(ttl, resendCooldown time.Duration, linkURL string) *VerificationPolicy {
+	return &VerificationPolicy{ttl: ttl, resendCooldown: resendCooldown, linkURL: linkURL}
+}
+
+func (p *VerificationPolicy) ExpiresAt(now time.Time) time.Time { return now.Add(p.ttl) }
+
+// CanResend reports whether the cooldown since the latest verification
+// mail has passed; latest may be nil.
+func (p *VerificationPolicy) CanResend(latest *entity.EmailVerification, now time.Time) bool {
+	return latest == nil || !now.Before(latest.CreatedAt().Add(p.resendCooldown))
+}
+
+func (p *VerificationPolicy) Link(token string) string {
+	sep := "?"
+	if strings.Contains(p.linkURL, "?") {
+		sep = "&"
+	}
+	return p.linkURL + sep + "token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+)
+
+func TestVerificationPolicy_CanResend(t *testing.T) {
+	p := NewVerificationPolicy(24*time.Hour, time.Minute, "https://example.com/verify")
+	latest, _ := entity.ReconstructEmailVerification("id", "user", "hash",
+		time.Now().Add(time.Hour), time.Time{}, time.Now())
+
+	if !p.CanResend(nil, time.Now()) {
+		t.Error("expected resend without a previous verification")
+	}
+	if p.CanResend(latest, latest.CreatedAt().Add(30*time.Second)) {
+		t.Error("expected resend to be blocked during the cooldown")
+	}
+	if !p.CanResend(latest, latest.CreatedAt().Add(time.Minute)) {
+		t.Error("expected resend once the cooldown has passed")
+	}
+}
+
+func TestVerificationPolicy_Link(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/verify", "https://example.com/verify?token=a%2Bb"},
+		{"https://example.com/verify?lang=en", "https://example.com/verify?lang=en&token=a%2Bb"},
+	}
+	for _, tt := range tests {
+		p := NewVerificationPolicy(time.Hour, time.Minute, tt.url)
+		if got := p.Link("a+b"); got != tt.want {
+			t.Errorf("Link() = %q, want %q", got, tt.want)
+		}
+	}
+}
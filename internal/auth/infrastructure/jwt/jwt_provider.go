@@ -13,17 +13,18 @@ import (
 var _ domain.TokenGenerator = (*provider)(nil)
 
 type claims struct {
-	UserID string `json:"user_id"`
+	UserID string   `json:"user_id"`
+	Roles  []string `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type provider struct {
-	secretKey     []byte
+	keys          *KeySet
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 }
 
-func NewProvider(secret, accessExpiry, refreshExpiry string) (domain.TokenGenerator, error) {
+func NewProvider(keys *KeySet, accessExpiry, refreshExpiry string) (domain.TokenGenerator, error) {
 	access, err := time.ParseDuration(accessExpiry)
 	if err != nil {
 		return nil, fmt.Errorf("invalid access expiry %q: %w", accessExpiry, err)
@@ -33,35 +34,43 @@ func NewProvider(secret, accessExpiry, refreshExpiry string) (domain.TokenGenera
 		return nil, fmt.Errorf("invalid refresh expiry %q: %w", refreshExpiry, err)
 	}
 	return &provider{
-		secretKey:     []byte(secret),
+		keys:          keys,
 		accessExpiry:  access,
 		refreshExpiry: refresh,
 	}, nil
 }
 
-func (p *provider) GenerateAccessToken(userID string) (string, error) {
+func (p *provider) GenerateAccessToken(userID string, roles []string) (string, error) {
 	jti, err := generateRandomString(16)
 	if err != nil {
 		return "", err
 	}
 	c := claims{
 		UserID: userID,
+		Roles:  roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(p.accessExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
-	return token.SignedString(p.secretKey)
+	key := p.keys.signing()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, c)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
 }
 
 func (p *provider) ValidateToken(tokenString string) (*domain.TokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return p.secretKey, nil
+		kid, _ := token.Header["kid"].(string)
+		public, ok := p.keys.publicKey(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return public, nil
 	})
 	if err != nil {
 		return nil, err
@@ -71,7 +80,7 @@ func (p *provider) ValidateToken(tokenString string) (*domain.TokenClaims, error
 		if c.IssuedAt != nil {
 			issuedAt = c.IssuedAt.Unix()
 		}
-		return &domain.TokenClaims{UserID: c.UserID, JTI: c.ID, IssuedAt: issuedAt}, nil
+		return &domain.TokenClaims{UserID: c.UserID, JTI: c.ID, IssuedAt: issuedAt, Roles: c.Roles}, nil
 	}
 	return nil, jwt.ErrSignatureInvalid
 }
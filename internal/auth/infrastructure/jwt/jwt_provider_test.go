@@ -1,15 +1,18 @@
 package jwt
 
 import (
+	"crypto/ed25519"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v4"
 )
 
 const testUUID = "550e8400-e29b-41d4-a716-446655440000"
 
 func newTestProvider(t *testing.T) *provider {
 	t.Helper()
-	p, err := NewProvider("test-secret-key", "15m", "168h")
+	p, err := NewProvider(newTestKeySet(t), "15m", "168h")
 	if err != nil {
 		t.Fatalf("NewProvider() error = %v", err)
 	}
@@ -19,7 +22,7 @@ func newTestProvider(t *testing.T) *provider {
 func TestProvider_GenerateAndValidate(t *testing.T) {
 	p := newTestProvider(t)
 
-	token, err := p.GenerateAccessToken(testUUID)
+	token, err := p.GenerateAccessToken(testUUID, []string{"bidder", "admin"})
 	if err != nil {
 		t.Fatalf("GenerateAccessToken() error = %v", err)
 	}
@@ -40,13 +43,16 @@ func TestProvider_GenerateAndValidate(t *testing.T) {
 	if claims.IssuedAt == 0 {
 		t.Error("expected non-zero IssuedAt")
 	}
+	if len(claims.Roles) != 2 || claims.Roles[0] != "bidder" || claims.Roles[1] != "admin" {
+		t.Errorf("Roles = %v, want [bidder admin]", claims.Roles)
+	}
 }
 
 func TestProvider_ValidateToken_InvalidSignature(t *testing.T) {
 	p := newTestProvider(t)
 
-	p2, _ := NewProvider("different-secret", "15m", "168h")
-	token, _ := p2.(*provider).GenerateAccessToken(testUUID)
+	p2, _ := NewProvider(newTestKeySet(t), "15m", "168h")
+	token, _ := p2.(*provider).GenerateAccessToken(testUUID, nil)
 
 	_, err := p.ValidateToken(token)
 	if err == nil {
@@ -54,6 +60,49 @@ func TestProvider_ValidateToken_InvalidSignature(t *testing.T) {
 	}
 }
 
+func TestProvider_ValidateToken_RejectsHMAC(t *testing.T) {
+	p := newTestProvider(t)
+
+	c := claims{UserID: testUUID, RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
+	token.Header["kid"] = p.keys.signing().id
+	signed, err := token.SignedString([]byte(p.keys.signing().private.Public().(ed25519.PublicKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ValidateToken(signed); err == nil {
+		t.Error("expected HS256 token to be rejected")
+	}
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	old := newTestKeySet(t)
+	next := newTestKeySet(t)
+	before, _ := NewProvider(old, "15m", "168h")
+	token, err := before.GenerateAccessToken(testUUID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, _ := NewProvider(&KeySet{keys: append(next.keys, old.keys...)}, "15m", "168h")
+	if _, err := rotated.ValidateToken(token); err != nil {
+		t.Errorf("token signed by the retiring key should still validate: %v", err)
+	}
+	fresh, _ := rotated.GenerateAccessToken(testUUID, nil)
+	parsed, _, _ := new(jwt.Parser).ParseUnverified(fresh, &claims{})
+	if parsed.Header["kid"] != next.signing().id {
+		t.Errorf("kid = %v, want the new key %q", parsed.Header["kid"], next.signing().id)
+	}
+
+	retired, _ := NewProvider(next, "15m", "168h")
+	if _, err := retired.ValidateToken(token); err == nil {
+		t.Error("expected token signed by a dropped key to be rejected")
+	}
+}
+
 func TestProvider_ValidateToken_Malformed(t *testing.T) {
 	p := newTestProvider(t)
 
@@ -66,8 +115,8 @@ func TestProvider_ValidateToken_Malformed(t *testing.T) {
 func TestProvider_UniqueJTI(t *testing.T) {
 	p := newTestProvider(t)
 
-	c1, _ := p.ValidateToken(func() string { t, _ := p.GenerateAccessToken(testUUID); return t }())
-	c2, _ := p.ValidateToken(func() string { t, _ := p.GenerateAccessToken(testUUID); return t }())
+	c1, _ := p.ValidateToken(func() string { t, _ := p.GenerateAccessToken(testUUID, nil); return t }())
+	c2, _ := p.ValidateToken(func() string { t, _ := p.GenerateAccessToken(testUUID, nil); return t }())
 
 	if c1.JTI == c2.JTI {
 		t.Error("expected unique JTI per token")
@@ -89,12 +138,12 @@ func TestProvider_RefreshExpiry(t *testing.T) {
 }
 
 func TestNewProvider_InvalidDuration(t *testing.T) {
-	_, err := NewProvider("secret", "invalid", "168h")
+	_, err := NewProvider(newTestKeySet(t), "invalid", "168h")
 	if err == nil {
 		t.Error("expected error for invalid access expiry")
 	}
 
-	_, err = NewProvider("secret", "15m", "notaduration")
+	_, err = NewProvider(newTestKeySet(t), "15m", "notaduration")
 	if err == nil {
 		t.Error("expected error for invalid refresh expiry")
 	}
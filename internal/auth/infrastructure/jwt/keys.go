@@ -0,0 +1,122 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/in-jun/go-structure-example/internal/shared/jwks"
+	"github.com/in-jun/go-structure-example/internal/shared/server"
+)
+
+var errNoSigningKeys = errors.New("at least one signing key is required")
+
+type signingKey struct {
+	id      string
+	private ed25519.PrivateKey
+}
+
+// KeySet holds the Ed25519 keys access tokens are signed with. The first
+// key signs; the rest only verify, so a retired key keeps validating the
+// tokens it issued until they expire. To rotate, put the new key first and
+// drop the old one once the access expiry has passed.
+type KeySet struct {
+	keys []signingKey
+}
+
+// LoadKeySet reads keys from a comma-separated list of kid=path entries,
+// each path a PKCS#8 PEM file as written by
+// `openssl genpkey -algorithm ed25519`.
+func LoadKeySet(spec string) (*KeySet, error) {
+	set := &KeySet{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid signing key entry %q, want kid=path", entry)
+		}
+		if _, exists := set.publicKey(kid); exists {
+			return nil, fmt.Errorf("duplicate signing key ID %q", kid)
+		}
+		private, err := readPrivateKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %w", kid, err)
+		}
+		set.keys = append(set.keys, signingKey{id: kid, private: private})
+	}
+	if len(set.keys) == 0 {
+		return nil, errNoSigningKeys
+	}
+	return set, nil
+}
+
+// GenerateKeySet creates a single throwaway key. Tokens it signs stop
+// validating when the process restarts, so it is only fit for local use.
+func GenerateKeySet() (*KeySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := generateRandomString(8)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{keys: []signingKey{{id: kid, private: private}}}, nil
+}
+
+func readPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from operator configuration
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+	return private, nil
+}
+
+func (s *KeySet) signing() signingKey { return s.keys[0] }
+
+func (s *KeySet) publicKey(kid string) (ed25519.PublicKey, bool) {
+	for _, k := range s.keys {
+		if k.id == kid {
+			return k.private.Public().(ed25519.PublicKey), true
+		}
+	}
+	return nil, false
+}
+
+func (s *KeySet) JWKS() jwks.Set {
+	set := jwks.Set{Keys: make([]jwks.Key, 0, len(s.keys))}
+	for _, k := range s.keys {
+		set.Keys = append(set.Keys, jwks.NewKey(k.id, k.private.Public().(ed25519.PublicKey)))
+	}
+	return set
+}
+
+func (s *KeySet) RegisterRoutes(mux *server.Router, mw server.Middleware) {
+	mux.Handle("GET /.well-known/jwks.json", mw(http.HandlerFunc(s.ServeJWKS)))
+}
+
+func (s *KeySet) ServeJWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	server.JSON(w, http.StatusOK, s.JWKS())
+}
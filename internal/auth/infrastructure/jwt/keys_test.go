@@ -0,0 +1,113 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/in-jun/go-structure-example/internal/shared/jwks"
+)
+
+func newTestKeySet(t *testing.T) *KeySet {
+	t.Helper()
+	keys, err := GenerateKeySet()
+	if err != nil {
+		t.Fatalf("GenerateKeySet() error = %v", err)
+	}
+	return keys
+}
+
+func writeTestKey(t *testing.T, dir, name string) ed25519.PublicKey {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return public
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	current := writeTestKey(t, dir, "current.pem")
+	writeTestKey(t, dir, "previous.pem")
+
+	keys, err := LoadKeySet("k2=" + filepath.Join(dir, "current.pem") + ", k1=" + filepath.Join(dir, "previous.pem"))
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+	if keys.signing().id != "k2" {
+		t.Errorf("signing kid = %q, want the first key k2", keys.signing().id)
+	}
+	if public, ok := keys.publicKey("k2"); !ok || !public.Equal(current) {
+		t.Error("expected k2 to hold the current key")
+	}
+	if _, ok := keys.publicKey("k1"); !ok {
+		t.Error("expected k1 to be kept for verification")
+	}
+}
+
+func TestLoadKeySet_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeTestKey(t, dir, "key.pem")
+	path := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(filepath.Join(dir, "junk.pem"), []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"missing kid", path},
+		{"duplicate kid", "k1=" + path + ",k1=" + path},
+		{"missing file", "k1=" + filepath.Join(dir, "absent.pem")},
+		{"not PEM", "k1=" + filepath.Join(dir, "junk.pem")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadKeySet(tt.spec); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestKeySet_ServeJWKS(t *testing.T) {
+	keys := newTestKeySet(t)
+	w := httptest.NewRecorder()
+	keys.ServeJWKS(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var set jwks.Set
+	if err := json.Unmarshal(w.Body.Bytes(), &set); err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Keys) != 1 || set.Keys[0].KeyID != keys.signing().id {
+		t.Fatalf("unexpected JWKS %+v", set)
+	}
+	public, err := set.Keys[0].PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey() error = %v", err)
+	}
+	if want, _ := keys.publicKey(keys.signing().id); !public.Equal(want) {
+		t.Error("published key does not match the signing key")
+	}
+}
@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+)
+
+var _ domain.Mailer = (*LogMailer)(nil)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9@._-]`)
+
+// LogMailer delivers nothing. It writes each message to dir as an .eml file,
+// or logs it when dir is empty, so verification links can be followed in
+// local development.
+type LogMailer struct {
+	dir  string
+	from string
+}
+
+func NewLogMailer(dir, from string) *LogMailer {
+	return &LogMailer{dir: dir, from: from}
+}
+
+func (m *LogMailer) Send(_ context.Context, email domain.Email) error {
+	now := time.Now()
+	if m.dir == "" {
+		slog.Info("email", "component", "log-mailer", "to", email.To, "subject", email.Subject, "body", email.Body)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), unsafeFileChars.ReplaceAllString(email.To, "_"))
+	return os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, email, now), 0o644)
+}
@@ -0,0 +1,134 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+)
+
+func TestBuildMessage(t *testing.T) {
+	msg := string(buildMessage("noreply@example.com", domain.Email{
+		To:      "test@example.com",
+		Subject: "Hello\r\nBcc: evil@example.com",
+		Body:    "line one\nline two",
+	}, time.Now()))
+
+	if !strings.Contains(msg, "To: test@example.com\r\n") {
+		t.Errorf("missing To header:\n%s", msg)
+	}
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("header injection was not stripped:\n%s", msg)
+	}
+	if !strings.HasSuffix(msg, "\r\n\r\nline one\r\nline two") {
+		t.Errorf("body not normalised to CRLF:\n%q", msg)
+	}
+}
+
+func TestLogMailer_WritesFile(t *testing.T) {
+	dir := t.TempDir()
+	m := NewLogMailer(dir, "noreply@example.com")
+
+	err := m.Send(context.Background(), domain.Email{To: "test@example.com", Subject: "Verify", Body: "click"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 .eml file, got %v", files)
+	}
+	content, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(content), "Subject: Verify") || !strings.HasSuffix(string(content), "click") {
+		t.Errorf("unexpected message:\n%s", content)
+	}
+}
+
+func TestLogMailer_LogsWithoutDir(t *testing.T) {
+	m := NewLogMailer("", "noreply@example.com")
+	if err := m.Send(context.Background(), domain.Email{To: "test@example.com", Subject: "Verify", Body: "click"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+}
+
+// serveSMTP accepts one session on a local listener and returns the DATA
+// it received.
+func serveSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				data <- b.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), data
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	addr, data := serveSMTP(t)
+	m := NewSMTPMailer(addr, "", "", "noreply@example.com")
+
+	err := m.Send(context.Background(), domain.Email{To: "test@example.com", Subject: "Verify", Body: "click"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if msg := <-data; !strings.Contains(msg, "To: test@example.com") || !strings.Contains(msg, "click") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
+
+func TestSMTPMailer_Unreachable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	m := NewSMTPMailer(addr, "", "", "noreply@example.com")
+	if err := m.Send(context.Background(), domain.Email{To: "test@example.com", Subject: "Verify", Body: "click"}); err == nil {
+		t.Fatal("expected error when the server is unreachable")
+	}
+}
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.EmailOutbox = (*emailOutbox)(nil)
+
+const outboundEmailColumns = "id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, updated_at"
+
+type emailOutbox struct {
+	db func(ctx context.Context) transaction.DBTX
+}
+
+func NewEmailOutbox(db func(ctx context.Context) transaction.DBTX) domain.EmailOutbox {
+	return &emailOutbox{db: db}
+}
+
+func (r *emailOutbox) Save(ctx context.Context, email *entity.OutboundEmail) error {
+	_, err := r.db(ctx).ExecContext(ctx,
+		"INSERT INTO email_outbox ("+outboundEmailColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		email.ID(), email.To(), email.Subject(), email.Body(), email.Status(),
+		email.Attempts(), email.LastError(), email.NextAttemptAt(), email.CreatedAt(), email.UpdatedAt())
+	if err != nil {
+		return errors.Internal("Failed to queue email")
+	}
+	return nil
+}
+
+func (r *emailOutbox) FindDue(ctx context.Context, before time.Time, limit int) ([]*entity.OutboundEmail, error) {
+	rows, err := r.db(ctx).QueryContext(ctx,
+		"SELECT "+outboundEmailColumns+" FROM email_outbox WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3",
+		entity.OutboundEmailStatusPending, before, limit)
+	if err != nil {
+		return nil, errors.Internal("Failed to list queued emails")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var emails []*entity.OutboundEmail
+	for rows.Next() {
+		var id, to, subject, body, status, lastError string
+		var attempts int
+		var nextAttemptAt, createdAt, updatedAt time.Time
+		if err := rows.Scan(&id, &to, &subject, &body, &status, &attempts, &lastError,
+			&nextAttemptAt, &createdAt, &updatedAt); err != nil {
+			return nil, errors.Internal("Failed to scan queued email")
+		}
+		emails = append(emails, entity.ReconstructOutboundEmail(id, to, subject, body, status,
+			attempts, lastError, nextAttemptAt, createdAt, updatedAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Failed to list queued emails")
+	}
+	return emails, nil
+}
+
+func (r *emailOutbox) Update(ctx context.Context, email *entity.OutboundEmail) error {
+	result, err := r.db(ctx).ExecContext(ctx,
+		"UPDATE email_outbox SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, updated_at = $5 WHERE id = $6",
+		email.Status(), email.Attempts(), email.LastError(), email.NextAttemptAt(), email.UpdatedAt(), email.ID())
+	if err != nil {
+		return errors.Internal("Failed to update queued email")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("Queued email not found")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/domain"
+	"github.com/in-jun/go-structure-example/internal/auth/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.EmailVerificationRepository = (*emailVerificationRepository)(nil)
+
+const emailVerificationColumns = "id, user_id, token_hash, expires_at, used_at, created_at"
+
+type emailVerificationRepository struct {
+	db func(ctx context.Context) transaction.DBTX
+}
+
+func NewEmailVerificationRepository(db func(ctx context.Context) transaction.DBTX) domain.EmailVerificationRepository {
+	return &emailVerificationRepository{db: db}
+}
+
+func (r *emailVerificationRepository) Save(ctx context.Context, v *entity.EmailVerification) error {
+	_, err := r.db(ctx).ExecContext(ctx,
+		"INSERT INTO email_verifications ("+emailVerificationColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		v.ID(), v.UserID(), v.TokenHash(), v.ExpiresAt(), nullTime(v.UsedAt()), v.CreatedAt())
+	if err != nil {
+		return errors.Internal("Failed to create email verification")
+	}
+	return nil
+}
+
+func (r *emailVerificationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.EmailVerification, error) {
+	query := "SELECT " + emailVerificationColumns + " FROM email_verifications WHERE token_hash = $1"
+	v, err := scanEmailVerification(r.db(ctx).QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		return nil, errors.Internal("Failed to get email verification")
+	}
+	return v, nil
+}
+
+func (r *emailVerificationRepository) FindLatestByUserID(ctx context.Context, userID string) (*entity.EmailVerification, error) {
+	query := "SELECT " + emailVerificationColumns + " FROM email_verifications WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1"
+	v, err := scanEmailVerification(r.db(ctx).QueryRowContext(ctx, query, userID))
+	if err != nil {
+		return nil, errors.Internal("Failed to get email verification")
+	}
+	return v, nil
+}
+
+// Update only ever records use, and only once: a token redeemed by a
+// concurrent request matches no row.
+func (r *emailVerificationRepository) Update(ctx context.Context, v *entity.EmailVerification) error {
+	result, err := r.db(ctx).ExecContext(ctx,
+		"UPDATE email_verifications SET used_at = $1 WHERE id = $2 AND used_at IS NULL",
+		nullTime(v.UsedAt()), v.ID())
+	if err != nil {
+		return errors.Internal("Failed to update email verification")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.BadRequest("Invalid verification token")
+	}
+	return nil
+}
+
+func (r *emailVerificationRepository) DeleteUnusedByUserID(ctx context.Context, userID string) error {
+	_, err := r.db(ctx).ExecContext(ctx,
+		"DELETE FROM email_verifications WHERE user_id = $1 AND used_at IS NULL", userID)
+	if err != nil {
+		return errors.Internal("Failed to delete email verifications")
+	}
+	return nil
+}
+
+func scanEmailVerification(row *sql.Row) (*entity.EmailVerification, error) {
+	var id, userID, tokenHash string
+	var expiresAt, createdAt time.Time
+	var usedAt sql.NullTime
+	err := row.Scan(&id, &userID, &tokenHash, &expiresAt, &usedAt, &createdAt)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructEmailVerification(id, userID, tokenHash, expiresAt, usedAt.Time, createdAt)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	stderrors "errors"
+	"strings"
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/auth/domain"
@@ -14,6 +15,10 @@ import (
 
 var _ domain.UserRepository = (*userRepository)(nil)
 
+// Roles are a TEXT[] column; they cross the driver as a comma-separated
+// string since database/sql has no array type.
+const userColumns = "id, email, password, name, array_to_string(roles, ','), verified_at, created_at, updated_at"
+
 type userRepository struct {
 	db func(ctx context.Context) transaction.DBTX
 }
@@ -23,29 +28,77 @@ func NewUserRepository(db func(ctx context.Context) transaction.DBTX) domain.Use
 }
 
 func (r *userRepository) Save(ctx context.Context, user *entity.User) error {
-	query := "INSERT INTO users (id, email, password, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	query := `INSERT INTO users (id, email, password, name, roles, verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, string_to_array($5, ','), $6, $7, $8)`
 	_, err := r.db(ctx).ExecContext(ctx, query,
-		user.ID(), user.Email(), user.HashedPassword(), user.Name(), user.CreatedAt(), user.UpdatedAt())
+		user.ID(), user.Email(), user.HashedPassword(), user.Name(), strings.Join(user.Roles(), ","),
+		nullTime(user.VerifiedAt()), user.CreatedAt(), user.UpdatedAt())
 	if err != nil {
 		return errors.Internal("Failed to create user")
 	}
 	return nil
 }
 
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	result, err := r.db(ctx).ExecContext(ctx,
+		"UPDATE users SET verified_at = $1, updated_at = $2 WHERE id = $3",
+		nullTime(user.VerifiedAt()), user.UpdatedAt(), user.ID())
+	if err != nil {
+		return errors.Internal("Failed to update user")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("User not found")
+	}
+	return nil
+}
+
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	query := "SELECT id, email, password, name, created_at, updated_at FROM users WHERE email = $1"
-	var id, e, password, name string
+	query := "SELECT " + userColumns + " FROM users WHERE email = $1"
+	user, err := r.scanUser(r.db(ctx).QueryRowContext(ctx, query, email))
+	if err != nil {
+		return nil, errors.Internal("Failed to get user by email")
+	}
+	return user, nil
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE id = $1"
+	user, err := r.scanUser(r.db(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, errors.Internal("Failed to get user by id")
+	}
+	return user, nil
+}
+
+func (r *userRepository) scanUser(row *sql.Row) (*entity.User, error) {
+	var id, email, password, name, roles string
+	var verifiedAt sql.NullTime
 	var createdAt, updatedAt time.Time
-	err := r.db(ctx).QueryRowContext(ctx, query, email).Scan(&id, &e, &password, &name, &createdAt, &updatedAt)
+	err := row.Scan(&id, &email, &password, &name, &roles, &verifiedAt, &createdAt, &updatedAt)
 	if stderrors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, errors.Internal("Failed to get user by email")
+		return nil, err
 	}
-	u, err := entity.ReconstructUser(id, e, password, name, createdAt, updatedAt)
+	u, err := entity.ReconstructUser(id, email, password, name, splitRoles(roles), verifiedAt.Time, createdAt, updatedAt)
 	if err != nil {
 		return nil, errors.Internal("Failed to reconstruct user")
 	}
 	return u, nil
 }
+
+func splitRoles(roles string) []string {
+	if roles == "" {
+		return nil
+	}
+	return strings.Split(roles, ",")
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/auth/application/command"
+)
+
+const mailBatchSize = 50
+
+type MailDispatcher struct {
+	handler  *command.DispatchEmailsHandler
+	interval time.Duration
+}
+
+func NewMailDispatcher(handler *command.DispatchEmailsHandler, interval time.Duration) *MailDispatcher {
+	return &MailDispatcher{handler: handler, interval: interval}
+}
+
+func (w *MailDispatcher) Start(ctx context.Context) {
+	slog.Info("mail dispatcher started", "component", "mail-dispatcher", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("mail dispatcher stopped", "component", "mail-dispatcher")
+			return
+		case <-ticker.C:
+			result, err := w.handler.Handle(ctx, command.DispatchEmails{Now: time.Now(), Limit: mailBatchSize})
+			if err != nil {
+				slog.Error("mail dispatch failed", "component", "mail-dispatcher", "error", err)
+				continue
+			}
+			if result.Sent+result.Failed+result.Pending > 0 {
+				slog.Info("emails dispatched", "component", "mail-dispatcher",
+					"sent", result.Sent, "failed", result.Failed, "pending", result.Pending)
+			}
+		}
+	}
+}
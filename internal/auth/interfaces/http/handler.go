@@ -5,6 +5,7 @@ import (
 
 	"github.com/in-jun/go-structure-example/internal/auth/application"
 	"github.com/in-jun/go-structure-example/internal/auth/application/command"
+	"github.com/in-jun/go-structure-example/internal/auth/application/query"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/middleware"
 	"github.com/in-jun/go-structure-example/internal/shared/server"
@@ -14,20 +15,30 @@ type Handler struct {
 	commands      application.CommandUseCase
 	queries       application.QueryUseCase
 	validateToken middleware.TokenValidator
+	identity      *middleware.IdentityVerifier
 }
 
-func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase, validateToken middleware.TokenValidator) *Handler {
-	return &Handler{commands: commands, queries: queries, validateToken: validateToken}
+func NewHandler(
+	commands application.CommandUseCase,
+	queries application.QueryUseCase,
+	validateToken middleware.TokenValidator,
+	identity *middleware.IdentityVerifier,
+) *Handler {
+	return &Handler{commands: commands, queries: queries, validateToken: validateToken, identity: identity}
 }
 
 func (h *Handler) RegisterRoutes(mux *server.Router, mw server.Middleware) {
 	authMw := middleware.Auth(h.validateToken)
+	serviceAuth := middleware.ServiceAuth(h.identity)
 
 	mux.Handle("POST /api/v1/auth/register", mw(http.HandlerFunc(h.Register)))
+	mux.Handle("POST /api/v1/auth/verify-email", mw(http.HandlerFunc(h.VerifyEmail)))
+	mux.Handle("POST /api/v1/auth/verify-email/resend", mw(authMw(http.HandlerFunc(h.ResendVerification))))
 	mux.Handle("POST /api/v1/auth/login", mw(http.HandlerFunc(h.Login)))
 	mux.Handle("POST /api/v1/auth/refresh", mw(http.HandlerFunc(h.Refresh)))
 	mux.Handle("POST /api/v1/auth/logout", mw(authMw(http.HandlerFunc(h.Logout))))
 	mux.Handle("POST /api/v1/auth/logout/all", mw(authMw(http.HandlerFunc(h.LogoutAll))))
+	mux.Handle("GET /internal/v1/users/{id}", mw(serviceAuth(http.HandlerFunc(h.GetUser))))
 }
 
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
@@ -46,7 +57,35 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	server.JSON(w, http.StatusCreated, MessageResponse{Message: "Registration successful"})
+	server.JSON(w, http.StatusCreated, MessageResponse{Message: "Registration successful, check your email to verify your address"})
+}
+
+// VerifyEmail unlocks bidding and selling; tokens issued before it carry
+// the old roles until the client refreshes.
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	if err := h.commands.VerifyEmail(r.Context(), command.VerifyEmail{Token: req.Token}); err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, MessageResponse{Message: "Email verified"})
+}
+
+func (h *Handler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	if err := h.commands.ResendVerification(r.Context(), command.ResendVerification{
+		UserID: server.UserID(r),
+	}); err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusAccepted, MessageResponse{Message: "Verification email sent"})
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -118,3 +157,17 @@ func (h *Handler) LogoutAll(w http.ResponseWriter, r *http.Request) {
 
 	server.JSON(w, http.StatusOK, MessageResponse{Message: "All sessions logged out successfully"})
 }
+
+// GetUser serves other services on the internal network; the gateway does
+// not route /internal paths.
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queries.GetUser(r.Context(), query.GetUser{
+		UserID: server.PathParam(r, "id"),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toUserResponse(result))
+}
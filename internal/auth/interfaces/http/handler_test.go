@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/auth/application"
 	"github.com/in-jun/go-structure-example/internal/auth/application/command"
@@ -18,13 +19,25 @@ import (
 
 const testUUID = "550e8400-e29b-41d4-a716-446655440000"
 
+var testIdentityKeys, _ = middleware.ParseIdentityKeys("k1=test-secret")
+
 type mockCommandUseCase struct {
-	loginResp   *command.LoginResult
-	refreshResp *command.RefreshResult
-	err         error
+	loginResp    *command.LoginResult
+	refreshResp  *command.RefreshResult
+	verifyToken  string
+	resendUserID string
+	err          error
 }
 
 func (m *mockCommandUseCase) Register(_ context.Context, _ command.Register) error { return m.err }
+func (m *mockCommandUseCase) VerifyEmail(_ context.Context, cmd command.VerifyEmail) error {
+	m.verifyToken = cmd.Token
+	return m.err
+}
+func (m *mockCommandUseCase) ResendVerification(_ context.Context, cmd command.ResendVerification) error {
+	m.resendUserID = cmd.UserID
+	return m.err
+}
 func (m *mockCommandUseCase) Login(_ context.Context, _ command.Login) (*command.LoginResult, error) {
 	return m.loginResp, m.err
 }
@@ -36,12 +49,16 @@ func (m *mockCommandUseCase) LogoutAll(_ context.Context, _ command.LogoutAll) e
 
 type mockQueryUseCase struct {
 	validateResp *query.Result
+	userResp     *query.UserResult
 	err          error
 }
 
 func (m *mockQueryUseCase) ValidateToken(_ context.Context, _ query.Validate) (*query.Result, error) {
 	return m.validateResp, m.err
 }
+func (m *mockQueryUseCase) GetUser(_ context.Context, _ query.GetUser) (*query.UserResult, error) {
+	return m.userResp, m.err
+}
 
 var _ application.CommandUseCase = (*mockCommandUseCase)(nil)
 var _ application.QueryUseCase = (*mockQueryUseCase)(nil)
@@ -51,7 +68,7 @@ func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server
 		return &middleware.ValidateTokenResult{UserID: testUUID, JTI: "test-jti"}, nil
 	})
 
-	h := NewHandler(cmdMock, qryMock, tokenValidator)
+	h := NewHandler(cmdMock, qryMock, tokenValidator, middleware.NewIdentityVerifier(testIdentityKeys, time.Minute))
 	mux := server.NewRouter()
 	h.RegisterRoutes(mux, server.Chain())
 	return mux
@@ -85,6 +102,67 @@ func TestHandler_Register_Error(t *testing.T) {
 	}
 }
 
+func TestHandler_VerifyEmail(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	mux := setupRouter(cmdMock, &mockQueryUseCase{})
+	body, _ := json.Marshal(VerifyEmailRequest{Token: "raw-token"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify-email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.verifyToken != "raw-token" {
+		t.Errorf("expected token to be passed through, got %q", cmdMock.verifyToken)
+	}
+}
+
+func TestHandler_VerifyEmail_Error(t *testing.T) {
+	mux := setupRouter(&mockCommandUseCase{err: errors.BadRequest("Verification token expired")}, &mockQueryUseCase{})
+	body, _ := json.Marshal(VerifyEmailRequest{Token: "raw-token"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify-email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	mux := setupRouter(cmdMock, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify-email/resend", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected 202, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.resendUserID != testUUID {
+		t.Errorf("expected the authenticated user, got %q", cmdMock.resendUserID)
+	}
+}
+
+func TestHandler_ResendVerification_MissingAuth(t *testing.T) {
+	mux := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify-email/resend", nil)
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
 func TestHandler_Login(t *testing.T) {
 	cmdMock := &mockCommandUseCase{
 		loginResp: &command.LoginResult{
@@ -178,6 +256,40 @@ func TestHandler_LogoutAll(t *testing.T) {
 	}
 }
 
+func TestHandler_GetUser(t *testing.T) {
+	user := &query.UserResult{ID: testUUID, Email: "test@example.com", Name: "Test"}
+	mux := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{userResp: user})
+	req := httptest.NewRequest("GET", "/internal/v1/users/"+testUUID, nil)
+	middleware.NewIdentitySigner(testIdentityKeys).Sign(req, middleware.Identity{Roles: []string{middleware.RoleService}})
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	var resp UserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Email != "test@example.com" {
+		t.Errorf("expected email test@example.com, got %q", resp.Email)
+	}
+}
+
+func TestHandler_GetUser_RequiresServiceIdentity(t *testing.T) {
+	user := &query.UserResult{ID: testUUID, Email: "test@example.com", Name: "Test"}
+	mux := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{userResp: user})
+	req := httptest.NewRequest("GET", "/internal/v1/users/"+testUUID, nil)
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d; body: %s", w.Code, w.Body.String())
+	}
+}
+
 func TestHandler_Register_BadJSON(t *testing.T) {
 	mux := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
 	req := httptest.NewRequest("POST", "/api/v1/auth/register", bytes.NewReader([]byte("{invalid}")))
@@ -6,6 +6,10 @@ type RegisterRequest struct {
 	Name     string `json:"name"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -1,6 +1,9 @@
 package http
 
-import "github.com/in-jun/go-structure-example/internal/auth/application/command"
+import (
+	"github.com/in-jun/go-structure-example/internal/auth/application/command"
+	"github.com/in-jun/go-structure-example/internal/auth/application/query"
+)
 
 type Response struct {
 	AccessToken  string `json:"access_token"`
@@ -12,6 +15,13 @@ type MessageResponse struct {
 	Message string `json:"message"`
 }
 
+type UserResponse struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
 func toLoginResponse(r *command.LoginResult) *Response {
 	return &Response{
 		AccessToken:  r.AccessToken,
@@ -27,3 +37,7 @@ func toRefreshResponse(r *command.RefreshResult) *Response {
 		ExpiresIn:    r.ExpiresIn,
 	}
 }
+
+func toUserResponse(r *query.UserResult) *UserResponse {
+	return &UserResponse{ID: r.ID, Email: r.Email, Name: r.Name, Verified: r.Verified}
+}
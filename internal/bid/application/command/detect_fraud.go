@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type DetectFraud struct {
+	Since time.Time
+}
+
+type DetectFraudResult struct {
+	Flagged int
+}
+
+type DetectFraudHandler struct {
+	signalRepo     domain.FraudSignalRepository
+	flagRepo       domain.FlagRepository
+	fraudPolicy    *service.FraudPolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewDetectFraudHandler(
+	signalRepo domain.FraudSignalRepository,
+	flagRepo domain.FlagRepository,
+	fraudPolicy *service.FraudPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *DetectFraudHandler {
+	return &DetectFraudHandler{
+		signalRepo: signalRepo, flagRepo: flagRepo, fraudPolicy: fraudPolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+func (h *DetectFraudHandler) Handle(ctx context.Context, cmd DetectFraud) (*DetectFraudResult, error) {
+	affinities, err := h.signalRepo.FindSellerAffinities(
+		ctx, time.Now().Add(-service.SellerAffinityLookback), cmd.Since, service.MinSellerAffinityAuctions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	outbids, err := h.signalRepo.FindSelfOutbids(ctx, cmd.Since, service.SelfOutbidWindow)
+	if err != nil {
+		return nil, err
+	}
+	newAccounts, err := h.signalRepo.FindNewAccountBids(ctx, cmd.Since, service.NewAccountAge)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := h.fraudPolicy.Evaluate(affinities, outbids, newAccounts)
+	if len(findings) == 0 {
+		return &DetectFraudResult{}, nil
+	}
+
+	var flagged int
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		flagged = 0
+		for _, f := range findings {
+			flag, err := entity.NewFlag(f.AuctionID, f.BidderID, f.Rule, f.Evidence)
+			if err != nil {
+				return errors.Internal("Failed to build flag")
+			}
+
+			created, err := h.flagRepo.Save(txCtx, flag)
+			if err != nil {
+				return err
+			}
+			if !created {
+				continue
+			}
+
+			if err := h.eventPublisher.Publish(txCtx, flag.Events()...); err != nil {
+				return err
+			}
+			flag.ClearEvents()
+			flagged++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DetectFraudResult{Flagged: flagged}, nil
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain"
-	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/query"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
@@ -16,12 +16,21 @@ type DetermineWinner struct {
 
 type DetermineWinnerHandler struct {
 	bidRepo        domain.BidRepository
+	offerRepo      domain.OfferRepository
 	eventPublisher domain.EventPublisher
 	transactor     transaction.Transactor
 }
 
-func NewDetermineWinnerHandler(bidRepo domain.BidRepository, eventPublisher domain.EventPublisher, transactor transaction.Transactor) *DetermineWinnerHandler {
-	return &DetermineWinnerHandler{bidRepo: bidRepo, eventPublisher: eventPublisher, transactor: transactor}
+func NewDetermineWinnerHandler(
+	bidRepo domain.BidRepository,
+	offerRepo domain.OfferRepository,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *DetermineWinnerHandler {
+	return &DetermineWinnerHandler{
+		bidRepo: bidRepo, offerRepo: offerRepo,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
 }
 
 func (h *DetermineWinnerHandler) Handle(ctx context.Context, cmd DetermineWinner) error {
@@ -34,7 +43,22 @@ func (h *DetermineWinnerHandler) Handle(ctx context.Context, cmd DetermineWinner
 			return errors.NotFound("No bids found for auction")
 		}
 
-		evt := event.NewBidWon(highest.ID(), highest.AuctionID(), highest.BidderID(), highest.Amount())
-		return h.eventPublisher.Publish(txCtx, evt)
+		existing, err := h.offerRepo.FindLatestByAuctionID(txCtx, cmd.AuctionID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return nil
+		}
+
+		offer := entity.NewOffer(highest, 1)
+		if err := h.offerRepo.Save(txCtx, offer); err != nil {
+			return err
+		}
+		if err := h.eventPublisher.Publish(txCtx, offer.Events()...); err != nil {
+			return err
+		}
+		offer.ClearEvents()
+		return nil
 	})
 }
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type OfferSecondChance struct {
+	AuctionID      string
+	FailedBidderID string
+}
+
+type OfferSecondChanceHandler struct {
+	bidRepo            domain.BidRepository
+	offerRepo          domain.OfferRepository
+	secondChancePolicy *service.SecondChancePolicy
+	eventPublisher     domain.EventPublisher
+	transactor         transaction.Transactor
+}
+
+func NewOfferSecondChanceHandler(
+	bidRepo domain.BidRepository,
+	offerRepo domain.OfferRepository,
+	secondChancePolicy *service.SecondChancePolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *OfferSecondChanceHandler {
+	return &OfferSecondChanceHandler{
+		bidRepo: bidRepo, offerRepo: offerRepo, secondChancePolicy: secondChancePolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+func (h *OfferSecondChanceHandler) Handle(ctx context.Context, cmd OfferSecondChance) error {
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		latest, err := h.offerRepo.FindLatestByAuctionID(txCtx, cmd.AuctionID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if latest == nil {
+			return h.eventPublisher.Publish(txCtx, event.NewBidCandidatesExhausted(cmd.AuctionID, 0))
+		}
+		if latest.BidderID() != cmd.FailedBidderID {
+			slog.Info("ignoring payment failure for superseded offer", "auction_id", cmd.AuctionID, "bidder_id", cmd.FailedBidderID)
+			return nil
+		}
+		if !h.secondChancePolicy.CanOffer(latest.Attempt()) {
+			return h.eventPublisher.Publish(txCtx, event.NewBidCandidatesExhausted(cmd.AuctionID, latest.Attempt()))
+		}
+
+		next, err := h.bidRepo.FindNextCandidate(txCtx, cmd.AuctionID)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return h.eventPublisher.Publish(txCtx, event.NewBidCandidatesExhausted(cmd.AuctionID, latest.Attempt()))
+		}
+
+		offer := entity.NewOffer(next, latest.Attempt()+1)
+		if err := h.offerRepo.Save(txCtx, offer); err != nil {
+			return err
+		}
+		if err := h.eventPublisher.Publish(txCtx, offer.Events()...); err != nil {
+			return err
+		}
+		offer.ClearEvents()
+		return nil
+	})
+}
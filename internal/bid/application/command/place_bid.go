@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain"
@@ -32,6 +33,7 @@ type PlaceBidHandler struct {
 	auctionClient  domain.AuctionClient
 	bidPolicy      *service.BidPolicy
 	eventPublisher domain.EventPublisher
+	highestCache   domain.HighestBidCache
 	transactor     transaction.Transactor
 }
 
@@ -40,12 +42,13 @@ func NewPlaceBidHandler(
 	auctionClient domain.AuctionClient,
 	bidPolicy *service.BidPolicy,
 	eventPublisher domain.EventPublisher,
+	highestCache domain.HighestBidCache,
 	transactor transaction.Transactor,
 ) *PlaceBidHandler {
 	return &PlaceBidHandler{
 		bidRepo: bidRepo, auctionClient: auctionClient,
 		bidPolicy: bidPolicy, eventPublisher: eventPublisher,
-		transactor: transactor,
+		highestCache: highestCache, transactor: transactor,
 	}
 }
 
@@ -92,11 +95,26 @@ func (h *PlaceBidHandler) Handle(ctx context.Context, cmd PlaceBid) (*PlaceBidRe
 			return err
 		}
 
+		count, err := h.bidRepo.CountByAuctionID(txCtx, pv.AuctionID)
+		if err != nil {
+			return err
+		}
+
 		if err := h.eventPublisher.Publish(txCtx, bid.Events()...); err != nil {
 			return err
 		}
 		bid.ClearEvents()
 
+		snapshot := domain.HighestBidSnapshot{
+			BidID: bid.ID(), AuctionID: bid.AuctionID(), BidderID: bid.BidderID(),
+			Amount: bid.Amount(), BidCount: count, CreatedAt: bid.CreatedAt(),
+		}
+		transaction.RegisterPostCommit(txCtx, func() {
+			if err := h.highestCache.Set(ctx, snapshot); err != nil {
+				slog.Warn("failed to update highest bid cache", "auction_id", snapshot.AuctionID, "error", err)
+			}
+		})
+
 		result = &PlaceBidResult{
 			ID: bid.ID(), AuctionID: bid.AuctionID(),
 			BidderID: bid.BidderID(), Amount: bid.Amount(),
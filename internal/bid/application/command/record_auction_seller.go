@@ -0,0 +1,24 @@
+package command
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+type RecordAuctionSeller struct {
+	AuctionID string
+	SellerID  string
+}
+
+type RecordAuctionSellerHandler struct {
+	signalRepo domain.FraudSignalRepository
+}
+
+func NewRecordAuctionSellerHandler(signalRepo domain.FraudSignalRepository) *RecordAuctionSellerHandler {
+	return &RecordAuctionSellerHandler{signalRepo: signalRepo}
+}
+
+func (h *RecordAuctionSellerHandler) Handle(ctx context.Context, cmd RecordAuctionSeller) error {
+	return h.signalRepo.SaveAuctionSeller(ctx, cmd.AuctionID, cmd.SellerID)
+}
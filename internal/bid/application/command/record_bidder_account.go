@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+type RecordBidderAccount struct {
+	UserID       string
+	RegisteredAt time.Time
+}
+
+type RecordBidderAccountHandler struct {
+	signalRepo domain.FraudSignalRepository
+}
+
+func NewRecordBidderAccountHandler(signalRepo domain.FraudSignalRepository) *RecordBidderAccountHandler {
+	return &RecordBidderAccountHandler{signalRepo: signalRepo}
+}
+
+func (h *RecordBidderAccountHandler) Handle(ctx context.Context, cmd RecordBidderAccount) error {
+	return h.signalRepo.SaveBidderAccount(ctx, cmd.UserID, cmd.RegisteredAt)
+}
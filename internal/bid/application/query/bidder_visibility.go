@@ -0,0 +1,81 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
+)
+
+type bidderVisibility struct {
+	pseudonyms *service.Pseudonyms
+	viewerID   string
+	isSeller   bool
+}
+
+// newBidderVisibility only asks the auction service for the seller when the viewer is authenticated.
+func newBidderVisibility(
+	ctx context.Context,
+	bidRepo domain.BidRepository,
+	auctionClient domain.AuctionClient,
+	pseudonymizer *service.Pseudonymizer,
+	auctionID, viewerID string,
+) (*bidderVisibility, error) {
+	bidders, err := bidRepo.FindBidders(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	v := &bidderVisibility{pseudonyms: pseudonymizer.Pseudonyms(auctionID, bidders), viewerID: viewerID}
+	if viewerID == "" {
+		return v, nil
+	}
+	auction, err := auctionClient.GetAuction(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	v.isSeller = auction.SellerID == viewerID
+	return v, nil
+}
+
+func (v *bidderVisibility) apply(r *Result) {
+	r.Bidder = v.pseudonyms.Of(r.BidderID)
+	if !v.isSeller && r.BidderID != v.viewerID {
+		r.BidderID = ""
+	}
+}
+
+// eventIdentityFields maps payload fields that hold a user ID to the field
+// that carries its pseudonym.
+var eventIdentityFields = map[string]string{"bidder_id": "bidder", "winner_id": "winner"}
+
+// applyToPayload gives event payloads the same treatment as bid rows: every
+// user ID gains a pseudonym and is dropped unless the viewer may see it.
+func (v *bidderVisibility) applyToPayload(payload []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	for idField, pseudonymField := range eventIdentityFields {
+		raw, ok := fields[idField]
+		if !ok {
+			continue
+		}
+		var userID string
+		if err := json.Unmarshal(raw, &userID); err != nil {
+			return nil, err
+		}
+		if userID == "" {
+			continue
+		}
+		pseudonym, err := json.Marshal(v.pseudonyms.Of(userID))
+		if err != nil {
+			return nil, err
+		}
+		fields[pseudonymField] = pseudonym
+		if !v.isSeller && userID != v.viewerID {
+			delete(fields, idField)
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -5,12 +5,14 @@ import (
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
 	"github.com/in-jun/go-structure-example/internal/bid/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 )
 
 type EventHistory struct {
 	AuctionID string
+	ViewerID  string
 }
 
 type EventHistoryItem struct {
@@ -25,11 +27,19 @@ type EventHistoryResult struct {
 }
 
 type EventHistoryHandler struct {
-	eventReader domain.EventReader
+	eventReader   domain.EventReader
+	bidRepo       domain.BidRepository
+	auctionClient domain.AuctionClient
+	pseudonymizer *service.Pseudonymizer
 }
 
-func NewEventHistoryHandler(eventReader domain.EventReader) *EventHistoryHandler {
-	return &EventHistoryHandler{eventReader: eventReader}
+func NewEventHistoryHandler(
+	eventReader domain.EventReader,
+	bidRepo domain.BidRepository,
+	auctionClient domain.AuctionClient,
+	pseudonymizer *service.Pseudonymizer,
+) *EventHistoryHandler {
+	return &EventHistoryHandler{eventReader: eventReader, bidRepo: bidRepo, auctionClient: auctionClient, pseudonymizer: pseudonymizer}
 }
 
 func (h *EventHistoryHandler) Handle(ctx context.Context, qry EventHistory) (*EventHistoryResult, error) {
@@ -43,9 +53,18 @@ func (h *EventHistoryHandler) Handle(ctx context.Context, qry EventHistory) (*Ev
 		return nil, err
 	}
 
+	visibility, err := newBidderVisibility(ctx, h.bidRepo, h.auctionClient, h.pseudonymizer, av.ID, qry.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
 	items := make([]EventHistoryItem, len(stored))
 	for i, e := range stored {
-		items[i] = EventHistoryItem{ID: e.ID, EventType: e.EventType, Payload: e.Payload, OccurredAt: e.OccurredAt}
+		payload, err := visibility.applyToPayload(e.Payload)
+		if err != nil {
+			return nil, errors.Internal("Failed to read event payload")
+		}
+		items[i] = EventHistoryItem{ID: e.ID, EventType: e.EventType, Payload: payload, OccurredAt: e.OccurredAt}
 	}
 	return &EventHistoryResult{Events: items}, nil
 }
@@ -2,31 +2,47 @@ package query
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
 	"github.com/in-jun/go-structure-example/internal/bid/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 )
 
 type GetHighest struct {
 	AuctionID string
+	ViewerID  string
 }
 
 type Result struct {
 	ID        string
 	AuctionID string
 	BidderID  string
+	Bidder    string
 	Amount    int64
+	BidCount  int64
 	CreatedAt time.Time
 }
 
 type GetHighestHandler struct {
-	bidRepo domain.BidRepository
+	bidRepo       domain.BidRepository
+	highestCache  domain.HighestBidCache
+	auctionClient domain.AuctionClient
+	pseudonymizer *service.Pseudonymizer
 }
 
-func NewGetHighestHandler(bidRepo domain.BidRepository) *GetHighestHandler {
-	return &GetHighestHandler{bidRepo: bidRepo}
+func NewGetHighestHandler(
+	bidRepo domain.BidRepository,
+	highestCache domain.HighestBidCache,
+	auctionClient domain.AuctionClient,
+	pseudonymizer *service.Pseudonymizer,
+) *GetHighestHandler {
+	return &GetHighestHandler{
+		bidRepo: bidRepo, highestCache: highestCache,
+		auctionClient: auctionClient, pseudonymizer: pseudonymizer,
+	}
 }
 
 func (h *GetHighestHandler) Handle(ctx context.Context, qry GetHighest) (*Result, error) {
@@ -35,17 +51,58 @@ func (h *GetHighestHandler) Handle(ctx context.Context, qry GetHighest) (*Result
 		return nil, errors.BadRequest(err.Error())
 	}
 
-	bid, err := h.bidRepo.FindHighestByAuctionID(ctx, av.ID)
+	snapshot, err := h.findHighest(ctx, av.ID)
 	if err != nil {
 		return nil, err
 	}
-	if bid == nil {
+	if snapshot == nil {
 		return nil, errors.NotFound("No bids found")
 	}
 
-	return &Result{
-		ID: bid.ID(), AuctionID: bid.AuctionID(),
-		BidderID: bid.BidderID(), Amount: bid.Amount(),
-		CreatedAt: bid.CreatedAt(),
-	}, nil
+	visibility, err := newBidderVisibility(ctx, h.bidRepo, h.auctionClient, h.pseudonymizer, av.ID, qry.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		ID: snapshot.BidID, AuctionID: snapshot.AuctionID,
+		BidderID: snapshot.BidderID, Amount: snapshot.Amount,
+		BidCount: snapshot.BidCount, CreatedAt: snapshot.CreatedAt,
+	}
+	visibility.apply(result)
+	return result, nil
+}
+
+func (h *GetHighestHandler) findHighest(ctx context.Context, auctionID string) (*domain.HighestBidSnapshot, error) {
+	cached, err := h.highestCache.Get(ctx, auctionID)
+	if err != nil {
+		slog.Warn("highest bid cache unavailable, falling back to repository", "auction_id", auctionID, "error", err)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	// The count is the cache version, so it is read first: a bid committed
+	// between the two reads then leaves an older version that its own
+	// write-through replaces, instead of a stale bid under a newer one.
+	count, err := h.bidRepo.CountByAuctionID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	bid, err := h.bidRepo.FindHighestByAuctionID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if bid == nil {
+		return nil, nil
+	}
+
+	snapshot := &domain.HighestBidSnapshot{
+		BidID: bid.ID(), AuctionID: bid.AuctionID(), BidderID: bid.BidderID(),
+		Amount: bid.Amount(), BidCount: count, CreatedAt: bid.CreatedAt(),
+	}
+	if err := h.highestCache.Set(ctx, *snapshot); err != nil {
+		slog.Warn("failed to populate highest bid cache", "auction_id", auctionID, "error", err)
+	}
+	return snapshot, nil
 }
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+const histogramBuckets = 10
+
+type GetStats struct {
+	AuctionID string
+}
+
+type HistogramBucket struct {
+	Min   int64
+	Max   int64
+	Count int64
+}
+
+type PricePoint struct {
+	At     time.Time
+	Amount int64
+}
+
+type StatsResult struct {
+	AuctionID     string
+	TotalBids     int64
+	UniqueBidders int64
+	FirstBidAt    *time.Time
+	LastBidAt     *time.Time
+	Histogram     []HistogramBucket
+	LeadingPrices []PricePoint
+}
+
+type GetStatsHandler struct {
+	bidRepo       domain.BidRepository
+	statsCache    domain.BidStatsCache
+	auctionClient domain.AuctionClient
+}
+
+func NewGetStatsHandler(bidRepo domain.BidRepository, statsCache domain.BidStatsCache, auctionClient domain.AuctionClient) *GetStatsHandler {
+	return &GetStatsHandler{bidRepo: bidRepo, statsCache: statsCache, auctionClient: auctionClient}
+}
+
+func (h *GetStatsHandler) Handle(ctx context.Context, qry GetStats) (*StatsResult, error) {
+	av, err := vo.NewAuctionIDVO(qry.AuctionID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	cached, err := h.statsCache.Get(ctx, av.ID)
+	if err != nil {
+		slog.Warn("bid stats cache unavailable", "auction_id", av.ID, "error", err)
+	}
+	if cached != nil {
+		return toStatsResult(av.ID, cached), nil
+	}
+
+	auction, err := h.auctionClient.GetAuction(ctx, av.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := h.bidRepo.GetStats(ctx, av.ID, histogramBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bids are frozen once an auction leaves the open state, so the stats can be cached.
+	switch auction.Status {
+	case domain.AuctionStatusClosed, domain.AuctionStatusSettled, domain.AuctionStatusCancelled:
+		if err := h.statsCache.Set(ctx, av.ID, stats); err != nil {
+			slog.Warn("failed to cache bid stats", "auction_id", av.ID, "error", err)
+		}
+	}
+
+	return toStatsResult(av.ID, stats), nil
+}
+
+func toStatsResult(auctionID string, stats *domain.BidStats) *StatsResult {
+	result := &StatsResult{
+		AuctionID: auctionID, TotalBids: stats.TotalBids, UniqueBidders: stats.UniqueBidders,
+		FirstBidAt: stats.FirstBidAt, LastBidAt: stats.LastBidAt,
+		Histogram:     make([]HistogramBucket, len(stats.Histogram)),
+		LeadingPrices: make([]PricePoint, len(stats.LeadingPrices)),
+	}
+	for i, b := range stats.Histogram {
+		result.Histogram[i] = HistogramBucket{Min: b.Min, Max: b.Max, Count: b.Count}
+	}
+	for i, p := range stats.LeadingPrices {
+		result.LeadingPrices[i] = PricePoint{At: p.At, Amount: p.Amount}
+	}
+	return result
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/service"
 	"github.com/in-jun/go-structure-example/internal/bid/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 )
 
 type ListBids struct {
 	AuctionID string
+	ViewerID  string
 	Page      int
 	Limit     int
 }
@@ -20,11 +22,13 @@ type ListResult struct {
 }
 
 type ListBidsHandler struct {
-	bidRepo domain.BidRepository
+	bidRepo       domain.BidRepository
+	auctionClient domain.AuctionClient
+	pseudonymizer *service.Pseudonymizer
 }
 
-func NewListBidsHandler(bidRepo domain.BidRepository) *ListBidsHandler {
-	return &ListBidsHandler{bidRepo: bidRepo}
+func NewListBidsHandler(bidRepo domain.BidRepository, auctionClient domain.AuctionClient, pseudonymizer *service.Pseudonymizer) *ListBidsHandler {
+	return &ListBidsHandler{bidRepo: bidRepo, auctionClient: auctionClient, pseudonymizer: pseudonymizer}
 }
 
 func (h *ListBidsHandler) Handle(ctx context.Context, qry ListBids) (*ListResult, error) {
@@ -39,12 +43,22 @@ func (h *ListBidsHandler) Handle(ctx context.Context, qry ListBids) (*ListResult
 	}
 
 	results := make([]Result, len(bids))
+	if len(bids) == 0 {
+		return &ListResult{Bids: results, Total: total}, nil
+	}
+
+	visibility, err := newBidderVisibility(ctx, h.bidRepo, h.auctionClient, h.pseudonymizer, av.ID, qry.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
 	for i, b := range bids {
 		results[i] = Result{
 			ID: b.ID(), AuctionID: b.AuctionID(),
 			BidderID: b.BidderID(), Amount: b.Amount(),
 			CreatedAt: b.CreatedAt(),
 		}
+		visibility.apply(&results[i])
 	}
 
 	return &ListResult{Bids: results, Total: total}, nil
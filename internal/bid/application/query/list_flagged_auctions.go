@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+type ListFlaggedAuctions struct {
+	Page  int
+	Limit int
+}
+
+type FlagResult struct {
+	ID        string
+	BidderID  string
+	Rule      string
+	Evidence  json.RawMessage
+	CreatedAt time.Time
+}
+
+type FlaggedAuctionResult struct {
+	AuctionID string
+	Flags     []FlagResult
+}
+
+type FlaggedAuctionListResult struct {
+	Auctions []FlaggedAuctionResult
+	Total    int64
+}
+
+type ListFlaggedAuctionsHandler struct {
+	flagRepo domain.FlagRepository
+}
+
+func NewListFlaggedAuctionsHandler(flagRepo domain.FlagRepository) *ListFlaggedAuctionsHandler {
+	return &ListFlaggedAuctionsHandler{flagRepo: flagRepo}
+}
+
+func (h *ListFlaggedAuctionsHandler) Handle(ctx context.Context, qry ListFlaggedAuctions) (*FlaggedAuctionListResult, error) {
+	flags, total, err := h.flagRepo.FindFlaggedAuctions(ctx, qry.Page, qry.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	auctions := []FlaggedAuctionResult{}
+	for _, f := range flags {
+		if n := len(auctions); n == 0 || auctions[n-1].AuctionID != f.AuctionID() {
+			auctions = append(auctions, FlaggedAuctionResult{AuctionID: f.AuctionID()})
+		}
+		last := &auctions[len(auctions)-1]
+		last.Flags = append(last.Flags, FlagResult{
+			ID: f.ID(), BidderID: f.BidderID(), Rule: f.Rule(),
+			Evidence: f.Evidence(), CreatedAt: f.CreatedAt(),
+		})
+	}
+
+	return &FlaggedAuctionListResult{Auctions: auctions, Total: total}, nil
+}
@@ -10,12 +10,16 @@ import (
 type CommandUseCase interface {
 	PlaceBid(ctx context.Context, cmd command.PlaceBid) (*command.PlaceBidResult, error)
 	DetermineWinner(ctx context.Context, cmd command.DetermineWinner) error
+	OfferSecondChance(ctx context.Context, cmd command.OfferSecondChance) error
+	DetectFraud(ctx context.Context, cmd command.DetectFraud) (*command.DetectFraudResult, error)
 }
 
 type QueryUseCase interface {
 	GetHighest(ctx context.Context, qry query.GetHighest) (*query.Result, error)
 	ListBids(ctx context.Context, qry query.ListBids) (*query.ListResult, error)
 	GetEvents(ctx context.Context, qry query.EventHistory) (*query.EventHistoryResult, error)
+	GetStats(ctx context.Context, qry query.GetStats) (*query.StatsResult, error)
+	ListFlaggedAuctions(ctx context.Context, qry query.ListFlaggedAuctions) (*query.FlaggedAuctionListResult, error)
 }
 
 var (
@@ -26,21 +30,31 @@ var (
 type service struct {
 	placeBid        *command.PlaceBidHandler
 	determineWinner *command.DetermineWinnerHandler
+	secondChance    *command.OfferSecondChanceHandler
+	detectFraud     *command.DetectFraudHandler
 	getHighest      *query.GetHighestHandler
 	listBids        *query.ListBidsHandler
 	getEvents       *query.EventHistoryHandler
+	getStats        *query.GetStatsHandler
+	listFlagged     *query.ListFlaggedAuctionsHandler
 }
 
 func NewService(
 	placeBid *command.PlaceBidHandler,
 	determineWinner *command.DetermineWinnerHandler,
+	secondChance *command.OfferSecondChanceHandler,
+	detectFraud *command.DetectFraudHandler,
 	getHighest *query.GetHighestHandler,
 	listBids *query.ListBidsHandler,
 	getEvents *query.EventHistoryHandler,
+	getStats *query.GetStatsHandler,
+	listFlagged *query.ListFlaggedAuctionsHandler,
 ) *service {
 	return &service{
 		placeBid: placeBid, determineWinner: determineWinner,
+		secondChance: secondChance, detectFraud: detectFraud,
 		getHighest: getHighest, listBids: listBids, getEvents: getEvents,
+		getStats: getStats, listFlagged: listFlagged,
 	}
 }
 
@@ -50,6 +64,12 @@ func (s *service) PlaceBid(ctx context.Context, cmd command.PlaceBid) (*command.
 func (s *service) DetermineWinner(ctx context.Context, cmd command.DetermineWinner) error {
 	return s.determineWinner.Handle(ctx, cmd)
 }
+func (s *service) OfferSecondChance(ctx context.Context, cmd command.OfferSecondChance) error {
+	return s.secondChance.Handle(ctx, cmd)
+}
+func (s *service) DetectFraud(ctx context.Context, cmd command.DetectFraud) (*command.DetectFraudResult, error) {
+	return s.detectFraud.Handle(ctx, cmd)
+}
 func (s *service) GetHighest(ctx context.Context, qry query.GetHighest) (*query.Result, error) {
 	return s.getHighest.Handle(ctx, qry)
 }
@@ -59,3 +79,9 @@ func (s *service) ListBids(ctx context.Context, qry query.ListBids) (*query.List
 func (s *service) GetEvents(ctx context.Context, qry query.EventHistory) (*query.EventHistoryResult, error) {
 	return s.getEvents.Handle(ctx, qry)
 }
+func (s *service) GetStats(ctx context.Context, qry query.GetStats) (*query.StatsResult, error) {
+	return s.getStats.Handle(ctx, qry)
+}
+func (s *service) ListFlaggedAuctions(ctx context.Context, qry query.ListFlaggedAuctions) (*query.FlaggedAuctionListResult, error) {
+	return s.listFlagged.Handle(ctx, qry)
+}
@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,19 +20,99 @@ import (
 )
 
 type mockBidRepo struct {
-	bid  *entity.Bid
-	bids []*entity.Bid
-	total int64
-	err  error
+	bid       *entity.Bid
+	bids      []*entity.Bid
+	total     int64
+	candidate *entity.Bid
+	err       error
+	onRead    func()
+}
+
+func (m *mockBidRepo) read() {
+	if m.onRead != nil {
+		m.onRead()
+	}
 }
 
 func (m *mockBidRepo) Save(_ context.Context, _ *entity.Bid) error { return m.err }
 func (m *mockBidRepo) FindHighestByAuctionID(_ context.Context, _ string, _ ...sharedQuery.Option) (*entity.Bid, error) {
-	return m.bid, m.err
+	bid := m.bid
+	m.read()
+	return bid, m.err
 }
 func (m *mockBidRepo) FindByAuctionID(_ context.Context, _ string, _, _ int) ([]*entity.Bid, int64, error) {
 	return m.bids, m.total, m.err
 }
+func (m *mockBidRepo) CountByAuctionID(_ context.Context, _ string) (int64, error) {
+	total := m.total
+	m.read()
+	return total, m.err
+}
+func (m *mockBidRepo) FindBidders(_ context.Context, _ string) ([]domain.BidderFirstBid, error) {
+	firstBids := map[string]time.Time{}
+	for _, b := range m.bids {
+		if at, ok := firstBids[b.BidderID()]; !ok || b.CreatedAt().Before(at) {
+			firstBids[b.BidderID()] = b.CreatedAt()
+		}
+	}
+	bidders := make([]domain.BidderFirstBid, 0, len(firstBids))
+	for id, at := range firstBids {
+		bidders = append(bidders, domain.BidderFirstBid{BidderID: id, FirstBidAt: at})
+	}
+	return bidders, m.err
+}
+func (m *mockBidRepo) GetStats(_ context.Context, _ string, _ int) (*domain.BidStats, error) {
+	return &domain.BidStats{TotalBids: m.total}, m.err
+}
+func (m *mockBidRepo) FindNextCandidate(_ context.Context, _ string) (*entity.Bid, error) {
+	return m.candidate, m.err
+}
+
+type mockStatsCache struct {
+	stats *domain.BidStats
+	sets  int
+}
+
+func (m *mockStatsCache) Get(_ context.Context, _ string) (*domain.BidStats, error) {
+	return m.stats, nil
+}
+func (m *mockStatsCache) Set(_ context.Context, _ string, stats *domain.BidStats) error {
+	m.sets++
+	return nil
+}
+
+type mockOfferRepo struct {
+	latest *entity.Offer
+	saved  []*entity.Offer
+}
+
+func (m *mockOfferRepo) Save(_ context.Context, offer *entity.Offer) error {
+	m.saved = append(m.saved, offer)
+	return nil
+}
+func (m *mockOfferRepo) FindLatestByAuctionID(_ context.Context, _ string, _ ...sharedQuery.Option) (*entity.Offer, error) {
+	return m.latest, nil
+}
+
+type mockHighestCache struct {
+	snapshot *domain.HighestBidSnapshot
+	getErr   error
+	sets     []domain.HighestBidSnapshot
+}
+
+func (m *mockHighestCache) Get(_ context.Context, _ string) (*domain.HighestBidSnapshot, error) {
+	return m.snapshot, m.getErr
+}
+
+// Set keeps only snapshots with a higher bid count, like the versioned
+// Redis write.
+func (m *mockHighestCache) Set(_ context.Context, snapshot domain.HighestBidSnapshot) error {
+	m.sets = append(m.sets, snapshot)
+	if m.snapshot == nil || m.snapshot.BidCount < snapshot.BidCount {
+		m.snapshot = &snapshot
+	}
+	return nil
+}
 
 type mockAuctionClient struct {
 	info *domain.AuctionInfo
@@ -41,9 +123,14 @@ func (m *mockAuctionClient) GetAuction(_ context.Context, _ string) (*domain.Auc
 	return m.info, m.err
 }
 
-type mockPublisher struct{}
+type mockPublisher struct {
+	published []domainEvent.Event
+}
 
-func (m *mockPublisher) Publish(_ context.Context, _ ...domainEvent.Event) error { return nil }
+func (m *mockPublisher) Publish(_ context.Context, events ...domainEvent.Event) error {
+	m.published = append(m.published, events...)
+	return nil
+}
 
 type mockTransactor struct{}
 
@@ -51,19 +138,78 @@ func (m *mockTransactor) WithinTransaction(_ context.Context, fn func(ctx contex
 	return fn(context.Background())
 }
 
-type mockEventReader struct{}
+type mockEventReader struct {
+	events []domainEvent.StoredEvent
+}
 
 func (m *mockEventReader) FindByAuctionID(_ context.Context, _ string) ([]domainEvent.StoredEvent, error) {
-	return nil, nil
+	return m.events, nil
+}
+
+type mockFlagRepo struct {
+	saved   []*entity.Flag
+	existed map[string]bool
+	flags   []*entity.Flag
+	total   int64
+	err     error
+}
+
+func (m *mockFlagRepo) Save(_ context.Context, flag *entity.Flag) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.existed[flag.AuctionID()+flag.BidderID()+flag.Rule()] {
+		return false, nil
+	}
+	m.saved = append(m.saved, flag)
+	return true, nil
+}
+func (m *mockFlagRepo) FindFlaggedAuctions(_ context.Context, _, _ int) ([]*entity.Flag, int64, error) {
+	return m.flags, m.total, m.err
+}
+
+type mockSignalRepo struct {
+	activeSince time.Time
+	affinities  []domain.SellerAffinity
+	outbids     []domain.SelfOutbid
+	newAccounts []domain.NewAccountBid
+	err         error
+}
+
+func (m *mockSignalRepo) SaveAuctionSeller(_ context.Context, _, _ string) error { return m.err }
+func (m *mockSignalRepo) SaveBidderAccount(_ context.Context, _ string, _ time.Time) error {
+	return m.err
+}
+func (m *mockSignalRepo) FindSellerAffinities(_ context.Context, _, activeSince time.Time, _ int) ([]domain.SellerAffinity, error) {
+	m.activeSince = activeSince
+	return m.affinities, m.err
+}
+func (m *mockSignalRepo) FindSelfOutbids(_ context.Context, _ time.Time, _ time.Duration) ([]domain.SelfOutbid, error) {
+	return m.outbids, m.err
+}
+func (m *mockSignalRepo) FindNewAccountBids(_ context.Context, _ time.Time, _ time.Duration) ([]domain.NewAccountBid, error) {
+	return m.newAccounts, m.err
 }
 
 func newTestService(repo *mockBidRepo, client *mockAuctionClient) *service {
+	return newFraudTestService(repo, client, &mockSignalRepo{}, &mockFlagRepo{})
+}
+
+func newFraudTestService(repo *mockBidRepo, client *mockAuctionClient, signals *mockSignalRepo, flags *mockFlagRepo) *service {
+	return newCachedTestService(repo, client, signals, flags, &mockHighestCache{})
+}
+
+func newCachedTestService(repo *mockBidRepo, client *mockAuctionClient, signals *mockSignalRepo, flags *mockFlagRepo, cache *mockHighestCache) *service {
 	return NewService(
-		command.NewPlaceBidHandler(repo, client, &domainService.BidPolicy{}, &mockPublisher{}, &mockTransactor{}),
-		command.NewDetermineWinnerHandler(repo, &mockPublisher{}, &mockTransactor{}),
-		query.NewGetHighestHandler(repo),
-		query.NewListBidsHandler(repo),
-		query.NewEventHistoryHandler(&mockEventReader{}),
+		command.NewPlaceBidHandler(repo, client, &domainService.BidPolicy{}, &mockPublisher{}, cache, &mockTransactor{}),
+		command.NewDetermineWinnerHandler(repo, &mockOfferRepo{}, &mockPublisher{}, &mockTransactor{}),
+		command.NewOfferSecondChanceHandler(repo, &mockOfferRepo{}, &domainService.SecondChancePolicy{MaxOffers: 2}, &mockPublisher{}, &mockTransactor{}),
+		command.NewDetectFraudHandler(signals, flags, &domainService.FraudPolicy{}, &mockPublisher{}, &mockTransactor{}),
+		query.NewGetHighestHandler(repo, cache, client, domainService.NewPseudonymizer([]byte("test-secret"))),
+		query.NewListBidsHandler(repo, client, domainService.NewPseudonymizer([]byte("test-secret"))),
+		query.NewEventHistoryHandler(&mockEventReader{}, repo, client, domainService.NewPseudonymizer([]byte("test-secret"))),
+		query.NewGetStatsHandler(repo, &mockStatsCache{}, client),
+		query.NewListFlaggedAuctionsHandler(flags),
 	)
 }
 
@@ -154,6 +300,49 @@ func TestBidService_ListBids(t *testing.T) {
 	}
 }
 
+func TestBidService_ListBids_Anonymized(t *testing.T) {
+	auctionID := uuid.New().String()
+	sellerID := uuid.New().String()
+	viewerID := uuid.New().String()
+	otherID := uuid.New().String()
+	now := time.Now()
+	own := entity.ReconstructBid(uuid.New().String(), auctionID, viewerID, 2000, now)
+	other := entity.ReconstructBid(uuid.New().String(), auctionID, otherID, 1500, now.Add(-time.Minute))
+
+	repo := &mockBidRepo{bids: []*entity.Bid{own, other}, total: 2}
+	client := &mockAuctionClient{info: &domain.AuctionInfo{ID: auctionID, SellerID: sellerID, Status: "open"}}
+	svc := newTestService(repo, client)
+
+	tests := []struct {
+		name        string
+		viewerID    string
+		wantOwnID   string
+		wantOtherID string
+	}{
+		{"anonymous", "", "", ""},
+		{"bidder sees own row", viewerID, viewerID, ""},
+		{"seller sees all", sellerID, viewerID, otherID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.ListBids(context.Background(), query.ListBids{
+				AuctionID: auctionID, ViewerID: tt.viewerID, Page: 1, Limit: 10,
+			})
+			if err != nil {
+				t.Fatalf("ListBids() error = %v", err)
+			}
+			if result.Bids[0].BidderID != tt.wantOwnID || result.Bids[1].BidderID != tt.wantOtherID {
+				t.Errorf("BidderIDs = (%q, %q), want (%q, %q)",
+					result.Bids[0].BidderID, result.Bids[1].BidderID, tt.wantOwnID, tt.wantOtherID)
+			}
+			if result.Bids[0].Bidder != "Bidder 2" || result.Bids[1].Bidder != "Bidder 1" {
+				t.Errorf("expected bidders numbered by first bid, got %q and %q", result.Bids[0].Bidder, result.Bids[1].Bidder)
+			}
+		})
+	}
+}
+
 func TestBidService_GetHighest(t *testing.T) {
 	auctionID := uuid.New().String()
 	now := time.Now()
@@ -170,6 +359,117 @@ func TestBidService_GetHighest(t *testing.T) {
 	}
 }
 
+func TestBidService_GetHighest_CacheHit(t *testing.T) {
+	auctionID := uuid.New().String()
+	cache := &mockHighestCache{snapshot: &domain.HighestBidSnapshot{
+		BidID: uuid.New().String(), AuctionID: auctionID, BidderID: uuid.New().String(),
+		Amount: 7000, BidCount: 4, CreatedAt: time.Now(),
+	}}
+	svc := newCachedTestService(&mockBidRepo{}, &mockAuctionClient{}, &mockSignalRepo{}, &mockFlagRepo{}, cache)
+
+	result, err := svc.GetHighest(context.Background(), query.GetHighest{AuctionID: auctionID})
+	if err != nil {
+		t.Fatalf("GetHighest() error = %v", err)
+	}
+	if result.Amount != 7000 || result.BidCount != 4 {
+		t.Errorf("got Amount=%d BidCount=%d, want 7000 and 4", result.Amount, result.BidCount)
+	}
+}
+
+func TestBidService_GetHighest_BidBetweenReads(t *testing.T) {
+	auctionID := uuid.New().String()
+	oldBid := entity.ReconstructBid(uuid.New().String(), auctionID, uuid.New().String(), 5000, time.Now())
+	newBid := entity.ReconstructBid(uuid.New().String(), auctionID, uuid.New().String(), 6000, time.Now())
+	cache := &mockHighestCache{}
+	repo := &mockBidRepo{bid: oldBid, total: 3}
+	// The new bid commits between the two reads, and its post-commit write
+	// through lands after the query has filled the cache.
+	repo.onRead = func() {
+		repo.onRead = nil
+		repo.bid, repo.total = newBid, 4
+	}
+	svc := newCachedTestService(repo, &mockAuctionClient{}, &mockSignalRepo{}, &mockFlagRepo{}, cache)
+
+	if _, err := svc.GetHighest(context.Background(), query.GetHighest{AuctionID: auctionID}); err != nil {
+		t.Fatalf("GetHighest() error = %v", err)
+	}
+	_ = cache.Set(context.Background(), domain.HighestBidSnapshot{
+		BidID: newBid.ID(), AuctionID: auctionID, BidderID: newBid.BidderID(),
+		Amount: newBid.Amount(), BidCount: 4, CreatedAt: newBid.CreatedAt(),
+	})
+	if cache.snapshot.BidID != newBid.ID() || cache.snapshot.Amount != 6000 {
+		t.Errorf("cache holds bid %s for %d, want the new bid for 6000", cache.snapshot.BidID, cache.snapshot.Amount)
+	}
+}
+
+func TestBidService_GetHighest_CacheDown(t *testing.T) {
+	auctionID := uuid.New().String()
+	bid := entity.ReconstructBid(uuid.New().String(), auctionID, uuid.New().String(), 5000, time.Now())
+	cache := &mockHighestCache{getErr: errors.Internal("redis down")}
+	svc := newCachedTestService(&mockBidRepo{bid: bid, total: 3}, &mockAuctionClient{}, &mockSignalRepo{}, &mockFlagRepo{}, cache)
+
+	result, err := svc.GetHighest(context.Background(), query.GetHighest{AuctionID: auctionID})
+	if err != nil {
+		t.Fatalf("GetHighest() error = %v", err)
+	}
+	if result.Amount != 5000 || result.BidCount != 3 {
+		t.Errorf("got Amount=%d BidCount=%d, want 5000 and 3", result.Amount, result.BidCount)
+	}
+	if len(cache.sets) != 1 {
+		t.Errorf("expected cache to be repopulated, got %d sets", len(cache.sets))
+	}
+}
+
+func TestBidService_PlaceBid_UpdatesCache(t *testing.T) {
+	auctionID := uuid.New().String()
+	client := &mockAuctionClient{
+		info: &domain.AuctionInfo{ID: auctionID, SellerID: uuid.New().String(), StartPrice: 1000, Status: "open"},
+	}
+	cache := &mockHighestCache{}
+	svc := newCachedTestService(&mockBidRepo{total: 1}, client, &mockSignalRepo{}, &mockFlagRepo{}, cache)
+
+	if _, err := svc.PlaceBid(context.Background(), command.PlaceBid{
+		UserID: uuid.New().String(), AuctionID: auctionID, Amount: 1000,
+	}); err != nil {
+		t.Fatalf("PlaceBid() error = %v", err)
+	}
+	if len(cache.sets) != 1 || cache.sets[0].Amount != 1000 || cache.sets[0].BidCount != 1 {
+		t.Errorf("unexpected cache writes: %+v", cache.sets)
+	}
+}
+
+func TestGetStatsHandler_CachesClosedAuctions(t *testing.T) {
+	auctionID := uuid.New().String()
+
+	tests := []struct {
+		status   string
+		wantSets int
+	}{
+		{"open", 0},
+		{"closed", 1},
+		{"settled", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			cache := &mockStatsCache{}
+			client := &mockAuctionClient{info: &domain.AuctionInfo{ID: auctionID, Status: tt.status}}
+			h := query.NewGetStatsHandler(&mockBidRepo{total: 4}, cache, client)
+
+			result, err := h.Handle(context.Background(), query.GetStats{AuctionID: auctionID})
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if result.TotalBids != 4 {
+				t.Errorf("TotalBids = %d, want 4", result.TotalBids)
+			}
+			if cache.sets != tt.wantSets {
+				t.Errorf("cache sets = %d, want %d", cache.sets, tt.wantSets)
+			}
+		})
+	}
+}
+
 func TestBidService_DetermineWinner(t *testing.T) {
 	auctionID := uuid.New().String()
 	now := time.Now()
@@ -183,6 +483,56 @@ func TestBidService_DetermineWinner(t *testing.T) {
 	}
 }
 
+func TestOfferSecondChanceHandler(t *testing.T) {
+	auctionID := uuid.New().String()
+	winnerID := uuid.New().String()
+	now := time.Now()
+	winningBid := entity.ReconstructBid(uuid.New().String(), auctionID, winnerID, 5000, now)
+	runnerUp := entity.ReconstructBid(uuid.New().String(), auctionID, uuid.New().String(), 4000, now)
+
+	tests := []struct {
+		name       string
+		attempt    int
+		failedID   string
+		candidate  *entity.Bid
+		wantEvent  string
+		wantOffers int
+	}{
+		{"offers runner-up", 1, winnerID, runnerUp, "bid.won", 1},
+		{"attempts exhausted", 3, winnerID, runnerUp, "bid.candidates_exhausted", 0},
+		{"no candidates left", 1, winnerID, nil, "bid.candidates_exhausted", 0},
+		{"stale failure ignored", 1, uuid.New().String(), runnerUp, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offers := &mockOfferRepo{latest: entity.ReconstructOffer(auctionID, winningBid.ID(), winnerID, 5000, tt.attempt, now)}
+			publisher := &mockPublisher{}
+			h := command.NewOfferSecondChanceHandler(&mockBidRepo{candidate: tt.candidate}, offers,
+				&domainService.SecondChancePolicy{MaxOffers: 2}, publisher, &mockTransactor{})
+
+			if err := h.Handle(context.Background(), command.OfferSecondChance{AuctionID: auctionID, FailedBidderID: tt.failedID}); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if len(offers.saved) != tt.wantOffers {
+				t.Errorf("saved offers = %d, want %d", len(offers.saved), tt.wantOffers)
+			}
+			if tt.wantEvent == "" {
+				if len(publisher.published) != 0 {
+					t.Errorf("expected no events, got %d", len(publisher.published))
+				}
+				return
+			}
+			if len(publisher.published) != 1 || publisher.published[0].EventName() != tt.wantEvent {
+				t.Fatalf("expected one %s event, got %v", tt.wantEvent, publisher.published)
+			}
+			if won, ok := publisher.published[0].(domainEvent.BidWon); ok && (won.Amount != 4000 || won.Attempt != 2) {
+				t.Errorf("unexpected bid.won: %+v", won)
+			}
+		})
+	}
+}
+
 func TestBidService_DetermineWinner_NoBids(t *testing.T) {
 	auctionID := uuid.New().String()
 	svc := newTestService(&mockBidRepo{bid: nil}, &mockAuctionClient{})
@@ -237,6 +587,66 @@ func TestBidService_PlaceBid_BidTooLow(t *testing.T) {
 	}
 }
 
+func TestEventHistory_ScrubsBidderIDs(t *testing.T) {
+	auctionID := uuid.New().String()
+	sellerID := uuid.New().String()
+	viewerID := uuid.New().String()
+	otherID := uuid.New().String()
+
+	marshal := func(e domainEvent.Event) []byte {
+		b, _ := json.Marshal(e)
+		return b
+	}
+	reader := &mockEventReader{events: []domainEvent.StoredEvent{
+		{ID: 1, EventType: "bid.placed", Payload: marshal(domainEvent.NewBidPlaced(uuid.New().String(), auctionID, viewerID, 1000))},
+		{ID: 2, EventType: "bid.placed", Payload: marshal(domainEvent.NewBidPlaced(uuid.New().String(), auctionID, otherID, 1500))},
+		{ID: 3, EventType: "bid.won", Payload: marshal(domainEvent.NewBidWon(uuid.New().String(), auctionID, otherID, 1500, 1))},
+	}}
+	client := &mockAuctionClient{info: &domain.AuctionInfo{ID: auctionID, SellerID: sellerID, Status: "ended"}}
+	h := query.NewEventHistoryHandler(reader, &mockBidRepo{}, client, domainService.NewPseudonymizer([]byte("test-secret")))
+
+	tests := []struct {
+		name      string
+		viewerID  string
+		wantShown []string
+		wantHid   []string
+	}{
+		{"anonymous", "", nil, []string{viewerID, otherID}},
+		{"bidder sees own id", viewerID, []string{viewerID}, []string{otherID}},
+		{"seller sees all", sellerID, []string{viewerID, otherID}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := h.Handle(context.Background(), query.EventHistory{AuctionID: auctionID, ViewerID: tt.viewerID})
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			var all string
+			for _, e := range result.Events {
+				var fields map[string]any
+				if err := json.Unmarshal(e.Payload, &fields); err != nil {
+					t.Fatalf("payload is not JSON: %v", err)
+				}
+				if fields["bidder"] == nil && fields["winner"] == nil {
+					t.Errorf("event %d has no pseudonym: %s", e.ID, e.Payload)
+				}
+				all += string(e.Payload)
+			}
+			for _, id := range tt.wantHid {
+				if strings.Contains(all, id) {
+					t.Errorf("raw user ID %s leaked: %s", id, all)
+				}
+			}
+			for _, id := range tt.wantShown {
+				if !strings.Contains(all, id) {
+					t.Errorf("expected user ID %s to be visible", id)
+				}
+			}
+		})
+	}
+}
+
 func TestBidService_GetEvents(t *testing.T) {
 	svc := newTestService(&mockBidRepo{}, &mockAuctionClient{})
 
@@ -270,3 +680,59 @@ func TestBidService_PlaceBid_AuctionNotFound(t *testing.T) {
 		t.Error("expected error when auction not found")
 	}
 }
+
+func TestBidService_DetectFraud(t *testing.T) {
+	auctionID := uuid.New().String()
+	bidderID := uuid.New().String()
+	now := time.Now()
+
+	signals := &mockSignalRepo{
+		outbids: []domain.SelfOutbid{{AuctionID: auctionID, BidderID: bidderID, Count: 3, FirstAt: now, LastAt: now}},
+		newAccounts: []domain.NewAccountBid{
+			{AuctionID: auctionID, BidderID: bidderID, RegisteredAt: now.Add(-time.Hour), FirstBidAt: now},
+		},
+	}
+	flags := &mockFlagRepo{existed: map[string]bool{auctionID + bidderID + domainService.RuleNewAccount: true}}
+	svc := newFraudTestService(&mockBidRepo{}, &mockAuctionClient{}, signals, flags)
+
+	since := now.Add(-time.Hour)
+	result, err := svc.DetectFraud(context.Background(), command.DetectFraud{Since: since})
+	if err != nil {
+		t.Fatalf("DetectFraud() error = %v", err)
+	}
+	if !signals.activeSince.Equal(since) {
+		t.Errorf("seller affinities were scanned for activity since %v, want %v", signals.activeSince, since)
+	}
+	if result.Flagged != 1 {
+		t.Errorf("Flagged = %d, want 1", result.Flagged)
+	}
+	if len(flags.saved) != 1 || flags.saved[0].Rule() != domainService.RuleRapidSelfOutbid {
+		t.Errorf("expected one rapid_self_outbid flag, got %d", len(flags.saved))
+	}
+}
+
+func TestBidService_ListFlaggedAuctions(t *testing.T) {
+	a1 := uuid.New().String()
+	a2 := uuid.New().String()
+	now := time.Now()
+	flags := &mockFlagRepo{
+		flags: []*entity.Flag{
+			entity.ReconstructFlag(uuid.New().String(), a1, uuid.New().String(), domainService.RuleNewAccount, []byte(`{}`), now),
+			entity.ReconstructFlag(uuid.New().String(), a1, uuid.New().String(), domainService.RuleRapidSelfOutbid, []byte(`{}`), now),
+			entity.ReconstructFlag(uuid.New().String(), a2, uuid.New().String(), domainService.RuleNewAccount, []byte(`{}`), now),
+		},
+		total: 2,
+	}
+	svc := newFraudTestService(&mockBidRepo{}, &mockAuctionClient{}, &mockSignalRepo{}, flags)
+
+	result, err := svc.ListFlaggedAuctions(context.Background(), query.ListFlaggedAuctions{Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListFlaggedAuctions() error = %v", err)
+	}
+	if len(result.Auctions) != 2 {
+		t.Fatalf("Auctions = %d, want 2", len(result.Auctions))
+	}
+	if len(result.Auctions[0].Flags) != 2 {
+		t.Errorf("first auction flags = %d, want 2", len(result.Auctions[0].Flags))
+	}
+}
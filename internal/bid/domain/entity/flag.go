@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+)
+
+var errInvalidFlag = errors.New("auction ID, bidder ID and rule are required")
+
+type Flag struct {
+	id        string
+	auctionID string
+	bidderID  string
+	rule      string
+	evidence  json.RawMessage
+	createdAt time.Time
+
+	events []event.Event
+}
+
+func NewFlag(auctionID, bidderID, rule string, evidence any) (*Flag, error) {
+	if auctionID == "" || bidderID == "" || rule == "" {
+		return nil, errInvalidFlag
+	}
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	f := &Flag{
+		id:        uuid.New().String(),
+		auctionID: auctionID,
+		bidderID:  bidderID,
+		rule:      rule,
+		evidence:  data,
+		createdAt: time.Now(),
+	}
+	f.record(event.NewBidFlagged(f.id, auctionID, bidderID, rule, data))
+	return f, nil
+}
+
+func ReconstructFlag(id, auctionID, bidderID, rule string, evidence json.RawMessage, createdAt time.Time) *Flag {
+	return &Flag{
+		id: id, auctionID: auctionID, bidderID: bidderID,
+		rule: rule, evidence: evidence, createdAt: createdAt,
+	}
+}
+
+func (f *Flag) ID() string                { return f.id }
+func (f *Flag) AuctionID() string         { return f.auctionID }
+func (f *Flag) BidderID() string          { return f.bidderID }
+func (f *Flag) Rule() string              { return f.rule }
+func (f *Flag) Evidence() json.RawMessage { return f.evidence }
+func (f *Flag) CreatedAt() time.Time      { return f.createdAt }
+
+func (f *Flag) Events() []event.Event { return f.events }
+func (f *Flag) ClearEvents()          { f.events = nil }
+func (f *Flag) record(e event.Event)  { f.events = append(f.events, e) }
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+)
+
+func TestNewFlag(t *testing.T) {
+	flag, err := NewFlag(testAuctionID, testBidderID, "new_account", map[string]int{"bids": 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(flag.Evidence()) != `{"bids":3}` {
+		t.Errorf("expected evidence '{\"bids\":3}', got '%s'", flag.Evidence())
+	}
+	if len(flag.Events()) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(flag.Events()))
+	}
+	evt, ok := flag.Events()[0].(event.BidFlagged)
+	if !ok {
+		t.Fatalf("expected BidFlagged event, got %T", flag.Events()[0])
+	}
+	if evt.FlagID != flag.ID() || evt.Rule != "new_account" {
+		t.Errorf("unexpected event: %+v", evt)
+	}
+}
+
+func TestNewFlag_MissingRule(t *testing.T) {
+	if _, err := NewFlag(testAuctionID, testBidderID, "", nil); err == nil {
+		t.Error("expected error for missing rule")
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+)
+
+type Offer struct {
+	auctionID string
+	bidID     string
+	bidderID  string
+	amount    int64
+	attempt   int
+	createdAt time.Time
+
+	events []event.Event
+}
+
+func NewOffer(bid *Bid, attempt int) *Offer {
+	o := &Offer{
+		auctionID: bid.AuctionID(),
+		bidID:     bid.ID(),
+		bidderID:  bid.BidderID(),
+		amount:    bid.Amount(),
+		attempt:   attempt,
+		createdAt: time.Now(),
+	}
+	o.record(event.NewBidWon(o.bidID, o.auctionID, o.bidderID, o.amount, attempt))
+	return o
+}
+
+func ReconstructOffer(auctionID, bidID, bidderID string, amount int64, attempt int, createdAt time.Time) *Offer {
+	return &Offer{
+		auctionID: auctionID, bidID: bidID, bidderID: bidderID,
+		amount: amount, attempt: attempt, createdAt: createdAt,
+	}
+}
+
+func (o *Offer) AuctionID() string    { return o.auctionID }
+func (o *Offer) BidID() string        { return o.bidID }
+func (o *Offer) BidderID() string     { return o.bidderID }
+func (o *Offer) Amount() int64        { return o.amount }
+func (o *Offer) Attempt() int         { return o.attempt }
+func (o *Offer) CreatedAt() time.Time { return o.createdAt }
+
+func (o *Offer) Events() []event.Event { return o.events }
+func (o *Offer) ClearEvents()          { o.events = nil }
+func (o *Offer) record(e event.Event)  { o.events = append(o.events, e) }
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
+)
+
+func TestNewOffer(t *testing.T) {
+	bid, _ := NewBid(testAuctionID, testBidderID, 1500)
+	offer := NewOffer(bid, 2)
+
+	if offer.BidID() != bid.ID() || offer.Amount() != 1500 || offer.Attempt() != 2 {
+		t.Errorf("unexpected offer: bid=%s amount=%d attempt=%d", offer.BidID(), offer.Amount(), offer.Attempt())
+	}
+	if len(offer.Events()) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(offer.Events()))
+	}
+	evt, ok := offer.Events()[0].(event.BidWon)
+	if !ok {
+		t.Fatalf("expected BidWon event, got %T", offer.Events()[0])
+	}
+	if evt.WinnerID != testBidderID || evt.Attempt != 2 {
+		t.Errorf("unexpected event: %+v", evt)
+	}
+}
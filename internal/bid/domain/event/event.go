@@ -1,6 +1,7 @@
 package event
 
 import (
+	"encoding/json"
 	"time"
 
 	sharedevent "github.com/in-jun/go-structure-example/internal/shared/event"
@@ -33,16 +34,51 @@ type BidWon struct {
 	AuctionID string    `json:"auction_id"`
 	WinnerID  string    `json:"winner_id"`
 	Amount    int64     `json:"amount"`
+	Attempt   int       `json:"attempt"`
 	Timestamp time.Time `json:"occurred_at"`
 }
 
-func NewBidWon(bidID, auctionID, winnerID string, amount int64) BidWon {
+func NewBidWon(bidID, auctionID, winnerID string, amount int64, attempt int) BidWon {
 	return BidWon{
 		BidID: bidID, AuctionID: auctionID, WinnerID: winnerID,
-		Amount: amount, Timestamp: time.Now(),
+		Amount: amount, Attempt: attempt, Timestamp: time.Now(),
 	}
 }
 
 func (e BidWon) EventName() string    { return "bid.won" }
 func (e BidWon) AggregateID() string  { return e.AuctionID }
 func (e BidWon) OccurredAt() time.Time { return e.Timestamp }
+
+type BidCandidatesExhausted struct {
+	AuctionID string    `json:"auction_id"`
+	Attempts  int       `json:"attempts"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewBidCandidatesExhausted(auctionID string, attempts int) BidCandidatesExhausted {
+	return BidCandidatesExhausted{AuctionID: auctionID, Attempts: attempts, Timestamp: time.Now()}
+}
+
+func (e BidCandidatesExhausted) EventName() string     { return "bid.candidates_exhausted" }
+func (e BidCandidatesExhausted) AggregateID() string   { return e.AuctionID }
+func (e BidCandidatesExhausted) OccurredAt() time.Time { return e.Timestamp }
+
+type BidFlagged struct {
+	FlagID    string          `json:"flag_id"`
+	AuctionID string          `json:"auction_id"`
+	BidderID  string          `json:"bidder_id"`
+	Rule      string          `json:"rule"`
+	Evidence  json.RawMessage `json:"evidence"`
+	Timestamp time.Time       `json:"occurred_at"`
+}
+
+func NewBidFlagged(flagID, auctionID, bidderID, rule string, evidence json.RawMessage) BidFlagged {
+	return BidFlagged{
+		FlagID: flagID, AuctionID: auctionID, BidderID: bidderID,
+		Rule: rule, Evidence: evidence, Timestamp: time.Now(),
+	}
+}
+
+func (e BidFlagged) EventName() string     { return "bid.flagged" }
+func (e BidFlagged) AggregateID() string   { return e.FlagID }
+func (e BidFlagged) OccurredAt() time.Time { return e.Timestamp }
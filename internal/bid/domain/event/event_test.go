@@ -21,7 +21,7 @@ func TestBidPlaced_EventName(t *testing.T) {
 }
 
 func TestBidWon_EventName(t *testing.T) {
-	e := NewBidWon(testBidID, testAuctionID, testBidderID, 1000)
+	e := NewBidWon(testBidID, testAuctionID, testBidderID, 1000, 1)
 	if e.EventName() != "bid.won" {
 		t.Errorf("EventName = %q, want bid.won", e.EventName())
 	}
@@ -29,3 +29,24 @@ func TestBidWon_EventName(t *testing.T) {
 		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testAuctionID)
 	}
 }
+
+func TestBidCandidatesExhausted_EventName(t *testing.T) {
+	e := NewBidCandidatesExhausted(testAuctionID, 3)
+	if e.EventName() != "bid.candidates_exhausted" {
+		t.Errorf("EventName = %q, want bid.candidates_exhausted", e.EventName())
+	}
+	if e.AggregateID() != testAuctionID {
+		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testAuctionID)
+	}
+}
+
+func TestBidFlagged_EventName(t *testing.T) {
+	e := NewBidFlagged("test-flag-id", testAuctionID, testBidderID, "new_account", []byte(`{}`))
+	if e.EventName() != "bid.flagged" {
+		t.Errorf("EventName = %q, want bid.flagged", e.EventName())
+	}
+	// AggregateID is the flag ID so flags stay out of the public per-auction history
+	if e.AggregateID() != "test-flag-id" {
+		t.Errorf("AggregateID = %q, want test-flag-id", e.AggregateID())
+	}
+}
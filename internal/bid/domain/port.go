@@ -2,18 +2,79 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/bid/domain/event"
 	"github.com/in-jun/go-structure-example/internal/shared/query"
 )
 
-const AuctionStatusOpen = "open"
+const (
+	AuctionStatusOpen      = "open"
+	AuctionStatusClosed    = "closed"
+	AuctionStatusSettled   = "settled"
+	AuctionStatusCancelled = "cancelled"
+)
 
 type BidRepository interface {
 	Save(ctx context.Context, bid *entity.Bid) error
 	FindHighestByAuctionID(ctx context.Context, auctionID string, opts ...query.Option) (*entity.Bid, error)
 	FindByAuctionID(ctx context.Context, auctionID string, page, limit int) ([]*entity.Bid, int64, error)
+	CountByAuctionID(ctx context.Context, auctionID string) (int64, error)
+	FindNextCandidate(ctx context.Context, auctionID string) (*entity.Bid, error)
+	GetStats(ctx context.Context, auctionID string, buckets int) (*BidStats, error)
+	FindBidders(ctx context.Context, auctionID string) ([]BidderFirstBid, error)
+}
+
+// BidderFirstBid is one bidder on an auction and when they first bid on it.
+type BidderFirstBid struct {
+	BidderID   string
+	FirstBidAt time.Time
+}
+
+type BidStats struct {
+	TotalBids     int64
+	UniqueBidders int64
+	FirstBidAt    *time.Time
+	LastBidAt     *time.Time
+	Histogram     []HistogramBucket
+	LeadingPrices []PricePoint
+}
+
+type HistogramBucket struct {
+	Min   int64
+	Max   int64
+	Count int64
+}
+
+type PricePoint struct {
+	At     time.Time
+	Amount int64
+}
+
+type BidStatsCache interface {
+	Get(ctx context.Context, auctionID string) (*BidStats, error)
+	Set(ctx context.Context, auctionID string, stats *BidStats) error
+}
+
+type OfferRepository interface {
+	Save(ctx context.Context, offer *entity.Offer) error
+	FindLatestByAuctionID(ctx context.Context, auctionID string, opts ...query.Option) (*entity.Offer, error)
+}
+
+type HighestBidCache interface {
+	Get(ctx context.Context, auctionID string) (*HighestBidSnapshot, error)
+	Set(ctx context.Context, snapshot HighestBidSnapshot) error
+}
+
+// HighestBidSnapshot is versioned by BidCount, which only grows as bids are accepted.
+type HighestBidSnapshot struct {
+	BidID     string
+	AuctionID string
+	BidderID  string
+	Amount    int64
+	BidCount  int64
+	CreatedAt time.Time
 }
 
 type AuctionClient interface {
@@ -34,3 +95,42 @@ type EventPublisher interface {
 type EventReader interface {
 	FindByAuctionID(ctx context.Context, auctionID string) ([]event.StoredEvent, error)
 }
+
+type FlagRepository interface {
+	Save(ctx context.Context, flag *entity.Flag) (bool, error)
+	FindFlaggedAuctions(ctx context.Context, page, limit int) ([]*entity.Flag, int64, error)
+}
+
+// FraudSignalRepository finds suspicious patterns in recent bids.
+// FindSellerAffinities counts a pair's auctions back to lookback but only
+// reports pairs that bid again at or after activeSince, so each scan looks
+// at the affinities its window added to.
+type FraudSignalRepository interface {
+	SaveAuctionSeller(ctx context.Context, auctionID, sellerID string) error
+	SaveBidderAccount(ctx context.Context, userID string, registeredAt time.Time) error
+	FindSellerAffinities(ctx context.Context, lookback, activeSince time.Time, minAuctions int) ([]SellerAffinity, error)
+	FindSelfOutbids(ctx context.Context, since time.Time, within time.Duration) ([]SelfOutbid, error)
+	FindNewAccountBids(ctx context.Context, since time.Time, accountAge time.Duration) ([]NewAccountBid, error)
+}
+
+type SellerAffinity struct {
+	SellerID   string
+	BidderID   string
+	AuctionIDs []string
+	BidCount   int64
+}
+
+type SelfOutbid struct {
+	AuctionID string
+	BidderID  string
+	Count     int64
+	FirstAt   time.Time
+	LastAt    time.Time
+}
+
+type NewAccountBid struct {
+	AuctionID    string
+	BidderID     string
+	RegisteredAt time.Time
+	FirstBidAt   time.Time
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+const (
+	RuleSellerAffinity  = "seller_affinity"
+	RuleRapidSelfOutbid = "rapid_self_outbid"
+	RuleNewAccount      = "new_account"
+)
+
+const (
+	SellerAffinityLookback    = 30 * 24 * time.Hour
+	MinSellerAffinityAuctions = 3
+	SelfOutbidWindow          = 2 * time.Minute
+	MinRapidSelfOutbids       = 2
+	NewAccountAge             = 24 * time.Hour
+)
+
+type Finding struct {
+	AuctionID string
+	BidderID  string
+	Rule      string
+	Evidence  any
+}
+
+type sellerAffinityEvidence struct {
+	SellerID   string   `json:"seller_id"`
+	AuctionIDs []string `json:"auction_ids"`
+	BidCount   int64    `json:"bid_count"`
+}
+
+type selfOutbidEvidence struct {
+	Count   int64     `json:"count"`
+	FirstAt time.Time `json:"first_at"`
+	LastAt  time.Time `json:"last_at"`
+}
+
+type newAccountEvidence struct {
+	RegisteredAt time.Time `json:"registered_at"`
+	FirstBidAt   time.Time `json:"first_bid_at"`
+}
+
+type FraudPolicy struct{}
+
+func (p *FraudPolicy) Evaluate(affinities []domain.SellerAffinity, outbids []domain.SelfOutbid, newAccounts []domain.NewAccountBid) []Finding {
+	var findings []Finding
+
+	for _, a := range affinities {
+		if len(a.AuctionIDs) < MinSellerAffinityAuctions {
+			continue
+		}
+		evidence := sellerAffinityEvidence{SellerID: a.SellerID, AuctionIDs: a.AuctionIDs, BidCount: a.BidCount}
+		for _, auctionID := range a.AuctionIDs {
+			findings = append(findings, Finding{
+				AuctionID: auctionID, BidderID: a.BidderID,
+				Rule: RuleSellerAffinity, Evidence: evidence,
+			})
+		}
+	}
+
+	for _, o := range outbids {
+		if o.Count < MinRapidSelfOutbids {
+			continue
+		}
+		findings = append(findings, Finding{
+			AuctionID: o.AuctionID, BidderID: o.BidderID, Rule: RuleRapidSelfOutbid,
+			Evidence: selfOutbidEvidence{Count: o.Count, FirstAt: o.FirstAt, LastAt: o.LastAt},
+		})
+	}
+
+	for _, n := range newAccounts {
+		if n.FirstBidAt.Sub(n.RegisteredAt) >= NewAccountAge {
+			continue
+		}
+		findings = append(findings, Finding{
+			AuctionID: n.AuctionID, BidderID: n.BidderID, Rule: RuleNewAccount,
+			Evidence: newAccountEvidence{RegisteredAt: n.RegisteredAt, FirstBidAt: n.FirstBidAt},
+		})
+	}
+
+	return findings
+}
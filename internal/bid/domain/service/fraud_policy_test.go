@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+func TestFraudPolicy_Evaluate(t *testing.T) {
+	p := &FraudPolicy{}
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		affinities  []domain.SellerAffinity
+		outbids     []domain.SelfOutbid
+		newAccounts []domain.NewAccountBid
+		wantRules   []string
+	}{
+		{
+			"seller affinity flags every auction",
+			[]domain.SellerAffinity{{SellerID: "s", BidderID: "b", AuctionIDs: []string{"a1", "a2", "a3"}, BidCount: 5}},
+			nil, nil,
+			[]string{RuleSellerAffinity, RuleSellerAffinity, RuleSellerAffinity},
+		},
+		{
+			"seller affinity below threshold",
+			[]domain.SellerAffinity{{SellerID: "s", BidderID: "b", AuctionIDs: []string{"a1", "a2"}, BidCount: 2}},
+			nil, nil, nil,
+		},
+		{
+			"rapid self outbid",
+			nil,
+			[]domain.SelfOutbid{{AuctionID: "a", BidderID: "b", Count: 2, FirstAt: now, LastAt: now}},
+			nil,
+			[]string{RuleRapidSelfOutbid},
+		},
+		{
+			"single self outbid",
+			nil,
+			[]domain.SelfOutbid{{AuctionID: "a", BidderID: "b", Count: 1, FirstAt: now, LastAt: now}},
+			nil, nil,
+		},
+		{
+			"new account bid",
+			nil, nil,
+			[]domain.NewAccountBid{{AuctionID: "a", BidderID: "b", RegisteredAt: now.Add(-time.Hour), FirstBidAt: now}},
+			[]string{RuleNewAccount},
+		},
+		{
+			"established account bid",
+			nil, nil,
+			[]domain.NewAccountBid{{AuctionID: "a", BidderID: "b", RegisteredAt: now.Add(-48 * time.Hour), FirstBidAt: now}},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := p.Evaluate(tt.affinities, tt.outbids, tt.newAccounts)
+			if len(findings) != len(tt.wantRules) {
+				t.Fatalf("expected %d findings, got %d", len(tt.wantRules), len(findings))
+			}
+			for i, f := range findings {
+				if f.Rule != tt.wantRules[i] {
+					t.Errorf("finding %d: expected rule %s, got %s", i, tt.wantRules[i], f.Rule)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+// Pseudonymizer numbers an auction's bidders in the order of their first
+// bid, so labels stay stable as bids arrive and cannot be linked across
+// auctions. Bidders whose first bids tie are ordered by an HMAC of auction
+// and bidder ID rather than by the IDs themselves.
+type Pseudonymizer struct {
+	secret []byte
+}
+
+func NewPseudonymizer(secret []byte) *Pseudonymizer {
+	return &Pseudonymizer{secret: secret}
+}
+
+// Pseudonyms labels the given bidders of one auction.
+func (p *Pseudonymizer) Pseudonyms(auctionID string, bidders []domain.BidderFirstBid) *Pseudonyms {
+	ordered := slices.Clone(bidders)
+	slices.SortStableFunc(ordered, func(a, b domain.BidderFirstBid) int {
+		if c := a.FirstBidAt.Compare(b.FirstBidAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(p.mac(auctionID, a.BidderID), p.mac(auctionID, b.BidderID))
+	})
+
+	numbers := make(map[string]int, len(ordered))
+	for _, b := range ordered {
+		if _, ok := numbers[b.BidderID]; !ok {
+			numbers[b.BidderID] = len(numbers) + 1
+		}
+	}
+	return &Pseudonyms{numbers: numbers}
+}
+
+func (p *Pseudonymizer) mac(auctionID, bidderID string) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(auctionID + ":" + bidderID))
+	return mac.Sum(nil)
+}
+
+// Pseudonyms maps bidder IDs to labels such as "Bidder 3".
+type Pseudonyms struct {
+	numbers map[string]int
+}
+
+// Of returns the bidder's label. A bidder who was not known when the labels
+// were drawn up, because their first bid landed since, gets the next number.
+func (p *Pseudonyms) Of(bidderID string) string {
+	n, ok := p.numbers[bidderID]
+	if !ok {
+		n = len(p.numbers) + 1
+		p.numbers[bidderID] = n
+	}
+	return fmt.Sprintf("Bidder %d", n)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+)
+
+func TestPseudonymizer_NumbersByFirstBid(t *testing.T) {
+	p := NewPseudonymizer([]byte("secret"))
+	start := time.Now()
+	bidders := []domain.BidderFirstBid{
+		{BidderID: "bidder-c", FirstBidAt: start.Add(2 * time.Minute)},
+		{BidderID: "bidder-a", FirstBidAt: start},
+		{BidderID: "bidder-b", FirstBidAt: start.Add(time.Minute)},
+	}
+
+	names := p.Pseudonyms("auction-1", bidders)
+	for id, want := range map[string]string{"bidder-a": "Bidder 1", "bidder-b": "Bidder 2", "bidder-c": "Bidder 3"} {
+		if got := names.Of(id); got != want {
+			t.Errorf("Of(%q) = %q, want %q", id, got, want)
+		}
+	}
+	if got := names.Of("bidder-d"); got != "Bidder 4" {
+		t.Errorf("expected a bidder who joined since to get the next number, got %q", got)
+	}
+	if names.Of("bidder-d") != "Bidder 4" {
+		t.Error("expected the late bidder's number to be stable")
+	}
+}
+
+func TestPseudonymizer_TiesAreStable(t *testing.T) {
+	p := NewPseudonymizer([]byte("secret"))
+	at := time.Now()
+	forward := []domain.BidderFirstBid{{BidderID: "bidder-1", FirstBidAt: at}, {BidderID: "bidder-2", FirstBidAt: at}}
+	backward := []domain.BidderFirstBid{forward[1], forward[0]}
+
+	if p.Pseudonyms("auction-1", forward).Of("bidder-1") != p.Pseudonyms("auction-1", backward).Of("bidder-1") {
+		t.Error("expected tied bidders to be numbered the same regardless of input order")
+	}
+}
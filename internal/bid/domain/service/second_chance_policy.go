@@ -0,0 +1,11 @@
+package service
+
+type SecondChancePolicy struct {
+	MaxOffers int
+}
+
+// CanOffer reports whether another runner-up may be offered after the given attempt.
+// Attempt 1 is the original winner, so MaxOffers counts second-chance offers only.
+func (p *SecondChancePolicy) CanOffer(lastAttempt int) bool {
+	return lastAttempt-1 < p.MaxOffers
+}
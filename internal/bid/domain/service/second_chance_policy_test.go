@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+func TestSecondChancePolicy_CanOffer(t *testing.T) {
+	p := &SecondChancePolicy{MaxOffers: 2}
+
+	tests := []struct {
+		lastAttempt int
+		want        bool
+	}{
+		{1, true},
+		{2, true},
+		{3, false},
+	}
+
+	for _, tt := range tests {
+		if got := p.CanOffer(tt.lastAttempt); got != tt.want {
+			t.Errorf("CanOffer(%d) = %v, want %v", tt.lastAttempt, got, tt.want)
+		}
+	}
+
+	if (&SecondChancePolicy{}).CanOffer(1) {
+		t.Error("expected no offers when MaxOffers is 0")
+	}
+}
@@ -2,7 +2,9 @@ package nats
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/bid/application/command"
 	sharedEvent "github.com/in-jun/go-structure-example/internal/shared/event"
@@ -12,25 +14,47 @@ import (
 )
 
 type Consumer struct {
-	nc                     *nats.Conn
-	determineWinnerHandler *command.DetermineWinnerHandler
-	dbGetter               func(ctx context.Context) transaction.DBTX
-	transactor             transaction.Transactor
-	subs                   []*nats.Subscription
+	nc                         *nats.Conn
+	determineWinnerHandler     *command.DetermineWinnerHandler
+	offerSecondChanceHandler   *command.OfferSecondChanceHandler
+	recordAuctionSellerHandler *command.RecordAuctionSellerHandler
+	recordBidderAccountHandler *command.RecordBidderAccountHandler
+	dbGetter                   func(ctx context.Context) transaction.DBTX
+	transactor                 transaction.Transactor
+	subs                       []*nats.Subscription
 }
 
 func NewConsumer(
 	nc *nats.Conn,
 	determineWinnerHandler *command.DetermineWinnerHandler,
+	offerSecondChanceHandler *command.OfferSecondChanceHandler,
+	recordAuctionSellerHandler *command.RecordAuctionSellerHandler,
+	recordBidderAccountHandler *command.RecordBidderAccountHandler,
 	dbGetter func(ctx context.Context) transaction.DBTX,
 	transactor transaction.Transactor,
 ) *Consumer {
 	return &Consumer{
-		nc: nc, determineWinnerHandler: determineWinnerHandler,
+		nc: nc, determineWinnerHandler: determineWinnerHandler, offerSecondChanceHandler: offerSecondChanceHandler,
+		recordAuctionSellerHandler: recordAuctionSellerHandler, recordBidderAccountHandler: recordBidderAccountHandler,
 		dbGetter: dbGetter, transactor: transactor,
 	}
 }
 
+type paymentFailedEvent struct {
+	AuctionID string `json:"auction_id"`
+	WinnerID  string `json:"winner_id"`
+}
+
+type auctionOpenedEvent struct {
+	AuctionID string `json:"auction_id"`
+	SellerID  string `json:"seller_id"`
+}
+
+type userRegisteredEvent struct {
+	UserID     string    `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
 func (c *Consumer) Start(_ context.Context) error {
 	sub, err := sharedNats.SubscribeIdempotent(c.nc, "auction.closed", "bid", c.dbGetter, c.transactor,
 		func(ctx context.Context, env *sharedEvent.Envelope) error {
@@ -42,7 +66,56 @@ func (c *Consumer) Start(_ context.Context) error {
 	}
 	c.subs = append(c.subs, sub)
 
-	slog.Info("NATS consumer started", "service", "bid", "subjects", "auction.closed")
+	sub, err = sharedNats.SubscribeIdempotent(c.nc, "payment.failed", "bid", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			var pe paymentFailedEvent
+			if err := json.Unmarshal(env.Payload, &pe); err != nil {
+				return err
+			}
+			slog.Info("received payment.failed", "service", "bid", "auction_id", pe.AuctionID, "winner_id", pe.WinnerID)
+			return c.offerSecondChanceHandler.Handle(ctx, command.OfferSecondChance{
+				AuctionID:      pe.AuctionID,
+				FailedBidderID: pe.WinnerID,
+			})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	sub, err = sharedNats.SubscribeIdempotent(c.nc, "auction.opened", "bid", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			var ae auctionOpenedEvent
+			if err := json.Unmarshal(env.Payload, &ae); err != nil {
+				return err
+			}
+			return c.recordAuctionSellerHandler.Handle(ctx, command.RecordAuctionSeller{
+				AuctionID: ae.AuctionID,
+				SellerID:  ae.SellerID,
+			})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	sub, err = sharedNats.SubscribeIdempotent(c.nc, "user.registered", "bid", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			var ue userRegisteredEvent
+			if err := json.Unmarshal(env.Payload, &ue); err != nil {
+				return err
+			}
+			return c.recordBidderAccountHandler.Handle(ctx, command.RecordBidderAccount{
+				UserID:       ue.UserID,
+				RegisteredAt: ue.OccurredAt,
+			})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	slog.Info("NATS consumer started", "service", "bid", "subjects", "auction.closed,payment.failed,auction.opened,user.registered")
 	return nil
 }
 
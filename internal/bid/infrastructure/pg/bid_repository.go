@@ -93,3 +93,138 @@ func (r *bidRepository) FindByAuctionID(ctx context.Context, auctionID string, p
 
 	return bids, total, nil
 }
+
+func (r *bidRepository) CountByAuctionID(ctx context.Context, auctionID string) (int64, error) {
+	db := r.dbGetter(ctx)
+	var count int64
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bids WHERE auction_id = $1", auctionID).Scan(&count); err != nil {
+		return 0, errors.Internal("Failed to count bids")
+	}
+	return count, nil
+}
+
+func (r *bidRepository) FindNextCandidate(ctx context.Context, auctionID string) (*entity.Bid, error) {
+	db := r.dbGetter(ctx)
+	var id, aucID, bidderID string
+	var amount int64
+	var createdAt time.Time
+
+	err := db.QueryRowContext(ctx,
+		`SELECT id, auction_id, bidder_id, amount, created_at FROM bids
+		WHERE auction_id = $1 AND bidder_id NOT IN (SELECT bidder_id FROM winner_offers WHERE auction_id = $1)
+		ORDER BY amount DESC LIMIT 1`,
+		auctionID,
+	).Scan(&id, &aucID, &bidderID, &amount, &createdAt)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to find next candidate")
+	}
+
+	return entity.ReconstructBid(id, aucID, bidderID, amount, createdAt), nil
+}
+
+func (r *bidRepository) FindBidders(ctx context.Context, auctionID string) ([]domain.BidderFirstBid, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx,
+		"SELECT bidder_id, MIN(created_at) FROM bids WHERE auction_id = $1 GROUP BY bidder_id",
+		auctionID,
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to find bidders")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var bidders []domain.BidderFirstBid
+	for rows.Next() {
+		var b domain.BidderFirstBid
+		if err := rows.Scan(&b.BidderID, &b.FirstBidAt); err != nil {
+			return nil, errors.Internal("Failed to scan bidder")
+		}
+		bidders = append(bidders, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating bidders")
+	}
+	return bidders, nil
+}
+
+func (r *bidRepository) GetStats(ctx context.Context, auctionID string, buckets int) (*domain.BidStats, error) {
+	db := r.dbGetter(ctx)
+	stats := &domain.BidStats{Histogram: []domain.HistogramBucket{}, LeadingPrices: []domain.PricePoint{}}
+
+	var minAmount, maxAmount sql.NullInt64
+	err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*), COUNT(DISTINCT bidder_id), MIN(created_at), MAX(created_at), MIN(amount), MAX(amount) FROM bids WHERE auction_id = $1",
+		auctionID,
+	).Scan(&stats.TotalBids, &stats.UniqueBidders, &stats.FirstBidAt, &stats.LastBidAt, &minAmount, &maxAmount)
+	if err != nil {
+		return nil, errors.Internal("Failed to get bid stats")
+	}
+	if stats.TotalBids == 0 {
+		return stats, nil
+	}
+
+	width := (maxAmount.Int64-minAmount.Int64)/int64(buckets) + 1
+	rows, err := db.QueryContext(ctx,
+		"SELECT (amount - $2) / $3 AS bucket, COUNT(*) FROM bids WHERE auction_id = $1 GROUP BY bucket ORDER BY bucket",
+		auctionID, minAmount.Int64, width,
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to get bid histogram")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var bucket, count int64
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, errors.Internal("Failed to scan bid histogram")
+		}
+		lower := minAmount.Int64 + bucket*width
+		stats.Histogram = append(stats.Histogram, domain.HistogramBucket{Min: lower, Max: lower + width - 1, Count: count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating bid histogram")
+	}
+
+	priceRows, err := db.QueryContext(ctx,
+		`SELECT created_at, amount FROM (
+			SELECT created_at, amount,
+				MAX(amount) OVER (ORDER BY created_at ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_max
+			FROM bids WHERE auction_id = $1
+		) t
+		WHERE prev_max IS NULL OR amount > prev_max
+		ORDER BY created_at`,
+		auctionID,
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to get leading prices")
+	}
+	defer func() {
+		if err := priceRows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	for priceRows.Next() {
+		var p domain.PricePoint
+		if err := priceRows.Scan(&p.At, &p.Amount); err != nil {
+			return nil, errors.Internal("Failed to scan leading price")
+		}
+		stats.LeadingPrices = append(stats.LeadingPrices, p)
+	}
+	if err := priceRows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating leading prices")
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,86 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.FlagRepository = (*flagRepository)(nil)
+
+type flagRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewFlagRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.FlagRepository {
+	return &flagRepository{dbGetter: dbGetter}
+}
+
+func (r *flagRepository) Save(ctx context.Context, flag *entity.Flag) (bool, error) {
+	db := r.dbGetter(ctx)
+	res, err := db.ExecContext(ctx,
+		`INSERT INTO bid_flags (id, auction_id, bidder_id, rule, evidence, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (auction_id, bidder_id, rule) DO NOTHING`,
+		flag.ID(), flag.AuctionID(), flag.BidderID(), flag.Rule(), []byte(flag.Evidence()), flag.CreatedAt(),
+	)
+	if err != nil {
+		return false, errors.Internal("Failed to create flag")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Internal("Failed to create flag")
+	}
+	return n > 0, nil
+}
+
+func (r *flagRepository) FindFlaggedAuctions(ctx context.Context, page, limit int) ([]*entity.Flag, int64, error) {
+	db := r.dbGetter(ctx)
+	offset := (page - 1) * limit
+
+	var total int64
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(DISTINCT auction_id) FROM bid_flags").Scan(&total); err != nil {
+		return nil, 0, errors.Internal("Failed to count flagged auctions")
+	}
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT f.id, f.auction_id, f.bidder_id, f.rule, f.evidence, f.created_at
+		FROM bid_flags f
+		JOIN (
+			SELECT auction_id, MAX(created_at) AS last_flagged_at FROM bid_flags
+			GROUP BY auction_id ORDER BY last_flagged_at DESC, auction_id LIMIT $1 OFFSET $2
+		) p ON p.auction_id = f.auction_id
+		ORDER BY p.last_flagged_at DESC, f.auction_id, f.created_at`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, 0, errors.Internal("Failed to list flagged auctions")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var flags []*entity.Flag
+	for rows.Next() {
+		var id, auctionID, bidderID, rule string
+		var evidence []byte
+		var createdAt time.Time
+		if err := rows.Scan(&id, &auctionID, &bidderID, &rule, &evidence, &createdAt); err != nil {
+			return nil, 0, errors.Internal("Failed to scan flag")
+		}
+		flags = append(flags, entity.ReconstructFlag(id, auctionID, bidderID, rule, json.RawMessage(evidence), createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Internal("Error iterating flags")
+	}
+
+	return flags, total, nil
+}
@@ -0,0 +1,154 @@
+package pg
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.FraudSignalRepository = (*fraudSignalRepository)(nil)
+
+type fraudSignalRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewFraudSignalRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.FraudSignalRepository {
+	return &fraudSignalRepository{dbGetter: dbGetter}
+}
+
+func (r *fraudSignalRepository) SaveAuctionSeller(ctx context.Context, auctionID, sellerID string) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO auction_sellers (auction_id, seller_id) VALUES ($1, $2) ON CONFLICT (auction_id) DO NOTHING",
+		auctionID, sellerID,
+	)
+	if err != nil {
+		return errors.Internal("Failed to save auction seller")
+	}
+	return nil
+}
+
+func (r *fraudSignalRepository) SaveBidderAccount(ctx context.Context, userID string, registeredAt time.Time) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO bidder_accounts (user_id, registered_at) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING",
+		userID, registeredAt,
+	)
+	if err != nil {
+		return errors.Internal("Failed to save bidder account")
+	}
+	return nil
+}
+
+func (r *fraudSignalRepository) FindSellerAffinities(ctx context.Context, lookback, activeSince time.Time, minAuctions int) ([]domain.SellerAffinity, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx,
+		`SELECT s.seller_id, b.bidder_id, STRING_AGG(DISTINCT b.auction_id::text, ','), COUNT(*)
+		FROM bids b
+		JOIN auction_sellers s ON s.auction_id = b.auction_id
+		WHERE b.created_at >= $1
+		GROUP BY s.seller_id, b.bidder_id
+		HAVING COUNT(DISTINCT b.auction_id) >= $3 AND MAX(b.created_at) >= $2`,
+		lookback, activeSince, minAuctions,
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to find seller affinities")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var affinities []domain.SellerAffinity
+	for rows.Next() {
+		var a domain.SellerAffinity
+		var auctionIDs string
+		if err := rows.Scan(&a.SellerID, &a.BidderID, &auctionIDs, &a.BidCount); err != nil {
+			return nil, errors.Internal("Failed to scan seller affinity")
+		}
+		a.AuctionIDs = strings.Split(auctionIDs, ",")
+		affinities = append(affinities, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating seller affinities")
+	}
+	return affinities, nil
+}
+
+func (r *fraudSignalRepository) FindSelfOutbids(ctx context.Context, since time.Time, within time.Duration) ([]domain.SelfOutbid, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx,
+		`SELECT auction_id, bidder_id, COUNT(*), MIN(created_at), MAX(created_at)
+		FROM (
+			SELECT auction_id, bidder_id, created_at,
+				LAG(bidder_id) OVER w AS prev_bidder_id,
+				LAG(created_at) OVER w AS prev_created_at
+			FROM bids
+			WHERE created_at >= $1
+			WINDOW w AS (PARTITION BY auction_id ORDER BY created_at)
+		) t
+		WHERE prev_bidder_id = bidder_id AND created_at - prev_created_at <= make_interval(secs => $2)
+		GROUP BY auction_id, bidder_id`,
+		since, within.Seconds(),
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to find self outbids")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var outbids []domain.SelfOutbid
+	for rows.Next() {
+		var o domain.SelfOutbid
+		if err := rows.Scan(&o.AuctionID, &o.BidderID, &o.Count, &o.FirstAt, &o.LastAt); err != nil {
+			return nil, errors.Internal("Failed to scan self outbid")
+		}
+		outbids = append(outbids, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating self outbids")
+	}
+	return outbids, nil
+}
+
+func (r *fraudSignalRepository) FindNewAccountBids(ctx context.Context, since time.Time, accountAge time.Duration) ([]domain.NewAccountBid, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx,
+		`SELECT b.auction_id, b.bidder_id, a.registered_at, MIN(b.created_at)
+		FROM bids b
+		JOIN bidder_accounts a ON a.user_id = b.bidder_id
+		WHERE b.created_at >= $1 AND b.created_at < a.registered_at + make_interval(secs => $2)
+		GROUP BY b.auction_id, b.bidder_id, a.registered_at`,
+		since, accountAge.Seconds(),
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to find new account bids")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var bids []domain.NewAccountBid
+	for rows.Next() {
+		var n domain.NewAccountBid
+		if err := rows.Scan(&n.AuctionID, &n.BidderID, &n.RegisteredAt, &n.FirstBidAt); err != nil {
+			return nil, errors.Internal("Failed to scan new account bid")
+		}
+		bids = append(bids, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Error iterating new account bids")
+	}
+	return bids, nil
+}
@@ -0,0 +1,60 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/bid/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.OfferRepository = (*offerRepository)(nil)
+
+type offerRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewOfferRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.OfferRepository {
+	return &offerRepository{dbGetter: dbGetter}
+}
+
+func (r *offerRepository) Save(ctx context.Context, offer *entity.Offer) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO winner_offers (auction_id, attempt, bid_id, bidder_id, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		offer.AuctionID(), offer.Attempt(), offer.BidID(), offer.BidderID(), offer.Amount(), offer.CreatedAt(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to create winner offer")
+	}
+	return nil
+}
+
+func (r *offerRepository) FindLatestByAuctionID(ctx context.Context, auctionID string, opts ...query.Option) (*entity.Offer, error) {
+	cfg := query.ApplyOptions(opts)
+	db := r.dbGetter(ctx)
+	var aucID, bidID, bidderID string
+	var amount int64
+	var attempt int
+	var createdAt time.Time
+
+	q := "SELECT auction_id, bid_id, bidder_id, amount, attempt, created_at FROM winner_offers WHERE auction_id = $1 ORDER BY attempt DESC LIMIT 1"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+
+	err := db.QueryRowContext(ctx, q, auctionID).Scan(&aucID, &bidID, &bidderID, &amount, &attempt, &createdAt)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get winner offer")
+	}
+
+	return entity.ReconstructOffer(aucID, bidID, bidderID, amount, attempt, createdAt), nil
+}
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+var _ domain.BidStatsCache = (*bidStatsCache)(nil)
+
+type bidStatsCache struct {
+	client *goredis.Client
+	ttl    time.Duration
+}
+
+func NewBidStatsCache(client *goredis.Client, ttl time.Duration) domain.BidStatsCache {
+	return &bidStatsCache{client: client, ttl: ttl}
+}
+
+type cachedHistogramBucket struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Count int64 `json:"count"`
+}
+
+type cachedPricePoint struct {
+	At     time.Time `json:"at"`
+	Amount int64     `json:"amount"`
+}
+
+type cachedBidStats struct {
+	TotalBids     int64                   `json:"total_bids"`
+	UniqueBidders int64                   `json:"unique_bidders"`
+	FirstBidAt    *time.Time              `json:"first_bid_at"`
+	LastBidAt     *time.Time              `json:"last_bid_at"`
+	Histogram     []cachedHistogramBucket `json:"histogram"`
+	LeadingPrices []cachedPricePoint      `json:"leading_prices"`
+}
+
+func (c *bidStatsCache) key(auctionID string) string {
+	return "bid_stats:" + auctionID
+}
+
+func (c *bidStatsCache) Get(ctx context.Context, auctionID string) (*domain.BidStats, error) {
+	data, err := c.client.Get(ctx, c.key(auctionID)).Bytes()
+	if stderrors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get bid stats from cache")
+	}
+
+	var raw cachedBidStats
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Internal("Failed to unmarshal cached bid stats")
+	}
+
+	stats := &domain.BidStats{
+		TotalBids: raw.TotalBids, UniqueBidders: raw.UniqueBidders,
+		FirstBidAt: raw.FirstBidAt, LastBidAt: raw.LastBidAt,
+		Histogram:     make([]domain.HistogramBucket, len(raw.Histogram)),
+		LeadingPrices: make([]domain.PricePoint, len(raw.LeadingPrices)),
+	}
+	for i, b := range raw.Histogram {
+		stats.Histogram[i] = domain.HistogramBucket{Min: b.Min, Max: b.Max, Count: b.Count}
+	}
+	for i, p := range raw.LeadingPrices {
+		stats.LeadingPrices[i] = domain.PricePoint{At: p.At, Amount: p.Amount}
+	}
+	return stats, nil
+}
+
+func (c *bidStatsCache) Set(ctx context.Context, auctionID string, stats *domain.BidStats) error {
+	raw := cachedBidStats{
+		TotalBids: stats.TotalBids, UniqueBidders: stats.UniqueBidders,
+		FirstBidAt: stats.FirstBidAt, LastBidAt: stats.LastBidAt,
+		Histogram:     make([]cachedHistogramBucket, len(stats.Histogram)),
+		LeadingPrices: make([]cachedPricePoint, len(stats.LeadingPrices)),
+	}
+	for i, b := range stats.Histogram {
+		raw.Histogram[i] = cachedHistogramBucket{Min: b.Min, Max: b.Max, Count: b.Count}
+	}
+	for i, p := range stats.LeadingPrices {
+		raw.LeadingPrices[i] = cachedPricePoint{At: p.At, Amount: p.Amount}
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return errors.Internal("Failed to marshal bid stats")
+	}
+	if err := c.client.Set(ctx, c.key(auctionID), data, c.ttl).Err(); err != nil {
+		return errors.Internal("Failed to cache bid stats")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/in-jun/go-structure-example/internal/bid/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+var _ domain.HighestBidCache = (*highestBidCache)(nil)
+
+var setIfNewerScript = goredis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'version')
+if current and tonumber(current) >= tonumber(ARGV[1]) then
+    return 0
+end
+redis.call('HSET', KEYS[1], 'version', ARGV[1], 'data', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+type highestBidCache struct {
+	client *goredis.Client
+	ttl    time.Duration
+}
+
+func NewHighestBidCache(client *goredis.Client, ttl time.Duration) domain.HighestBidCache {
+	return &highestBidCache{client: client, ttl: ttl}
+}
+
+type cachedHighestBid struct {
+	BidID     string    `json:"bid_id"`
+	AuctionID string    `json:"auction_id"`
+	BidderID  string    `json:"bidder_id"`
+	Amount    int64     `json:"amount"`
+	BidCount  int64     `json:"bid_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *highestBidCache) key(auctionID string) string {
+	return "highest_bid:" + auctionID
+}
+
+func (c *highestBidCache) Get(ctx context.Context, auctionID string) (*domain.HighestBidSnapshot, error) {
+	data, err := c.client.HGet(ctx, c.key(auctionID), "data").Bytes()
+	if stderrors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get highest bid from cache")
+	}
+
+	var raw cachedHighestBid
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Internal("Failed to unmarshal cached highest bid")
+	}
+	return &domain.HighestBidSnapshot{
+		BidID: raw.BidID, AuctionID: raw.AuctionID, BidderID: raw.BidderID,
+		Amount: raw.Amount, BidCount: raw.BidCount, CreatedAt: raw.CreatedAt,
+	}, nil
+}
+
+func (c *highestBidCache) Set(ctx context.Context, snapshot domain.HighestBidSnapshot) error {
+	data, err := json.Marshal(cachedHighestBid{
+		BidID: snapshot.BidID, AuctionID: snapshot.AuctionID, BidderID: snapshot.BidderID,
+		Amount: snapshot.Amount, BidCount: snapshot.BidCount, CreatedAt: snapshot.CreatedAt,
+	})
+	if err != nil {
+		return errors.Internal("Failed to marshal highest bid")
+	}
+
+	err = setIfNewerScript.Run(ctx, c.client, []string{c.key(snapshot.AuctionID)},
+		snapshot.BidCount, data, c.ttl.Milliseconds()).Err()
+	if err != nil {
+		return errors.Internal("Failed to cache highest bid")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/bid/application/command"
+)
+
+const scanLookback = 24 * time.Hour
+
+type FraudScanner struct {
+	handler  *command.DetectFraudHandler
+	interval time.Duration
+}
+
+func NewFraudScanner(handler *command.DetectFraudHandler, interval time.Duration) *FraudScanner {
+	return &FraudScanner{handler: handler, interval: interval}
+}
+
+func (s *FraudScanner) Start(ctx context.Context) {
+	slog.Info("fraud scanner started", "component", "fraud-scanner", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("fraud scanner stopped", "component", "fraud-scanner")
+			return
+		case <-ticker.C:
+			result, err := s.handler.Handle(ctx, command.DetectFraud{Since: time.Now().Add(-scanLookback)})
+			if err != nil {
+				slog.Error("fraud scan failed", "component", "fraud-scanner", "error", err)
+				continue
+			}
+			if result.Flagged > 0 {
+				slog.Warn("suspicious bidding flagged", "component", "fraud-scanner", "flags", result.Flagged)
+			}
+		}
+	}
+}
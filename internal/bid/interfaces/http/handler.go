@@ -15,19 +15,25 @@ import (
 type Handler struct {
 	commands application.CommandUseCase
 	queries  application.QueryUseCase
+	identity *middleware.IdentityVerifier
 }
 
-func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase) *Handler {
-	return &Handler{commands: commands, queries: queries}
+func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase, identity *middleware.IdentityVerifier) *Handler {
+	return &Handler{commands: commands, queries: queries, identity: identity}
 }
 
 func (h *Handler) RegisterRoutes(mux *server.Router, mw server.Middleware) {
-	gatewayAuth := middleware.GatewayAuth()
-
-	mux.Handle("GET /api/v1/auctions/{auction_id}/bids", mw(http.HandlerFunc(h.ListBids)))
-	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/highest", mw(http.HandlerFunc(h.GetHighest)))
-	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/events", mw(http.HandlerFunc(h.GetEvents)))
-	mux.Handle("POST /api/v1/auctions/{auction_id}/bids", mw(gatewayAuth(http.HandlerFunc(h.PlaceBid))))
+	gatewayAuth := middleware.GatewayAuth(h.identity)
+	optionalGatewayAuth := middleware.OptionalGatewayAuth(h.identity)
+	bidder := middleware.RequireRole(middleware.RoleBidder)
+	admin := middleware.RequireRole(middleware.RoleAdmin)
+
+	mux.Handle("GET /api/v1/auctions/{auction_id}/bids", mw(optionalGatewayAuth(http.HandlerFunc(h.ListBids))))
+	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/highest", mw(optionalGatewayAuth(http.HandlerFunc(h.GetHighest))))
+	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/events", mw(optionalGatewayAuth(http.HandlerFunc(h.GetEvents))))
+	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/stats", mw(http.HandlerFunc(h.GetStats)))
+	mux.Handle("POST /api/v1/auctions/{auction_id}/bids", mw(gatewayAuth(bidder(http.HandlerFunc(h.PlaceBid)))))
+	mux.Handle("GET /api/v1/admin/flagged-auctions", mw(gatewayAuth(admin(http.HandlerFunc(h.ListFlaggedAuctions)))))
 }
 
 func (h *Handler) PlaceBid(w http.ResponseWriter, r *http.Request) {
@@ -69,6 +75,7 @@ func (h *Handler) ListBids(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.queries.ListBids(r.Context(), query.ListBids{
 		AuctionID: auctionID,
+		ViewerID:  server.UserID(r),
 		Page:      page,
 		Limit:     limit,
 	})
@@ -85,6 +92,7 @@ func (h *Handler) GetEvents(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.queries.GetEvents(r.Context(), query.EventHistory{
 		AuctionID: auctionID,
+		ViewerID:  server.UserID(r),
 	})
 	if err != nil {
 		middleware.HandleError(w, err)
@@ -94,11 +102,26 @@ func (h *Handler) GetEvents(w http.ResponseWriter, r *http.Request) {
 	server.JSON(w, http.StatusOK, toEventHistoryResponse(result))
 }
 
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	auctionID := r.PathValue("auction_id")
+
+	result, err := h.queries.GetStats(r.Context(), query.GetStats{
+		AuctionID: auctionID,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toStatsResponse(result))
+}
+
 func (h *Handler) GetHighest(w http.ResponseWriter, r *http.Request) {
 	auctionID := r.PathValue("auction_id")
 
 	result, err := h.queries.GetHighest(r.Context(), query.GetHighest{
 		AuctionID: auctionID,
+		ViewerID:  server.UserID(r),
 	})
 	if err != nil {
 		middleware.HandleError(w, err)
@@ -107,3 +130,28 @@ func (h *Handler) GetHighest(w http.ResponseWriter, r *http.Request) {
 
 	server.JSON(w, http.StatusOK, toGetResponse(result))
 }
+
+func (h *Handler) ListFlaggedAuctions(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(server.QueryDefault(r, "page", "1"))
+	limit, _ := strconv.Atoi(server.QueryDefault(r, "limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.queries.ListFlaggedAuctions(r.Context(), query.ListFlaggedAuctions{
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toFlaggedAuctionListResponse(result))
+}
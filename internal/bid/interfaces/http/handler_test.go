@@ -25,10 +25,17 @@ func (m *mockCommandUseCase) PlaceBid(_ context.Context, _ command.PlaceBid) (*c
 func (m *mockCommandUseCase) DetermineWinner(_ context.Context, _ command.DetermineWinner) error {
 	return m.err
 }
+func (m *mockCommandUseCase) OfferSecondChance(_ context.Context, _ command.OfferSecondChance) error {
+	return m.err
+}
+func (m *mockCommandUseCase) DetectFraud(_ context.Context, _ command.DetectFraud) (*command.DetectFraudResult, error) {
+	return &command.DetectFraudResult{}, m.err
+}
 
 type mockQueryUseCase struct {
 	highestResp *query.Result
 	listResp    *query.ListResult
+	flaggedResp *query.FlaggedAuctionListResult
 	err         error
 }
 
@@ -41,13 +48,19 @@ func (m *mockQueryUseCase) ListBids(_ context.Context, _ query.ListBids) (*query
 func (m *mockQueryUseCase) GetEvents(_ context.Context, _ query.EventHistory) (*query.EventHistoryResult, error) {
 	return &query.EventHistoryResult{Events: []query.EventHistoryItem{}}, m.err
 }
+func (m *mockQueryUseCase) GetStats(_ context.Context, _ query.GetStats) (*query.StatsResult, error) {
+	return &query.StatsResult{AuctionID: testAuctionID, TotalBids: 3}, m.err
+}
+func (m *mockQueryUseCase) ListFlaggedAuctions(_ context.Context, _ query.ListFlaggedAuctions) (*query.FlaggedAuctionListResult, error) {
+	return m.flaggedResp, m.err
+}
 
 const testUserID = "550e8400-e29b-41d4-a716-446655440000"
 const testAuctionID = "660e8400-e29b-41d4-a716-446655440000"
 
 func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server.Router {
 	mux := server.NewRouter()
-	h := NewHandler(cmdMock, qryMock)
+	h := NewHandler(cmdMock, qryMock, nil)
 	noopMw := server.Middleware(func(next http.Handler) http.Handler { return next })
 
 	injectUser := func(next http.Handler) http.Handler {
@@ -60,7 +73,9 @@ func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server
 	mux.Handle("GET /api/v1/auctions/{auction_id}/bids", noopMw(http.HandlerFunc(h.ListBids)))
 	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/highest", noopMw(http.HandlerFunc(h.GetHighest)))
 	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/events", noopMw(http.HandlerFunc(h.GetEvents)))
+	mux.Handle("GET /api/v1/auctions/{auction_id}/bids/stats", noopMw(http.HandlerFunc(h.GetStats)))
 	mux.Handle("POST /api/v1/auctions/{auction_id}/bids", noopMw(injectUser(http.HandlerFunc(h.PlaceBid))))
+	mux.Handle("GET /api/v1/admin/flagged-auctions", noopMw(injectUser(http.HandlerFunc(h.ListFlaggedAuctions))))
 
 	return mux
 }
@@ -204,3 +219,50 @@ func TestHandler_ListBids_Error(t *testing.T) {
 		t.Errorf("expected status 500, got %d", w.Code)
 	}
 }
+
+func TestHandler_ListFlaggedAuctions(t *testing.T) {
+	qryMock := &mockQueryUseCase{
+		flaggedResp: &query.FlaggedAuctionListResult{
+			Auctions: []query.FlaggedAuctionResult{
+				{AuctionID: testAuctionID, Flags: []query.FlagResult{
+					{ID: "f1", BidderID: testUserID, Rule: "new_account", Evidence: []byte(`{}`)},
+				}},
+			},
+			Total: 1,
+		},
+	}
+
+	router := setupRouter(&mockCommandUseCase{}, qryMock)
+	req := httptest.NewRequest("GET", "/api/v1/admin/flagged-auctions", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var resp FlaggedAuctionListResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Total != 1 || len(resp.Auctions) != 1 || len(resp.Auctions[0].Flags) != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHandler_GetStats(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("GET", "/api/v1/auctions/"+testAuctionID+"/bids/stats", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var resp StatsResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.TotalBids != 3 {
+		t.Errorf("expected total_bids 3, got %d", resp.TotalBids)
+	}
+}
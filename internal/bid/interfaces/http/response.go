@@ -11,8 +11,10 @@ import (
 type Response struct {
 	ID        string    `json:"id"`
 	AuctionID string    `json:"auction_id"`
-	BidderID  string    `json:"bidder_id"`
+	BidderID  string    `json:"bidder_id,omitempty"`
+	Bidder    string    `json:"bidder,omitempty"`
 	Amount    int64     `json:"amount"`
+	BidCount  int64     `json:"bid_count,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -36,7 +38,9 @@ func toGetResponse(r *query.Result) *Response {
 		ID:        r.ID,
 		AuctionID: r.AuctionID,
 		BidderID:  r.BidderID,
+		Bidder:    r.Bidder,
 		Amount:    r.Amount,
+		BidCount:  r.BidCount,
 		CreatedAt: r.CreatedAt,
 	}
 }
@@ -67,9 +71,80 @@ func toListResponse(r *query.ListResult) *ListResponse {
 			ID:        b.ID,
 			AuctionID: b.AuctionID,
 			BidderID:  b.BidderID,
+			Bidder:    b.Bidder,
 			Amount:    b.Amount,
 			CreatedAt: b.CreatedAt,
 		}
 	}
 	return &ListResponse{Bids: bids, Total: r.Total}
 }
+
+type FlagResponse struct {
+	ID        string          `json:"id"`
+	BidderID  string          `json:"bidder_id"`
+	Rule      string          `json:"rule"`
+	Evidence  json.RawMessage `json:"evidence"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type FlaggedAuctionResponse struct {
+	AuctionID string         `json:"auction_id"`
+	Flags     []FlagResponse `json:"flags"`
+}
+
+type FlaggedAuctionListResponse struct {
+	Auctions []FlaggedAuctionResponse `json:"auctions"`
+	Total    int64                    `json:"total"`
+}
+
+func toFlaggedAuctionListResponse(r *query.FlaggedAuctionListResult) *FlaggedAuctionListResponse {
+	auctions := make([]FlaggedAuctionResponse, len(r.Auctions))
+	for i, a := range r.Auctions {
+		flags := make([]FlagResponse, len(a.Flags))
+		for j, f := range a.Flags {
+			flags[j] = FlagResponse{
+				ID: f.ID, BidderID: f.BidderID, Rule: f.Rule,
+				Evidence: f.Evidence, CreatedAt: f.CreatedAt,
+			}
+		}
+		auctions[i] = FlaggedAuctionResponse{AuctionID: a.AuctionID, Flags: flags}
+	}
+	return &FlaggedAuctionListResponse{Auctions: auctions, Total: r.Total}
+}
+
+type HistogramBucketResponse struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Count int64 `json:"count"`
+}
+
+type PricePointResponse struct {
+	At     time.Time `json:"at"`
+	Amount int64     `json:"amount"`
+}
+
+type StatsResponse struct {
+	AuctionID     string                    `json:"auction_id"`
+	TotalBids     int64                     `json:"total_bids"`
+	UniqueBidders int64                     `json:"unique_bidders"`
+	FirstBidAt    *time.Time                `json:"first_bid_at"`
+	LastBidAt     *time.Time                `json:"last_bid_at"`
+	Histogram     []HistogramBucketResponse `json:"histogram"`
+	LeadingPrices []PricePointResponse      `json:"leading_prices"`
+}
+
+func toStatsResponse(r *query.StatsResult) *StatsResponse {
+	resp := &StatsResponse{
+		AuctionID: r.AuctionID, TotalBids: r.TotalBids, UniqueBidders: r.UniqueBidders,
+		FirstBidAt: r.FirstBidAt, LastBidAt: r.LastBidAt,
+		Histogram:     make([]HistogramBucketResponse, len(r.Histogram)),
+		LeadingPrices: make([]PricePointResponse, len(r.LeadingPrices)),
+	}
+	for i, b := range r.Histogram {
+		resp.Histogram[i] = HistogramBucketResponse{Min: b.Min, Max: b.Max, Count: b.Count}
+	}
+	for i, p := range r.LeadingPrices {
+		resp.LeadingPrices[i] = PricePointResponse{At: p.At, Amount: p.Amount}
+	}
+	return resp
+}
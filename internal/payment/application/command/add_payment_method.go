@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+// AddPaymentMethod saves a method tokenized by the gateway. The card details
+// are taken from the gateway, and the token must have been issued to the
+// user. The user's first method becomes the default regardless of
+// MakeDefault.
+type AddPaymentMethod struct {
+	UserID      string
+	Token       string
+	MakeDefault bool
+}
+
+type PaymentMethodResult struct {
+	PaymentMethodID string
+	IsDefault       bool
+}
+
+type AddPaymentMethodHandler struct {
+	methodRepo domain.PaymentMethodRepository
+	gateway    domain.PaymentMethodGateway
+	transactor transaction.Transactor
+}
+
+func NewAddPaymentMethodHandler(
+	methodRepo domain.PaymentMethodRepository,
+	gateway domain.PaymentMethodGateway,
+	transactor transaction.Transactor,
+) *AddPaymentMethodHandler {
+	return &AddPaymentMethodHandler{methodRepo: methodRepo, gateway: gateway, transactor: transactor}
+}
+
+func (h *AddPaymentMethodHandler) Handle(ctx context.Context, cmd AddPaymentMethod) (*PaymentMethodResult, error) {
+	if cmd.Token == "" {
+		return nil, errors.BadRequest("Payment method token is required")
+	}
+	// A token issued to someone else is reported like an unknown one so
+	// tokens cannot be probed.
+	card, err := h.gateway.LookupPaymentMethod(ctx, cmd.Token)
+	if stderrors.Is(err, domain.ErrUnknownPaymentMethod) || (err == nil && card.CustomerID != cmd.UserID) {
+		return nil, errors.BadRequest("Unknown payment method token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := entity.NewPaymentMethod(cmd.UserID, card.Token, card.Brand, card.Last4, card.ExpMonth, card.ExpYear, time.Now())
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		methods, err := h.methodRepo.FindByUserID(txCtx, cmd.UserID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if cmd.MakeDefault || len(methods) == 0 {
+			if err := clearDefault(txCtx, h.methodRepo, methods); err != nil {
+				return err
+			}
+			if err := method.MakeDefault(); err != nil {
+				return errors.Conflict(err.Error())
+			}
+		}
+		return h.methodRepo.Save(txCtx, method)
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentMethodResult{PaymentMethodID: method.ID(), IsDefault: method.IsDefault()}, nil
+}
+
+// clearDefault unsets the current default among methods so another can take
+// its place.
+func clearDefault(ctx context.Context, methodRepo domain.PaymentMethodRepository, methods []*entity.PaymentMethod) error {
+	for _, m := range methods {
+		if !m.IsDefault() {
+			continue
+		}
+		m.ClearDefault()
+		if err := methodRepo.Update(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
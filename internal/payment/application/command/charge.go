@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+// chargeRunner drives a payment that is already marked processing through
+// the gateway and records the outcome in its own transaction. The gateway
+// call happens outside any transaction so no row lock is held meanwhile.
+// Every gateway call is kept as a payment attempt for auditing.
+type chargeRunner struct {
+	paymentRepo    domain.PaymentRepository
+	methodRepo     domain.PaymentMethodRepository
+	processor      *service.PaymentProcessor
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+// run charges processing as it was when marked processing: its current
+// attempt, amount and chosen payment method.
+func (c *chargeRunner) run(ctx context.Context, processing *entity.Payment) (string, error) {
+	paymentID := processing.ID()
+	token, err := c.methodToken(ctx, processing.PaymentMethodID())
+	if err != nil {
+		return "", err
+	}
+
+	result, err := c.processor.Charge(ctx, paymentID, token, processing.ChargeAttempts(), processing.Amount())
+	if err != nil {
+		for _, a := range result.Attempts {
+			if err := c.paymentRepo.SaveAttempt(ctx, a); err != nil {
+				slog.Error("failed to record payment attempt", "payment_id", paymentID, "operation", a.Operation, "error", err)
+			}
+		}
+		slog.Warn("payment charge outcome unknown, leaving for recovery", "payment_id", paymentID, "error", err)
+		return entity.StatusProcessing, nil
+	}
+
+	var status string
+	err = c.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		for _, a := range result.Attempts {
+			if err := c.paymentRepo.SaveAttempt(txCtx, a); err != nil {
+				return err
+			}
+		}
+
+		payment, err := c.paymentRepo.FindByID(txCtx, paymentID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+		status = payment.Status()
+		if !payment.IsProcessing() {
+			return nil
+		}
+
+		if err := c.processor.Settle(payment, result); err != nil {
+			return errors.Conflict(err.Error())
+		}
+		status = payment.Status()
+		if len(payment.Events()) == 0 {
+			return nil
+		}
+
+		if err := c.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := c.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+			return err
+		}
+		payment.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	return status, err
+}
+
+// methodToken looks up the gateway token of the payment's method. Removed
+// methods are still found, so a charge already started with one can finish.
+func (c *chargeRunner) methodToken(ctx context.Context, methodID string) (string, error) {
+	if methodID == "" {
+		return "", nil
+	}
+	method, err := c.methodRepo.FindByID(ctx, methodID)
+	if err != nil {
+		return "", err
+	}
+	if method == nil {
+		return "", errors.NotFound("Payment method not found")
+	}
+	return method.Token(), nil
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type ConfirmDelivery struct {
+	UserID    string
+	PaymentID string
+}
+
+type ConfirmDeliveryResult struct {
+	PaymentID string
+	Status    string
+}
+
+type ConfirmDeliveryHandler struct {
+	paymentRepo    domain.PaymentRepository
+	disputeRepo    domain.DisputeRepository
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewConfirmDeliveryHandler(
+	paymentRepo domain.PaymentRepository,
+	disputeRepo domain.DisputeRepository,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *ConfirmDeliveryHandler {
+	return &ConfirmDeliveryHandler{
+		paymentRepo: paymentRepo, disputeRepo: disputeRepo,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle lets the buyer release escrow early once the item has arrived.
+func (h *ConfirmDeliveryHandler) Handle(ctx context.Context, cmd ConfirmDelivery) (*ConfirmDeliveryResult, error) {
+	pv, err := vo.NewPaymentIDVO(cmd.PaymentID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	var result *ConfirmDeliveryResult
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payment, err := h.paymentRepo.FindByID(txCtx, pv.ID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+		if !payment.IsOwnedBy(cmd.UserID) {
+			return errors.Forbidden("Not authorized")
+		}
+		dispute, err := h.disputeRepo.FindActiveByPaymentID(txCtx, payment.ID())
+		if err != nil {
+			return err
+		}
+		if dispute != nil {
+			return errors.Conflict("Payment is under dispute")
+		}
+
+		if err := payment.ReleaseEscrow(entity.EscrowReleaseConfirmed); err != nil {
+			if stderrors.Is(err, entity.ErrNotHeld) {
+				return errors.Conflict(err.Error())
+			}
+			return err
+		}
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+			return err
+		}
+		payment.ClearEvents()
+
+		result = &ConfirmDeliveryResult{PaymentID: payment.ID(), Status: payment.Status()}
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
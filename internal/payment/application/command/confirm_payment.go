@@ -2,47 +2,61 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
-	"github.com/in-jun/go-structure-example/internal/shared/query"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 )
 
+// ConfirmPayment charges the payment to PaymentMethodID, or to the user's
+// default method when it is empty.
 type ConfirmPayment struct {
-	UserID    string
+	UserID          string
+	PaymentID       string
+	PaymentMethodID string
+}
+
+type ConfirmPaymentResult struct {
 	PaymentID string
+	Status    string
+	Pending   bool
 }
 
 type ConfirmPaymentHandler struct {
-	paymentRepo    domain.PaymentRepository
-	processor      *service.PaymentProcessor
-	eventPublisher domain.EventPublisher
-	transactor     transaction.Transactor
+	paymentRepo domain.PaymentRepository
+	methodRepo  domain.PaymentMethodRepository
+	transactor  transaction.Transactor
+	charger     *chargeRunner
 }
 
 func NewConfirmPaymentHandler(
 	paymentRepo domain.PaymentRepository,
+	methodRepo domain.PaymentMethodRepository,
 	processor *service.PaymentProcessor,
 	eventPublisher domain.EventPublisher,
 	transactor transaction.Transactor,
 ) *ConfirmPaymentHandler {
 	return &ConfirmPaymentHandler{
-		paymentRepo: paymentRepo, processor: processor,
-		eventPublisher: eventPublisher, transactor: transactor,
+		paymentRepo: paymentRepo, methodRepo: methodRepo, transactor: transactor,
+		charger: &chargeRunner{
+			paymentRepo: paymentRepo, methodRepo: methodRepo, processor: processor,
+			eventPublisher: eventPublisher, transactor: transactor,
+		},
 	}
 }
 
-func (h *ConfirmPaymentHandler) Handle(ctx context.Context, cmd ConfirmPayment) error {
+func (h *ConfirmPaymentHandler) Handle(ctx context.Context, cmd ConfirmPayment) (*ConfirmPaymentResult, error) {
 	pv, err := vo.NewPaymentIDVO(cmd.PaymentID)
 	if err != nil {
-		return errors.BadRequest(err.Error())
+		return nil, errors.BadRequest(err.Error())
 	}
 
-	var declined bool
+	var processing *entity.Payment
 	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
 		payment, err := h.paymentRepo.FindByID(txCtx, pv.ID, query.ForUpdate())
 		if err != nil {
@@ -54,28 +68,69 @@ func (h *ConfirmPaymentHandler) Handle(ctx context.Context, cmd ConfirmPayment)
 		if !payment.IsOwnedBy(cmd.UserID) {
 			return errors.Forbidden("Not authorized")
 		}
-
-		if err := h.processor.Process(txCtx, payment); err != nil {
-			return errors.Conflict(err.Error())
+		if payment.IsProcessing() {
+			return errors.Conflict("Payment is already being processed")
 		}
 
-		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+		methodID, err := h.paymentMethod(txCtx, cmd)
+		if err != nil {
 			return err
 		}
 
-		if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
-			return err
+		if err := payment.StartProcessing(methodID); err != nil {
+			return errors.Conflict(err.Error())
 		}
-		payment.ClearEvents()
+		processing = payment
 
-		declined = payment.Status() == entity.StatusFailed
-		return nil
+		return h.paymentRepo.Update(txCtx, payment)
 	}, transaction.WithIsolation(transaction.Pessimistic))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	status, err := h.charger.run(ctx, processing)
+	if err != nil {
+		return nil, err
 	}
-	if declined {
-		return errors.BadRequest("Payment was declined")
+	if status == entity.StatusFailed {
+		return nil, errors.BadRequest("Payment was declined")
+	}
+	return &ConfirmPaymentResult{
+		PaymentID: pv.ID,
+		Status:    status,
+		Pending:   status == entity.StatusProcessing,
+	}, nil
+}
+
+// paymentMethod picks the method to charge. Without a saved method the
+// charge goes ahead with none and the provider collects the instrument.
+func (h *ConfirmPaymentHandler) paymentMethod(ctx context.Context, cmd ConfirmPayment) (string, error) {
+	var method *entity.PaymentMethod
+	var err error
+	if cmd.PaymentMethodID == "" {
+		method, err = h.methodRepo.FindDefaultByUserID(ctx, cmd.UserID)
+		if err != nil || method == nil {
+			return "", err
+		}
+	} else {
+		mv, err := vo.NewPaymentMethodIDVO(cmd.PaymentMethodID)
+		if err != nil {
+			return "", errors.BadRequest(err.Error())
+		}
+		method, err = h.methodRepo.FindByID(ctx, mv.ID)
+		if err != nil {
+			return "", err
+		}
+		if method == nil || method.IsRemoved() {
+			return "", errors.NotFound("Payment method not found")
+		}
+		if !method.IsOwnedBy(cmd.UserID) {
+			return "", errors.Forbidden("Not authorized")
+		}
+	}
+
+	if err := method.Usable(time.Now()); err != nil {
+		return "", errors.BadRequest(err.Error())
 	}
-	return nil
+	return method.ID(), nil
 }
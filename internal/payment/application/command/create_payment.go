@@ -2,9 +2,11 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 )
@@ -20,21 +22,46 @@ type CreatePaymentResult struct {
 	AuctionID string
 	WinnerID  string
 	Amount    int64
+	Fee       int64
 	Status    string
+	DueAt     time.Time
 }
 
 type CreatePaymentHandler struct {
 	paymentRepo    domain.PaymentRepository
+	auctionClient  domain.AuctionClient
+	deadlinePolicy *service.DeadlinePolicy
+	feePolicy      *service.FeePolicy
+	escrowHold     time.Duration
 	eventPublisher domain.EventPublisher
 	transactor     transaction.Transactor
 }
 
-func NewCreatePaymentHandler(paymentRepo domain.PaymentRepository, eventPublisher domain.EventPublisher, transactor transaction.Transactor) *CreatePaymentHandler {
-	return &CreatePaymentHandler{paymentRepo: paymentRepo, eventPublisher: eventPublisher, transactor: transactor}
+func NewCreatePaymentHandler(
+	paymentRepo domain.PaymentRepository,
+	auctionClient domain.AuctionClient,
+	deadlinePolicy *service.DeadlinePolicy,
+	feePolicy *service.FeePolicy,
+	escrowHold time.Duration,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *CreatePaymentHandler {
+	return &CreatePaymentHandler{
+		paymentRepo: paymentRepo, auctionClient: auctionClient, deadlinePolicy: deadlinePolicy, feePolicy: feePolicy,
+		escrowHold: escrowHold, eventPublisher: eventPublisher, transactor: transactor,
+	}
 }
 
+// Handle charges the platform fee of the auction's category, which is looked
+// up from the auction service since bid.won does not carry it.
 func (h *CreatePaymentHandler) Handle(ctx context.Context, cmd CreatePayment) (*CreatePaymentResult, error) {
-	payment, err := entity.NewPayment(cmd.AuctionID, cmd.WinnerID, cmd.Amount)
+	auction, err := h.auctionClient.GetAuction(ctx, cmd.AuctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := h.feePolicy.Calculate(cmd.Amount, auction.Category)
+	payment, err := entity.NewPayment(cmd.AuctionID, cmd.WinnerID, cmd.Amount, fee, h.escrowHold, h.deadlinePolicy.DueAt(time.Now()))
 	if err != nil {
 		return nil, errors.BadRequest(err.Error())
 	}
@@ -52,7 +79,7 @@ func (h *CreatePaymentHandler) Handle(ctx context.Context, cmd CreatePayment) (*
 		result = &CreatePaymentResult{
 			ID: payment.ID(), AuctionID: payment.AuctionID(),
 			WinnerID: payment.WinnerID(), Amount: payment.Amount(),
-			Status: payment.Status(),
+			Fee: payment.Fee().Amount, Status: payment.Status(), DueAt: payment.DueAt(),
 		}
 		return nil
 	})
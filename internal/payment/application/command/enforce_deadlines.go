@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type EnforceDeadlines struct {
+	Now   time.Time
+	Limit int
+}
+
+type EnforceDeadlinesResult struct {
+	Expired  int
+	Reminded int
+}
+
+type EnforceDeadlinesHandler struct {
+	paymentRepo    domain.PaymentRepository
+	deadlinePolicy *service.DeadlinePolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewEnforceDeadlinesHandler(
+	paymentRepo domain.PaymentRepository,
+	deadlinePolicy *service.DeadlinePolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *EnforceDeadlinesHandler {
+	return &EnforceDeadlinesHandler{
+		paymentRepo: paymentRepo, deadlinePolicy: deadlinePolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+func (h *EnforceDeadlinesHandler) Handle(ctx context.Context, cmd EnforceDeadlines) (*EnforceDeadlinesResult, error) {
+	candidates, err := h.paymentRepo.FindPendingDueBefore(ctx, cmd.Now.Add(h.deadlinePolicy.Horizon()), cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnforceDeadlinesResult{}
+	for _, c := range candidates {
+		var expired, reminded bool
+		err := h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			payment, err := h.paymentRepo.FindByID(txCtx, c.ID(), query.ForUpdate())
+			if err != nil {
+				return err
+			}
+			if payment == nil {
+				return errors.NotFound("Payment not found")
+			}
+
+			if payment.IsOverdue(cmd.Now) {
+				if err := payment.Fail(service.DeadlineExpiredReason); err != nil {
+					return errors.Conflict(err.Error())
+				}
+				expired = true
+			} else if reminder, ok := h.deadlinePolicy.DueReminder(payment, cmd.Now); ok {
+				if err := payment.Remind(reminder); err != nil {
+					return errors.Conflict(err.Error())
+				}
+				reminded = true
+			} else {
+				return nil
+			}
+
+			if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+				return err
+			}
+
+			if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+				return err
+			}
+			payment.ClearEvents()
+			return nil
+		}, transaction.WithIsolation(transaction.Pessimistic))
+		if err != nil {
+			slog.Error("failed to enforce payment deadline", "payment_id", c.ID(), "error", err)
+			continue
+		}
+		if expired {
+			result.Expired++
+		}
+		if reminded {
+			result.Reminded++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type EnforceDisputeDeadlines struct {
+	Now   time.Time
+	Limit int
+}
+
+type EnforceDisputeDeadlinesResult struct {
+	Escalated int
+	Overdue   int
+}
+
+type EnforceDisputeDeadlinesHandler struct {
+	disputeRepo    domain.DisputeRepository
+	disputePolicy  *service.DisputePolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewEnforceDisputeDeadlinesHandler(
+	disputeRepo domain.DisputeRepository,
+	disputePolicy *service.DisputePolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *EnforceDisputeDeadlinesHandler {
+	return &EnforceDisputeDeadlinesHandler{
+		disputeRepo: disputeRepo, disputePolicy: disputePolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle escalates disputes the seller did not answer in time and flags
+// those still waiting for review past their deadline.
+func (h *EnforceDisputeDeadlinesHandler) Handle(ctx context.Context, cmd EnforceDisputeDeadlines) (*EnforceDisputeDeadlinesResult, error) {
+	candidates, err := h.disputeRepo.FindDeadlineDue(ctx, cmd.Now, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnforceDisputeDeadlinesResult{}
+	for _, c := range candidates {
+		var escalated, overdue bool
+		err := h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			dispute, err := h.disputeRepo.FindByID(txCtx, c.ID(), query.ForUpdate())
+			if err != nil {
+				return err
+			}
+			if dispute == nil {
+				return errors.NotFound("Dispute not found")
+			}
+
+			if dispute.IsResponseOverdue(cmd.Now) {
+				if err := dispute.Escalate(h.disputePolicy.ReviewBy(cmd.Now)); err != nil {
+					return errors.Conflict(err.Error())
+				}
+				escalated = true
+			} else if dispute.IsReviewOverdue(cmd.Now) {
+				if err := dispute.MarkReviewOverdue(); err != nil {
+					return errors.Conflict(err.Error())
+				}
+				overdue = true
+			} else {
+				return nil
+			}
+
+			if err := h.disputeRepo.Update(txCtx, dispute); err != nil {
+				return err
+			}
+
+			if err := h.eventPublisher.Publish(txCtx, dispute.Events()...); err != nil {
+				return err
+			}
+			dispute.ClearEvents()
+			return nil
+		}, transaction.WithIsolation(transaction.Pessimistic))
+		if err != nil {
+			slog.Error("failed to enforce dispute deadline", "dispute_id", c.ID(), "error", err)
+			continue
+		}
+		if escalated {
+			result.Escalated++
+		}
+		if overdue {
+			result.Overdue++
+		}
+	}
+	return result, nil
+}
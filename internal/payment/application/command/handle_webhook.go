@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type HandleWebhook struct {
+	Provider  string
+	Payload   []byte
+	Signature string
+}
+
+type HandleWebhookHandler struct {
+	paymentRepo    domain.PaymentRepository
+	processor      *service.PaymentProcessor
+	verifiers      map[string]domain.WebhookVerifier
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewHandleWebhookHandler(
+	paymentRepo domain.PaymentRepository,
+	processor *service.PaymentProcessor,
+	verifiers map[string]domain.WebhookVerifier,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *HandleWebhookHandler {
+	return &HandleWebhookHandler{
+		paymentRepo: paymentRepo, processor: processor, verifiers: verifiers,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+func (h *HandleWebhookHandler) Handle(ctx context.Context, cmd HandleWebhook) error {
+	verifier, ok := h.verifiers[cmd.Provider]
+	if !ok {
+		return errors.NotFound("Unknown payment provider")
+	}
+
+	evt, err := verifier.Verify(cmd.Payload, cmd.Signature)
+	if err != nil {
+		slog.Warn("rejected payment webhook", "provider", cmd.Provider, "error", err)
+		return errors.Unauthorized("Invalid webhook signature")
+	}
+
+	pv, err := vo.NewPaymentIDVO(evt.PaymentID)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payment, err := h.paymentRepo.FindByID(txCtx, pv.ID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+
+		if err := h.processor.ApplyWebhook(payment, evt); err != nil {
+			if stderrors.Is(err, service.ErrUnknownWebhookType) {
+				return errors.BadRequest(err.Error())
+			}
+			return errors.Conflict(err.Error())
+		}
+
+		if len(payment.Events()) == 0 {
+			return nil
+		}
+
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+			return err
+		}
+		payment.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+// errInvoiceExists rolls back a lost race so its invoice number is reused.
+var errInvoiceExists = stderrors.New("invoice already issued")
+
+type IssueInvoice struct {
+	PaymentID string
+}
+
+type IssueInvoiceHandler struct {
+	paymentRepo    domain.PaymentRepository
+	invoiceRepo    domain.InvoiceRepository
+	auctionClient  domain.AuctionClient
+	userClient     domain.UserClient
+	taxBasisPoints int64
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewIssueInvoiceHandler(
+	paymentRepo domain.PaymentRepository,
+	invoiceRepo domain.InvoiceRepository,
+	auctionClient domain.AuctionClient,
+	userClient domain.UserClient,
+	taxBasisPoints int64,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *IssueInvoiceHandler {
+	return &IssueInvoiceHandler{
+		paymentRepo: paymentRepo, invoiceRepo: invoiceRepo, auctionClient: auctionClient,
+		userClient: userClient, taxBasisPoints: taxBasisPoints,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle issues the invoice for a completed payment once. Buyer and seller
+// details are fetched before the transaction so the counter lock is short.
+func (h *IssueInvoiceHandler) Handle(ctx context.Context, cmd IssueInvoice) error {
+	existing, err := h.invoiceRepo.FindByPaymentID(ctx, cmd.PaymentID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	payment, err := h.paymentRepo.FindByID(ctx, cmd.PaymentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil {
+		return errors.NotFound("Payment not found")
+	}
+
+	auction, err := h.auctionClient.GetAuction(ctx, payment.AuctionID())
+	if err != nil {
+		return err
+	}
+	buyer, err := h.party(ctx, payment.WinnerID())
+	if err != nil {
+		return err
+	}
+	seller, err := h.party(ctx, auction.SellerID)
+	if err != nil {
+		return err
+	}
+
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		seq, err := h.invoiceRepo.NextSequence(txCtx)
+		if err != nil {
+			return err
+		}
+		existing, err := h.invoiceRepo.FindByPaymentID(txCtx, payment.ID())
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			slog.Info("invoice already issued", "payment_id", payment.ID(), "number", existing.Number())
+			return errInvoiceExists
+		}
+
+		invoice, err := entity.NewInvoice(seq, payment, buyer, seller, h.taxBasisPoints)
+		if err != nil {
+			return errors.Conflict(err.Error())
+		}
+		if err := h.invoiceRepo.Save(txCtx, invoice); err != nil {
+			return err
+		}
+		if err := h.eventPublisher.Publish(txCtx, invoice.Events()...); err != nil {
+			return err
+		}
+		invoice.ClearEvents()
+		return nil
+	})
+	if stderrors.Is(err, errInvoiceExists) {
+		return nil
+	}
+	return err
+}
+
+func (h *IssueInvoiceHandler) party(ctx context.Context, userID string) (entity.InvoiceParty, error) {
+	user, err := h.userClient.GetUser(ctx, userID)
+	if err != nil {
+		return entity.InvoiceParty{}, err
+	}
+	return entity.InvoiceParty{ID: user.ID, Name: user.Name, Email: user.Email}, nil
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type OpenDispute struct {
+	UserID    string
+	PaymentID string
+	Reason    string
+}
+
+type DisputeResult struct {
+	DisputeID string
+	Status    string
+}
+
+type OpenDisputeHandler struct {
+	paymentRepo    domain.PaymentRepository
+	disputeRepo    domain.DisputeRepository
+	ledgerRepo     domain.LedgerRepository
+	auctionClient  domain.AuctionClient
+	disputePolicy  *service.DisputePolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewOpenDisputeHandler(
+	paymentRepo domain.PaymentRepository,
+	disputeRepo domain.DisputeRepository,
+	ledgerRepo domain.LedgerRepository,
+	auctionClient domain.AuctionClient,
+	disputePolicy *service.DisputePolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *OpenDisputeHandler {
+	return &OpenDisputeHandler{
+		paymentRepo: paymentRepo, disputeRepo: disputeRepo, ledgerRepo: ledgerRepo,
+		auctionClient: auctionClient, disputePolicy: disputePolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle opens a dispute for the buyer. The seller's payable account is
+// locked while the dispute is saved so a concurrent payout either finishes
+// first or sees the dispute.
+func (h *OpenDisputeHandler) Handle(ctx context.Context, cmd OpenDispute) (*DisputeResult, error) {
+	pv, err := vo.NewPaymentIDVO(cmd.PaymentID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if cmd.Reason == "" {
+		return nil, errors.BadRequest("Dispute reason is required")
+	}
+
+	payment, err := h.paymentRepo.FindByID(ctx, pv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, errors.NotFound("Payment not found")
+	}
+	if !payment.IsOwnedBy(cmd.UserID) {
+		return nil, errors.Forbidden("Not authorized")
+	}
+	auction, err := h.auctionClient.GetAuction(ctx, payment.AuctionID())
+	if err != nil {
+		return nil, err
+	}
+
+	var dispute *entity.Dispute
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payment, err := h.paymentRepo.FindByID(txCtx, pv.ID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+		active, err := h.disputeRepo.FindActiveByPaymentID(txCtx, payment.ID())
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return errors.Conflict("Payment already has an open dispute")
+		}
+		now := time.Now()
+		if !h.disputePolicy.CanOpen(payment, now) {
+			return errors.Conflict("Dispute window has closed")
+		}
+
+		dispute, err = entity.NewDispute(payment, auction.SellerID, cmd.Reason, h.disputePolicy.RespondBy(now))
+		if err != nil {
+			return errors.Conflict(err.Error())
+		}
+		if err := h.ledgerRepo.LockAccount(txCtx, entity.AccountSellerPayable, auction.SellerID); err != nil {
+			return err
+		}
+		if err := h.disputeRepo.Save(txCtx, dispute); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, dispute.Events()...); err != nil {
+			return err
+		}
+		dispute.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeResult{DisputeID: dispute.ID(), Status: dispute.Status()}, nil
+}
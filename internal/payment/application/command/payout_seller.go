@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type PayoutSeller struct {
+	SellerID string
+}
+
+type PayoutSellerResult struct {
+	ID     string
+	Amount int64
+	Status string
+}
+
+type PayoutSellerHandler struct {
+	payoutRepo     domain.PayoutRepository
+	ledgerRepo     domain.LedgerRepository
+	disputeRepo    domain.DisputeRepository
+	payoutGateway  domain.PayoutGateway
+	ledgerPolicy   *service.LedgerPolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewPayoutSellerHandler(
+	payoutRepo domain.PayoutRepository,
+	ledgerRepo domain.LedgerRepository,
+	disputeRepo domain.DisputeRepository,
+	payoutGateway domain.PayoutGateway,
+	ledgerPolicy *service.LedgerPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *PayoutSellerHandler {
+	return &PayoutSellerHandler{
+		payoutRepo: payoutRepo, ledgerRepo: ledgerRepo, disputeRepo: disputeRepo, payoutGateway: payoutGateway,
+		ledgerPolicy: ledgerPolicy, eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle pays out the seller's whole payable balance. A payout whose
+// transfer outcome is unknown stays pending and is resumed with the same
+// idempotency key on the next call instead of starting a new one. No new
+// payout starts while any of the seller's sales is under dispute, or while
+// a refund granted by a dispute is pending and not yet off the balance.
+func (h *PayoutSellerHandler) Handle(ctx context.Context, cmd PayoutSeller) (*PayoutSellerResult, error) {
+	var payout *entity.Payout
+	err := h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := h.ledgerRepo.LockAccount(txCtx, entity.AccountSellerPayable, cmd.SellerID); err != nil {
+			return err
+		}
+		pending, err := h.payoutRepo.FindPendingBySeller(txCtx, cmd.SellerID)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			payout = pending
+			return nil
+		}
+		disputed, err := h.disputeRepo.HasUnsettledForSeller(txCtx, cmd.SellerID)
+		if err != nil {
+			return err
+		}
+		if disputed {
+			return errors.Conflict("Payouts are on hold while a dispute or its refund is open")
+		}
+
+		// seller_payable is a liability, so what we owe shows as a credit.
+		balance, err := h.ledgerRepo.Balance(txCtx, entity.AccountSellerPayable, cmd.SellerID)
+		if err != nil {
+			return err
+		}
+		if -balance <= 0 {
+			return errors.BadRequest("No balance to pay out")
+		}
+
+		payout, err = entity.NewPayout(cmd.SellerID, -balance)
+		if err != nil {
+			return errors.BadRequest(err.Error())
+		}
+		return h.payoutRepo.Save(txCtx, payout)
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+
+	transferID, transferErr := h.payoutGateway.Transfer(ctx, payout.ID(), payout.SellerID(), payout.Amount())
+	if transferErr != nil && !stderrors.Is(transferErr, domain.ErrDeclined) {
+		slog.Warn("payout transfer outcome unknown, leaving pending", "payout_id", payout.ID(), "error", transferErr)
+		return &PayoutSellerResult{ID: payout.ID(), Amount: payout.Amount(), Status: payout.Status()}, nil
+	}
+
+	var result *PayoutSellerResult
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payout, err := h.payoutRepo.FindByID(txCtx, payout.ID(), query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payout == nil {
+			return errors.NotFound("Payout not found")
+		}
+		result = &PayoutSellerResult{ID: payout.ID(), Amount: payout.Amount(), Status: payout.Status()}
+		if payout.Status() != entity.PayoutStatusPending {
+			return nil
+		}
+
+		if transferErr != nil {
+			if err := payout.MarkFailed(transferErr.Error()); err != nil {
+				return errors.Conflict(err.Error())
+			}
+		} else {
+			if err := payout.MarkPaid(transferID); err != nil {
+				return errors.Conflict(err.Error())
+			}
+			entry, err := h.ledgerPolicy.PayoutEntry(payout)
+			if err != nil {
+				return errors.Internal(err.Error())
+			}
+			if _, err := h.ledgerRepo.Post(txCtx, entry); err != nil {
+				return err
+			}
+		}
+		result.Status = payout.Status()
+
+		if err := h.payoutRepo.Update(txCtx, payout); err != nil {
+			return err
+		}
+		if err := h.eventPublisher.Publish(txCtx, payout.Events()...); err != nil {
+			return err
+		}
+		payout.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == entity.PayoutStatusFailed {
+		return nil, errors.BadRequest("Payout was declined")
+	}
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+	"github.com/in-jun/go-structure-example/internal/shared/validation"
+)
+
+// reconcileSlack widens the gateway query so a capture made moments before
+// its payment was marked completed is still found.
+const reconcileSlack = 5 * time.Minute
+
+type ReconcilePayments struct {
+	From time.Time
+	To   time.Time
+}
+
+type ReconcilePaymentsResult struct {
+	Transactions int
+	Payments     int
+	Issues       int
+}
+
+type ReconcilePaymentsHandler struct {
+	paymentRepo    domain.PaymentRepository
+	refundRepo     domain.RefundRepository
+	issueRepo      domain.ReconciliationRepository
+	gateway        domain.PaymentGateway
+	policy         *service.ReconciliationPolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewReconcilePaymentsHandler(
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	issueRepo domain.ReconciliationRepository,
+	gateway domain.PaymentGateway,
+	policy *service.ReconciliationPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *ReconcilePaymentsHandler {
+	return &ReconcilePaymentsHandler{
+		paymentRepo: paymentRepo, refundRepo: refundRepo, issueRepo: issueRepo,
+		gateway: gateway, policy: policy, eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle compares the gateway's transactions with payments updated in the
+// window. Windows may overlap; an issue is only stored and announced once.
+func (h *ReconcilePaymentsHandler) Handle(ctx context.Context, cmd ReconcilePayments) (*ReconcilePaymentsResult, error) {
+	txs, err := h.gateway.ListTransactions(ctx, cmd.From.Add(-reconcileSlack), cmd.To.Add(reconcileSlack))
+	if err != nil {
+		return nil, err
+	}
+	window, err := h.paymentRepo.FindUpdatedBetween(ctx, cmd.From, cmd.To)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make(map[string]*entity.Payment, len(window))
+	for _, p := range window {
+		payments[p.ID()] = p
+	}
+	refunds := make(map[string]*entity.Refund)
+	for _, tx := range txs {
+		if _, ok := payments[tx.PaymentID]; !ok {
+			payment, err := h.findPayment(ctx, tx.PaymentID)
+			if err != nil {
+				return nil, err
+			}
+			payments[tx.PaymentID] = payment
+		}
+		if tx.Type == domain.TransactionRefund {
+			if _, ok := refunds[tx.RefundID]; !ok {
+				refund, err := h.findRefund(ctx, tx.RefundID)
+				if err != nil {
+					return nil, err
+				}
+				refunds[tx.RefundID] = refund
+			}
+		}
+	}
+
+	result := &ReconcilePaymentsResult{Transactions: len(txs), Payments: len(window)}
+	for _, issue := range h.policy.Compare(window, payments, refunds, txs) {
+		err := h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			saved, err := h.issueRepo.Save(txCtx, issue)
+			if err != nil || !saved {
+				return err
+			}
+			result.Issues++
+			if err := h.eventPublisher.Publish(txCtx, issue.Events()...); err != nil {
+				return err
+			}
+			issue.ClearEvents()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// findPayment treats IDs that are not ours, such as a charge made outside
+// this service, as unknown instead of failing the lookup.
+func (h *ReconcilePaymentsHandler) findPayment(ctx context.Context, id string) (*entity.Payment, error) {
+	pv, err := vo.NewPaymentIDVO(id)
+	if err != nil {
+		return nil, nil
+	}
+	return h.paymentRepo.FindByID(ctx, pv.ID)
+}
+
+func (h *ReconcilePaymentsHandler) findRefund(ctx context.Context, id string) (*entity.Refund, error) {
+	parsed, err := validation.ParseUUID(id)
+	if err != nil {
+		return nil, nil
+	}
+	return h.refundRepo.FindByID(ctx, parsed)
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type RecordRefund struct {
+	PaymentID string
+	RefundID  string
+}
+
+type RecordRefundHandler struct {
+	paymentRepo   domain.PaymentRepository
+	refundRepo    domain.RefundRepository
+	ledgerRepo    domain.LedgerRepository
+	auctionClient domain.AuctionClient
+	ledgerPolicy  *service.LedgerPolicy
+	transactor    transaction.Transactor
+}
+
+func NewRecordRefundHandler(
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	ledgerRepo domain.LedgerRepository,
+	auctionClient domain.AuctionClient,
+	ledgerPolicy *service.LedgerPolicy,
+	transactor transaction.Transactor,
+) *RecordRefundHandler {
+	return &RecordRefundHandler{
+		paymentRepo: paymentRepo, refundRepo: refundRepo, ledgerRepo: ledgerRepo, auctionClient: auctionClient,
+		ledgerPolicy: ledgerPolicy, transactor: transactor,
+	}
+}
+
+// Handle takes a completed refund back out of the seller's payable balance
+// and the platform fee. Refunds of money still held in escrow were never
+// credited to the seller and post nothing. Posting is idempotent per refund.
+func (h *RecordRefundHandler) Handle(ctx context.Context, cmd RecordRefund) error {
+	payment, err := h.paymentRepo.FindByID(ctx, cmd.PaymentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil {
+		return errors.NotFound("Payment not found")
+	}
+	refund, err := h.refundRepo.FindByID(ctx, cmd.RefundID)
+	if err != nil {
+		return err
+	}
+	if refund == nil || refund.PaymentID() != payment.ID() {
+		return errors.NotFound("Refund not found")
+	}
+	if !payment.IsSettleable() {
+		slog.Info("refund of unsettled payment, nothing to reverse", "payment_id", payment.ID(), "refund_id", refund.ID())
+		return nil
+	}
+
+	auction, err := h.auctionClient.GetAuction(ctx, payment.AuctionID())
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.ledgerPolicy.RefundEntry(payment, refund, auction.SellerID)
+	if err != nil {
+		return errors.Conflict(err.Error())
+	}
+
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		posted, err := h.ledgerRepo.Post(txCtx, entry)
+		if err != nil {
+			return err
+		}
+		if !posted {
+			slog.Info("refund already recorded", "payment_id", payment.ID(), "refund_id", refund.ID())
+		}
+		return nil
+	})
+}
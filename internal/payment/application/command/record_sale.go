@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type RecordSale struct {
+	AuctionID string
+}
+
+type RecordSaleHandler struct {
+	paymentRepo   domain.PaymentRepository
+	ledgerRepo    domain.LedgerRepository
+	auctionClient domain.AuctionClient
+	ledgerPolicy  *service.LedgerPolicy
+	transactor    transaction.Transactor
+}
+
+func NewRecordSaleHandler(
+	paymentRepo domain.PaymentRepository,
+	ledgerRepo domain.LedgerRepository,
+	auctionClient domain.AuctionClient,
+	ledgerPolicy *service.LedgerPolicy,
+	transactor transaction.Transactor,
+) *RecordSaleHandler {
+	return &RecordSaleHandler{
+		paymentRepo: paymentRepo, ledgerRepo: ledgerRepo, auctionClient: auctionClient,
+		ledgerPolicy: ledgerPolicy, transactor: transactor,
+	}
+}
+
+// Handle credits the seller of a settled auction with the captured payment,
+// less the platform fee. Posting is idempotent per payment.
+func (h *RecordSaleHandler) Handle(ctx context.Context, cmd RecordSale) error {
+	payments, err := h.paymentRepo.FindByAuctionID(ctx, cmd.AuctionID)
+	if err != nil {
+		return err
+	}
+	var payment *entity.Payment
+	for _, p := range payments {
+		if p.IsSettleable() {
+			payment = p
+			break
+		}
+	}
+	if payment == nil {
+		return errors.NotFound("Settled payment not found")
+	}
+
+	auction, err := h.auctionClient.GetAuction(ctx, cmd.AuctionID)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.ledgerPolicy.SaleEntry(payment, auction.SellerID)
+	if err != nil {
+		return errors.Conflict(err.Error())
+	}
+
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		posted, err := h.ledgerRepo.Post(txCtx, entry)
+		if err != nil {
+			return err
+		}
+		if !posted {
+			slog.Info("sale already recorded", "auction_id", cmd.AuctionID, "payment_id", payment.ID())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+const abandonedProcessingReason = "payment processing could not be resolved"
+
+type RecoverPayments struct {
+	StaleBefore  time.Time
+	GiveUpBefore time.Time
+	Limit        int
+}
+
+type RecoverPaymentsResult struct {
+	Resolved   int
+	Abandoned  int
+	Unresolved int
+}
+
+type RecoverPaymentsHandler struct {
+	paymentRepo    domain.PaymentRepository
+	processor      *service.PaymentProcessor
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+	charger        *chargeRunner
+}
+
+func NewRecoverPaymentsHandler(
+	paymentRepo domain.PaymentRepository,
+	methodRepo domain.PaymentMethodRepository,
+	processor *service.PaymentProcessor,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *RecoverPaymentsHandler {
+	return &RecoverPaymentsHandler{
+		paymentRepo: paymentRepo, processor: processor, eventPublisher: eventPublisher, transactor: transactor,
+		charger: &chargeRunner{
+			paymentRepo: paymentRepo, methodRepo: methodRepo, processor: processor,
+			eventPublisher: eventPublisher, transactor: transactor,
+		},
+	}
+}
+
+// Handle retries payments left in processing by an interrupted confirm.
+// The retry reuses the interrupted attempt's idempotency key, so the gateway
+// returns the original outcome instead of charging twice. Payments still
+// unresolved after the give-up cutoff are settled from the gateway's
+// transaction list: completed if it shows a capture, failed otherwise.
+func (h *RecoverPaymentsHandler) Handle(ctx context.Context, cmd RecoverPayments) (*RecoverPaymentsResult, error) {
+	payments, err := h.paymentRepo.FindStaleProcessing(ctx, cmd.StaleBefore, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecoverPaymentsResult{}
+	for _, p := range payments {
+		if p.UpdatedAt().Before(cmd.GiveUpBefore) {
+			status, err := h.abandon(ctx, p)
+			if err != nil {
+				slog.Error("failed to abandon processing payment", "payment_id", p.ID(), "error", err)
+				continue
+			}
+			if status == entity.StatusFailed {
+				result.Abandoned++
+			} else {
+				result.Resolved++
+			}
+			continue
+		}
+
+		status, err := h.charger.run(ctx, p)
+		if err != nil {
+			slog.Error("failed to recover processing payment", "payment_id", p.ID(), "error", err)
+			continue
+		}
+		if status == entity.StatusProcessing {
+			result.Unresolved++
+		} else {
+			result.Resolved++
+		}
+	}
+	return result, nil
+}
+
+// abandon stops waiting on the gateway for a payment. A charge the gateway
+// captured completes the payment, so the buyer is never failed for money
+// that was taken; only a payment with no capture on record is failed.
+func (h *RecoverPaymentsHandler) abandon(ctx context.Context, stale *entity.Payment) (string, error) {
+	captured, err := h.processor.Captured(ctx, stale, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	var status string
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payment, err := h.paymentRepo.FindByID(txCtx, stale.ID(), query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+		status = payment.Status()
+		if !payment.IsProcessing() {
+			return nil
+		}
+
+		if captured {
+			err = payment.Complete()
+		} else {
+			err = payment.Fail(abandonedProcessingReason)
+		}
+		if err != nil {
+			return errors.Conflict(err.Error())
+		}
+		status = payment.Status()
+
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+			return err
+		}
+		payment.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	return status, err
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+// refundRunner makes one gateway attempt for a pending refund and records
+// the outcome in its own transaction, like chargeRunner does for charges.
+type refundRunner struct {
+	paymentRepo    domain.PaymentRepository
+	refundRepo     domain.RefundRepository
+	processor      *service.PaymentProcessor
+	retryPolicy    *service.RefundRetryPolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func (r *refundRunner) run(ctx context.Context, pending *entity.Refund) (string, error) {
+	gatewayErr := r.processor.Refund(ctx, pending, time.Now())
+
+	var status string
+	err := r.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		payment, err := r.paymentRepo.FindByID(txCtx, pending.PaymentID(), query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+		refund, err := r.refundRepo.FindByID(txCtx, pending.ID(), query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if refund == nil {
+			return errors.NotFound("Refund not found")
+		}
+		status = refund.Status()
+		if !refund.IsPending() {
+			return nil
+		}
+
+		attempt, err := r.processor.SettleRefund(payment, refund, gatewayErr, r.retryPolicy, time.Now())
+		if err != nil {
+			return errors.Conflict(err.Error())
+		}
+		status = refund.Status()
+
+		if err := r.refundRepo.Update(txCtx, refund); err != nil {
+			return err
+		}
+		if err := r.refundRepo.SaveAttempt(txCtx, attempt); err != nil {
+			return err
+		}
+		if len(payment.Events()) == 0 {
+			return nil
+		}
+
+		if err := r.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := r.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+			return err
+		}
+		payment.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	return status, err
+}
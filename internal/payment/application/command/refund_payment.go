@@ -2,8 +2,11 @@ package command
 
 import (
 	"context"
+	stderrors "errors"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
 	"github.com/in-jun/go-structure-example/internal/shared/query"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
@@ -13,36 +16,55 @@ import (
 
 type RefundPayment struct {
 	UserID    string
+	Admin     bool // admins may refund payments they do not own
 	PaymentID string
+	Amount    int64
 	Reason    string
 }
 
+type RefundPaymentResult struct {
+	RefundID string
+	Status   string
+	Pending  bool
+}
+
 type RefundPaymentHandler struct {
-	paymentRepo    domain.PaymentRepository
-	processor      *service.PaymentProcessor
-	eventPublisher domain.EventPublisher
-	transactor     transaction.Transactor
+	paymentRepo domain.PaymentRepository
+	refundRepo  domain.RefundRepository
+	retryPolicy *service.RefundRetryPolicy
+	transactor  transaction.Transactor
+	refunder    *refundRunner
 }
 
 func NewRefundPaymentHandler(
 	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
 	processor *service.PaymentProcessor,
+	retryPolicy *service.RefundRetryPolicy,
 	eventPublisher domain.EventPublisher,
 	transactor transaction.Transactor,
 ) *RefundPaymentHandler {
 	return &RefundPaymentHandler{
-		paymentRepo: paymentRepo, processor: processor,
-		eventPublisher: eventPublisher, transactor: transactor,
+		paymentRepo: paymentRepo, refundRepo: refundRepo,
+		retryPolicy: retryPolicy, transactor: transactor,
+		refunder: &refundRunner{
+			paymentRepo: paymentRepo, refundRepo: refundRepo, processor: processor,
+			retryPolicy: retryPolicy, eventPublisher: eventPublisher, transactor: transactor,
+		},
 	}
 }
 
-func (h *RefundPaymentHandler) Handle(ctx context.Context, cmd RefundPayment) error {
+func (h *RefundPaymentHandler) Handle(ctx context.Context, cmd RefundPayment) (*RefundPaymentResult, error) {
 	pv, err := vo.NewPaymentIDVO(cmd.PaymentID)
 	if err != nil {
-		return errors.BadRequest(err.Error())
+		return nil, errors.BadRequest(err.Error())
+	}
+	if cmd.Amount < 0 {
+		return nil, errors.BadRequest(entity.ErrInvalidRefundAmount.Error())
 	}
 
-	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+	var refund *entity.Refund
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
 		payment, err := h.paymentRepo.FindByID(txCtx, pv.ID, query.ForUpdate())
 		if err != nil {
 			return err
@@ -50,27 +72,55 @@ func (h *RefundPaymentHandler) Handle(ctx context.Context, cmd RefundPayment) er
 		if payment == nil {
 			return errors.NotFound("Payment not found")
 		}
-		if !payment.IsOwnedBy(cmd.UserID) {
+		if !cmd.Admin && !payment.IsOwnedBy(cmd.UserID) {
 			return errors.Forbidden("Not authorized")
 		}
+		if payment.Status() == entity.StatusRefundPending {
+			return errors.Conflict("A refund is already in progress")
+		}
 
 		reason := cmd.Reason
 		if reason == "" {
 			reason = "User requested refund"
 		}
 
-		if err := h.processor.ProcessRefund(txCtx, payment, reason); err != nil {
-			return errors.Conflict(err.Error())
+		amount := cmd.Amount
+		if amount == 0 {
+			amount = payment.Refundable()
 		}
 
-		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
-			return err
+		refund, err = payment.Refund(amount, reason)
+		if stderrors.Is(err, entity.ErrRefundExceedsBalance) {
+			return errors.BadRequest(err.Error())
+		}
+		if err != nil {
+			return errors.Conflict(err.Error())
+		}
+		// Give the inline attempt below a head start before the retry
+		// worker may pick the refund up.
+		if err := refund.RetryAt(time.Now().Add(h.retryPolicy.Delay(1))); err != nil {
+			return errors.Conflict(err.Error())
 		}
 
-		if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
 			return err
 		}
-		payment.ClearEvents()
-		return nil
+		return h.refundRepo.Save(txCtx, refund)
 	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := h.refunder.run(ctx, refund)
+	if err != nil {
+		return nil, err
+	}
+	if status == entity.RefundStatusFailed {
+		return nil, errors.BadRequest("Refund was declined")
+	}
+	return &RefundPaymentResult{
+		RefundID: refund.ID(),
+		Status:   status,
+		Pending:  status == entity.RefundStatusPending,
+	}, nil
 }
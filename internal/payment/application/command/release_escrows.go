@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type ReleaseEscrows struct {
+	Now   time.Time
+	Limit int
+}
+
+type ReleaseEscrowsResult struct {
+	Released int
+}
+
+type ReleaseEscrowsHandler struct {
+	paymentRepo    domain.PaymentRepository
+	disputeRepo    domain.DisputeRepository
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewReleaseEscrowsHandler(
+	paymentRepo domain.PaymentRepository,
+	disputeRepo domain.DisputeRepository,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *ReleaseEscrowsHandler {
+	return &ReleaseEscrowsHandler{
+		paymentRepo: paymentRepo, disputeRepo: disputeRepo,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+// Handle releases escrow whose hold expired without the buyer confirming
+// delivery. Payments under dispute stay held until the dispute is resolved.
+func (h *ReleaseEscrowsHandler) Handle(ctx context.Context, cmd ReleaseEscrows) (*ReleaseEscrowsResult, error) {
+	candidates, err := h.paymentRepo.FindEscrowDue(ctx, cmd.Now, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReleaseEscrowsResult{}
+	for _, c := range candidates {
+		var released bool
+		err := h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			payment, err := h.paymentRepo.FindByID(txCtx, c.ID(), query.ForUpdate())
+			if err != nil {
+				return err
+			}
+			if payment == nil {
+				return errors.NotFound("Payment not found")
+			}
+			if !payment.IsEscrowDue(cmd.Now) {
+				return nil
+			}
+			dispute, err := h.disputeRepo.FindActiveByPaymentID(txCtx, payment.ID())
+			if err != nil {
+				return err
+			}
+			if dispute != nil {
+				return nil
+			}
+
+			if err := payment.ReleaseEscrow(entity.EscrowReleaseTimeout); err != nil {
+				return errors.Conflict(err.Error())
+			}
+			if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+				return err
+			}
+
+			if err := h.eventPublisher.Publish(txCtx, payment.Events()...); err != nil {
+				return err
+			}
+			payment.ClearEvents()
+			released = true
+			return nil
+		}, transaction.WithIsolation(transaction.Pessimistic))
+		if err != nil {
+			slog.Error("failed to release escrow", "payment_id", c.ID(), "error", err)
+			continue
+		}
+		if released {
+			result.Released++
+		}
+	}
+	return result, nil
+}
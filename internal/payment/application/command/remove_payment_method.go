@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type RemovePaymentMethod struct {
+	UserID          string
+	PaymentMethodID string
+}
+
+type RemovePaymentMethodHandler struct {
+	methodRepo domain.PaymentMethodRepository
+	transactor transaction.Transactor
+}
+
+func NewRemovePaymentMethodHandler(
+	methodRepo domain.PaymentMethodRepository,
+	transactor transaction.Transactor,
+) *RemovePaymentMethodHandler {
+	return &RemovePaymentMethodHandler{methodRepo: methodRepo, transactor: transactor}
+}
+
+// Handle removes the method. When it was the default, the most recently
+// added remaining method becomes the default.
+func (h *RemovePaymentMethodHandler) Handle(ctx context.Context, cmd RemovePaymentMethod) error {
+	mv, err := vo.NewPaymentMethodIDVO(cmd.PaymentMethodID)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	return h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		methods, err := h.methodRepo.FindByUserID(txCtx, cmd.UserID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		method, err := ownedMethod(txCtx, h.methodRepo, mv.ID, cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		wasDefault := method.IsDefault()
+		if err := method.Remove(); err != nil {
+			return errors.Conflict(err.Error())
+		}
+		if err := h.methodRepo.Update(txCtx, method); err != nil {
+			return err
+		}
+		if !wasDefault {
+			return nil
+		}
+
+		for _, next := range methods {
+			if next.ID() == method.ID() {
+				continue
+			}
+			if err := next.MakeDefault(); err != nil {
+				return errors.Conflict(err.Error())
+			}
+			return h.methodRepo.Update(txCtx, next)
+		}
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+}
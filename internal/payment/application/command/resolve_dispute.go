@@ -0,0 +1,154 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type ResolveDispute struct {
+	DisputeID  string
+	Resolution string
+	Amount     int64
+}
+
+type ResolveDisputeResult struct {
+	DisputeID    string
+	Status       string
+	Resolution   string
+	RefundID     string
+	RefundStatus string
+}
+
+type ResolveDisputeHandler struct {
+	disputeRepo    domain.DisputeRepository
+	paymentRepo    domain.PaymentRepository
+	refundRepo     domain.RefundRepository
+	retryPolicy    *service.RefundRetryPolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+	refunder       *refundRunner
+}
+
+func NewResolveDisputeHandler(
+	disputeRepo domain.DisputeRepository,
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	processor *service.PaymentProcessor,
+	retryPolicy *service.RefundRetryPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *ResolveDisputeHandler {
+	return &ResolveDisputeHandler{
+		disputeRepo: disputeRepo, paymentRepo: paymentRepo, refundRepo: refundRepo,
+		retryPolicy: retryPolicy, eventPublisher: eventPublisher, transactor: transactor,
+		refunder: &refundRunner{
+			paymentRepo: paymentRepo, refundRepo: refundRepo, processor: processor,
+			retryPolicy: retryPolicy, eventPublisher: eventPublisher, transactor: transactor,
+		},
+	}
+}
+
+// Handle closes a dispute and starts any refund it grants. A partial refund
+// of funds still in escrow releases them to the seller first, since held
+// funds can only be refunded in full. The seller is credited the whole sale
+// and the refunded share comes back off the balance once the refund
+// completes; payouts wait for it.
+func (h *ResolveDisputeHandler) Handle(ctx context.Context, cmd ResolveDispute) (*ResolveDisputeResult, error) {
+	dv, err := vo.NewDisputeIDVO(cmd.DisputeID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if cmd.Amount < 0 {
+		return nil, errors.BadRequest(entity.ErrInvalidRefundAmount.Error())
+	}
+
+	var dispute *entity.Dispute
+	var refund *entity.Refund
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		dispute, err = h.disputeRepo.FindByID(txCtx, dv.ID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if dispute == nil {
+			return errors.NotFound("Dispute not found")
+		}
+		payment, err := h.paymentRepo.FindByID(txCtx, dispute.PaymentID(), query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.NotFound("Payment not found")
+		}
+
+		amount := cmd.Amount
+		if cmd.Resolution == entity.DisputeResolutionRefund {
+			amount = payment.Refundable()
+		}
+		if err := dispute.Resolve(cmd.Resolution, amount); err != nil {
+			if stderrors.Is(err, entity.ErrDisputeResolved) {
+				return errors.Conflict(err.Error())
+			}
+			return errors.BadRequest(err.Error())
+		}
+
+		if dispute.RefundAmount() > 0 {
+			if payment.Status() == entity.StatusEscrowHeld && dispute.RefundAmount() < payment.Refundable() {
+				if err := payment.ReleaseEscrow(entity.EscrowReleaseDispute); err != nil {
+					return errors.Conflict(err.Error())
+				}
+			}
+			refund, err = payment.Refund(dispute.RefundAmount(), "Dispute "+dispute.ID()+" resolved")
+			if stderrors.Is(err, entity.ErrRefundExceedsBalance) {
+				return errors.BadRequest(err.Error())
+			}
+			if err != nil {
+				return errors.Conflict(err.Error())
+			}
+			if err := refund.RetryAt(time.Now().Add(h.retryPolicy.Delay(1))); err != nil {
+				return errors.Conflict(err.Error())
+			}
+			if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+				return err
+			}
+			if err := h.refundRepo.Save(txCtx, refund); err != nil {
+				return err
+			}
+		}
+		if err := h.disputeRepo.Update(txCtx, dispute); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, append(dispute.Events(), payment.Events()...)...); err != nil {
+			return err
+		}
+		dispute.ClearEvents()
+		payment.ClearEvents()
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResolveDisputeResult{DisputeID: dispute.ID(), Status: dispute.Status(), Resolution: dispute.Resolution()}
+	if refund == nil {
+		return result, nil
+	}
+	// The refund is saved and retried by the worker if this attempt fails,
+	// so the resolution stands either way.
+	status, err := h.refunder.run(ctx, refund)
+	if err != nil {
+		return nil, err
+	}
+	result.RefundID = refund.ID()
+	result.RefundStatus = status
+	return result, nil
+}
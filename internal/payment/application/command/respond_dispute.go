@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type RespondDispute struct {
+	UserID    string
+	DisputeID string
+	Response  string
+}
+
+type RespondDisputeHandler struct {
+	disputeRepo    domain.DisputeRepository
+	disputePolicy  *service.DisputePolicy
+	eventPublisher domain.EventPublisher
+	transactor     transaction.Transactor
+}
+
+func NewRespondDisputeHandler(
+	disputeRepo domain.DisputeRepository,
+	disputePolicy *service.DisputePolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *RespondDisputeHandler {
+	return &RespondDisputeHandler{
+		disputeRepo: disputeRepo, disputePolicy: disputePolicy,
+		eventPublisher: eventPublisher, transactor: transactor,
+	}
+}
+
+func (h *RespondDisputeHandler) Handle(ctx context.Context, cmd RespondDispute) (*DisputeResult, error) {
+	dv, err := vo.NewDisputeIDVO(cmd.DisputeID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	if cmd.Response == "" {
+		return nil, errors.BadRequest("Dispute response is required")
+	}
+
+	var result *DisputeResult
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		dispute, err := h.disputeRepo.FindByID(txCtx, dv.ID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if dispute == nil {
+			return errors.NotFound("Dispute not found")
+		}
+		if dispute.SellerID() != cmd.UserID {
+			return errors.Forbidden("Not authorized")
+		}
+
+		if err := dispute.Respond(cmd.Response, h.disputePolicy.ReviewBy(time.Now())); err != nil {
+			if stderrors.Is(err, entity.ErrDisputeNotOpen) {
+				return errors.Conflict(err.Error())
+			}
+			return errors.BadRequest(err.Error())
+		}
+		if err := h.disputeRepo.Update(txCtx, dispute); err != nil {
+			return err
+		}
+
+		if err := h.eventPublisher.Publish(txCtx, dispute.Events()...); err != nil {
+			return err
+		}
+		dispute.ClearEvents()
+
+		result = &DisputeResult{DisputeID: dispute.ID(), Status: dispute.Status()}
+		return nil
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
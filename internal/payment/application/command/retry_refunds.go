@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/service"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type RetryRefunds struct {
+	Now   time.Time
+	Limit int
+}
+
+type RetryRefundsResult struct {
+	Refunded int
+	Failed   int
+	Pending  int
+}
+
+type RetryRefundsHandler struct {
+	refundRepo domain.RefundRepository
+	refunder   *refundRunner
+}
+
+func NewRetryRefundsHandler(
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	processor *service.PaymentProcessor,
+	retryPolicy *service.RefundRetryPolicy,
+	eventPublisher domain.EventPublisher,
+	transactor transaction.Transactor,
+) *RetryRefundsHandler {
+	return &RetryRefundsHandler{
+		refundRepo: refundRepo,
+		refunder: &refundRunner{
+			paymentRepo: paymentRepo, refundRepo: refundRepo, processor: processor,
+			retryPolicy: retryPolicy, eventPublisher: eventPublisher, transactor: transactor,
+		},
+	}
+}
+
+// Handle makes another gateway attempt for every pending refund whose
+// backoff has elapsed.
+func (h *RetryRefundsHandler) Handle(ctx context.Context, cmd RetryRefunds) (*RetryRefundsResult, error) {
+	refunds, err := h.refundRepo.FindDue(ctx, cmd.Now, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RetryRefundsResult{}
+	for _, refund := range refunds {
+		status, err := h.refunder.run(ctx, refund)
+		if err != nil {
+			slog.Error("failed to retry refund", "refund_id", refund.ID(), "payment_id", refund.PaymentID(), "error", err)
+			continue
+		}
+		switch status {
+		case entity.RefundStatusSucceeded:
+			result.Refunded++
+		case entity.RefundStatusFailed:
+			result.Failed++
+		default:
+			result.Pending++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+type SetDefaultPaymentMethod struct {
+	UserID          string
+	PaymentMethodID string
+}
+
+type SetDefaultPaymentMethodHandler struct {
+	methodRepo domain.PaymentMethodRepository
+	transactor transaction.Transactor
+}
+
+func NewSetDefaultPaymentMethodHandler(
+	methodRepo domain.PaymentMethodRepository,
+	transactor transaction.Transactor,
+) *SetDefaultPaymentMethodHandler {
+	return &SetDefaultPaymentMethodHandler{methodRepo: methodRepo, transactor: transactor}
+}
+
+func (h *SetDefaultPaymentMethodHandler) Handle(ctx context.Context, cmd SetDefaultPaymentMethod) (*PaymentMethodResult, error) {
+	mv, err := vo.NewPaymentMethodIDVO(cmd.PaymentMethodID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	err = h.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		methods, err := h.methodRepo.FindByUserID(txCtx, cmd.UserID, query.ForUpdate())
+		if err != nil {
+			return err
+		}
+		method, err := ownedMethod(txCtx, h.methodRepo, mv.ID, cmd.UserID)
+		if err != nil {
+			return err
+		}
+		if method.IsDefault() {
+			return nil
+		}
+
+		if err := clearDefault(txCtx, h.methodRepo, methods); err != nil {
+			return err
+		}
+		if err := method.MakeDefault(); err != nil {
+			return errors.Conflict(err.Error())
+		}
+		return h.methodRepo.Update(txCtx, method)
+	}, transaction.WithIsolation(transaction.Pessimistic))
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentMethodResult{PaymentMethodID: mv.ID, IsDefault: true}, nil
+}
+
+// ownedMethod loads a method the user has not removed, hiding other users'
+// methods behind a not-found error.
+func ownedMethod(ctx context.Context, methodRepo domain.PaymentMethodRepository, id, userID string) (*entity.PaymentMethod, error) {
+	method, err := methodRepo.FindByID(ctx, id, query.ForUpdate())
+	if err != nil {
+		return nil, err
+	}
+	if method == nil || method.IsRemoved() || !method.IsOwnedBy(userID) {
+		return nil, errors.NotFound("Payment method not found")
+	}
+	return method, nil
+}
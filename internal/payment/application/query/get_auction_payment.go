@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type GetAuctionPayment struct {
+	AuctionID string
+	UserID    string
+}
+
+type GetAuctionPaymentHandler struct {
+	paymentRepo   domain.PaymentRepository
+	auctionClient domain.AuctionClient
+}
+
+func NewGetAuctionPaymentHandler(paymentRepo domain.PaymentRepository, auctionClient domain.AuctionClient) *GetAuctionPaymentHandler {
+	return &GetAuctionPaymentHandler{paymentRepo: paymentRepo, auctionClient: auctionClient}
+}
+
+// Handle shows the seller the auction's latest payment. A winner sees their
+// own payment, which is an earlier one if the item went to a runner-up.
+func (h *GetAuctionPaymentHandler) Handle(ctx context.Context, qry GetAuctionPayment) (*Result, error) {
+	av, err := vo.NewAuctionIDVO(qry.AuctionID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	payments, err := h.paymentRepo.FindByAuctionID(ctx, av.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		return nil, errors.NotFound("Payment not found")
+	}
+
+	for _, p := range payments {
+		if p.IsOwnedBy(qry.UserID) {
+			return toResult(p), nil
+		}
+	}
+
+	auction, err := h.auctionClient.GetAuction(ctx, av.ID)
+	if err != nil {
+		return nil, err
+	}
+	if auction.SellerID != qry.UserID {
+		return nil, errors.Forbidden("Not authorized")
+	}
+	return toResult(payments[0]), nil
+}
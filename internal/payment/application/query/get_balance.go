@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+type GetBalance struct {
+	UserID string
+}
+
+type BalanceResult struct {
+	Payable int64
+	PaidOut int64
+}
+
+type GetBalanceHandler struct {
+	ledgerRepo domain.LedgerRepository
+}
+
+func NewGetBalanceHandler(ledgerRepo domain.LedgerRepository) *GetBalanceHandler {
+	return &GetBalanceHandler{ledgerRepo: ledgerRepo}
+}
+
+// Both accounts are credit-normal, so balances are negated for display.
+func (h *GetBalanceHandler) Handle(ctx context.Context, qry GetBalance) (*BalanceResult, error) {
+	payable, err := h.ledgerRepo.Balance(ctx, entity.AccountSellerPayable, qry.UserID)
+	if err != nil {
+		return nil, err
+	}
+	paidOut, err := h.ledgerRepo.Balance(ctx, entity.AccountSellerPaidOut, qry.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &BalanceResult{Payable: -payable, PaidOut: -paidOut}, nil
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type GetDispute struct {
+	DisputeID string
+	UserID    string
+}
+
+type DisputeResult struct {
+	ID            string
+	PaymentID     string
+	BuyerID       string
+	SellerID      string
+	Reason        string
+	Response      string
+	Status        string
+	Resolution    string
+	RefundAmount  int64
+	RespondBy     time.Time
+	ReviewBy      time.Time
+	ReviewOverdue bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type GetDisputeHandler struct {
+	disputeRepo domain.DisputeRepository
+}
+
+func NewGetDisputeHandler(disputeRepo domain.DisputeRepository) *GetDisputeHandler {
+	return &GetDisputeHandler{disputeRepo: disputeRepo}
+}
+
+func (h *GetDisputeHandler) Handle(ctx context.Context, qry GetDispute) (*DisputeResult, error) {
+	dv, err := vo.NewDisputeIDVO(qry.DisputeID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	dispute, err := h.disputeRepo.FindByID(ctx, dv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if dispute == nil {
+		return nil, errors.NotFound("Dispute not found")
+	}
+	if !dispute.IsVisibleTo(qry.UserID) {
+		return nil, errors.Forbidden("Not authorized")
+	}
+	return toDisputeResult(dispute), nil
+}
+
+func toDisputeResult(d *entity.Dispute) *DisputeResult {
+	return &DisputeResult{
+		ID: d.ID(), PaymentID: d.PaymentID(), BuyerID: d.BuyerID(), SellerID: d.SellerID(),
+		Reason: d.Reason(), Response: d.Response(), Status: d.Status(),
+		Resolution: d.Resolution(), RefundAmount: d.RefundAmount(),
+		RespondBy: d.RespondBy(), ReviewBy: d.ReviewBy(), ReviewOverdue: d.ReviewOverdue(),
+		CreatedAt: d.CreatedAt(), UpdatedAt: d.UpdatedAt(),
+	}
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type GetInvoice struct {
+	PaymentID string
+	UserID    string
+}
+
+type InvoiceParty struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+type InvoiceLine struct {
+	Kind        string
+	Description string
+	Amount      int64
+}
+
+type InvoiceResult struct {
+	ID        string
+	Number    string
+	PaymentID string
+	AuctionID string
+	Buyer     InvoiceParty
+	Seller    InvoiceParty
+	Lines     []InvoiceLine
+	Total     int64
+	SellerNet int64
+	IssuedAt  time.Time
+}
+
+type GetInvoiceHandler struct {
+	invoiceRepo domain.InvoiceRepository
+}
+
+func NewGetInvoiceHandler(invoiceRepo domain.InvoiceRepository) *GetInvoiceHandler {
+	return &GetInvoiceHandler{invoiceRepo: invoiceRepo}
+}
+
+func (h *GetInvoiceHandler) Handle(ctx context.Context, qry GetInvoice) (*InvoiceResult, error) {
+	pv, err := vo.NewPaymentIDVO(qry.PaymentID)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+
+	invoice, err := h.invoiceRepo.FindByPaymentID(ctx, pv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, errors.NotFound("Invoice not found")
+	}
+	if !invoice.IsVisibleTo(qry.UserID) {
+		return nil, errors.Forbidden("Not authorized")
+	}
+
+	lines := make([]InvoiceLine, 0, len(invoice.Lines()))
+	for _, l := range invoice.Lines() {
+		lines = append(lines, InvoiceLine{Kind: l.Kind, Description: l.Description, Amount: l.Amount})
+	}
+	buyer, seller := invoice.Buyer(), invoice.Seller()
+	return &InvoiceResult{
+		ID: invoice.ID(), Number: invoice.Number(),
+		PaymentID: invoice.PaymentID(), AuctionID: invoice.AuctionID(),
+		Buyer:  InvoiceParty{ID: buyer.ID, Name: buyer.Name, Email: buyer.Email},
+		Seller: InvoiceParty{ID: seller.ID, Name: seller.Name, Email: seller.Email},
+		Lines:  lines, Total: invoice.Total(), SellerNet: invoice.SellerNet(), IssuedAt: invoice.IssuedAt(),
+	}, nil
+}
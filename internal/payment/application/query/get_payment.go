@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/vo"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 )
@@ -19,9 +20,22 @@ type Result struct {
 	AuctionID string
 	WinnerID  string
 	Amount    int64
+	Fee       Fee
+	Refunded  int64
 	Status    string
+	DueAt     time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	EscrowReleaseAt time.Time
+	PaymentMethodID string
+}
+
+type Fee struct {
+	Amount       int64
+	Rule         string
+	Category     string
+	SellerAmount int64
 }
 
 type GetPaymentHandler struct {
@@ -49,10 +63,20 @@ func (h *GetPaymentHandler) Handle(ctx context.Context, qry GetPayment) (*Result
 		return nil, errors.Forbidden("Not authorized")
 	}
 
+	return toResult(payment), nil
+}
+
+func toResult(payment *entity.Payment) *Result {
 	return &Result{
 		ID: payment.ID(), AuctionID: payment.AuctionID(),
-		WinnerID: payment.WinnerID(), Amount: payment.Amount(),
-		Status: payment.Status(), CreatedAt: payment.CreatedAt(),
-		UpdatedAt: payment.UpdatedAt(),
-	}, nil
+		WinnerID: payment.WinnerID(), Amount: payment.Amount(), Refunded: payment.Refunded(),
+		Fee: Fee{
+			Amount: payment.Fee().Amount, Rule: payment.Fee().Rule,
+			Category: payment.Fee().Category, SellerAmount: payment.SellerAmount(),
+		},
+		Status: payment.Status(), DueAt: payment.DueAt(),
+		CreatedAt: payment.CreatedAt(), UpdatedAt: payment.UpdatedAt(),
+		EscrowReleaseAt: payment.Escrow().ReleaseAt,
+		PaymentMethodID: payment.PaymentMethodID(),
+	}
 }
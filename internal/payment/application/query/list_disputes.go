@@ -0,0 +1,45 @@
+package query
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type ListDisputes struct {
+	Status string
+	Page   int
+	Limit  int
+}
+
+type DisputeListResult struct {
+	Disputes []DisputeResult
+	Total    int64
+}
+
+type ListDisputesHandler struct {
+	disputeRepo domain.DisputeRepository
+}
+
+func NewListDisputesHandler(disputeRepo domain.DisputeRepository) *ListDisputesHandler {
+	return &ListDisputesHandler{disputeRepo: disputeRepo}
+}
+
+func (h *ListDisputesHandler) Handle(ctx context.Context, qry ListDisputes) (*DisputeListResult, error) {
+	if qry.Status != "" && !entity.IsKnownDisputeStatus(qry.Status) {
+		return nil, errors.BadRequest("Unknown dispute status")
+	}
+
+	disputes, total, err := h.disputeRepo.List(ctx, qry.Status, qry.Page, qry.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DisputeResult, len(disputes))
+	for i, d := range disputes {
+		results[i] = *toDisputeResult(d)
+	}
+	return &DisputeListResult{Disputes: results, Total: total}, nil
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+type ListPaymentMethods struct {
+	UserID string
+}
+
+type PaymentMethodResult struct {
+	ID        string
+	Brand     string
+	Last4     string
+	ExpMonth  int
+	ExpYear   int
+	IsDefault bool
+	IsExpired bool
+	CreatedAt time.Time
+}
+
+type PaymentMethodListResult struct {
+	PaymentMethods []PaymentMethodResult
+}
+
+type ListPaymentMethodsHandler struct {
+	methodRepo domain.PaymentMethodRepository
+}
+
+func NewListPaymentMethodsHandler(methodRepo domain.PaymentMethodRepository) *ListPaymentMethodsHandler {
+	return &ListPaymentMethodsHandler{methodRepo: methodRepo}
+}
+
+func (h *ListPaymentMethodsHandler) Handle(ctx context.Context, qry ListPaymentMethods) (*PaymentMethodListResult, error) {
+	methods, err := h.methodRepo.FindByUserID(ctx, qry.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]PaymentMethodResult, len(methods))
+	for i, m := range methods {
+		results[i] = PaymentMethodResult{
+			ID: m.ID(), Brand: m.Brand(), Last4: m.Last4(), ExpMonth: m.ExpMonth(), ExpYear: m.ExpYear(),
+			IsDefault: m.IsDefault(), IsExpired: m.IsExpired(now), CreatedAt: m.CreatedAt(),
+		}
+	}
+	return &PaymentMethodListResult{PaymentMethods: results}, nil
+}
@@ -0,0 +1,46 @@
+package query
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type ListPayments struct {
+	UserID string
+	Status string
+	Page   int
+	Limit  int
+}
+
+type ListResult struct {
+	Payments []Result
+	Total    int64
+}
+
+type ListPaymentsHandler struct {
+	paymentRepo domain.PaymentRepository
+}
+
+func NewListPaymentsHandler(paymentRepo domain.PaymentRepository) *ListPaymentsHandler {
+	return &ListPaymentsHandler{paymentRepo: paymentRepo}
+}
+
+func (h *ListPaymentsHandler) Handle(ctx context.Context, qry ListPayments) (*ListResult, error) {
+	if qry.Status != "" && !entity.IsKnownStatus(qry.Status) {
+		return nil, errors.BadRequest("Unknown payment status")
+	}
+
+	payments, total, err := h.paymentRepo.FindByWinnerID(ctx, qry.UserID, qry.Status, qry.Page, qry.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(payments))
+	for i, p := range payments {
+		results[i] = *toResult(p)
+	}
+	return &ListResult{Payments: results, Total: total}, nil
+}
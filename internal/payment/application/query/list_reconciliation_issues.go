@@ -0,0 +1,60 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+)
+
+type ListReconciliationIssues struct {
+	Kind  string
+	Page  int
+	Limit int
+}
+
+type ReconciliationIssueResult struct {
+	ID            string
+	Kind          string
+	PaymentID     string
+	TransactionID string
+	Expected      int64
+	Actual        int64
+	DetectedAt    time.Time
+}
+
+type ReconciliationIssueListResult struct {
+	Issues []ReconciliationIssueResult
+	Total  int64
+}
+
+type ListReconciliationIssuesHandler struct {
+	issueRepo domain.ReconciliationRepository
+}
+
+func NewListReconciliationIssuesHandler(issueRepo domain.ReconciliationRepository) *ListReconciliationIssuesHandler {
+	return &ListReconciliationIssuesHandler{issueRepo: issueRepo}
+}
+
+func (h *ListReconciliationIssuesHandler) Handle(ctx context.Context, qry ListReconciliationIssues) (*ReconciliationIssueListResult, error) {
+	if qry.Kind != "" && !entity.IsKnownIssueKind(qry.Kind) {
+		return nil, errors.BadRequest("Unknown reconciliation issue kind")
+	}
+
+	issues, total, err := h.issueRepo.List(ctx, qry.Kind, qry.Page, qry.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ReconciliationIssueResult, len(issues))
+	for i, issue := range issues {
+		results[i] = ReconciliationIssueResult{
+			ID: issue.ID(), Kind: issue.Kind(), PaymentID: issue.PaymentID(),
+			TransactionID: issue.TransactionID(), Expected: issue.Expected(),
+			Actual: issue.Actual(), DetectedAt: issue.DetectedAt(),
+		}
+	}
+	return &ReconciliationIssueListResult{Issues: results, Total: total}, nil
+}
@@ -9,13 +9,30 @@ import (
 
 type CommandUseCase interface {
 	CreatePayment(ctx context.Context, cmd command.CreatePayment) (*command.CreatePaymentResult, error)
-	ConfirmPayment(ctx context.Context, cmd command.ConfirmPayment) error
-	RefundPayment(ctx context.Context, cmd command.RefundPayment) error
+	ConfirmPayment(ctx context.Context, cmd command.ConfirmPayment) (*command.ConfirmPaymentResult, error)
+	RefundPayment(ctx context.Context, cmd command.RefundPayment) (*command.RefundPaymentResult, error)
+	HandleWebhook(ctx context.Context, cmd command.HandleWebhook) error
+	PayoutSeller(ctx context.Context, cmd command.PayoutSeller) (*command.PayoutSellerResult, error)
+	ConfirmDelivery(ctx context.Context, cmd command.ConfirmDelivery) (*command.ConfirmDeliveryResult, error)
+	OpenDispute(ctx context.Context, cmd command.OpenDispute) (*command.DisputeResult, error)
+	RespondDispute(ctx context.Context, cmd command.RespondDispute) (*command.DisputeResult, error)
+	ResolveDispute(ctx context.Context, cmd command.ResolveDispute) (*command.ResolveDisputeResult, error)
+	AddPaymentMethod(ctx context.Context, cmd command.AddPaymentMethod) (*command.PaymentMethodResult, error)
+	SetDefaultPaymentMethod(ctx context.Context, cmd command.SetDefaultPaymentMethod) (*command.PaymentMethodResult, error)
+	RemovePaymentMethod(ctx context.Context, cmd command.RemovePaymentMethod) error
 }
 
 type QueryUseCase interface {
 	GetPayment(ctx context.Context, qry query.GetPayment) (*query.Result, error)
 	GetEvents(ctx context.Context, qry query.EventHistory) (*query.EventHistoryResult, error)
+	ListPayments(ctx context.Context, qry query.ListPayments) (*query.ListResult, error)
+	GetAuctionPayment(ctx context.Context, qry query.GetAuctionPayment) (*query.Result, error)
+	GetBalance(ctx context.Context, qry query.GetBalance) (*query.BalanceResult, error)
+	ListReconciliationIssues(ctx context.Context, qry query.ListReconciliationIssues) (*query.ReconciliationIssueListResult, error)
+	GetInvoice(ctx context.Context, qry query.GetInvoice) (*query.InvoiceResult, error)
+	GetDispute(ctx context.Context, qry query.GetDispute) (*query.DisputeResult, error)
+	ListDisputes(ctx context.Context, qry query.ListDisputes) (*query.DisputeListResult, error)
+	ListPaymentMethods(ctx context.Context, qry query.ListPaymentMethods) (*query.PaymentMethodListResult, error)
 }
 
 var (
@@ -27,35 +44,128 @@ type service struct {
 	createPayment  *command.CreatePaymentHandler
 	confirmPayment *command.ConfirmPaymentHandler
 	refundPayment  *command.RefundPaymentHandler
+	handleWebhook  *command.HandleWebhookHandler
+	payoutSeller   *command.PayoutSellerHandler
 	getPayment     *query.GetPaymentHandler
 	getEvents      *query.EventHistoryHandler
+	listPayments   *query.ListPaymentsHandler
+	auctionPayment *query.GetAuctionPaymentHandler
+	getBalance     *query.GetBalanceHandler
+	listIssues     *query.ListReconciliationIssuesHandler
+	getInvoice     *query.GetInvoiceHandler
+
+	confirmDelivery *command.ConfirmDeliveryHandler
+	openDispute     *command.OpenDisputeHandler
+	respondDispute  *command.RespondDisputeHandler
+	resolveDispute  *command.ResolveDisputeHandler
+	getDispute      *query.GetDisputeHandler
+	listDisputes    *query.ListDisputesHandler
+
+	addMethod        *command.AddPaymentMethodHandler
+	setDefaultMethod *command.SetDefaultPaymentMethodHandler
+	removeMethod     *command.RemovePaymentMethodHandler
+	listMethods      *query.ListPaymentMethodsHandler
 }
 
 func NewService(
 	createPayment *command.CreatePaymentHandler,
 	confirmPayment *command.ConfirmPaymentHandler,
 	refundPayment *command.RefundPaymentHandler,
+	handleWebhook *command.HandleWebhookHandler,
+	payoutSeller *command.PayoutSellerHandler,
 	getPayment *query.GetPaymentHandler,
 	getEvents *query.EventHistoryHandler,
+	listPayments *query.ListPaymentsHandler,
+	auctionPayment *query.GetAuctionPaymentHandler,
+	getBalance *query.GetBalanceHandler,
+	listIssues *query.ListReconciliationIssuesHandler,
+	getInvoice *query.GetInvoiceHandler,
+	confirmDelivery *command.ConfirmDeliveryHandler,
+	openDispute *command.OpenDisputeHandler,
+	respondDispute *command.RespondDisputeHandler,
+	resolveDispute *command.ResolveDisputeHandler,
+	getDispute *query.GetDisputeHandler,
+	listDisputes *query.ListDisputesHandler,
+	addMethod *command.AddPaymentMethodHandler,
+	setDefaultMethod *command.SetDefaultPaymentMethodHandler,
+	removeMethod *command.RemovePaymentMethodHandler,
+	listMethods *query.ListPaymentMethodsHandler,
 ) *service {
 	return &service{
 		createPayment: createPayment, confirmPayment: confirmPayment,
-		refundPayment: refundPayment, getPayment: getPayment, getEvents: getEvents,
+		refundPayment: refundPayment, handleWebhook: handleWebhook,
+		payoutSeller: payoutSeller, getBalance: getBalance, listIssues: listIssues,
+		getPayment: getPayment, getEvents: getEvents,
+		listPayments: listPayments, auctionPayment: auctionPayment, getInvoice: getInvoice,
+		confirmDelivery: confirmDelivery, openDispute: openDispute, respondDispute: respondDispute,
+		resolveDispute: resolveDispute, getDispute: getDispute, listDisputes: listDisputes,
+		addMethod: addMethod, setDefaultMethod: setDefaultMethod, removeMethod: removeMethod, listMethods: listMethods,
 	}
 }
 
 func (s *service) CreatePayment(ctx context.Context, cmd command.CreatePayment) (*command.CreatePaymentResult, error) {
 	return s.createPayment.Handle(ctx, cmd)
 }
-func (s *service) ConfirmPayment(ctx context.Context, cmd command.ConfirmPayment) error {
+func (s *service) ConfirmPayment(ctx context.Context, cmd command.ConfirmPayment) (*command.ConfirmPaymentResult, error) {
 	return s.confirmPayment.Handle(ctx, cmd)
 }
-func (s *service) RefundPayment(ctx context.Context, cmd command.RefundPayment) error {
+func (s *service) RefundPayment(ctx context.Context, cmd command.RefundPayment) (*command.RefundPaymentResult, error) {
 	return s.refundPayment.Handle(ctx, cmd)
 }
+func (s *service) HandleWebhook(ctx context.Context, cmd command.HandleWebhook) error {
+	return s.handleWebhook.Handle(ctx, cmd)
+}
+func (s *service) PayoutSeller(ctx context.Context, cmd command.PayoutSeller) (*command.PayoutSellerResult, error) {
+	return s.payoutSeller.Handle(ctx, cmd)
+}
+func (s *service) ConfirmDelivery(ctx context.Context, cmd command.ConfirmDelivery) (*command.ConfirmDeliveryResult, error) {
+	return s.confirmDelivery.Handle(ctx, cmd)
+}
+func (s *service) OpenDispute(ctx context.Context, cmd command.OpenDispute) (*command.DisputeResult, error) {
+	return s.openDispute.Handle(ctx, cmd)
+}
+func (s *service) RespondDispute(ctx context.Context, cmd command.RespondDispute) (*command.DisputeResult, error) {
+	return s.respondDispute.Handle(ctx, cmd)
+}
+func (s *service) ResolveDispute(ctx context.Context, cmd command.ResolveDispute) (*command.ResolveDisputeResult, error) {
+	return s.resolveDispute.Handle(ctx, cmd)
+}
+func (s *service) AddPaymentMethod(ctx context.Context, cmd command.AddPaymentMethod) (*command.PaymentMethodResult, error) {
+	return s.addMethod.Handle(ctx, cmd)
+}
+func (s *service) SetDefaultPaymentMethod(ctx context.Context, cmd command.SetDefaultPaymentMethod) (*command.PaymentMethodResult, error) {
+	return s.setDefaultMethod.Handle(ctx, cmd)
+}
+func (s *service) RemovePaymentMethod(ctx context.Context, cmd command.RemovePaymentMethod) error {
+	return s.removeMethod.Handle(ctx, cmd)
+}
 func (s *service) GetPayment(ctx context.Context, qry query.GetPayment) (*query.Result, error) {
 	return s.getPayment.Handle(ctx, qry)
 }
 func (s *service) GetEvents(ctx context.Context, qry query.EventHistory) (*query.EventHistoryResult, error) {
 	return s.getEvents.Handle(ctx, qry)
 }
+func (s *service) ListPayments(ctx context.Context, qry query.ListPayments) (*query.ListResult, error) {
+	return s.listPayments.Handle(ctx, qry)
+}
+func (s *service) GetAuctionPayment(ctx context.Context, qry query.GetAuctionPayment) (*query.Result, error) {
+	return s.auctionPayment.Handle(ctx, qry)
+}
+func (s *service) GetBalance(ctx context.Context, qry query.GetBalance) (*query.BalanceResult, error) {
+	return s.getBalance.Handle(ctx, qry)
+}
+func (s *service) ListReconciliationIssues(ctx context.Context, qry query.ListReconciliationIssues) (*query.ReconciliationIssueListResult, error) {
+	return s.listIssues.Handle(ctx, qry)
+}
+func (s *service) GetInvoice(ctx context.Context, qry query.GetInvoice) (*query.InvoiceResult, error) {
+	return s.getInvoice.Handle(ctx, qry)
+}
+func (s *service) GetDispute(ctx context.Context, qry query.GetDispute) (*query.DisputeResult, error) {
+	return s.getDispute.Handle(ctx, qry)
+}
+func (s *service) ListDisputes(ctx context.Context, qry query.ListDisputes) (*query.DisputeListResult, error) {
+	return s.listDisputes.Handle(ctx, qry)
+}
+func (s *service) ListPaymentMethods(ctx context.Context, qry query.ListPaymentMethods) (*query.PaymentMethodListResult, error) {
+	return s.listMethods.Handle(ctx, qry)
+}
@@ -2,34 +2,349 @@ package application
 
 import (
 	"context"
+	stderrors "errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/in-jun/go-structure-example/internal/payment/application/command"
 	"github.com/in-jun/go-structure-example/internal/payment/application/query"
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	sharedErrors "github.com/in-jun/go-structure-example/internal/shared/errors"
 	sharedQuery "github.com/in-jun/go-structure-example/internal/shared/query"
 	domainEvent "github.com/in-jun/go-structure-example/internal/payment/domain/event"
 	domainService "github.com/in-jun/go-structure-example/internal/payment/domain/service"
 	"github.com/in-jun/go-structure-example/internal/shared/transaction"
 )
 
+var (
+	testDueAt        = time.Now().Add(48 * time.Hour)
+	testRetryPolicy  = domainService.NewRefundRetryPolicy(time.Minute, time.Hour, 3)
+	testSellerID     = uuid.New().String()
+	testLedgerPolicy = domainService.NewLedgerPolicy()
+
+	testDisputePolicy = domainService.NewDisputePolicy(30*24*time.Hour, 72*time.Hour, 120*time.Hour)
+)
+
 type mockPaymentRepo struct {
-	payment *entity.Payment
-	err     error
+	payment   *entity.Payment
+	saved     *entity.Payment
+	stale     []*entity.Payment
+	due       []*entity.Payment
+	byAuction []*entity.Payment
+	attempts  []entity.PaymentAttempt
+	err       error
 }
 
-func (m *mockPaymentRepo) Save(_ context.Context, _ *entity.Payment) error { return m.err }
+func (m *mockPaymentRepo) Save(_ context.Context, payment *entity.Payment) error {
+	m.saved = payment
+	return m.err
+}
 func (m *mockPaymentRepo) FindByID(_ context.Context, _ string, _ ...sharedQuery.Option) (*entity.Payment, error) {
 	return m.payment, m.err
 }
 func (m *mockPaymentRepo) Update(_ context.Context, _ *entity.Payment) error { return m.err }
+func (m *mockPaymentRepo) FindStaleProcessing(_ context.Context, _ time.Time, _ int) ([]*entity.Payment, error) {
+	return m.stale, m.err
+}
+func (m *mockPaymentRepo) FindPendingDueBefore(_ context.Context, _ time.Time, _ int) ([]*entity.Payment, error) {
+	return m.due, m.err
+}
+func (m *mockPaymentRepo) FindByWinnerID(_ context.Context, _, _ string, _, _ int) ([]*entity.Payment, int64, error) {
+	return m.byAuction, int64(len(m.byAuction)), m.err
+}
+func (m *mockPaymentRepo) FindByAuctionID(_ context.Context, _ string) ([]*entity.Payment, error) {
+	return m.byAuction, m.err
+}
+func (m *mockPaymentRepo) FindUpdatedBetween(_ context.Context, _, _ time.Time) ([]*entity.Payment, error) {
+	return m.due, m.err
+}
+func (m *mockPaymentRepo) FindEscrowDue(_ context.Context, _ time.Time, _ int) ([]*entity.Payment, error) {
+	return m.due, m.err
+}
+func (m *mockPaymentRepo) SaveAttempt(_ context.Context, attempt entity.PaymentAttempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return m.err
+}
+
+type mockRefundRepo struct {
+	refunds  map[string]*entity.Refund
+	due      []*entity.Refund
+	attempts []entity.RefundAttempt
+}
+
+func (m *mockRefundRepo) Save(_ context.Context, refund *entity.Refund) error {
+	if m.refunds == nil {
+		m.refunds = make(map[string]*entity.Refund)
+	}
+	m.refunds[refund.ID()] = refund
+	return nil
+}
+func (m *mockRefundRepo) FindByID(_ context.Context, id string, _ ...sharedQuery.Option) (*entity.Refund, error) {
+	return m.refunds[id], nil
+}
+func (m *mockRefundRepo) FindByPaymentID(_ context.Context, _ string) ([]*entity.Refund, error) {
+	return nil, nil
+}
+func (m *mockRefundRepo) FindDue(_ context.Context, _ time.Time, _ int) ([]*entity.Refund, error) {
+	return m.due, nil
+}
+func (m *mockRefundRepo) Update(_ context.Context, _ *entity.Refund) error { return nil }
+func (m *mockRefundRepo) SaveAttempt(_ context.Context, attempt entity.RefundAttempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+
+type mockLedgerRepo struct {
+	posted   map[string]bool
+	balances map[string]int64
+}
+
+func (m *mockLedgerRepo) Post(_ context.Context, entry *entity.JournalEntry) (bool, error) {
+	if m.posted == nil {
+		m.posted = make(map[string]bool)
+		m.balances = make(map[string]int64)
+	}
+	key := entry.Kind() + ":" + entry.Reference()
+	if m.posted[key] {
+		return false, nil
+	}
+	m.posted[key] = true
+	for _, p := range entry.Postings() {
+		m.balances[p.Account+":"+p.OwnerID] += p.Amount
+	}
+	return true, nil
+}
+func (m *mockLedgerRepo) Balance(_ context.Context, account, ownerID string) (int64, error) {
+	return m.balances[account+":"+ownerID], nil
+}
+func (m *mockLedgerRepo) LockAccount(_ context.Context, _, _ string) error { return nil }
+
+type mockDisputeRepo struct {
+	disputes map[string]*entity.Dispute
+	payments []*entity.Payment
+}
+
+func (m *mockDisputeRepo) Save(_ context.Context, dispute *entity.Dispute) error {
+	if m.disputes == nil {
+		m.disputes = make(map[string]*entity.Dispute)
+	}
+	m.disputes[dispute.ID()] = dispute
+	return nil
+}
+func (m *mockDisputeRepo) FindByID(_ context.Context, id string, _ ...sharedQuery.Option) (*entity.Dispute, error) {
+	return m.disputes[id], nil
+}
+func (m *mockDisputeRepo) Update(_ context.Context, _ *entity.Dispute) error { return nil }
+func (m *mockDisputeRepo) FindActiveByPaymentID(_ context.Context, paymentID string) (*entity.Dispute, error) {
+	for _, d := range m.disputes {
+		if d.IsActive() && d.PaymentID() == paymentID {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockDisputeRepo) HasUnsettledForSeller(_ context.Context, sellerID string) (bool, error) {
+	for _, d := range m.disputes {
+		if d.SellerID() != sellerID {
+			continue
+		}
+		if d.IsActive() {
+			return true, nil
+		}
+		for _, p := range m.payments {
+			if p.ID() == d.PaymentID() && d.RefundAmount() > 0 && p.Status() == entity.StatusRefundPending {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+func (m *mockDisputeRepo) FindDeadlineDue(_ context.Context, _ time.Time, _ int) ([]*entity.Dispute, error) {
+	var due []*entity.Dispute
+	for _, d := range m.disputes {
+		if d.IsActive() {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+func (m *mockDisputeRepo) List(_ context.Context, _ string, _, _ int) ([]*entity.Dispute, int64, error) {
+	return nil, 0, nil
+}
+
+type mockMethodRepo struct {
+	methods []*entity.PaymentMethod
+}
+
+func (m *mockMethodRepo) Save(_ context.Context, method *entity.PaymentMethod) error {
+	m.methods = append([]*entity.PaymentMethod{method}, m.methods...)
+	return nil
+}
+func (m *mockMethodRepo) FindByID(_ context.Context, id string, _ ...sharedQuery.Option) (*entity.PaymentMethod, error) {
+	for _, method := range m.methods {
+		if method.ID() == id {
+			return method, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockMethodRepo) FindByUserID(_ context.Context, userID string, _ ...sharedQuery.Option) ([]*entity.PaymentMethod, error) {
+	var methods []*entity.PaymentMethod
+	for _, method := range m.methods {
+		if method.IsOwnedBy(userID) && !method.IsRemoved() {
+			methods = append(methods, method)
+		}
+	}
+	return methods, nil
+}
+func (m *mockMethodRepo) FindDefaultByUserID(_ context.Context, userID string) (*entity.PaymentMethod, error) {
+	for _, method := range m.methods {
+		if method.IsOwnedBy(userID) && method.IsDefault() {
+			return method, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockMethodRepo) Update(_ context.Context, _ *entity.PaymentMethod) error { return nil }
+
+type mockPayoutRepo struct {
+	payouts map[string]*entity.Payout
+}
+
+func (m *mockPayoutRepo) Save(_ context.Context, payout *entity.Payout) error {
+	if m.payouts == nil {
+		m.payouts = make(map[string]*entity.Payout)
+	}
+	m.payouts[payout.ID()] = payout
+	return nil
+}
+func (m *mockPayoutRepo) FindByID(_ context.Context, id string, _ ...sharedQuery.Option) (*entity.Payout, error) {
+	return m.payouts[id], nil
+}
+func (m *mockPayoutRepo) Update(_ context.Context, _ *entity.Payout) error { return nil }
+func (m *mockPayoutRepo) FindPendingBySeller(_ context.Context, sellerID string) (*entity.Payout, error) {
+	for _, p := range m.payouts {
+		if p.SellerID() == sellerID && p.Status() == entity.PayoutStatusPending {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
 
-type mockGateway struct{}
+type mockPayoutGateway struct {
+	err   error
+	calls []string
+}
+
+func (m *mockPayoutGateway) Transfer(_ context.Context, payoutID, _ string, _ int64) (string, error) {
+	m.calls = append(m.calls, payoutID)
+	if m.err != nil {
+		return "", m.err
+	}
+	return "po-" + payoutID, nil
+}
+
+type mockIssueRepo struct {
+	issues []*entity.ReconciliationIssue
+}
+
+func (m *mockIssueRepo) Save(_ context.Context, issue *entity.ReconciliationIssue) (bool, error) {
+	for _, i := range m.issues {
+		if i.Kind() == issue.Kind() && i.PaymentID() == issue.PaymentID() && i.TransactionID() == issue.TransactionID() {
+			return false, nil
+		}
+	}
+	m.issues = append(m.issues, issue)
+	return true, nil
+}
+func (m *mockIssueRepo) List(_ context.Context, _ string, _, _ int) ([]*entity.ReconciliationIssue, int64, error) {
+	return m.issues, int64(len(m.issues)), nil
+}
+
+type mockInvoiceRepo struct {
+	sequence int64
+	invoices map[string]*entity.Invoice
+}
+
+func (m *mockInvoiceRepo) NextSequence(_ context.Context) (int64, error) {
+	m.sequence++
+	return m.sequence, nil
+}
+func (m *mockInvoiceRepo) Save(_ context.Context, invoice *entity.Invoice) error {
+	if m.invoices == nil {
+		m.invoices = make(map[string]*entity.Invoice)
+	}
+	m.invoices[invoice.PaymentID()] = invoice
+	return nil
+}
+func (m *mockInvoiceRepo) FindByPaymentID(_ context.Context, paymentID string) (*entity.Invoice, error) {
+	return m.invoices[paymentID], nil
+}
+
+type mockUserClient struct{}
+
+func (m *mockUserClient) GetUser(_ context.Context, userID string) (*domain.UserInfo, error) {
+	return &domain.UserInfo{ID: userID, Name: "User " + userID[:8], Email: userID[:8] + "@example.com"}, nil
+}
+
+type mockAuctionClient struct {
+	sellerID string
+	category string
+}
+
+func (m *mockAuctionClient) GetAuction(_ context.Context, auctionID string) (*domain.AuctionInfo, error) {
+	return &domain.AuctionInfo{ID: auctionID, SellerID: m.sellerID, Status: "closed", Category: m.category}, nil
+}
+
+type mockGateway struct {
+	authorizeErr error
+	refundErr    error
+	transactions []domain.GatewayTransaction
+	keys         []string
+	methods      []string
+}
+
+func (m *mockGateway) Authorize(_ context.Context, key, paymentID, paymentMethod string, _ int64) (string, error) {
+	m.keys = append(m.keys, key)
+	m.methods = append(m.methods, paymentMethod)
+	if m.authorizeErr != nil {
+		return "", m.authorizeErr
+	}
+	return "auth-" + paymentID, nil
+}
+func (m *mockGateway) Capture(_ context.Context, key, _ string, _ int64) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+func (m *mockGateway) Refund(_ context.Context, _, _ string, _ int64) error { return m.refundErr }
+func (m *mockGateway) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return m.transactions, nil
+}
+
+type mockMethodGateway struct {
+	methods map[string]domain.GatewayPaymentMethod
+}
+
+// issue registers a card token for userID, as the gateway's client-side
+// tokenization would.
+func (m *mockMethodGateway) issue(userID, token string) {
+	if m.methods == nil {
+		m.methods = make(map[string]domain.GatewayPaymentMethod)
+	}
+	m.methods[token] = domain.GatewayPaymentMethod{
+		Token: token, CustomerID: userID, Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: time.Now().Year() + 2,
+	}
+}
 
-func (m *mockGateway) Charge(_ context.Context, _ string, _ int64) error { return nil }
-func (m *mockGateway) Refund(_ context.Context, _ string, _ int64) error { return nil }
+func (m *mockMethodGateway) LookupPaymentMethod(_ context.Context, token string) (*domain.GatewayPaymentMethod, error) {
+	method, ok := m.methods[token]
+	if !ok {
+		return nil, domain.ErrUnknownPaymentMethod
+	}
+	return &method, nil
+}
 
 type mockPublisher struct{}
 
@@ -41,20 +356,59 @@ func (m *mockTransactor) WithinTransaction(_ context.Context, fn func(ctx contex
 	return fn(context.Background())
 }
 
+type mockVerifier struct {
+	evt *domain.WebhookEvent
+	err error
+}
+
+func (m *mockVerifier) Verify(_ []byte, _ string) (*domain.WebhookEvent, error) { return m.evt, m.err }
+
 type mockEventReader struct{}
 
 func (m *mockEventReader) FindByPaymentID(_ context.Context, _ string) ([]domainEvent.StoredEvent, error) {
 	return nil, nil
 }
 
+func newTestFeePolicy() *domainService.FeePolicy {
+	rule, _ := domainService.ParseFeeRule("percentage:500")
+	return domainService.NewFeePolicy(rule, nil)
+}
+
 func newTestService(repo *mockPaymentRepo) *service {
-	processor := domainService.NewPaymentProcessor(&mockGateway{})
+	return newTestServiceWith(repo, &mockGateway{}, &mockVerifier{})
+}
+
+func newTestServiceWithVerifier(repo *mockPaymentRepo, verifier domain.WebhookVerifier) *service {
+	return newTestServiceWith(repo, &mockGateway{}, verifier)
+}
+
+func newTestServiceWith(repo *mockPaymentRepo, gateway domain.PaymentGateway, verifier domain.WebhookVerifier) *service {
+	processor := domainService.NewPaymentProcessor(gateway)
+	verifiers := map[string]domain.WebhookVerifier{"fakepay": verifier}
 	return NewService(
-		command.NewCreatePaymentHandler(repo, &mockPublisher{}, &mockTransactor{}),
-		command.NewConfirmPaymentHandler(repo, processor, &mockPublisher{}, &mockTransactor{}),
-		command.NewRefundPaymentHandler(repo, processor, &mockPublisher{}, &mockTransactor{}),
+		command.NewCreatePaymentHandler(repo, &mockAuctionClient{sellerID: testSellerID}, domainService.NewDeadlinePolicy(48*time.Hour, nil), newTestFeePolicy(), 0, &mockPublisher{}, &mockTransactor{}),
+		command.NewConfirmPaymentHandler(repo, &mockMethodRepo{}, processor, &mockPublisher{}, &mockTransactor{}),
+		command.NewRefundPaymentHandler(repo, &mockRefundRepo{}, processor, testRetryPolicy, &mockPublisher{}, &mockTransactor{}),
+		command.NewHandleWebhookHandler(repo, processor, verifiers, &mockPublisher{}, &mockTransactor{}),
+		command.NewPayoutSellerHandler(&mockPayoutRepo{}, &mockLedgerRepo{}, &mockDisputeRepo{}, &mockPayoutGateway{}, testLedgerPolicy, &mockPublisher{}, &mockTransactor{}),
 		query.NewGetPaymentHandler(repo),
 		query.NewEventHistoryHandler(&mockEventReader{}),
+		query.NewListPaymentsHandler(repo),
+		query.NewGetAuctionPaymentHandler(repo, &mockAuctionClient{sellerID: testSellerID}),
+		query.NewGetBalanceHandler(&mockLedgerRepo{}),
+		query.NewListReconciliationIssuesHandler(&mockIssueRepo{}),
+		query.NewGetInvoiceHandler(&mockInvoiceRepo{}),
+		command.NewConfirmDeliveryHandler(repo, &mockDisputeRepo{}, &mockPublisher{}, &mockTransactor{}),
+		command.NewOpenDisputeHandler(repo, &mockDisputeRepo{}, &mockLedgerRepo{}, &mockAuctionClient{sellerID: testSellerID},
+			testDisputePolicy, &mockPublisher{}, &mockTransactor{}),
+		command.NewRespondDisputeHandler(&mockDisputeRepo{}, testDisputePolicy, &mockPublisher{}, &mockTransactor{}),
+		command.NewResolveDisputeHandler(&mockDisputeRepo{}, repo, &mockRefundRepo{}, processor, testRetryPolicy, &mockPublisher{}, &mockTransactor{}),
+		query.NewGetDisputeHandler(&mockDisputeRepo{}),
+		query.NewListDisputesHandler(&mockDisputeRepo{}),
+		command.NewAddPaymentMethodHandler(&mockMethodRepo{}, &mockMethodGateway{}, &mockTransactor{}),
+		command.NewSetDefaultPaymentMethodHandler(&mockMethodRepo{}, &mockTransactor{}),
+		command.NewRemovePaymentMethodHandler(&mockMethodRepo{}, &mockTransactor{}),
+		query.NewListPaymentMethodsHandler(&mockMethodRepo{}),
 	)
 }
 
@@ -72,30 +426,123 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 	if result.Status != entity.StatusPending {
 		t.Errorf("Status = %q, want %q", result.Status, entity.StatusPending)
 	}
+	if result.Amount != 5000 || result.Fee != 250 {
+		t.Errorf("Amount/Fee = %d/%d, want 5000/250", result.Amount, result.Fee)
+	}
+}
+
+func TestCreatePayment_CategoryFee(t *testing.T) {
+	defaultRule, _ := domainService.ParseFeeRule("percentage:500")
+	categories, _ := domainService.ParseFeeCategories("electronics=percentage:300;art=flat:700")
+	feePolicy := domainService.NewFeePolicy(defaultRule, categories)
+
+	tests := []struct {
+		category     string
+		wantFee      int64
+		wantCategory string
+	}{
+		{"electronics", 150, "electronics"},
+		{"art", 700, "art"},
+		{"books", 250, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			repo := &mockPaymentRepo{}
+			handler := command.NewCreatePaymentHandler(repo, &mockAuctionClient{sellerID: testSellerID, category: tt.category},
+				domainService.NewDeadlinePolicy(48*time.Hour, nil), feePolicy, 0, &mockPublisher{}, &mockTransactor{})
+
+			result, err := handler.Handle(context.Background(), command.CreatePayment{
+				AuctionID: uuid.New().String(), WinnerID: uuid.New().String(), Amount: 5000,
+			})
+			if err != nil {
+				t.Fatalf("CreatePayment() error = %v", err)
+			}
+			if result.Fee != tt.wantFee {
+				t.Errorf("Fee = %d, want %d", result.Fee, tt.wantFee)
+			}
+			if got := repo.saved.Fee().Category; got != tt.wantCategory {
+				t.Errorf("fee category = %q, want %q", got, tt.wantCategory)
+			}
+		})
+	}
 }
 
 func TestPaymentService_ConfirmPayment(t *testing.T) {
 	winnerID := uuid.New().String()
-	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000)
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
 	repo := &mockPaymentRepo{payment: payment}
 	svc := newTestService(repo)
 
-	err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+	result, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if err != nil {
+		t.Fatalf("ConfirmPayment() error = %v", err)
+	}
+	if result.Status != entity.StatusCompleted || result.Pending {
+		t.Errorf("Status = %q (pending %v), want %q", result.Status, result.Pending, entity.StatusCompleted)
+	}
+}
+
+func TestPaymentService_ConfirmPayment_Declined(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	gateway := &mockGateway{authorizeErr: domain.ErrDeclined}
+	svc := newTestServiceWith(&mockPaymentRepo{payment: payment}, gateway, &mockVerifier{})
+
+	_, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+		t.Fatalf("expected bad request error, got %v", err)
+	}
+	if payment.Status() != entity.StatusFailed {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusFailed)
+	}
+}
+
+func TestPaymentService_ConfirmPayment_OutcomeUnknown(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	gateway := &mockGateway{authorizeErr: stderrors.New("connection reset")}
+	svc := newTestServiceWith(&mockPaymentRepo{payment: payment}, gateway, &mockVerifier{})
+
+	result, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
 		UserID:    winnerID,
 		PaymentID: payment.ID(),
 	})
 	if err != nil {
 		t.Fatalf("ConfirmPayment() error = %v", err)
 	}
+	if !result.Pending || payment.Status() != entity.StatusProcessing {
+		t.Errorf("expected payment to stay processing, got %q (pending %v)", payment.Status(), result.Pending)
+	}
+}
+
+func TestPaymentService_ConfirmPayment_AlreadyProcessing(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	_ = payment.StartProcessing("")
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
 }
 
 func TestPaymentService_ConfirmPayment_NotOwner(t *testing.T) {
 	winnerID := uuid.New().String()
-	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000)
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
 	repo := &mockPaymentRepo{payment: payment}
 	svc := newTestService(repo)
 
-	err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+	_, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
 		UserID:    uuid.New().String(),
 		PaymentID: payment.ID(),
 	})
@@ -106,7 +553,7 @@ func TestPaymentService_ConfirmPayment_NotOwner(t *testing.T) {
 
 func TestPaymentService_GetPayment(t *testing.T) {
 	now := time.Now()
-	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.StatusPending, now, now)
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{}, entity.StatusPending, "", now.Add(48*time.Hour), 0, 0, 0, now, now)
 	svc := newTestService(&mockPaymentRepo{payment: payment})
 
 	result, err := svc.GetPayment(context.Background(), query.GetPayment{PaymentID: payment.ID()})
@@ -121,7 +568,7 @@ func TestPaymentService_GetPayment(t *testing.T) {
 func TestPaymentService_GetPayment_ByOwner(t *testing.T) {
 	now := time.Now()
 	winnerID := uuid.New().String()
-	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.StatusPending, now, now)
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, entity.Escrow{}, entity.StatusPending, "", now.Add(48*time.Hour), 0, 0, 0, now, now)
 	svc := newTestService(&mockPaymentRepo{payment: payment})
 
 	result, err := svc.GetPayment(context.Background(), query.GetPayment{
@@ -139,7 +586,7 @@ func TestPaymentService_GetPayment_ByOwner(t *testing.T) {
 func TestPaymentService_GetPayment_NotOwner(t *testing.T) {
 	now := time.Now()
 	winnerID := uuid.New().String()
-	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.StatusPending, now, now)
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, entity.Escrow{}, entity.StatusPending, "", now.Add(48*time.Hour), 0, 0, 0, now, now)
 	svc := newTestService(&mockPaymentRepo{payment: payment})
 
 	_, err := svc.GetPayment(context.Background(), query.GetPayment{
@@ -153,7 +600,7 @@ func TestPaymentService_GetPayment_NotOwner(t *testing.T) {
 
 func TestPaymentService_RefundPayment_NotOwner(t *testing.T) {
 	winnerID := uuid.New().String()
-	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000)
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
 	if err := payment.Complete(); err != nil {
 		t.Fatal(err)
 	}
@@ -161,7 +608,7 @@ func TestPaymentService_RefundPayment_NotOwner(t *testing.T) {
 
 	svc := newTestService(&mockPaymentRepo{payment: payment})
 
-	err := svc.RefundPayment(context.Background(), command.RefundPayment{
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
 		UserID:    uuid.New().String(),
 		PaymentID: payment.ID(),
 	})
@@ -170,9 +617,47 @@ func TestPaymentService_RefundPayment_NotOwner(t *testing.T) {
 	}
 }
 
+func TestPaymentService_RefundPayment_ByAdmin(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
+		UserID:    uuid.New().String(),
+		Admin:     true,
+		PaymentID: payment.ID(),
+	})
+	if err != nil {
+		t.Fatalf("RefundPayment() by admin error = %v", err)
+	}
+}
+
 func TestPaymentService_RefundPayment(t *testing.T) {
 	winnerID := uuid.New().String()
-	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000)
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+}
+
+func TestPaymentService_RefundPayment_Partial(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
 	if err := payment.Complete(); err != nil {
 		t.Fatal(err)
 	}
@@ -180,13 +665,26 @@ func TestPaymentService_RefundPayment(t *testing.T) {
 
 	svc := newTestService(&mockPaymentRepo{payment: payment})
 
-	err := svc.RefundPayment(context.Background(), command.RefundPayment{
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
 		UserID:    winnerID,
 		PaymentID: payment.ID(),
+		Amount:    2000,
 	})
 	if err != nil {
 		t.Fatalf("RefundPayment() error = %v", err)
 	}
+	if payment.Status() != entity.StatusPartiallyRefunded || payment.Refundable() != 3000 {
+		t.Errorf("Status = %q, Refundable = %d", payment.Status(), payment.Refundable())
+	}
+
+	_, err = svc.RefundPayment(context.Background(), command.RefundPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+		Amount:    3001,
+	})
+	if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+		t.Errorf("expected bad request for refund over balance, got %v", err)
+	}
 }
 
 func TestPaymentService_GetEvents(t *testing.T) {
@@ -213,7 +711,7 @@ func TestPaymentService_GetPayment_NotFound(t *testing.T) {
 func TestPaymentService_ConfirmPayment_NotFound(t *testing.T) {
 	svc := newTestService(&mockPaymentRepo{payment: nil})
 
-	err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
+	_, err := svc.ConfirmPayment(context.Background(), command.ConfirmPayment{
 		UserID:    uuid.New().String(),
 		PaymentID: uuid.New().String(),
 	})
@@ -225,7 +723,7 @@ func TestPaymentService_ConfirmPayment_NotFound(t *testing.T) {
 func TestPaymentService_RefundPayment_NotFound(t *testing.T) {
 	svc := newTestService(&mockPaymentRepo{payment: nil})
 
-	err := svc.RefundPayment(context.Background(), command.RefundPayment{
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
 		UserID:    uuid.New().String(),
 		PaymentID: uuid.New().String(),
 	})
@@ -233,3 +731,1001 @@ func TestPaymentService_RefundPayment_NotFound(t *testing.T) {
 		t.Error("expected error for not found payment")
 	}
 }
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	payment.ClearEvents()
+	verifier := &mockVerifier{evt: &domain.WebhookEvent{Type: domain.WebhookChargeSucceeded, PaymentID: payment.ID()}}
+	svc := newTestServiceWithVerifier(&mockPaymentRepo{payment: payment}, verifier)
+
+	err := svc.HandleWebhook(context.Background(), command.HandleWebhook{Provider: "fakepay", Payload: []byte("{}")})
+	if err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+	if payment.Status() != entity.StatusCompleted {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusCompleted)
+	}
+}
+
+func TestPaymentService_HandleWebhook_UnknownProvider(t *testing.T) {
+	svc := newTestService(&mockPaymentRepo{})
+
+	err := svc.HandleWebhook(context.Background(), command.HandleWebhook{Provider: "acme"})
+	if !stderrors.Is(err, sharedErrors.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestPaymentService_HandleWebhook_InvalidSignature(t *testing.T) {
+	verifier := &mockVerifier{err: stderrors.New("webhook signature mismatch")}
+	svc := newTestServiceWithVerifier(&mockPaymentRepo{}, verifier)
+
+	err := svc.HandleWebhook(context.Background(), command.HandleWebhook{Provider: "fakepay"})
+	if !stderrors.Is(err, sharedErrors.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestPaymentService_HandleWebhook_AlreadySettled(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+	verifier := &mockVerifier{evt: &domain.WebhookEvent{Type: domain.WebhookChargeFailed, PaymentID: payment.ID()}}
+	svc := newTestServiceWithVerifier(&mockPaymentRepo{payment: payment}, verifier)
+
+	err := svc.HandleWebhook(context.Background(), command.HandleWebhook{Provider: "fakepay"})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+}
+
+func TestRecoverPayments(t *testing.T) {
+	now := time.Now()
+	stale := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusProcessing, "", testDueAt, 0, 1, 0, now.Add(-10*time.Minute), now.Add(-5*time.Minute))
+	repo := &mockPaymentRepo{payment: stale, stale: []*entity.Payment{stale}}
+	gateway := &mockGateway{}
+	handler := command.NewRecoverPaymentsHandler(repo, &mockMethodRepo{}, domainService.NewPaymentProcessor(gateway), &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.RecoverPayments{
+		StaleBefore:  now.Add(-time.Minute),
+		GiveUpBefore: now.Add(-time.Hour),
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Resolved != 1 || stale.Status() != entity.StatusCompleted {
+		t.Errorf("expected payment to be resolved, got %+v with status %q", result, stale.Status())
+	}
+	if stale.ChargeAttempts() != 1 || len(repo.attempts) != 2 {
+		t.Fatalf("expected the interrupted attempt to be retried and audited, got attempt %d with %d records",
+			stale.ChargeAttempts(), len(repo.attempts))
+	}
+	for i, a := range repo.attempts {
+		if a.Attempt != 1 || a.IdempotencyKey != gateway.keys[i] {
+			t.Errorf("unexpected attempt record %+v", a)
+		}
+	}
+}
+
+func TestConfirmPayment_UnknownOutcomeIsAudited(t *testing.T) {
+	now := time.Now()
+	winnerID := uuid.New().String()
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusPending, "", testDueAt, 0, 0, 0, now, now)
+	repo := &mockPaymentRepo{payment: payment}
+	gateway := &mockGateway{authorizeErr: stderrors.New("connection reset")}
+	handler := command.NewConfirmPaymentHandler(repo, &mockMethodRepo{}, domainService.NewPaymentProcessor(gateway), &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.ConfirmPayment{UserID: winnerID, PaymentID: payment.ID()})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if !result.Pending || payment.ChargeAttempts() != 1 {
+		t.Errorf("expected a pending first attempt, got %+v at attempt %d", result, payment.ChargeAttempts())
+	}
+	if len(repo.attempts) != 1 || repo.attempts[0].Operation != entity.AttemptAuthorize || repo.attempts[0].Error == "" {
+		t.Errorf("expected the failed authorization to be recorded, got %+v", repo.attempts)
+	}
+}
+
+func addTestMethod(t *testing.T, methods *mockMethodRepo, userID, token string, makeDefault bool) string {
+	t.Helper()
+	gateway := &mockMethodGateway{}
+	gateway.issue(userID, token)
+	handler := command.NewAddPaymentMethodHandler(methods, gateway, &mockTransactor{})
+	result, err := handler.Handle(context.Background(), command.AddPaymentMethod{
+		UserID: userID, Token: token, MakeDefault: makeDefault,
+	})
+	if err != nil {
+		t.Fatalf("AddPaymentMethod() error = %v", err)
+	}
+	return result.PaymentMethodID
+}
+
+func TestAddPaymentMethod_VerifiesToken(t *testing.T) {
+	methods := &mockMethodRepo{}
+	gateway := &mockMethodGateway{}
+	userID := uuid.New().String()
+	gateway.issue(userID, "tok_mine")
+	gateway.issue(uuid.New().String(), "tok_theirs")
+	handler := command.NewAddPaymentMethodHandler(methods, gateway, &mockTransactor{})
+
+	for _, token := range []string{"tok_theirs", "tok_unknown", ""} {
+		_, err := handler.Handle(context.Background(), command.AddPaymentMethod{UserID: userID, Token: token})
+		if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+			t.Errorf("token %q: expected bad request, got %v", token, err)
+		}
+	}
+
+	result, err := handler.Handle(context.Background(), command.AddPaymentMethod{UserID: userID, Token: "tok_mine"})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	method, _ := methods.FindByID(context.Background(), result.PaymentMethodID)
+	card := gateway.methods["tok_mine"]
+	if method.Brand() != card.Brand || method.Last4() != card.Last4 || method.ExpYear() != card.ExpYear {
+		t.Errorf("expected card details from the gateway, got %s %s %d", method.Brand(), method.Last4(), method.ExpYear())
+	}
+}
+
+func TestPaymentMethods_DefaultFollowsChanges(t *testing.T) {
+	methods := &mockMethodRepo{}
+	userID := uuid.New().String()
+
+	first := addTestMethod(t, methods, userID, "tok_first", false)
+	second := addTestMethod(t, methods, userID, "tok_second", false)
+	third := addTestMethod(t, methods, userID, "tok_third", true)
+
+	isDefault := func(id string) bool {
+		m, _ := methods.FindByID(context.Background(), id)
+		return m.IsDefault()
+	}
+	if isDefault(first) || isDefault(second) || !isDefault(third) {
+		t.Fatalf("expected only the method added as default to be default")
+	}
+
+	setDefault := command.NewSetDefaultPaymentMethodHandler(methods, &mockTransactor{})
+	if _, err := setDefault.Handle(context.Background(), command.SetDefaultPaymentMethod{UserID: userID, PaymentMethodID: first}); err != nil {
+		t.Fatalf("SetDefaultPaymentMethod() error = %v", err)
+	}
+	if !isDefault(first) || isDefault(third) {
+		t.Fatalf("expected the default to move to the first method")
+	}
+
+	remove := command.NewRemovePaymentMethodHandler(methods, &mockTransactor{})
+	if err := remove.Handle(context.Background(), command.RemovePaymentMethod{UserID: userID, PaymentMethodID: first}); err != nil {
+		t.Fatalf("RemovePaymentMethod() error = %v", err)
+	}
+	if !isDefault(third) {
+		t.Errorf("expected the newest remaining method to become default")
+	}
+
+	err := remove.Handle(context.Background(), command.RemovePaymentMethod{UserID: uuid.New().String(), PaymentMethodID: second})
+	if !stderrors.Is(err, sharedErrors.ErrNotFound) {
+		t.Errorf("expected another user's method to be not found, got %v", err)
+	}
+}
+
+func TestConfirmPayment_ChargesPaymentMethod(t *testing.T) {
+	now := time.Now()
+	winnerID := uuid.New().String()
+	methods := &mockMethodRepo{}
+	addTestMethod(t, methods, winnerID, "tok_default", false)
+	chosen := addTestMethod(t, methods, winnerID, "tok_chosen", false)
+	other := addTestMethod(t, methods, uuid.New().String(), "tok_other", false)
+
+	newPending := func() *entity.Payment {
+		return entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, entity.Escrow{},
+			entity.StatusPending, "", testDueAt, 0, 0, 0, now, now)
+	}
+	confirm := func(payment *entity.Payment, gateway *mockGateway, methodID string) error {
+		handler := command.NewConfirmPaymentHandler(&mockPaymentRepo{payment: payment}, methods,
+			domainService.NewPaymentProcessor(gateway), &mockPublisher{}, &mockTransactor{})
+		_, err := handler.Handle(context.Background(), command.ConfirmPayment{
+			UserID: winnerID, PaymentID: payment.ID(), PaymentMethodID: methodID,
+		})
+		return err
+	}
+
+	gateway := &mockGateway{}
+	payment := newPending()
+	if err := confirm(payment, gateway, chosen); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if payment.PaymentMethodID() != chosen || gateway.methods[0] != "tok_chosen" {
+		t.Errorf("expected the chosen method to be charged, got %q via %v", payment.PaymentMethodID(), gateway.methods)
+	}
+
+	gateway = &mockGateway{}
+	if err := confirm(newPending(), gateway, ""); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if gateway.methods[0] != "tok_default" {
+		t.Errorf("expected the default method to be charged, got %v", gateway.methods)
+	}
+
+	err := confirm(newPending(), &mockGateway{}, other)
+	if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+		t.Errorf("expected forbidden for another user's method, got %v", err)
+	}
+}
+
+func TestRecoverPayments_GivesUp(t *testing.T) {
+	now := time.Now()
+	stale := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusProcessing, "", testDueAt, 0, 1, 0, now.Add(-2*time.Hour), now.Add(-2*time.Hour))
+	repo := &mockPaymentRepo{payment: stale, stale: []*entity.Payment{stale}}
+	gateway := &mockGateway{authorizeErr: stderrors.New("connection reset")}
+	handler := command.NewRecoverPaymentsHandler(repo, &mockMethodRepo{}, domainService.NewPaymentProcessor(gateway), &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.RecoverPayments{
+		StaleBefore:  now.Add(-time.Minute),
+		GiveUpBefore: now.Add(-time.Hour),
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Abandoned != 1 || stale.Status() != entity.StatusFailed {
+		t.Errorf("expected payment to be abandoned, got %+v with status %q", result, stale.Status())
+	}
+}
+
+func TestRecoverPayments_GivesUpOnCapturedCharge(t *testing.T) {
+	now := time.Now()
+	stale := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusProcessing, "", testDueAt, 0, 1, 0, now.Add(-2*time.Hour), now.Add(-2*time.Hour))
+	repo := &mockPaymentRepo{payment: stale, stale: []*entity.Payment{stale}}
+	gateway := &mockGateway{
+		authorizeErr: stderrors.New("connection reset"),
+		transactions: []domain.GatewayTransaction{
+			{ID: "tx-other", Type: domain.TransactionCapture, PaymentID: uuid.New().String(), Amount: 5000, CreatedAt: now.Add(-time.Hour)},
+			{ID: "tx-1", Type: domain.TransactionCapture, PaymentID: stale.ID(), Amount: 5000, CreatedAt: now.Add(-time.Hour)},
+		},
+	}
+	handler := command.NewRecoverPaymentsHandler(repo, &mockMethodRepo{}, domainService.NewPaymentProcessor(gateway), &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.RecoverPayments{
+		StaleBefore:  now.Add(-time.Minute),
+		GiveUpBefore: now.Add(-time.Hour),
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Resolved != 1 || result.Abandoned != 0 || stale.Status() != entity.StatusCompleted {
+		t.Errorf("expected the captured payment to be recovered, got %+v with status %q", result, stale.Status())
+	}
+}
+
+func TestEnforceDeadlines_ExpiresOverduePayment(t *testing.T) {
+	now := time.Now()
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusPending, "", now.Add(-time.Minute), 0, 0, 0, now.Add(-48*time.Hour), now.Add(-48*time.Hour))
+	repo := &mockPaymentRepo{payment: payment, due: []*entity.Payment{payment}}
+	policy := domainService.NewDeadlinePolicy(48*time.Hour, []time.Duration{24 * time.Hour, time.Hour})
+	handler := command.NewEnforceDeadlinesHandler(repo, policy, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.EnforceDeadlines{Now: now, Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Expired != 1 || result.Reminded != 0 {
+		t.Errorf("result = %+v, want 1 expired", result)
+	}
+	if payment.Status() != entity.StatusFailed {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusFailed)
+	}
+}
+
+func TestEnforceDeadlines_RemindsBeforeDueDate(t *testing.T) {
+	now := time.Now()
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusPending, "", now.Add(12*time.Hour), 0, 0, 0, now.Add(-36*time.Hour), now.Add(-36*time.Hour))
+	repo := &mockPaymentRepo{payment: payment, due: []*entity.Payment{payment}}
+	policy := domainService.NewDeadlinePolicy(48*time.Hour, []time.Duration{24 * time.Hour, time.Hour})
+	handler := command.NewEnforceDeadlinesHandler(repo, policy, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.EnforceDeadlines{Now: now, Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Reminded != 1 || result.Expired != 0 {
+		t.Errorf("result = %+v, want 1 reminded", result)
+	}
+	if payment.RemindersSent() != 1 || payment.Status() != entity.StatusPending {
+		t.Errorf("RemindersSent = %d, Status = %q", payment.RemindersSent(), payment.Status())
+	}
+}
+
+func newHeldPayment(t *testing.T, winnerID string) *entity.Payment {
+	t.Helper()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 72*time.Hour, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+	return payment
+}
+
+func TestPaymentService_ConfirmDelivery(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment := newHeldPayment(t, winnerID)
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	result, err := svc.ConfirmDelivery(context.Background(), command.ConfirmDelivery{UserID: winnerID, PaymentID: payment.ID()})
+	if err != nil {
+		t.Fatalf("ConfirmDelivery() error = %v", err)
+	}
+	if result.Status != entity.StatusEscrowReleased {
+		t.Errorf("Status = %q, want %q", result.Status, entity.StatusEscrowReleased)
+	}
+	if payment.Escrow().ReleasedAt.IsZero() {
+		t.Error("expected ReleasedAt to be set")
+	}
+}
+
+func TestPaymentService_ConfirmDelivery_NotOwner(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.ConfirmDelivery(context.Background(), command.ConfirmDelivery{UserID: uuid.New().String(), PaymentID: payment.ID()})
+	if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+	if payment.Status() != entity.StatusEscrowHeld {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusEscrowHeld)
+	}
+}
+
+func TestPaymentService_ConfirmDelivery_NotHeld(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.ConfirmDelivery(context.Background(), command.ConfirmDelivery{UserID: winnerID, PaymentID: payment.ID()})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestReleaseEscrows_ReleasesExpiredHolds(t *testing.T) {
+	now := time.Now()
+	expired := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{},
+		entity.Escrow{Hold: time.Hour, ReleaseAt: now.Add(-time.Minute)}, entity.StatusEscrowHeld, "", now, 0, 1, 0, now, now)
+	repo := &mockPaymentRepo{payment: expired, due: []*entity.Payment{expired}}
+	handler := command.NewReleaseEscrowsHandler(repo, &mockDisputeRepo{}, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.ReleaseEscrows{Now: now, Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Released != 1 {
+		t.Errorf("Released = %d, want 1", result.Released)
+	}
+	if expired.Status() != entity.StatusEscrowReleased {
+		t.Errorf("Status = %q, want %q", expired.Status(), entity.StatusEscrowReleased)
+	}
+}
+
+func TestPaymentService_RefundPayment_GatewayUnavailable(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+	gateway := &mockGateway{refundErr: stderrors.New("connection reset")}
+	svc := newTestServiceWith(&mockPaymentRepo{payment: payment}, gateway, &mockVerifier{})
+
+	result, err := svc.RefundPayment(context.Background(), command.RefundPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	if !result.Pending || result.Status != entity.RefundStatusPending {
+		t.Errorf("result = %+v, want pending refund", result)
+	}
+	if payment.Status() != entity.StatusRefundPending {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusRefundPending)
+	}
+}
+
+func TestPaymentService_RefundPayment_AlreadyPending(t *testing.T) {
+	winnerID := uuid.New().String()
+	now := time.Now()
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), winnerID, 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusRefundPending, "", testDueAt, 0, 1, 0, now, now)
+	svc := newTestService(&mockPaymentRepo{payment: payment})
+
+	_, err := svc.RefundPayment(context.Background(), command.RefundPayment{
+		UserID:    winnerID,
+		PaymentID: payment.ID(),
+	})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
+
+func newPendingRefund(t *testing.T, gatewayErr error, attempts int) (*entity.Payment, *mockRefundRepo, *command.RetryRefundsHandler) {
+	t.Helper()
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := payment.Refund(5000, "damaged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+	now := time.Now()
+	refund := entity.ReconstructRefund(pending.ID(), payment.ID(), 5000, "damaged", entity.RefundStatusPending,
+		attempts, "", now, now, now)
+
+	refunds := &mockRefundRepo{due: []*entity.Refund{refund}}
+	_ = refunds.Save(context.Background(), refund)
+	handler := command.NewRetryRefundsHandler(&mockPaymentRepo{payment: payment}, refunds,
+		domainService.NewPaymentProcessor(&mockGateway{refundErr: gatewayErr}), testRetryPolicy,
+		&mockPublisher{}, &mockTransactor{})
+	return payment, refunds, handler
+}
+
+func TestRetryRefunds_Succeeds(t *testing.T) {
+	payment, refunds, handler := newPendingRefund(t, nil, 1)
+
+	result, err := handler.Handle(context.Background(), command.RetryRefunds{Now: time.Now(), Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Refunded != 1 {
+		t.Errorf("result = %+v, want 1 refunded", result)
+	}
+	if payment.Status() != entity.StatusRefunded {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusRefunded)
+	}
+	if len(refunds.attempts) != 1 || refunds.attempts[0].Number != 2 {
+		t.Errorf("attempts = %+v, want attempt 2 recorded", refunds.attempts)
+	}
+}
+
+func TestRetryRefunds_BacksOff(t *testing.T) {
+	payment, refunds, handler := newPendingRefund(t, stderrors.New("connection reset"), 1)
+	before := time.Now()
+
+	result, err := handler.Handle(context.Background(), command.RetryRefunds{Now: before, Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Pending != 1 {
+		t.Errorf("result = %+v, want 1 pending", result)
+	}
+	if payment.Status() != entity.StatusRefundPending {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusRefundPending)
+	}
+	refund := refunds.due[0]
+	if refund.Attempts() != 2 || refund.NextAttemptAt().Before(before.Add(2*time.Minute)) {
+		t.Errorf("attempts = %d, next attempt %v, want 2 attempts and a 2m backoff", refund.Attempts(), refund.NextAttemptAt())
+	}
+}
+
+func TestRetryRefunds_GivesUp(t *testing.T) {
+	payment, _, handler := newPendingRefund(t, stderrors.New("connection reset"), 2)
+
+	result, err := handler.Handle(context.Background(), command.RetryRefunds{Now: time.Now(), Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Failed != 1 {
+		t.Errorf("result = %+v, want 1 failed", result)
+	}
+	if payment.Status() != entity.StatusCompleted {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusCompleted)
+	}
+	if len(payment.Events()) != 0 {
+		t.Errorf("expected events to be published and cleared, got %d", len(payment.Events()))
+	}
+}
+
+func TestPaymentService_ListPayments_UnknownStatus(t *testing.T) {
+	svc := newTestService(&mockPaymentRepo{})
+
+	_, err := svc.ListPayments(context.Background(), query.ListPayments{UserID: uuid.New().String(), Status: "paid", Page: 1, Limit: 10})
+	if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+		t.Errorf("expected bad request, got %v", err)
+	}
+}
+
+func TestPaymentService_GetAuctionPayment(t *testing.T) {
+	auctionID := uuid.New().String()
+	failedWinner := uuid.New().String()
+	runnerUp := uuid.New().String()
+	first, _ := entity.NewPayment(auctionID, failedWinner, 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	second, _ := entity.NewPayment(auctionID, runnerUp, 4500, entity.FeeBreakdown{}, 0, testDueAt)
+	svc := newTestService(&mockPaymentRepo{byAuction: []*entity.Payment{second, first}})
+
+	tests := []struct {
+		name   string
+		userID string
+		wantID string
+		wantOK bool
+	}{
+		{"failed winner sees own payment", failedWinner, first.ID(), true},
+		{"runner-up sees own payment", runnerUp, second.ID(), true},
+		{"seller sees latest payment", testSellerID, second.ID(), true},
+		{"stranger is forbidden", uuid.New().String(), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.GetAuctionPayment(context.Background(), query.GetAuctionPayment{AuctionID: auctionID, UserID: tt.userID})
+			if !tt.wantOK {
+				if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+					t.Errorf("expected forbidden, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAuctionPayment() error = %v", err)
+			}
+			if result.ID != tt.wantID {
+				t.Errorf("ID = %q, want %q", result.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestPaymentService_GetAuctionPayment_NotFound(t *testing.T) {
+	svc := newTestService(&mockPaymentRepo{})
+
+	_, err := svc.GetAuctionPayment(context.Background(), query.GetAuctionPayment{AuctionID: uuid.New().String(), UserID: testSellerID})
+	if !stderrors.Is(err, sharedErrors.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func recordTestSale(t *testing.T, ledger *mockLedgerRepo, amount int64) *entity.Payment {
+	t.Helper()
+	now := time.Now()
+	payment := entity.ReconstructPayment(uuid.New().String(), uuid.New().String(), uuid.New().String(), amount,
+		entity.FeeBreakdown{Amount: amount / 20, Rule: "percentage:500"}, entity.Escrow{}, entity.StatusCompleted, "", now, 0, 1, 0, now, now)
+	handler := command.NewRecordSaleHandler(&mockPaymentRepo{byAuction: []*entity.Payment{payment}}, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+	if err := handler.Handle(context.Background(), command.RecordSale{AuctionID: payment.AuctionID()}); err != nil {
+		t.Fatalf("RecordSale() error = %v", err)
+	}
+	return payment
+}
+
+func TestRecordSale_CreditsSellerOnce(t *testing.T) {
+	ledger := &mockLedgerRepo{}
+	payment := recordTestSale(t, ledger, 10000)
+
+	handler := command.NewRecordSaleHandler(&mockPaymentRepo{byAuction: []*entity.Payment{payment}}, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+	if err := handler.Handle(context.Background(), command.RecordSale{AuctionID: payment.AuctionID()}); err != nil {
+		t.Fatalf("RecordSale() replay error = %v", err)
+	}
+
+	if got := ledger.balances[entity.AccountSellerPayable+":"+testSellerID]; got != -9500 {
+		t.Errorf("seller payable = %d, want -9500", got)
+	}
+	if got := ledger.balances[entity.AccountPlatformRevenue+":"]; got != -500 {
+		t.Errorf("platform revenue = %d, want -500", got)
+	}
+}
+
+func TestRecordSale_NoCompletedPayment(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	handler := command.NewRecordSaleHandler(&mockPaymentRepo{byAuction: []*entity.Payment{payment}}, &mockLedgerRepo{},
+		&mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+
+	err := handler.Handle(context.Background(), command.RecordSale{AuctionID: payment.AuctionID()})
+	if !stderrors.Is(err, sharedErrors.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestRecordRefund_ReducesSellerBalance(t *testing.T) {
+	ledger := &mockLedgerRepo{}
+	payment := recordTestSale(t, ledger, 10000)
+	refund, err := payment.Refund(4000, "damaged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := payment.CompleteRefund(refund); err != nil {
+		t.Fatal(err)
+	}
+	refunds := &mockRefundRepo{}
+	_ = refunds.Save(context.Background(), refund)
+
+	handler := command.NewRecordRefundHandler(&mockPaymentRepo{payment: payment}, refunds, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+	for range 2 {
+		if err := handler.Handle(context.Background(), command.RecordRefund{PaymentID: payment.ID(), RefundID: refund.ID()}); err != nil {
+			t.Fatalf("RecordRefund() error = %v", err)
+		}
+	}
+
+	if got := ledger.balances[entity.AccountSellerPayable+":"+testSellerID]; got != -5700 {
+		t.Errorf("seller payable = %d, want -5700", got)
+	}
+	if got := ledger.balances[entity.AccountPlatformRevenue+":"]; got != -300 {
+		t.Errorf("platform revenue = %d, want -300", got)
+	}
+	if got := ledger.balances[entity.AccountGatewayClearing+":"]; got != 6000 {
+		t.Errorf("gateway clearing = %d, want 6000", got)
+	}
+
+	payout := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, &mockDisputeRepo{}, &mockPayoutGateway{}, testLedgerPolicy,
+		&mockPublisher{}, &mockTransactor{})
+	result, err := payout.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if err != nil {
+		t.Fatalf("PayoutSeller() error = %v", err)
+	}
+	if result.Amount != 5700 {
+		t.Errorf("payout amount = %d, want 5700", result.Amount)
+	}
+}
+
+func TestRecordRefund_HeldEscrowPostsNothing(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{Amount: 250}, time.Hour, testDueAt)
+	_ = payment.Complete()
+	refund, _ := payment.Refund(5000, "not delivered")
+	_ = payment.CompleteRefund(refund)
+	refunds := &mockRefundRepo{}
+	_ = refunds.Save(context.Background(), refund)
+	ledger := &mockLedgerRepo{}
+
+	handler := command.NewRecordRefundHandler(&mockPaymentRepo{payment: payment}, refunds, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+	if err := handler.Handle(context.Background(), command.RecordRefund{PaymentID: payment.ID(), RefundID: refund.ID()}); err != nil {
+		t.Fatalf("RecordRefund() error = %v", err)
+	}
+	if len(ledger.posted) != 0 {
+		t.Errorf("expected no entries, got %v", ledger.posted)
+	}
+}
+
+func TestPayoutSeller(t *testing.T) {
+	ledger := &mockLedgerRepo{}
+	recordTestSale(t, ledger, 10000)
+	handler := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, &mockDisputeRepo{}, &mockPayoutGateway{}, testLedgerPolicy, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if err != nil {
+		t.Fatalf("PayoutSeller() error = %v", err)
+	}
+	if result.Status != entity.PayoutStatusPaid || result.Amount != 9500 {
+		t.Errorf("result = %+v, want paid 9500", result)
+	}
+	if got := ledger.balances[entity.AccountSellerPayable+":"+testSellerID]; got != 0 {
+		t.Errorf("seller payable = %d, want 0", got)
+	}
+	if got := ledger.balances[entity.AccountSellerPaidOut+":"+testSellerID]; got != -9500 {
+		t.Errorf("seller paid out = %d, want -9500", got)
+	}
+
+	_, err = handler.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+		t.Errorf("expected bad request for empty balance, got %v", err)
+	}
+}
+
+func TestPayoutSeller_ResumesUnknownOutcome(t *testing.T) {
+	ledger := &mockLedgerRepo{}
+	recordTestSale(t, ledger, 10000)
+	gateway := &mockPayoutGateway{err: stderrors.New("connection reset")}
+	handler := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, &mockDisputeRepo{}, gateway, testLedgerPolicy, &mockPublisher{}, &mockTransactor{})
+
+	first, err := handler.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if err != nil {
+		t.Fatalf("PayoutSeller() error = %v", err)
+	}
+	if first.Status != entity.PayoutStatusPending {
+		t.Fatalf("Status = %q, want %q", first.Status, entity.PayoutStatusPending)
+	}
+
+	gateway.err = nil
+	second, err := handler.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if err != nil {
+		t.Fatalf("PayoutSeller() retry error = %v", err)
+	}
+	if second.ID != first.ID || second.Status != entity.PayoutStatusPaid {
+		t.Errorf("retry = %+v, want payout %s paid", second, first.ID)
+	}
+	if len(gateway.calls) != 2 || gateway.calls[1] != first.ID {
+		t.Errorf("transfer calls = %v, want the same payout twice", gateway.calls)
+	}
+}
+
+func TestPayoutSeller_Declined(t *testing.T) {
+	ledger := &mockLedgerRepo{}
+	recordTestSale(t, ledger, 10000)
+	handler := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, &mockDisputeRepo{}, &mockPayoutGateway{err: domain.ErrDeclined},
+		testLedgerPolicy, &mockPublisher{}, &mockTransactor{})
+
+	_, err := handler.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if !stderrors.Is(err, sharedErrors.ErrBadRequest) {
+		t.Fatalf("expected bad request, got %v", err)
+	}
+	if got := ledger.balances[entity.AccountSellerPayable+":"+testSellerID]; got != -9500 {
+		t.Errorf("seller payable = %d, want -9500 after a declined payout", got)
+	}
+}
+
+func openTestDispute(t *testing.T, payment *entity.Payment, disputes *mockDisputeRepo, ledger *mockLedgerRepo) *command.DisputeResult {
+	t.Helper()
+	handler := command.NewOpenDisputeHandler(&mockPaymentRepo{payment: payment}, disputes, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testDisputePolicy, &mockPublisher{}, &mockTransactor{})
+	result, err := handler.Handle(context.Background(), command.OpenDispute{
+		UserID: payment.WinnerID(), PaymentID: payment.ID(), Reason: "item not as described",
+	})
+	if err != nil {
+		t.Fatalf("OpenDispute() error = %v", err)
+	}
+	return result
+}
+
+func TestOpenDispute_HoldsEscrowAndPayouts(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	disputes := &mockDisputeRepo{}
+	ledger := &mockLedgerRepo{}
+	recordTestSale(t, ledger, 10000)
+	opened := openTestDispute(t, payment, disputes, ledger)
+	if opened.Status != entity.DisputeStatusOpen {
+		t.Errorf("Status = %q, want %q", opened.Status, entity.DisputeStatusOpen)
+	}
+
+	repo := &mockPaymentRepo{payment: payment, due: []*entity.Payment{payment}}
+	confirm := command.NewConfirmDeliveryHandler(repo, disputes, &mockPublisher{}, &mockTransactor{})
+	_, err := confirm.Handle(context.Background(), command.ConfirmDelivery{UserID: payment.WinnerID(), PaymentID: payment.ID()})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict confirming a disputed delivery, got %v", err)
+	}
+
+	release := command.NewReleaseEscrowsHandler(repo, disputes, &mockPublisher{}, &mockTransactor{})
+	result, err := release.Handle(context.Background(), command.ReleaseEscrows{Now: time.Now().Add(100 * time.Hour), Limit: 10})
+	if err != nil || result.Released != 0 {
+		t.Errorf("ReleaseEscrows() = %+v, %v; want nothing released", result, err)
+	}
+
+	payout := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, disputes, &mockPayoutGateway{}, testLedgerPolicy,
+		&mockPublisher{}, &mockTransactor{})
+	_, err = payout.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict paying out a disputed seller, got %v", err)
+	}
+	if payment.Status() != entity.StatusEscrowHeld {
+		t.Errorf("Status = %q, want %q", payment.Status(), entity.StatusEscrowHeld)
+	}
+
+	_, err = command.NewOpenDisputeHandler(&mockPaymentRepo{payment: payment}, disputes, ledger,
+		&mockAuctionClient{sellerID: testSellerID}, testDisputePolicy, &mockPublisher{}, &mockTransactor{}).
+		Handle(context.Background(), command.OpenDispute{UserID: payment.WinnerID(), PaymentID: payment.ID(), Reason: "again"})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict opening a second dispute, got %v", err)
+	}
+}
+
+func TestOpenDispute_NotOwner(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	handler := command.NewOpenDisputeHandler(&mockPaymentRepo{payment: payment}, &mockDisputeRepo{}, &mockLedgerRepo{},
+		&mockAuctionClient{sellerID: testSellerID}, testDisputePolicy, &mockPublisher{}, &mockTransactor{})
+
+	_, err := handler.Handle(context.Background(), command.OpenDispute{
+		UserID: uuid.New().String(), PaymentID: payment.ID(), Reason: "not mine",
+	})
+	if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestRespondDispute_OnlySeller(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	disputes := &mockDisputeRepo{}
+	opened := openTestDispute(t, payment, disputes, &mockLedgerRepo{})
+	handler := command.NewRespondDisputeHandler(disputes, testDisputePolicy, &mockPublisher{}, &mockTransactor{})
+
+	_, err := handler.Handle(context.Background(), command.RespondDispute{
+		UserID: payment.WinnerID(), DisputeID: opened.DisputeID, Response: "shipped as described",
+	})
+	if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+		t.Fatalf("expected forbidden error for the buyer, got %v", err)
+	}
+
+	result, err := handler.Handle(context.Background(), command.RespondDispute{
+		UserID: testSellerID, DisputeID: opened.DisputeID, Response: "shipped as described",
+	})
+	if err != nil {
+		t.Fatalf("RespondDispute() error = %v", err)
+	}
+	if result.Status != entity.DisputeStatusUnderReview {
+		t.Errorf("Status = %q, want %q", result.Status, entity.DisputeStatusUnderReview)
+	}
+}
+
+func TestResolveDispute_PartialRefundReleasesEscrow(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	disputes := &mockDisputeRepo{}
+	opened := openTestDispute(t, payment, disputes, &mockLedgerRepo{})
+	handler := command.NewResolveDisputeHandler(disputes, &mockPaymentRepo{payment: payment}, &mockRefundRepo{},
+		domainService.NewPaymentProcessor(&mockGateway{}), testRetryPolicy, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.ResolveDispute{
+		DisputeID: opened.DisputeID, Resolution: entity.DisputeResolutionPartial, Amount: 1000,
+	})
+	if err != nil {
+		t.Fatalf("ResolveDispute() error = %v", err)
+	}
+	if result.Status != entity.DisputeStatusResolved || result.RefundStatus != entity.RefundStatusSucceeded {
+		t.Errorf("result = %+v, want resolved with a succeeded refund", result)
+	}
+	if payment.Status() != entity.StatusPartiallyRefunded || payment.Refunded() != 1000 {
+		t.Errorf("payment = %q refunded %d, want partially refunded 1000", payment.Status(), payment.Refunded())
+	}
+	if payment.Escrow().ReleasedAt.IsZero() {
+		t.Error("expected the rest of the escrow to be released")
+	}
+
+	_, err = handler.Handle(context.Background(), command.ResolveDispute{
+		DisputeID: opened.DisputeID, Resolution: entity.DisputeResolutionReject,
+	})
+	if !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Errorf("expected conflict resolving twice, got %v", err)
+	}
+}
+
+func TestResolveDispute_PartialRefundCreditsSellerNet(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000,
+		entity.FeeBreakdown{Amount: 250, Rule: "percentage:500"}, 72*time.Hour, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	payment.ClearEvents()
+	repo := &mockPaymentRepo{payment: payment, byAuction: []*entity.Payment{payment}}
+	disputes := &mockDisputeRepo{payments: []*entity.Payment{payment}}
+	refunds := &mockRefundRepo{}
+	ledger := &mockLedgerRepo{}
+	gateway := &mockGateway{refundErr: stderrors.New("connection reset")}
+	opened := openTestDispute(t, payment, disputes, ledger)
+
+	resolve := command.NewResolveDisputeHandler(disputes, repo, refunds, domainService.NewPaymentProcessor(gateway),
+		testRetryPolicy, &mockPublisher{}, &mockTransactor{})
+	result, err := resolve.Handle(context.Background(), command.ResolveDispute{
+		DisputeID: opened.DisputeID, Resolution: entity.DisputeResolutionPartial, Amount: 1000,
+	})
+	if err != nil {
+		t.Fatalf("ResolveDispute() error = %v", err)
+	}
+	if result.RefundStatus != entity.RefundStatusPending {
+		t.Fatalf("RefundStatus = %q, want %q", result.RefundStatus, entity.RefundStatusPending)
+	}
+
+	// The escrow release settles the auction and credits the whole sale.
+	recordSale := command.NewRecordSaleHandler(repo, ledger, &mockAuctionClient{sellerID: testSellerID}, testLedgerPolicy, &mockTransactor{})
+	if err := recordSale.Handle(context.Background(), command.RecordSale{AuctionID: payment.AuctionID()}); err != nil {
+		t.Fatalf("RecordSale() error = %v", err)
+	}
+	payout := command.NewPayoutSellerHandler(&mockPayoutRepo{}, ledger, disputes, &mockPayoutGateway{}, testLedgerPolicy,
+		&mockPublisher{}, &mockTransactor{})
+	if _, err := payout.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID}); !stderrors.Is(err, sharedErrors.ErrConflict) {
+		t.Fatalf("expected conflict paying out while the refund is pending, got %v", err)
+	}
+
+	gateway.refundErr = nil
+	refund := refunds.refunds[result.RefundID]
+	refunds.due = []*entity.Refund{refund}
+	retry := command.NewRetryRefundsHandler(repo, refunds, domainService.NewPaymentProcessor(gateway), testRetryPolicy,
+		&mockPublisher{}, &mockTransactor{})
+	if _, err := retry.Handle(context.Background(), command.RetryRefunds{Now: time.Now().Add(time.Hour), Limit: 10}); err != nil {
+		t.Fatalf("RetryRefunds() error = %v", err)
+	}
+	recordRefund := command.NewRecordRefundHandler(repo, refunds, ledger, &mockAuctionClient{sellerID: testSellerID},
+		testLedgerPolicy, &mockTransactor{})
+	if err := recordRefund.Handle(context.Background(), command.RecordRefund{PaymentID: payment.ID(), RefundID: refund.ID()}); err != nil {
+		t.Fatalf("RecordRefund() error = %v", err)
+	}
+
+	// 1000 of 5000 refunded takes 950 from the seller's 4750 and 50 from the fee.
+	want := payment.SellerAmount() - 950
+	if got := ledger.balances[entity.AccountSellerPayable+":"+testSellerID]; got != -want {
+		t.Errorf("seller payable = %d, want %d", got, -want)
+	}
+	paid, err := payout.Handle(context.Background(), command.PayoutSeller{SellerID: testSellerID})
+	if err != nil {
+		t.Fatalf("PayoutSeller() error = %v", err)
+	}
+	if paid.Amount != want {
+		t.Errorf("payout amount = %d, want %d", paid.Amount, want)
+	}
+}
+
+func TestEnforceDisputeDeadlines_EscalatesUnanswered(t *testing.T) {
+	payment := newHeldPayment(t, uuid.New().String())
+	disputes := &mockDisputeRepo{}
+	opened := openTestDispute(t, payment, disputes, &mockLedgerRepo{})
+	handler := command.NewEnforceDisputeDeadlinesHandler(disputes, testDisputePolicy, &mockPublisher{}, &mockTransactor{})
+
+	result, err := handler.Handle(context.Background(), command.EnforceDisputeDeadlines{Now: time.Now(), Limit: 10})
+	if err != nil || result.Escalated != 0 {
+		t.Fatalf("Handle() = %+v, %v; want nothing escalated before the deadline", result, err)
+	}
+
+	result, err = handler.Handle(context.Background(), command.EnforceDisputeDeadlines{Now: time.Now().Add(73 * time.Hour), Limit: 10})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Escalated != 1 || disputes.disputes[opened.DisputeID].Status() != entity.DisputeStatusUnderReview {
+		t.Errorf("result = %+v, want the dispute escalated", result)
+	}
+}
+
+func TestReconcilePayments_StoresIssueOnce(t *testing.T) {
+	payment, _ := entity.NewPayment(uuid.New().String(), uuid.New().String(), 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	gateway := &mockGateway{transactions: []domain.GatewayTransaction{
+		{ID: "tx-1", Type: domain.TransactionCapture, PaymentID: payment.ID(), Amount: 4500},
+		{ID: "tx-2", Type: domain.TransactionCapture, PaymentID: "ch_external", Amount: 100},
+	}}
+	issues := &mockIssueRepo{}
+	handler := command.NewReconcilePaymentsHandler(&mockPaymentRepo{due: []*entity.Payment{payment}}, &mockRefundRepo{},
+		issues, gateway, domainService.NewReconciliationPolicy(), &mockPublisher{}, &mockTransactor{})
+
+	cmd := command.ReconcilePayments{From: time.Now().Add(-time.Hour), To: time.Now()}
+	result, err := handler.Handle(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("ReconcilePayments() error = %v", err)
+	}
+	if result.Issues != 2 || result.Transactions != 2 || result.Payments != 1 {
+		t.Errorf("result = %+v, want 2 issues over 2 transactions and 1 payment", result)
+	}
+
+	result, err = handler.Handle(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("ReconcilePayments() replay error = %v", err)
+	}
+	if result.Issues != 0 || len(issues.issues) != 2 {
+		t.Errorf("replay stored %d new issues (total %d), want 0 (total 2)", result.Issues, len(issues.issues))
+	}
+}
+
+func TestIssueInvoice_NumbersOncePerPayment(t *testing.T) {
+	winnerID := uuid.New().String()
+	payment, _ := entity.NewPayment(uuid.New().String(), winnerID, 10000, entity.FeeBreakdown{Amount: 500}, 0, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	invoices := &mockInvoiceRepo{}
+	handler := command.NewIssueInvoiceHandler(&mockPaymentRepo{payment: payment}, invoices,
+		&mockAuctionClient{sellerID: testSellerID}, &mockUserClient{}, 0, &mockPublisher{}, &mockTransactor{})
+
+	for range 2 {
+		if err := handler.Handle(context.Background(), command.IssueInvoice{PaymentID: payment.ID()}); err != nil {
+			t.Fatalf("IssueInvoice() error = %v", err)
+		}
+	}
+	if invoices.sequence != 1 {
+		t.Errorf("allocated %d invoice numbers, want 1", invoices.sequence)
+	}
+
+	getInvoice := query.NewGetInvoiceHandler(invoices)
+	result, err := getInvoice.Handle(context.Background(), query.GetInvoice{PaymentID: payment.ID(), UserID: testSellerID})
+	if err != nil {
+		t.Fatalf("GetInvoice() error = %v", err)
+	}
+	if result.Number != "INV-00000001" || result.Buyer.ID != winnerID || result.SellerNet != 9500 {
+		t.Errorf("invoice = %+v", result)
+	}
+
+	_, err = getInvoice.Handle(context.Background(), query.GetInvoice{PaymentID: payment.ID(), UserID: uuid.New().String()})
+	if !stderrors.Is(err, sharedErrors.ErrForbidden) {
+		t.Errorf("expected forbidden for a stranger, got %v", err)
+	}
+}
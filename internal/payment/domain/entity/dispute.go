@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/event"
+)
+
+const (
+	DisputeStatusOpen        = "open"
+	DisputeStatusUnderReview = "under_review"
+	DisputeStatusResolved    = "resolved"
+
+	DisputeResolutionRefund  = "refund"
+	DisputeResolutionPartial = "partial"
+	DisputeResolutionReject  = "reject"
+
+	DisputeEscalatedNoResponse = "seller_no_response"
+)
+
+var (
+	errDisputeReason          = errors.New("dispute reason is required")
+	errDisputeResponse        = errors.New("dispute response is required")
+	errDisputeSeller          = errors.New("dispute seller ID is required")
+	ErrNotDisputable          = errors.New("payment cannot be disputed in its current status")
+	ErrDisputeNotOpen         = errors.New("dispute is not awaiting the seller's response")
+	ErrDisputeResolved        = errors.New("dispute is already resolved")
+	ErrUnknownResolution      = errors.New("unknown dispute resolution")
+	ErrInvalidDisputeAmount   = errors.New("partial resolution needs a refund amount")
+	errDisputeOverdueNotified = errors.New("dispute review is already marked overdue")
+)
+
+func IsKnownDisputeStatus(status string) bool {
+	switch status {
+	case DisputeStatusOpen, DisputeStatusUnderReview, DisputeStatusResolved:
+		return true
+	}
+	return false
+}
+
+// Dispute is a buyer's claim against a captured payment. The seller answers
+// it, or lets the response deadline pass, and an admin then resolves it.
+// While a dispute is active its payment's escrow and the seller's payouts
+// are held back.
+type Dispute struct {
+	id           string
+	paymentID    string
+	buyerID      string
+	sellerID     string
+	reason       string
+	response     string
+	status       string
+	resolution   string
+	refundAmount int64
+	respondBy    time.Time
+	reviewBy     time.Time
+	overdue      bool
+	createdAt    time.Time
+	updatedAt    time.Time
+
+	events []event.Event
+}
+
+func NewDispute(payment *Payment, sellerID, reason string, respondBy time.Time) (*Dispute, error) {
+	if reason == "" {
+		return nil, errDisputeReason
+	}
+	if sellerID == "" {
+		return nil, errDisputeSeller
+	}
+	if !payment.IsCaptured() || payment.Refundable() <= 0 || payment.Status() == StatusRefundPending {
+		return nil, ErrNotDisputable
+	}
+	now := time.Now()
+	d := &Dispute{
+		id:        uuid.New().String(),
+		paymentID: payment.ID(),
+		buyerID:   payment.WinnerID(),
+		sellerID:  sellerID,
+		reason:    reason,
+		status:    DisputeStatusOpen,
+		respondBy: respondBy,
+		createdAt: now,
+		updatedAt: now,
+	}
+	d.record(event.NewDisputeOpened(d.id, d.paymentID, d.buyerID, d.sellerID, reason, respondBy))
+	return d, nil
+}
+
+func ReconstructDispute(
+	id, paymentID, buyerID, sellerID, reason, response, status, resolution string, refundAmount int64,
+	respondBy, reviewBy time.Time, overdue bool, createdAt, updatedAt time.Time,
+) *Dispute {
+	return &Dispute{
+		id: id, paymentID: paymentID, buyerID: buyerID, sellerID: sellerID,
+		reason: reason, response: response, status: status, resolution: resolution,
+		refundAmount: refundAmount, respondBy: respondBy, reviewBy: reviewBy, overdue: overdue,
+		createdAt: createdAt, updatedAt: updatedAt,
+	}
+}
+
+func (d *Dispute) ID() string           { return d.id }
+func (d *Dispute) PaymentID() string    { return d.paymentID }
+func (d *Dispute) BuyerID() string      { return d.buyerID }
+func (d *Dispute) SellerID() string     { return d.sellerID }
+func (d *Dispute) Reason() string       { return d.reason }
+func (d *Dispute) Response() string     { return d.response }
+func (d *Dispute) Status() string       { return d.status }
+func (d *Dispute) Resolution() string   { return d.resolution }
+func (d *Dispute) RefundAmount() int64  { return d.refundAmount }
+func (d *Dispute) RespondBy() time.Time { return d.respondBy }
+func (d *Dispute) ReviewBy() time.Time  { return d.reviewBy }
+func (d *Dispute) ReviewOverdue() bool  { return d.overdue }
+func (d *Dispute) CreatedAt() time.Time { return d.createdAt }
+func (d *Dispute) UpdatedAt() time.Time { return d.updatedAt }
+
+func (d *Dispute) IsActive() bool { return d.status != DisputeStatusResolved }
+
+func (d *Dispute) IsVisibleTo(userID string) bool {
+	return d.buyerID == userID || d.sellerID == userID
+}
+
+// IsResponseOverdue reports whether the seller let the response deadline
+// pass without answering.
+func (d *Dispute) IsResponseOverdue(now time.Time) bool {
+	return d.status == DisputeStatusOpen && !now.Before(d.respondBy)
+}
+
+func (d *Dispute) IsReviewOverdue(now time.Time) bool {
+	return d.status == DisputeStatusUnderReview && !d.overdue && !now.Before(d.reviewBy)
+}
+
+func (d *Dispute) Respond(response string, reviewBy time.Time) error {
+	if d.status != DisputeStatusOpen {
+		return ErrDisputeNotOpen
+	}
+	if response == "" {
+		return errDisputeResponse
+	}
+	d.response = response
+	d.status = DisputeStatusUnderReview
+	d.reviewBy = reviewBy
+	d.updatedAt = time.Now()
+	d.record(event.NewDisputeResponded(d.id, d.paymentID, d.sellerID, reviewBy))
+	return nil
+}
+
+// Escalate hands a dispute the seller did not answer in time to review.
+func (d *Dispute) Escalate(reviewBy time.Time) error {
+	if d.status != DisputeStatusOpen {
+		return ErrDisputeNotOpen
+	}
+	d.status = DisputeStatusUnderReview
+	d.reviewBy = reviewBy
+	d.updatedAt = time.Now()
+	d.record(event.NewDisputeEscalated(d.id, d.paymentID, DisputeEscalatedNoResponse, reviewBy))
+	return nil
+}
+
+// MarkReviewOverdue flags a dispute nobody resolved by its review deadline.
+// It is flagged once; the dispute stays under review.
+func (d *Dispute) MarkReviewOverdue() error {
+	if d.status != DisputeStatusUnderReview {
+		return ErrDisputeResolved
+	}
+	if d.overdue {
+		return errDisputeOverdueNotified
+	}
+	d.overdue = true
+	d.updatedAt = time.Now()
+	d.record(event.NewDisputeReviewOverdue(d.id, d.paymentID, d.reviewBy))
+	return nil
+}
+
+// Resolve closes the dispute. Refund and partial resolutions carry the
+// amount to return to the buyer; reject returns nothing.
+func (d *Dispute) Resolve(resolution string, refundAmount int64) error {
+	if !d.IsActive() {
+		return ErrDisputeResolved
+	}
+	switch resolution {
+	case DisputeResolutionRefund, DisputeResolutionPartial:
+		if refundAmount <= 0 {
+			return ErrInvalidDisputeAmount
+		}
+	case DisputeResolutionReject:
+		refundAmount = 0
+	default:
+		return ErrUnknownResolution
+	}
+	d.status = DisputeStatusResolved
+	d.resolution = resolution
+	d.refundAmount = refundAmount
+	d.updatedAt = time.Now()
+	d.record(event.NewDisputeResolved(d.id, d.paymentID, d.buyerID, d.sellerID, resolution, refundAmount))
+	return nil
+}
+
+func (d *Dispute) Events() []event.Event { return d.events }
+func (d *Dispute) ClearEvents()          { d.events = nil }
+func (d *Dispute) record(e event.Event)  { d.events = append(d.events, e) }
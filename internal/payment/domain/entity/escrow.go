@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	EscrowReleaseConfirmed = "delivery_confirmed"
+	EscrowReleaseTimeout   = "auto_release"
+	EscrowReleaseDispute   = "dispute_resolved"
+)
+
+// Escrow keeps captured funds from the seller until the buyer confirms
+// delivery or ReleaseAt passes. A zero Hold settles on completion. ReleaseAt
+// is set once the funds are held and ReleasedAt once they are handed over.
+type Escrow struct {
+	Hold       time.Duration
+	ReleaseAt  time.Time
+	ReleasedAt time.Time
+}
+
+func (e Escrow) Enabled() bool { return e.Hold > 0 }
+
+func (e Escrow) holding() bool { return !e.ReleaseAt.IsZero() && e.ReleasedAt.IsZero() }
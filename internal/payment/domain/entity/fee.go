@@ -0,0 +1,14 @@
+package entity
+
+import "errors"
+
+var errInvalidFee = errors.New("fee must be between zero and the payment amount")
+
+// FeeBreakdown is the platform commission taken from a sale. The buyer is
+// charged the winning bid and the seller receives it less the fee. Rule
+// records how the fee was calculated, and Category which override applied.
+type FeeBreakdown struct {
+	Amount   int64
+	Rule     string
+	Category string
+}
@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/event"
+)
+
+const (
+	LineHammerPrice = "hammer_price"
+	LinePlatformFee = "platform_fee"
+	LineTax         = "tax"
+)
+
+var (
+	errInvalidInvoiceSequence = errors.New("invoice sequence must be positive")
+	errInvalidInvoiceParty    = errors.New("buyer and seller are required")
+	errInvalidTaxRate         = errors.New("tax basis points must not be negative")
+	ErrNotInvoiceable         = errors.New("payment has not been captured")
+)
+
+type InvoiceParty struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+type InvoiceLine struct {
+	Kind        string
+	Description string
+	Amount      int64
+}
+
+// Invoice is the record of a completed sale. Buyer and seller details are
+// copied at issue time so later profile changes do not rewrite history.
+type Invoice struct {
+	id        string
+	sequence  int64
+	paymentID string
+	auctionID string
+	buyer     InvoiceParty
+	seller    InvoiceParty
+	lines     []InvoiceLine
+	total     int64
+	sellerNet int64
+	issuedAt  time.Time
+
+	events []event.Event
+}
+
+// NewInvoice issues an invoice for a captured payment. The buyer pays the
+// hammer price, tax included at taxBasisPoints; the platform fee is taken
+// from the seller's side.
+func NewInvoice(sequence int64, payment *Payment, buyer, seller InvoiceParty, taxBasisPoints int64) (*Invoice, error) {
+	if sequence <= 0 {
+		return nil, errInvalidInvoiceSequence
+	}
+	if buyer.ID == "" || seller.ID == "" {
+		return nil, errInvalidInvoiceParty
+	}
+	if taxBasisPoints < 0 {
+		return nil, errInvalidTaxRate
+	}
+	if !payment.IsCaptured() {
+		return nil, ErrNotInvoiceable
+	}
+
+	lines := []InvoiceLine{
+		{Kind: LineHammerPrice, Description: "Hammer price for auction " + payment.AuctionID(), Amount: payment.Amount()},
+		{Kind: LinePlatformFee, Description: "Platform fee (" + payment.Fee().Rule + "), paid by seller", Amount: payment.Fee().Amount},
+		{Kind: LineTax, Description: fmt.Sprintf("Tax included at %d bps", taxBasisPoints), Amount: includedTax(payment.Amount(), taxBasisPoints)},
+	}
+	inv := &Invoice{
+		id:        uuid.New().String(),
+		sequence:  sequence,
+		paymentID: payment.ID(),
+		auctionID: payment.AuctionID(),
+		buyer:     buyer,
+		seller:    seller,
+		lines:     lines,
+		total:     payment.Amount(),
+		sellerNet: payment.SellerAmount(),
+		issuedAt:  time.Now(),
+	}
+	inv.record(event.NewInvoiceIssued(inv.id, inv.Number(), inv.paymentID, buyer.ID, seller.ID, inv.total))
+	return inv, nil
+}
+
+func ReconstructInvoice(
+	id string, sequence int64, paymentID, auctionID string, buyer, seller InvoiceParty,
+	lines []InvoiceLine, total, sellerNet int64, issuedAt time.Time,
+) *Invoice {
+	return &Invoice{
+		id: id, sequence: sequence, paymentID: paymentID, auctionID: auctionID,
+		buyer: buyer, seller: seller, lines: lines, total: total, sellerNet: sellerNet, issuedAt: issuedAt,
+	}
+}
+
+// includedTax is the tax portion of a tax-inclusive gross amount.
+func includedTax(gross, basisPoints int64) int64 {
+	return gross * basisPoints / (10000 + basisPoints)
+}
+
+func (i *Invoice) ID() string           { return i.id }
+func (i *Invoice) Sequence() int64      { return i.sequence }
+func (i *Invoice) Number() string       { return fmt.Sprintf("INV-%08d", i.sequence) }
+func (i *Invoice) PaymentID() string    { return i.paymentID }
+func (i *Invoice) AuctionID() string    { return i.auctionID }
+func (i *Invoice) Buyer() InvoiceParty  { return i.buyer }
+func (i *Invoice) Seller() InvoiceParty { return i.seller }
+func (i *Invoice) Lines() []InvoiceLine { return i.lines }
+func (i *Invoice) Total() int64         { return i.total }
+func (i *Invoice) SellerNet() int64     { return i.sellerNet }
+func (i *Invoice) IssuedAt() time.Time  { return i.issuedAt }
+
+func (i *Invoice) IsVisibleTo(userID string) bool {
+	return userID == i.buyer.ID || userID == i.seller.ID
+}
+
+func (i *Invoice) Events() []event.Event { return i.events }
+func (i *Invoice) ClearEvents()          { i.events = nil }
+func (i *Invoice) record(e event.Event)  { i.events = append(i.events, e) }
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AccountGatewayClearing = "gateway_clearing"
+	AccountPlatformRevenue = "platform_revenue"
+	AccountSellerPayable   = "seller_payable"
+	AccountSellerPaidOut   = "seller_paid_out"
+)
+
+const (
+	EntryKindSale   = "sale"
+	EntryKindRefund = "refund"
+	EntryKindPayout = "payout"
+)
+
+var (
+	errEmptyEntry      = errors.New("journal entry needs at least two postings")
+	ErrUnbalancedEntry = errors.New("journal entry debits and credits do not balance")
+)
+
+// Posting moves Amount into or out of an account. Debits are positive and
+// credits negative, so the postings of a balanced entry sum to zero.
+type Posting struct {
+	Account string
+	OwnerID string
+	Amount  int64
+}
+
+func Debit(account, ownerID string, amount int64) Posting {
+	return Posting{Account: account, OwnerID: ownerID, Amount: amount}
+}
+
+func Credit(account, ownerID string, amount int64) Posting {
+	return Posting{Account: account, OwnerID: ownerID, Amount: -amount}
+}
+
+// JournalEntry is an immutable, balanced set of postings. Kind and
+// Reference identify the business fact it records, so an entry is never
+// posted twice for the same fact.
+type JournalEntry struct {
+	id        string
+	kind      string
+	reference string
+	postings  []Posting
+	createdAt time.Time
+}
+
+// NewJournalEntry drops zero postings, such as a waived fee, and rejects
+// entries that do not balance.
+func NewJournalEntry(kind, reference string, postings ...Posting) (*JournalEntry, error) {
+	var kept []Posting
+	var sum int64
+	for _, p := range postings {
+		if p.Amount == 0 {
+			continue
+		}
+		kept = append(kept, p)
+		sum += p.Amount
+	}
+	if len(kept) < 2 {
+		return nil, errEmptyEntry
+	}
+	if sum != 0 {
+		return nil, ErrUnbalancedEntry
+	}
+	return &JournalEntry{
+		id:        uuid.New().String(),
+		kind:      kind,
+		reference: reference,
+		postings:  kept,
+		createdAt: time.Now(),
+	}, nil
+}
+
+func (e *JournalEntry) ID() string           { return e.id }
+func (e *JournalEntry) Kind() string         { return e.kind }
+func (e *JournalEntry) Reference() string    { return e.reference }
+func (e *JournalEntry) Postings() []Posting  { return e.postings }
+func (e *JournalEntry) CreatedAt() time.Time { return e.createdAt }
@@ -9,97 +9,320 @@ import (
 )
 
 const (
-	StatusPending   = "pending"
-	StatusCompleted = "completed"
-	StatusFailed    = "failed"
-	StatusRefunded  = "refunded"
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+
+	StatusPartiallyRefunded = "partially_refunded"
+	StatusRefundPending     = "refund_pending"
+
+	StatusEscrowHeld     = "escrow_held"
+	StatusEscrowReleased = "escrow_released"
 )
 
 var (
 	errInvalidInput   = errors.New("auction ID and winner ID are required")
 	errInvalidAmount  = errors.New("payment amount must be positive")
+	errInvalidDueAt   = errors.New("payment due date must be in the future")
+	errReminderSent   = errors.New("payment reminder already sent")
 	ErrNotPending     = errors.New("payment is not in pending status")
 	ErrNotCompleted   = errors.New("payment is not in completed status")
+
+	ErrInvalidRefundAmount  = errors.New("refund amount must be positive")
+	ErrRefundExceedsBalance = errors.New("refund amount exceeds the refundable balance")
+	ErrNotRefundable        = errors.New("payment cannot be refunded in its current status")
+	ErrNoRefundPending      = errors.New("payment has no refund pending")
+	errRefundMismatch       = errors.New("refund does not belong to this payment")
+	ErrPartialEscrowRefund  = errors.New("held escrow can only be refunded in full")
+
+	errInvalidEscrowHold = errors.New("escrow hold must not be negative")
+	ErrNotHeld           = errors.New("payment is not held in escrow")
 )
 
+func IsKnownStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusProcessing, StatusCompleted, StatusFailed, StatusRefunded,
+		StatusPartiallyRefunded, StatusRefundPending,
+		StatusEscrowHeld, StatusEscrowReleased:
+		return true
+	}
+	return false
+}
+
 type Payment struct {
 	id        string
 	auctionID string
 	winnerID  string
 	amount    int64
+	fee       FeeBreakdown
+	escrow    Escrow
 	status    string
+	methodID  string
+	dueAt     time.Time
+	reminders int
+	attempts  int
+	refunded  int64
 	createdAt time.Time
 	updatedAt time.Time
 
 	events []event.Event
 }
 
-func NewPayment(auctionID, winnerID string, amount int64) (*Payment, error) {
+func NewPayment(auctionID, winnerID string, amount int64, fee FeeBreakdown, escrowHold time.Duration, dueAt time.Time) (*Payment, error) {
 	if auctionID == "" || winnerID == "" {
 		return nil, errInvalidInput
 	}
 	if amount <= 0 {
 		return nil, errInvalidAmount
 	}
+	if fee.Amount < 0 || fee.Amount > amount {
+		return nil, errInvalidFee
+	}
+	if escrowHold < 0 {
+		return nil, errInvalidEscrowHold
+	}
 	now := time.Now()
+	if !dueAt.After(now) {
+		return nil, errInvalidDueAt
+	}
 	p := &Payment{
 		id:        uuid.New().String(),
 		auctionID: auctionID,
 		winnerID:  winnerID,
 		amount:    amount,
+		fee:       fee,
+		escrow:    Escrow{Hold: escrowHold},
 		status:    StatusPending,
+		dueAt:     dueAt,
 		createdAt: now,
 		updatedAt: now,
 	}
-	p.record(event.NewPaymentCreated(p.id, auctionID, winnerID, amount))
+	p.record(event.NewPaymentCreated(p.id, auctionID, winnerID, amount, fee.Amount, fee.Rule))
 	return p, nil
 }
 
-func ReconstructPayment(id, auctionID, winnerID string, amount int64, status string, createdAt, updatedAt time.Time) *Payment {
+func ReconstructPayment(
+	id, auctionID, winnerID string, amount int64, fee FeeBreakdown, escrow Escrow, status, paymentMethodID string,
+	dueAt time.Time, reminders, chargeAttempts int, refunded int64, createdAt, updatedAt time.Time,
+) *Payment {
 	return &Payment{
-		id: id, auctionID: auctionID, winnerID: winnerID,
-		amount: amount, status: status,
-		createdAt: createdAt, updatedAt: updatedAt,
+		id: id, auctionID: auctionID, winnerID: winnerID, amount: amount, fee: fee, escrow: escrow,
+		status: status, methodID: paymentMethodID, dueAt: dueAt, reminders: reminders,
+		attempts: chargeAttempts, refunded: refunded, createdAt: createdAt, updatedAt: updatedAt,
 	}
 }
 
-func (p *Payment) ID() string           { return p.id }
-func (p *Payment) AuctionID() string    { return p.auctionID }
-func (p *Payment) WinnerID() string     { return p.winnerID }
-func (p *Payment) Amount() int64        { return p.amount }
-func (p *Payment) Status() string       { return p.status }
-func (p *Payment) CreatedAt() time.Time { return p.createdAt }
-func (p *Payment) UpdatedAt() time.Time { return p.updatedAt }
+func (p *Payment) ID() string              { return p.id }
+func (p *Payment) AuctionID() string       { return p.auctionID }
+func (p *Payment) WinnerID() string        { return p.winnerID }
+func (p *Payment) Amount() int64           { return p.amount }
+func (p *Payment) Fee() FeeBreakdown       { return p.fee }
+func (p *Payment) SellerAmount() int64     { return p.amount - p.fee.Amount }
+func (p *Payment) Escrow() Escrow          { return p.escrow }
+func (p *Payment) Status() string          { return p.status }
+func (p *Payment) PaymentMethodID() string { return p.methodID }
+func (p *Payment) DueAt() time.Time        { return p.dueAt }
+func (p *Payment) RemindersSent() int      { return p.reminders }
+func (p *Payment) ChargeAttempts() int     { return p.attempts }
+func (p *Payment) Refunded() int64         { return p.refunded }
+func (p *Payment) Refundable() int64       { return p.amount - p.refunded }
+func (p *Payment) CreatedAt() time.Time    { return p.createdAt }
+func (p *Payment) UpdatedAt() time.Time    { return p.updatedAt }
 
 func (p *Payment) IsOwnedBy(userID string) bool { return p.winnerID == userID }
 
-func (p *Payment) Complete() error {
+// IsOverdue reports whether the winner let the payment deadline pass without
+// starting a charge.
+func (p *Payment) IsOverdue(now time.Time) bool {
+	return p.status == StatusPending && !now.Before(p.dueAt)
+}
+
+func (p *Payment) Remind(reminder int) error {
 	if p.status != StatusPending {
 		return ErrNotPending
 	}
-	p.status = StatusCompleted
+	if reminder <= p.reminders {
+		return errReminderSent
+	}
+	p.reminders = reminder
 	p.updatedAt = time.Now()
-	p.record(event.NewPaymentCompleted(p.id, p.auctionID, p.winnerID, p.amount))
+	p.record(event.NewPaymentReminder(p.id, p.auctionID, p.winnerID, p.amount, p.dueAt, reminder))
 	return nil
 }
 
-func (p *Payment) Fail(reason string) error {
+// StartProcessing persists the intent to charge before the gateway is
+// called, so an interrupted charge can be found and resolved later. Each
+// call opens a new charge attempt; recovery of an interrupted charge reuses
+// the attempt so the gateway sees the same idempotency key. The payment
+// method is empty when the buyer has none saved.
+func (p *Payment) StartProcessing(paymentMethodID string) error {
 	if p.status != StatusPending {
 		return ErrNotPending
 	}
+	p.status = StatusProcessing
+	p.methodID = paymentMethodID
+	p.attempts++
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *Payment) IsProcessing() bool { return p.status == StatusProcessing }
+
+// IsCaptured reports whether the buyer's money was taken, regardless of
+// any refunds since.
+func (p *Payment) IsCaptured() bool {
+	switch p.status {
+	case StatusCompleted, StatusPartiallyRefunded, StatusRefunded, StatusRefundPending,
+		StatusEscrowHeld, StatusEscrowReleased:
+		return true
+	}
+	return false
+}
+
+// IsSettleable reports whether the seller may be credited: the money was
+// captured and is not, or no longer, held in escrow. Refunds since do not
+// change it; the ledger reverses them with entries of their own.
+func (p *Payment) IsSettleable() bool {
+	return p.IsCaptured() && !p.escrow.holding()
+}
+
+func (p *Payment) awaitingSettlement() bool {
+	return p.status == StatusPending || p.status == StatusProcessing
+}
+
+func (p *Payment) Complete() error {
+	if !p.awaitingSettlement() {
+		return ErrNotPending
+	}
+	now := time.Now()
+	p.status = StatusCompleted
+	if p.escrow.Enabled() {
+		p.status = StatusEscrowHeld
+		p.escrow.ReleaseAt = now.Add(p.escrow.Hold)
+	}
+	p.updatedAt = now
+	p.record(event.NewPaymentCompleted(p.id, p.auctionID, p.winnerID, p.amount, p.fee.Amount, p.fee.Rule, p.escrow.Enabled()))
+	return nil
+}
+
+// ReleaseEscrow hands held funds over to the seller, either because the
+// buyer confirmed delivery or because the hold expired.
+func (p *Payment) ReleaseEscrow(reason string) error {
+	if p.status != StatusEscrowHeld {
+		return ErrNotHeld
+	}
+	now := time.Now()
+	p.status = StatusEscrowReleased
+	p.escrow.ReleasedAt = now
+	p.updatedAt = now
+	p.record(event.NewPaymentEscrowReleased(p.id, p.auctionID, p.winnerID, p.SellerAmount(), reason))
+	return nil
+}
+
+func (p *Payment) IsEscrowDue(now time.Time) bool {
+	return p.status == StatusEscrowHeld && !now.Before(p.escrow.ReleaseAt)
+}
+
+func (p *Payment) Fail(reason string) error {
+	if !p.awaitingSettlement() {
+		return ErrNotPending
+	}
 	p.status = StatusFailed
 	p.updatedAt = time.Now()
 	p.record(event.NewPaymentFailed(p.id, p.auctionID, p.winnerID, p.amount, reason))
 	return nil
 }
 
-func (p *Payment) Refund(reason string) error {
-	if p.status != StatusCompleted {
-		return ErrNotCompleted
+// Refund starts returning part or all of the captured amount. The payment
+// stays refund_pending until the gateway outcome is recorded, so only one
+// refund is in flight at a time.
+func (p *Payment) Refund(amount int64, reason string) (*Refund, error) {
+	switch p.status {
+	case StatusCompleted, StatusPartiallyRefunded, StatusEscrowHeld, StatusEscrowReleased:
+	default:
+		return nil, ErrNotRefundable
 	}
-	p.status = StatusRefunded
+	if amount <= 0 {
+		return nil, ErrInvalidRefundAmount
+	}
+	if amount > p.Refundable() {
+		return nil, ErrRefundExceedsBalance
+	}
+	if p.status == StatusEscrowHeld && amount != p.Refundable() {
+		return nil, ErrPartialEscrowRefund
+	}
+
+	p.status = StatusRefundPending
 	p.updatedAt = time.Now()
-	p.record(event.NewPaymentRefunded(p.id, p.auctionID, p.winnerID, p.amount, reason))
+	return newRefund(p.id, amount, reason), nil
+}
+
+// CompleteRefund settles a pending refund. The payment ends refunded once
+// the refunded total reaches the captured amount.
+func (p *Payment) CompleteRefund(refund *Refund) error {
+	if err := p.checkPendingRefund(refund); err != nil {
+		return err
+	}
+	if err := refund.succeed(); err != nil {
+		return err
+	}
+
+	p.refunded += refund.Amount()
+	p.updatedAt = time.Now()
+	if p.refunded == p.amount {
+		p.status = StatusRefunded
+		p.record(event.NewPaymentRefunded(p.id, p.auctionID, p.winnerID, refund.ID(), p.amount, refund.Reason()))
+	} else {
+		p.status = StatusPartiallyRefunded
+		p.record(event.NewPaymentPartiallyRefunded(
+			p.id, p.auctionID, p.winnerID, refund.ID(), refund.Amount(), p.refunded, refund.Reason()))
+	}
+	return nil
+}
+
+// FailRefund records a refund the gateway rejected. The failure stays on
+// the refund, which ends failed; the payment goes back to the status it had
+// before the refund started, so held escrow can still be released or
+// refunded and settled funds stay settled. A payment status for the failure
+// would hide that state and block the refund from being retried.
+func (p *Payment) FailRefund(refund *Refund, reason string) error {
+	if err := p.checkPendingRefund(refund); err != nil {
+		return err
+	}
+	if err := refund.fail(); err != nil {
+		return err
+	}
+
+	p.status = p.capturedStatus()
+	p.updatedAt = time.Now()
+	p.record(event.NewPaymentRefundFailed(p.id, p.auctionID, p.winnerID, refund.ID(), refund.Amount(), reason))
+	return nil
+}
+
+// capturedStatus is the status a captured payment holds between refunds.
+// It follows from the escrow and the refunded total, neither of which a
+// refund changes until it succeeds.
+func (p *Payment) capturedStatus() string {
+	switch {
+	case p.escrow.holding():
+		return StatusEscrowHeld
+	case p.refunded > 0:
+		return StatusPartiallyRefunded
+	case !p.escrow.ReleasedAt.IsZero():
+		return StatusEscrowReleased
+	}
+	return StatusCompleted
+}
+
+func (p *Payment) checkPendingRefund(refund *Refund) error {
+	if p.status != StatusRefundPending {
+		return ErrNoRefundPending
+	}
+	if refund.PaymentID() != p.id {
+		return errRefundMismatch
+	}
 	return nil
 }
 
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	AttemptAuthorize = "authorize"
+	AttemptCapture   = "capture"
+)
+
+// PaymentAttempt is one call to the gateway made while charging a payment.
+// Request and Response hold what was exchanged, as JSON, for auditing.
+// Calls recovered after an unknown outcome repeat the attempt number and
+// idempotency key of the call they retry.
+type PaymentAttempt struct {
+	PaymentID      string
+	Attempt        int
+	Operation      string
+	IdempotencyKey string
+	Request        string
+	Response       string
+	Error          string
+	Latency        time.Duration
+	AttemptedAt    time.Time
+}
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errMethodToken          = errors.New("payment method token is required")
+	errMethodCardNumber     = errors.New("payment method token must come from the gateway, not a card number")
+	errMethodBrand          = errors.New("payment method brand is required")
+	errMethodLast4          = errors.New("payment method last4 must be four digits")
+	errMethodExpiry         = errors.New("payment method expiry is invalid")
+	ErrPaymentMethodExpired = errors.New("payment method has expired")
+	ErrPaymentMethodRemoved = errors.New("payment method was removed")
+)
+
+// PaymentMethod is an instrument a user saved with the gateway. Only the
+// gateway's token and what is safe to display are kept; the card number
+// never reaches us. Removed methods are kept so payments that used them can
+// still be traced.
+type PaymentMethod struct {
+	id        string
+	userID    string
+	token     string
+	brand     string
+	last4     string
+	expMonth  int
+	expYear   int
+	isDefault bool
+	createdAt time.Time
+	updatedAt time.Time
+	removedAt time.Time
+}
+
+func NewPaymentMethod(userID, token, brand, last4 string, expMonth, expYear int, now time.Time) (*PaymentMethod, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errMethodToken
+	}
+	if looksLikeCardNumber(token) {
+		return nil, errMethodCardNumber
+	}
+	brand = strings.ToLower(strings.TrimSpace(brand))
+	if brand == "" {
+		return nil, errMethodBrand
+	}
+	if len(last4) != 4 || !isDigits(last4) {
+		return nil, errMethodLast4
+	}
+	if expMonth < 1 || expMonth > 12 || expYear < 2000 || expYear > 9999 {
+		return nil, errMethodExpiry
+	}
+	m := &PaymentMethod{
+		id:        uuid.New().String(),
+		userID:    userID,
+		token:     token,
+		brand:     brand,
+		last4:     last4,
+		expMonth:  expMonth,
+		expYear:   expYear,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if m.IsExpired(now) {
+		return nil, ErrPaymentMethodExpired
+	}
+	return m, nil
+}
+
+func ReconstructPaymentMethod(
+	id, userID, token, brand, last4 string, expMonth, expYear int, isDefault bool,
+	createdAt, updatedAt, removedAt time.Time,
+) *PaymentMethod {
+	return &PaymentMethod{
+		id: id, userID: userID, token: token, brand: brand, last4: last4,
+		expMonth: expMonth, expYear: expYear, isDefault: isDefault,
+		createdAt: createdAt, updatedAt: updatedAt, removedAt: removedAt,
+	}
+}
+
+func (m *PaymentMethod) ID() string           { return m.id }
+func (m *PaymentMethod) UserID() string       { return m.userID }
+func (m *PaymentMethod) Token() string        { return m.token }
+func (m *PaymentMethod) Brand() string        { return m.brand }
+func (m *PaymentMethod) Last4() string        { return m.last4 }
+func (m *PaymentMethod) ExpMonth() int        { return m.expMonth }
+func (m *PaymentMethod) ExpYear() int         { return m.expYear }
+func (m *PaymentMethod) IsDefault() bool      { return m.isDefault }
+func (m *PaymentMethod) CreatedAt() time.Time { return m.createdAt }
+func (m *PaymentMethod) UpdatedAt() time.Time { return m.updatedAt }
+func (m *PaymentMethod) RemovedAt() time.Time { return m.removedAt }
+
+func (m *PaymentMethod) IsOwnedBy(userID string) bool { return m.userID == userID }
+func (m *PaymentMethod) IsRemoved() bool              { return !m.removedAt.IsZero() }
+
+// IsExpired reports whether the card's expiry month has ended.
+func (m *PaymentMethod) IsExpired(now time.Time) bool {
+	end := time.Date(m.expYear, time.Month(m.expMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(end)
+}
+
+// Usable checks the method can be charged by userID at now.
+func (m *PaymentMethod) Usable(now time.Time) error {
+	if m.IsRemoved() {
+		return ErrPaymentMethodRemoved
+	}
+	if m.IsExpired(now) {
+		return ErrPaymentMethodExpired
+	}
+	return nil
+}
+
+func (m *PaymentMethod) MakeDefault() error {
+	if m.IsRemoved() {
+		return ErrPaymentMethodRemoved
+	}
+	m.isDefault = true
+	m.updatedAt = time.Now()
+	return nil
+}
+
+func (m *PaymentMethod) ClearDefault() {
+	m.isDefault = false
+	m.updatedAt = time.Now()
+}
+
+func (m *PaymentMethod) Remove() error {
+	if m.IsRemoved() {
+		return ErrPaymentMethodRemoved
+	}
+	now := time.Now()
+	m.isDefault = false
+	m.removedAt = now
+	m.updatedAt = now
+	return nil
+}
+
+// looksLikeCardNumber catches a raw card number sent where the gateway's
+// token was expected.
+func looksLikeCardNumber(s string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	return len(digits) >= 12 && len(digits) <= 19 && isDigits(digits)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -10,10 +11,11 @@ import (
 var (
 	testAuctionID = uuid.New().String()
 	testWinnerID  = uuid.New().String()
+	testDueAt     = time.Now().Add(48 * time.Hour)
 )
 
 func TestNewPayment(t *testing.T) {
-	payment, err := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, err := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -45,7 +47,7 @@ func TestNewPayment_Invariants(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewPayment(tt.auctionID, tt.winnerID, tt.amount)
+			_, err := NewPayment(tt.auctionID, tt.winnerID, tt.amount, FeeBreakdown{}, 0, testDueAt)
 			if err == nil {
 				t.Errorf("expected error for %s", tt.name)
 			}
@@ -54,7 +56,7 @@ func TestNewPayment_Invariants(t *testing.T) {
 }
 
 func TestPayment_Complete(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 	payment.ClearEvents()
 
 	if err := payment.Complete(); err != nil {
@@ -73,7 +75,7 @@ func TestPayment_Complete(t *testing.T) {
 }
 
 func TestPayment_Fail(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 	payment.ClearEvents()
 
 	if err := payment.Fail("declined"); err != nil {
@@ -87,8 +89,33 @@ func TestPayment_Fail(t *testing.T) {
 	}
 }
 
+func TestPayment_StartProcessing(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	payment.ClearEvents()
+
+	if err := payment.StartProcessing(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !payment.IsProcessing() {
+		t.Errorf("expected status '%s', got '%s'", StatusProcessing, payment.Status())
+	}
+	if len(payment.Events()) != 0 {
+		t.Errorf("expected no events, got %d", len(payment.Events()))
+	}
+	if err := payment.StartProcessing(""); err == nil {
+		t.Error("expected error processing a payment twice")
+	}
+
+	if err := payment.Complete(); err != nil {
+		t.Fatalf("unexpected error completing processing payment: %v", err)
+	}
+	if payment.Status() != StatusCompleted {
+		t.Errorf("expected status '%s', got '%s'", StatusCompleted, payment.Status())
+	}
+}
+
 func TestPayment_Fail_NotPending(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 	if err := payment.Complete(); err != nil {
 		t.Fatal(err)
 	}
@@ -99,7 +126,7 @@ func TestPayment_Fail_NotPending(t *testing.T) {
 }
 
 func TestPayment_IsOwnedBy(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 
 	if !payment.IsOwnedBy(testWinnerID) {
 		t.Error("expected IsOwnedBy to return true for winner")
@@ -110,11 +137,18 @@ func TestPayment_IsOwnedBy(t *testing.T) {
 }
 
 func TestPayment_Refund(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 	payment.ClearEvents()
 	_ = payment.Complete()
 
-	if err := payment.Refund("customer request"); err != nil {
+	refund, err := payment.Refund(5000, "customer request")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusRefundPending || refund.Status() != RefundStatusPending {
+		t.Errorf("expected refund pending, got payment '%s', refund '%s'", payment.Status(), refund.Status())
+	}
+	if err := payment.CompleteRefund(refund); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if payment.Status() != StatusRefunded {
@@ -126,17 +160,177 @@ func TestPayment_Refund(t *testing.T) {
 }
 
 func TestPayment_Refund_NotCompleted(t *testing.T) {
-	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000)
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
 
-	if err := payment.Refund("reason"); err == nil {
+	if _, err := payment.Refund(5000, "reason"); err == nil {
 		t.Error("expected error refunding pending payment")
 	}
 }
 
+func TestPayment_Refund_Partial(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	_ = payment.Complete()
+	payment.ClearEvents()
+
+	refund, err := payment.Refund(1500, "damaged item")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := payment.Refund(500, "second"); err != ErrNotRefundable {
+		t.Errorf("expected ErrNotRefundable while a refund is pending, got %v", err)
+	}
+	if err := payment.CompleteRefund(refund); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Amount() != 1500 || refund.Status() != RefundStatusSucceeded {
+		t.Errorf("unexpected refund amount %d status %q", refund.Amount(), refund.Status())
+	}
+	if payment.Status() != StatusPartiallyRefunded {
+		t.Errorf("expected status '%s', got '%s'", StatusPartiallyRefunded, payment.Status())
+	}
+	if payment.Refundable() != 3500 {
+		t.Errorf("expected refundable 3500, got %d", payment.Refundable())
+	}
+	if len(payment.Events()) != 1 || payment.Events()[0].EventName() != "payment.partially_refunded" {
+		t.Errorf("expected payment.partially_refunded event, got %v", payment.Events())
+	}
+
+	refund, err = payment.Refund(3500, "remainder")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := payment.CompleteRefund(refund); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusRefunded {
+		t.Errorf("expected status '%s', got '%s'", StatusRefunded, payment.Status())
+	}
+	if payment.Events()[1].EventName() != "payment.refunded" {
+		t.Errorf("expected payment.refunded event, got %s", payment.Events()[1].EventName())
+	}
+}
+
+func TestPayment_Refund_ExceedsBalance(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	_ = payment.Complete()
+	refund, _ := payment.Refund(4000, "partial")
+	_ = payment.CompleteRefund(refund)
+
+	if _, err := payment.Refund(1001, "too much"); err != ErrRefundExceedsBalance {
+		t.Errorf("expected ErrRefundExceedsBalance, got %v", err)
+	}
+	if _, err := payment.Refund(0, "nothing"); err != ErrInvalidRefundAmount {
+		t.Errorf("expected ErrInvalidRefundAmount, got %v", err)
+	}
+	if payment.Refunded() != 4000 {
+		t.Errorf("expected refunded 4000, got %d", payment.Refunded())
+	}
+}
+
+func TestPayment_FailRefund(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	_ = payment.Complete()
+	payment.ClearEvents()
+	refund, _ := payment.Refund(5000, "customer request")
+
+	if err := payment.FailRefund(refund, "card closed"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusCompleted || refund.Status() != RefundStatusFailed {
+		t.Errorf("expected completed payment and failed refund, got payment '%s', refund '%s'", payment.Status(), refund.Status())
+	}
+	if len(payment.Events()) != 1 || payment.Events()[0].EventName() != "payment.refund_failed" {
+		t.Errorf("expected payment.refund_failed event, got %v", payment.Events())
+	}
+	if payment.Refunded() != 0 {
+		t.Errorf("expected nothing refunded, got %d", payment.Refunded())
+	}
+	if _, err := payment.Refund(5000, "try again"); err != nil {
+		t.Errorf("expected a failed refund to be retryable, got %v", err)
+	}
+}
+
+func TestPayment_Escrow(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 72*time.Hour, testDueAt)
+	if err := payment.Complete(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusEscrowHeld || payment.IsSettleable() {
+		t.Fatalf("expected held, unsettleable payment, got '%s'", payment.Status())
+	}
+	if payment.IsEscrowDue(time.Now()) || !payment.IsEscrowDue(time.Now().Add(73*time.Hour)) {
+		t.Error("expected escrow to fall due only after the hold")
+	}
+	if _, err := payment.Refund(1000, "partial"); err != ErrPartialEscrowRefund {
+		t.Errorf("expected ErrPartialEscrowRefund, got %v", err)
+	}
+
+	if err := payment.ReleaseEscrow(EscrowReleaseConfirmed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusEscrowReleased || !payment.IsSettleable() {
+		t.Errorf("expected released, settleable payment, got '%s'", payment.Status())
+	}
+	if err := payment.ReleaseEscrow(EscrowReleaseConfirmed); err != ErrNotHeld {
+		t.Errorf("expected ErrNotHeld, got %v", err)
+	}
+	events := payment.Events()
+	if len(events) != 3 || events[2].EventName() != "payment.escrow_released" {
+		t.Errorf("expected payment.escrow_released event, got %v", events)
+	}
+}
+
+func TestPayment_FailRefund_ReturnsToEscrow(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, time.Hour, testDueAt)
+	_ = payment.Complete()
+	refund, _ := payment.Refund(5000, "not delivered")
+
+	if err := payment.FailRefund(refund, "card closed"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != StatusEscrowHeld {
+		t.Errorf("expected status '%s', got '%s'", StatusEscrowHeld, payment.Status())
+	}
+}
+
+func TestPayment_FailRefund_RestoresStatus(t *testing.T) {
+	partial, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	_ = partial.Complete()
+	first, _ := partial.Refund(1000, "damaged box")
+	_ = partial.CompleteRefund(first)
+
+	released, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, time.Hour, testDueAt)
+	_ = released.Complete()
+	_ = released.ReleaseEscrow(EscrowReleaseConfirmed)
+
+	tests := []struct {
+		name    string
+		payment *Payment
+		want    string
+	}{
+		{"partially refunded", partial, StatusPartiallyRefunded},
+		{"escrow released", released, StatusEscrowReleased},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund, err := tt.payment.Refund(1000, "customer request")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tt.payment.FailRefund(refund, "card closed"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.payment.Status() != tt.want {
+				t.Errorf("expected status '%s', got '%s'", tt.want, tt.payment.Status())
+			}
+		})
+	}
+}
+
 func TestReconstructPayment(t *testing.T) {
 	id := uuid.New().String()
 	now := time.Now()
-	payment := ReconstructPayment(id, testAuctionID, testWinnerID, 5000, StatusCompleted, now, now)
+	payment := ReconstructPayment(id, testAuctionID, testWinnerID, 5000, FeeBreakdown{}, Escrow{}, StatusCompleted, "", now.Add(48*time.Hour), 0, 1, 0, now, now)
 
 	if payment.ID() != id {
 		t.Errorf("expected ID '%s', got '%s'", id, payment.ID())
@@ -148,3 +342,233 @@ func TestReconstructPayment(t *testing.T) {
 		t.Error("reconstructed payment should have no events")
 	}
 }
+
+func TestNewPayment_DueDateInPast(t *testing.T) {
+	if _, err := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, time.Now().Add(-time.Minute)); err == nil {
+		t.Error("expected error for due date in the past")
+	}
+}
+
+func TestPayment_IsOverdue(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+
+	if payment.IsOverdue(time.Now()) {
+		t.Error("payment should not be overdue before its due date")
+	}
+	if !payment.IsOverdue(testDueAt) {
+		t.Error("payment should be overdue at its due date")
+	}
+	_ = payment.Complete()
+	if payment.IsOverdue(testDueAt.Add(time.Hour)) {
+		t.Error("completed payment should never be overdue")
+	}
+}
+
+func TestPayment_Remind(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	payment.ClearEvents()
+
+	if err := payment.Remind(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.RemindersSent() != 1 {
+		t.Errorf("expected 1 reminder sent, got %d", payment.RemindersSent())
+	}
+	if len(payment.Events()) != 1 || payment.Events()[0].EventName() != "payment.reminder" {
+		t.Errorf("expected payment.reminder event, got %v", payment.Events())
+	}
+	if err := payment.Remind(1); err == nil {
+		t.Error("expected error sending the same reminder twice")
+	}
+}
+
+func TestNewJournalEntry(t *testing.T) {
+	entry, err := NewJournalEntry(EntryKindSale, "payment-1",
+		Debit(AccountGatewayClearing, "", 1000),
+		Credit(AccountPlatformRevenue, "", 0),
+		Credit(AccountSellerPayable, "seller-1", 1000),
+	)
+	if err != nil {
+		t.Fatalf("NewJournalEntry() error = %v", err)
+	}
+	if len(entry.Postings()) != 2 {
+		t.Errorf("Postings() = %v, want the zero posting dropped", entry.Postings())
+	}
+
+	if _, err := NewJournalEntry(EntryKindSale, "payment-1",
+		Debit(AccountGatewayClearing, "", 1000),
+		Credit(AccountSellerPayable, "seller-1", 900),
+	); err != ErrUnbalancedEntry {
+		t.Errorf("expected ErrUnbalancedEntry, got %v", err)
+	}
+}
+
+func TestPayout_MarkPaid(t *testing.T) {
+	payout, err := NewPayout("seller-1", 9500)
+	if err != nil {
+		t.Fatalf("NewPayout() error = %v", err)
+	}
+	if err := payout.MarkPaid("po-1"); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	if payout.Status() != PayoutStatusPaid || payout.TransferID() != "po-1" {
+		t.Errorf("payout = %s/%s, want paid/po-1", payout.Status(), payout.TransferID())
+	}
+	if len(payout.Events()) != 1 || payout.Events()[0].EventName() != "payout.completed" {
+		t.Errorf("Events() = %v, want payout.completed", payout.Events())
+	}
+	if err := payout.MarkFailed("late decline"); err != ErrPayoutNotPending {
+		t.Errorf("expected ErrPayoutNotPending, got %v", err)
+	}
+}
+
+func TestNewPayout_InvalidAmount(t *testing.T) {
+	if _, err := NewPayout("seller-1", 0); err == nil {
+		t.Error("expected error for zero payout")
+	}
+}
+
+func TestNewPayment_Fee(t *testing.T) {
+	p, err := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{Amount: 250, Rule: "percentage:500"}, 0, testDueAt)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	if p.SellerAmount() != 4750 {
+		t.Errorf("SellerAmount() = %d, want 4750", p.SellerAmount())
+	}
+
+	if _, err := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{Amount: 5001}, 0, testDueAt); err == nil {
+		t.Error("expected error for fee above amount")
+	}
+}
+
+func TestNewInvoice(t *testing.T) {
+	p, _ := NewPayment(testAuctionID, testWinnerID, 11000, FeeBreakdown{Amount: 550, Rule: "percentage:500"}, 0, testDueAt)
+	if err := p.Complete(); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	buyer := InvoiceParty{ID: testWinnerID, Name: "Buyer", Email: "buyer@example.com"}
+	seller := InvoiceParty{ID: "seller-1", Name: "Seller", Email: "seller@example.com"}
+
+	inv, err := NewInvoice(42, p, buyer, seller, 1000)
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+	if inv.Number() != "INV-00000042" {
+		t.Errorf("Number() = %q, want INV-00000042", inv.Number())
+	}
+	want := map[string]int64{LineHammerPrice: 11000, LinePlatformFee: 550, LineTax: 1000}
+	for _, l := range inv.Lines() {
+		if l.Amount != want[l.Kind] {
+			t.Errorf("%s = %d, want %d", l.Kind, l.Amount, want[l.Kind])
+		}
+	}
+	if inv.Total() != 11000 || inv.SellerNet() != 10450 {
+		t.Errorf("Total/SellerNet = %d/%d, want 11000/10450", inv.Total(), inv.SellerNet())
+	}
+	if !inv.IsVisibleTo("seller-1") || inv.IsVisibleTo("stranger") {
+		t.Error("invoice should be visible to buyer and seller only")
+	}
+	if len(inv.Events()) != 1 || inv.Events()[0].EventName() != "payment.invoice_issued" {
+		t.Errorf("Events() = %v, want payment.invoice_issued", inv.Events())
+	}
+}
+
+func TestNewInvoice_NotCaptured(t *testing.T) {
+	p, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	party := InvoiceParty{ID: testWinnerID}
+
+	if _, err := NewInvoice(1, p, party, party, 0); err != ErrNotInvoiceable {
+		t.Errorf("expected ErrNotInvoiceable, got %v", err)
+	}
+}
+
+func TestDispute_Lifecycle(t *testing.T) {
+	payment, _ := NewPayment(testAuctionID, testWinnerID, 5000, FeeBreakdown{}, 0, testDueAt)
+	if _, err := NewDispute(payment, "seller-1", "never arrived", time.Now().Add(time.Hour)); err != ErrNotDisputable {
+		t.Errorf("expected ErrNotDisputable for an uncaptured payment, got %v", err)
+	}
+	_ = payment.Complete()
+
+	dispute, err := NewDispute(payment, "seller-1", "never arrived", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dispute.IsActive() || dispute.BuyerID() != testWinnerID {
+		t.Errorf("expected an active dispute for the buyer, got '%s'", dispute.Status())
+	}
+	if dispute.IsResponseOverdue(time.Now()) || !dispute.IsResponseOverdue(time.Now().Add(2*time.Hour)) {
+		t.Error("expected the response to fall due only after the deadline")
+	}
+
+	if err := dispute.Escalate(time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dispute.Respond("too late", time.Now()); err != ErrDisputeNotOpen {
+		t.Errorf("expected ErrDisputeNotOpen, got %v", err)
+	}
+	if err := dispute.Resolve(DisputeResolutionPartial, 0); err != ErrInvalidDisputeAmount {
+		t.Errorf("expected ErrInvalidDisputeAmount, got %v", err)
+	}
+	if err := dispute.Resolve(DisputeResolutionReject, 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dispute.IsActive() || dispute.RefundAmount() != 0 {
+		t.Errorf("expected a rejected dispute with no refund, got '%s' %d", dispute.Status(), dispute.RefundAmount())
+	}
+
+	names := make([]string, 0, len(dispute.Events()))
+	for _, e := range dispute.Events() {
+		names = append(names, e.EventName())
+	}
+	want := []string{"dispute.opened", "dispute.escalated", "dispute.resolved"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Errorf("events = %v, want %v", names, want)
+	}
+}
+
+func TestNewPaymentMethod(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		token    string
+		last4    string
+		expMonth int
+		expYear  int
+		wantErr  error
+	}{
+		{"valid", "tok_visa", "4242", 3, 2026, nil},
+		{"card number instead of token", "4242 4242 4242 4242", "4242", 12, 2030, errMethodCardNumber},
+		{"missing token", "", "4242", 12, 2030, errMethodToken},
+		{"bad last4", "tok_visa", "42a2", 12, 2030, errMethodLast4},
+		{"bad month", "tok_visa", "4242", 13, 2030, errMethodExpiry},
+		{"expired", "tok_visa", "4242", 2, 2026, ErrPaymentMethodExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, err := NewPaymentMethod(testWinnerID, tt.token, "Visa", tt.last4, tt.expMonth, tt.expYear, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewPaymentMethod() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (method.Brand() != "visa" || method.IsDefault()) {
+				t.Errorf("unexpected method %+v", method)
+			}
+		})
+	}
+}
+
+func TestPaymentMethod_Remove(t *testing.T) {
+	method, _ := NewPaymentMethod(testWinnerID, "tok_visa", "visa", "4242", 12, time.Now().Year()+1, time.Now())
+	_ = method.MakeDefault()
+
+	if err := method.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if method.IsDefault() || !errors.Is(method.Usable(time.Now()), ErrPaymentMethodRemoved) {
+		t.Errorf("expected a removed method to be unusable and not default")
+	}
+	if err := method.MakeDefault(); !errors.Is(err, ErrPaymentMethodRemoved) {
+		t.Errorf("expected ErrPaymentMethodRemoved, got %v", err)
+	}
+}
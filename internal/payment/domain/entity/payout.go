@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/event"
+)
+
+const (
+	PayoutStatusPending = "pending"
+	PayoutStatusPaid    = "paid"
+	PayoutStatusFailed  = "failed"
+)
+
+var (
+	errInvalidSeller       = errors.New("seller ID is required")
+	errInvalidPayoutAmount = errors.New("payout amount must be positive")
+	ErrPayoutNotPending    = errors.New("payout is not in pending status")
+)
+
+type Payout struct {
+	id         string
+	sellerID   string
+	amount     int64
+	status     string
+	transferID string
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	events []event.Event
+}
+
+func NewPayout(sellerID string, amount int64) (*Payout, error) {
+	if sellerID == "" {
+		return nil, errInvalidSeller
+	}
+	if amount <= 0 {
+		return nil, errInvalidPayoutAmount
+	}
+	now := time.Now()
+	return &Payout{
+		id:        uuid.New().String(),
+		sellerID:  sellerID,
+		amount:    amount,
+		status:    PayoutStatusPending,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructPayout(id, sellerID string, amount int64, status, transferID string, createdAt, updatedAt time.Time) *Payout {
+	return &Payout{
+		id: id, sellerID: sellerID, amount: amount, status: status,
+		transferID: transferID, createdAt: createdAt, updatedAt: updatedAt,
+	}
+}
+
+func (p *Payout) ID() string           { return p.id }
+func (p *Payout) SellerID() string     { return p.sellerID }
+func (p *Payout) Amount() int64        { return p.amount }
+func (p *Payout) Status() string       { return p.status }
+func (p *Payout) TransferID() string   { return p.transferID }
+func (p *Payout) CreatedAt() time.Time { return p.createdAt }
+func (p *Payout) UpdatedAt() time.Time { return p.updatedAt }
+
+func (p *Payout) MarkPaid(transferID string) error {
+	if p.status != PayoutStatusPending {
+		return ErrPayoutNotPending
+	}
+	p.status = PayoutStatusPaid
+	p.transferID = transferID
+	p.updatedAt = time.Now()
+	p.record(event.NewPayoutCompleted(p.id, p.sellerID, p.amount, transferID))
+	return nil
+}
+
+func (p *Payout) MarkFailed(reason string) error {
+	if p.status != PayoutStatusPending {
+		return ErrPayoutNotPending
+	}
+	p.status = PayoutStatusFailed
+	p.updatedAt = time.Now()
+	p.record(event.NewPayoutFailed(p.id, p.sellerID, p.amount, reason))
+	return nil
+}
+
+func (p *Payout) Events() []event.Event { return p.events }
+func (p *Payout) ClearEvents()          { p.events = nil }
+func (p *Payout) record(e event.Event)  { p.events = append(p.events, e) }
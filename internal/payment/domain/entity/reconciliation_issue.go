@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/event"
+)
+
+const (
+	// IssueMissingCapture: we consider the payment captured but the gateway
+	// has no capture for it.
+	IssueMissingCapture = "missing_capture"
+	// IssueAmountMismatch: the gateway moved a different amount than we
+	// recorded.
+	IssueAmountMismatch = "amount_mismatch"
+	// IssueOrphanCharge: the gateway captured money for a payment we do not
+	// know or never completed.
+	IssueOrphanCharge = "orphan_charge"
+	// IssueOrphanRefund: the gateway refunded money we have no successful
+	// refund for.
+	IssueOrphanRefund = "orphan_refund"
+)
+
+func IsKnownIssueKind(kind string) bool {
+	switch kind {
+	case IssueMissingCapture, IssueAmountMismatch, IssueOrphanCharge, IssueOrphanRefund:
+		return true
+	}
+	return false
+}
+
+type ReconciliationIssue struct {
+	id            string
+	kind          string
+	paymentID     string
+	transactionID string
+	expected      int64
+	actual        int64
+	detectedAt    time.Time
+
+	events []event.Event
+}
+
+func NewReconciliationIssue(kind, paymentID, transactionID string, expected, actual int64) *ReconciliationIssue {
+	i := &ReconciliationIssue{
+		id:            uuid.New().String(),
+		kind:          kind,
+		paymentID:     paymentID,
+		transactionID: transactionID,
+		expected:      expected,
+		actual:        actual,
+		detectedAt:    time.Now(),
+	}
+	i.record(event.NewReconciliationIssueDetected(i.id, kind, paymentID, transactionID, expected, actual))
+	return i
+}
+
+func ReconstructReconciliationIssue(id, kind, paymentID, transactionID string, expected, actual int64, detectedAt time.Time) *ReconciliationIssue {
+	return &ReconciliationIssue{
+		id: id, kind: kind, paymentID: paymentID, transactionID: transactionID,
+		expected: expected, actual: actual, detectedAt: detectedAt,
+	}
+}
+
+func (i *ReconciliationIssue) ID() string            { return i.id }
+func (i *ReconciliationIssue) Kind() string          { return i.kind }
+func (i *ReconciliationIssue) PaymentID() string     { return i.paymentID }
+func (i *ReconciliationIssue) TransactionID() string { return i.transactionID }
+func (i *ReconciliationIssue) Expected() int64       { return i.expected }
+func (i *ReconciliationIssue) Actual() int64         { return i.actual }
+func (i *ReconciliationIssue) DetectedAt() time.Time { return i.detectedAt }
+
+func (i *ReconciliationIssue) Events() []event.Event { return i.events }
+func (i *ReconciliationIssue) ClearEvents()          { i.events = nil }
+func (i *ReconciliationIssue) record(e event.Event)  { i.events = append(i.events, e) }
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+var ErrRefundNotPending = errors.New("refund is not in pending status")
+
+type Refund struct {
+	id            string
+	paymentID     string
+	amount        int64
+	reason        string
+	status        string
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// RefundAttempt is one call to the gateway made for a refund.
+type RefundAttempt struct {
+	RefundID    string
+	Number      int
+	Error       string
+	AttemptedAt time.Time
+}
+
+func newRefund(paymentID string, amount int64, reason string) *Refund {
+	now := time.Now()
+	return &Refund{
+		id:            uuid.New().String(),
+		paymentID:     paymentID,
+		amount:        amount,
+		reason:        reason,
+		status:        RefundStatusPending,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}
+}
+
+func ReconstructRefund(
+	id, paymentID string, amount int64, reason, status string,
+	attempts int, lastError string, nextAttemptAt, createdAt, updatedAt time.Time,
+) *Refund {
+	return &Refund{
+		id: id, paymentID: paymentID, amount: amount, reason: reason, status: status,
+		attempts: attempts, lastError: lastError, nextAttemptAt: nextAttemptAt,
+		createdAt: createdAt, updatedAt: updatedAt,
+	}
+}
+
+func (r *Refund) ID() string               { return r.id }
+func (r *Refund) PaymentID() string        { return r.paymentID }
+func (r *Refund) Amount() int64            { return r.amount }
+func (r *Refund) Reason() string           { return r.reason }
+func (r *Refund) Status() string           { return r.status }
+func (r *Refund) Attempts() int            { return r.attempts }
+func (r *Refund) LastError() string        { return r.lastError }
+func (r *Refund) NextAttemptAt() time.Time { return r.nextAttemptAt }
+func (r *Refund) CreatedAt() time.Time     { return r.createdAt }
+func (r *Refund) UpdatedAt() time.Time     { return r.updatedAt }
+
+func (r *Refund) IsPending() bool   { return r.status == RefundStatusPending }
+func (r *Refund) IsSucceeded() bool { return r.status == RefundStatusSucceeded }
+
+// RecordAttempt counts a gateway call; a nil error means it succeeded.
+func (r *Refund) RecordAttempt(err error) (RefundAttempt, error) {
+	if !r.IsPending() {
+		return RefundAttempt{}, ErrRefundNotPending
+	}
+	r.attempts++
+	r.lastError = ""
+	if err != nil {
+		r.lastError = err.Error()
+	}
+	r.updatedAt = time.Now()
+	return RefundAttempt{RefundID: r.id, Number: r.attempts, Error: r.lastError, AttemptedAt: r.updatedAt}, nil
+}
+
+func (r *Refund) RetryAt(at time.Time) error {
+	if !r.IsPending() {
+		return ErrRefundNotPending
+	}
+	r.nextAttemptAt = at
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *Refund) succeed() error {
+	if !r.IsPending() {
+		return ErrRefundNotPending
+	}
+	r.status = RefundStatusSucceeded
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *Refund) fail() error {
+	if !r.IsPending() {
+		return ErrRefundNotPending
+	}
+	r.status = RefundStatusFailed
+	r.updatedAt = time.Now()
+	return nil
+}
@@ -10,17 +10,21 @@ type Event = sharedevent.Event
 type StoredEvent = sharedevent.StoredEvent
 
 type PaymentCreated struct {
-	PaymentID string    `json:"payment_id"`
-	AuctionID string    `json:"auction_id"`
-	WinnerID  string    `json:"winner_id"`
-	Amount    int64     `json:"amount"`
-	Timestamp time.Time `json:"occurred_at"`
+	PaymentID    string    `json:"payment_id"`
+	AuctionID    string    `json:"auction_id"`
+	WinnerID     string    `json:"winner_id"`
+	Amount       int64     `json:"amount"`
+	Fee          int64     `json:"fee"`
+	FeeRule      string    `json:"fee_rule"`
+	SellerAmount int64     `json:"seller_amount"`
+	Timestamp    time.Time `json:"occurred_at"`
 }
 
-func NewPaymentCreated(paymentID, auctionID, winnerID string, amount int64) PaymentCreated {
+func NewPaymentCreated(paymentID, auctionID, winnerID string, amount, fee int64, feeRule string) PaymentCreated {
 	return PaymentCreated{
 		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
-		Amount: amount, Timestamp: time.Now(),
+		Amount: amount, Fee: fee, FeeRule: feeRule, SellerAmount: amount - fee,
+		Timestamp: time.Now(),
 	}
 }
 
@@ -28,18 +32,25 @@ func (e PaymentCreated) EventName() string     { return "payment.created" }
 func (e PaymentCreated) AggregateID() string   { return e.PaymentID }
 func (e PaymentCreated) OccurredAt() time.Time { return e.Timestamp }
 
+// PaymentCompleted is published when the buyer's money is captured. With
+// Escrow set the funds are held and the sale settles on PaymentEscrowReleased.
 type PaymentCompleted struct {
-	PaymentID string    `json:"payment_id"`
-	AuctionID string    `json:"auction_id"`
-	WinnerID  string    `json:"winner_id"`
-	Amount    int64     `json:"amount"`
-	Timestamp time.Time `json:"occurred_at"`
+	PaymentID    string    `json:"payment_id"`
+	AuctionID    string    `json:"auction_id"`
+	WinnerID     string    `json:"winner_id"`
+	Amount       int64     `json:"amount"`
+	Fee          int64     `json:"fee"`
+	FeeRule      string    `json:"fee_rule"`
+	SellerAmount int64     `json:"seller_amount"`
+	Escrow       bool      `json:"escrow"`
+	Timestamp    time.Time `json:"occurred_at"`
 }
 
-func NewPaymentCompleted(paymentID, auctionID, winnerID string, amount int64) PaymentCompleted {
+func NewPaymentCompleted(paymentID, auctionID, winnerID string, amount, fee int64, feeRule string, escrow bool) PaymentCompleted {
 	return PaymentCompleted{
 		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
-		Amount: amount, Timestamp: time.Now(),
+		Amount: amount, Fee: fee, FeeRule: feeRule, SellerAmount: amount - fee,
+		Escrow: escrow, Timestamp: time.Now(),
 	}
 }
 
@@ -71,18 +82,280 @@ type PaymentRefunded struct {
 	PaymentID string    `json:"payment_id"`
 	AuctionID string    `json:"auction_id"`
 	WinnerID  string    `json:"winner_id"`
+	RefundID  string    `json:"refund_id"`
 	Amount    int64     `json:"amount"`
 	Reason    string    `json:"reason"`
 	Timestamp time.Time `json:"occurred_at"`
 }
 
-func NewPaymentRefunded(paymentID, auctionID, winnerID string, amount int64, reason string) PaymentRefunded {
+func NewPaymentRefunded(paymentID, auctionID, winnerID, refundID string, amount int64, reason string) PaymentRefunded {
 	return PaymentRefunded{
 		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
-		Amount: amount, Reason: reason, Timestamp: time.Now(),
+		RefundID: refundID, Amount: amount, Reason: reason, Timestamp: time.Now(),
 	}
 }
 
 func (e PaymentRefunded) EventName() string     { return "payment.refunded" }
 func (e PaymentRefunded) AggregateID() string   { return e.PaymentID }
 func (e PaymentRefunded) OccurredAt() time.Time { return e.Timestamp }
+
+type PaymentPartiallyRefunded struct {
+	PaymentID     string    `json:"payment_id"`
+	AuctionID     string    `json:"auction_id"`
+	WinnerID      string    `json:"winner_id"`
+	RefundID      string    `json:"refund_id"`
+	Amount        int64     `json:"amount"`
+	RefundedTotal int64     `json:"refunded_total"`
+	Reason        string    `json:"reason"`
+	Timestamp     time.Time `json:"occurred_at"`
+}
+
+func NewPaymentPartiallyRefunded(
+	paymentID, auctionID, winnerID, refundID string, amount, refundedTotal int64, reason string,
+) PaymentPartiallyRefunded {
+	return PaymentPartiallyRefunded{
+		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID, RefundID: refundID,
+		Amount: amount, RefundedTotal: refundedTotal, Reason: reason, Timestamp: time.Now(),
+	}
+}
+
+func (e PaymentPartiallyRefunded) EventName() string     { return "payment.partially_refunded" }
+func (e PaymentPartiallyRefunded) AggregateID() string   { return e.PaymentID }
+func (e PaymentPartiallyRefunded) OccurredAt() time.Time { return e.Timestamp }
+
+type PaymentRefundFailed struct {
+	PaymentID string    `json:"payment_id"`
+	AuctionID string    `json:"auction_id"`
+	WinnerID  string    `json:"winner_id"`
+	RefundID  string    `json:"refund_id"`
+	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewPaymentRefundFailed(paymentID, auctionID, winnerID, refundID string, amount int64, reason string) PaymentRefundFailed {
+	return PaymentRefundFailed{
+		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
+		RefundID: refundID, Amount: amount, Reason: reason, Timestamp: time.Now(),
+	}
+}
+
+func (e PaymentRefundFailed) EventName() string     { return "payment.refund_failed" }
+func (e PaymentRefundFailed) AggregateID() string   { return e.PaymentID }
+func (e PaymentRefundFailed) OccurredAt() time.Time { return e.Timestamp }
+
+type PaymentReminder struct {
+	PaymentID string    `json:"payment_id"`
+	AuctionID string    `json:"auction_id"`
+	WinnerID  string    `json:"winner_id"`
+	Amount    int64     `json:"amount"`
+	DueAt     time.Time `json:"due_at"`
+	Reminder  int       `json:"reminder"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewPaymentReminder(paymentID, auctionID, winnerID string, amount int64, dueAt time.Time, reminder int) PaymentReminder {
+	return PaymentReminder{
+		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
+		Amount: amount, DueAt: dueAt, Reminder: reminder, Timestamp: time.Now(),
+	}
+}
+
+func (e PaymentReminder) EventName() string     { return "payment.reminder" }
+func (e PaymentReminder) AggregateID() string   { return e.PaymentID }
+func (e PaymentReminder) OccurredAt() time.Time { return e.Timestamp }
+
+type PayoutCompleted struct {
+	PayoutID   string    `json:"payout_id"`
+	SellerID   string    `json:"seller_id"`
+	Amount     int64     `json:"amount"`
+	TransferID string    `json:"transfer_id"`
+	Timestamp  time.Time `json:"occurred_at"`
+}
+
+func NewPayoutCompleted(payoutID, sellerID string, amount int64, transferID string) PayoutCompleted {
+	return PayoutCompleted{
+		PayoutID: payoutID, SellerID: sellerID, Amount: amount,
+		TransferID: transferID, Timestamp: time.Now(),
+	}
+}
+
+func (e PayoutCompleted) EventName() string     { return "payout.completed" }
+func (e PayoutCompleted) AggregateID() string   { return e.PayoutID }
+func (e PayoutCompleted) OccurredAt() time.Time { return e.Timestamp }
+
+type PayoutFailed struct {
+	PayoutID  string    `json:"payout_id"`
+	SellerID  string    `json:"seller_id"`
+	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewPayoutFailed(payoutID, sellerID string, amount int64, reason string) PayoutFailed {
+	return PayoutFailed{
+		PayoutID: payoutID, SellerID: sellerID, Amount: amount,
+		Reason: reason, Timestamp: time.Now(),
+	}
+}
+
+func (e PayoutFailed) EventName() string     { return "payout.failed" }
+func (e PayoutFailed) AggregateID() string   { return e.PayoutID }
+func (e PayoutFailed) OccurredAt() time.Time { return e.Timestamp }
+
+type ReconciliationIssueDetected struct {
+	IssueID       string    `json:"issue_id"`
+	Kind          string    `json:"kind"`
+	PaymentID     string    `json:"payment_id"`
+	TransactionID string    `json:"transaction_id"`
+	Expected      int64     `json:"expected"`
+	Actual        int64     `json:"actual"`
+	Timestamp     time.Time `json:"occurred_at"`
+}
+
+func NewReconciliationIssueDetected(issueID, kind, paymentID, transactionID string, expected, actual int64) ReconciliationIssueDetected {
+	return ReconciliationIssueDetected{
+		IssueID: issueID, Kind: kind, PaymentID: paymentID, TransactionID: transactionID,
+		Expected: expected, Actual: actual, Timestamp: time.Now(),
+	}
+}
+
+func (e ReconciliationIssueDetected) EventName() string     { return "payment.reconciliation_issue_detected" }
+func (e ReconciliationIssueDetected) AggregateID() string   { return e.IssueID }
+func (e ReconciliationIssueDetected) OccurredAt() time.Time { return e.Timestamp }
+
+type InvoiceIssued struct {
+	InvoiceID string    `json:"invoice_id"`
+	Number    string    `json:"number"`
+	PaymentID string    `json:"payment_id"`
+	BuyerID   string    `json:"buyer_id"`
+	SellerID  string    `json:"seller_id"`
+	Total     int64     `json:"total"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewInvoiceIssued(invoiceID, number, paymentID, buyerID, sellerID string, total int64) InvoiceIssued {
+	return InvoiceIssued{
+		InvoiceID: invoiceID, Number: number, PaymentID: paymentID,
+		BuyerID: buyerID, SellerID: sellerID, Total: total, Timestamp: time.Now(),
+	}
+}
+
+func (e InvoiceIssued) EventName() string     { return "payment.invoice_issued" }
+func (e InvoiceIssued) AggregateID() string   { return e.InvoiceID }
+func (e InvoiceIssued) OccurredAt() time.Time { return e.Timestamp }
+
+type PaymentEscrowReleased struct {
+	PaymentID    string    `json:"payment_id"`
+	AuctionID    string    `json:"auction_id"`
+	WinnerID     string    `json:"winner_id"`
+	SellerAmount int64     `json:"seller_amount"`
+	Reason       string    `json:"reason"`
+	Timestamp    time.Time `json:"occurred_at"`
+}
+
+func NewPaymentEscrowReleased(paymentID, auctionID, winnerID string, sellerAmount int64, reason string) PaymentEscrowReleased {
+	return PaymentEscrowReleased{
+		PaymentID: paymentID, AuctionID: auctionID, WinnerID: winnerID,
+		SellerAmount: sellerAmount, Reason: reason, Timestamp: time.Now(),
+	}
+}
+
+func (e PaymentEscrowReleased) EventName() string     { return "payment.escrow_released" }
+func (e PaymentEscrowReleased) AggregateID() string   { return e.PaymentID }
+func (e PaymentEscrowReleased) OccurredAt() time.Time { return e.Timestamp }
+
+type DisputeOpened struct {
+	DisputeID string    `json:"dispute_id"`
+	PaymentID string    `json:"payment_id"`
+	BuyerID   string    `json:"buyer_id"`
+	SellerID  string    `json:"seller_id"`
+	Reason    string    `json:"reason"`
+	RespondBy time.Time `json:"respond_by"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewDisputeOpened(disputeID, paymentID, buyerID, sellerID, reason string, respondBy time.Time) DisputeOpened {
+	return DisputeOpened{
+		DisputeID: disputeID, PaymentID: paymentID, BuyerID: buyerID, SellerID: sellerID,
+		Reason: reason, RespondBy: respondBy, Timestamp: time.Now(),
+	}
+}
+
+func (e DisputeOpened) EventName() string     { return "dispute.opened" }
+func (e DisputeOpened) AggregateID() string   { return e.DisputeID }
+func (e DisputeOpened) OccurredAt() time.Time { return e.Timestamp }
+
+type DisputeResponded struct {
+	DisputeID string    `json:"dispute_id"`
+	PaymentID string    `json:"payment_id"`
+	SellerID  string    `json:"seller_id"`
+	ReviewBy  time.Time `json:"review_by"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewDisputeResponded(disputeID, paymentID, sellerID string, reviewBy time.Time) DisputeResponded {
+	return DisputeResponded{
+		DisputeID: disputeID, PaymentID: paymentID, SellerID: sellerID,
+		ReviewBy: reviewBy, Timestamp: time.Now(),
+	}
+}
+
+func (e DisputeResponded) EventName() string     { return "dispute.responded" }
+func (e DisputeResponded) AggregateID() string   { return e.DisputeID }
+func (e DisputeResponded) OccurredAt() time.Time { return e.Timestamp }
+
+type DisputeEscalated struct {
+	DisputeID string    `json:"dispute_id"`
+	PaymentID string    `json:"payment_id"`
+	Reason    string    `json:"reason"`
+	ReviewBy  time.Time `json:"review_by"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewDisputeEscalated(disputeID, paymentID, reason string, reviewBy time.Time) DisputeEscalated {
+	return DisputeEscalated{
+		DisputeID: disputeID, PaymentID: paymentID, Reason: reason,
+		ReviewBy: reviewBy, Timestamp: time.Now(),
+	}
+}
+
+func (e DisputeEscalated) EventName() string     { return "dispute.escalated" }
+func (e DisputeEscalated) AggregateID() string   { return e.DisputeID }
+func (e DisputeEscalated) OccurredAt() time.Time { return e.Timestamp }
+
+type DisputeReviewOverdue struct {
+	DisputeID string    `json:"dispute_id"`
+	PaymentID string    `json:"payment_id"`
+	ReviewBy  time.Time `json:"review_by"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewDisputeReviewOverdue(disputeID, paymentID string, reviewBy time.Time) DisputeReviewOverdue {
+	return DisputeReviewOverdue{DisputeID: disputeID, PaymentID: paymentID, ReviewBy: reviewBy, Timestamp: time.Now()}
+}
+
+func (e DisputeReviewOverdue) EventName() string     { return "dispute.review_overdue" }
+func (e DisputeReviewOverdue) AggregateID() string   { return e.DisputeID }
+func (e DisputeReviewOverdue) OccurredAt() time.Time { return e.Timestamp }
+
+type DisputeResolved struct {
+	DisputeID    string    `json:"dispute_id"`
+	PaymentID    string    `json:"payment_id"`
+	BuyerID      string    `json:"buyer_id"`
+	SellerID     string    `json:"seller_id"`
+	Resolution   string    `json:"resolution"`
+	RefundAmount int64     `json:"refund_amount"`
+	Timestamp    time.Time `json:"occurred_at"`
+}
+
+func NewDisputeResolved(disputeID, paymentID, buyerID, sellerID, resolution string, refundAmount int64) DisputeResolved {
+	return DisputeResolved{
+		DisputeID: disputeID, PaymentID: paymentID, BuyerID: buyerID, SellerID: sellerID,
+		Resolution: resolution, RefundAmount: refundAmount, Timestamp: time.Now(),
+	}
+}
+
+func (e DisputeResolved) EventName() string     { return "dispute.resolved" }
+func (e DisputeResolved) AggregateID() string   { return e.DisputeID }
+func (e DisputeResolved) OccurredAt() time.Time { return e.Timestamp }
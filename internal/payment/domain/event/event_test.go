@@ -1,13 +1,16 @@
 package event
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 const testPaymentID = "test-payment-id"
 const testAuctionID = "test-auction-id"
 const testWinnerID = "test-winner-id"
 
 func TestPaymentCreated_EventName(t *testing.T) {
-	e := NewPaymentCreated(testPaymentID, testAuctionID, testWinnerID, 5000)
+	e := NewPaymentCreated(testPaymentID, testAuctionID, testWinnerID, 5000, 250, "percentage:500")
 	if e.EventName() != "payment.created" {
 		t.Errorf("EventName = %q, want payment.created", e.EventName())
 	}
@@ -20,7 +23,7 @@ func TestPaymentCreated_EventName(t *testing.T) {
 }
 
 func TestPaymentCompleted_EventName(t *testing.T) {
-	e := NewPaymentCompleted(testPaymentID, testAuctionID, testWinnerID, 5000)
+	e := NewPaymentCompleted(testPaymentID, testAuctionID, testWinnerID, 5000, 250, "percentage:500", false)
 	if e.EventName() != "payment.completed" {
 		t.Errorf("EventName = %q, want payment.completed", e.EventName())
 	}
@@ -40,7 +43,7 @@ func TestPaymentFailed_EventName(t *testing.T) {
 }
 
 func TestPaymentRefunded_EventName(t *testing.T) {
-	e := NewPaymentRefunded(testPaymentID, testAuctionID, testWinnerID, 5000, "customer request")
+	e := NewPaymentRefunded(testPaymentID, testAuctionID, testWinnerID, "refund-1", 5000, "customer request")
 	if e.EventName() != "payment.refunded" {
 		t.Errorf("EventName = %q, want payment.refunded", e.EventName())
 	}
@@ -48,3 +51,37 @@ func TestPaymentRefunded_EventName(t *testing.T) {
 		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testPaymentID)
 	}
 }
+
+func TestPaymentPartiallyRefunded_EventName(t *testing.T) {
+	e := NewPaymentPartiallyRefunded(testPaymentID, testAuctionID, testWinnerID, "refund-1", 1000, 1500, "damaged item")
+	if e.EventName() != "payment.partially_refunded" {
+		t.Errorf("EventName = %q, want payment.partially_refunded", e.EventName())
+	}
+	if e.AggregateID() != testPaymentID {
+		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testPaymentID)
+	}
+}
+
+func TestPaymentRefundFailed_EventName(t *testing.T) {
+	e := NewPaymentRefundFailed(testPaymentID, testAuctionID, testWinnerID, "refund-1", 1000, "card closed")
+	if e.EventName() != "payment.refund_failed" {
+		t.Errorf("EventName = %q, want payment.refund_failed", e.EventName())
+	}
+	if e.AggregateID() != testPaymentID {
+		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testPaymentID)
+	}
+}
+
+func TestPaymentReminder_EventName(t *testing.T) {
+	dueAt := time.Now().Add(time.Hour)
+	e := NewPaymentReminder(testPaymentID, testAuctionID, testWinnerID, 5000, dueAt, 2)
+	if e.EventName() != "payment.reminder" {
+		t.Errorf("EventName = %q, want payment.reminder", e.EventName())
+	}
+	if e.AggregateID() != testPaymentID {
+		t.Errorf("AggregateID = %q, want %q", e.AggregateID(), testPaymentID)
+	}
+	if !e.DueAt.Equal(dueAt) || e.Reminder != 2 {
+		t.Errorf("unexpected reminder payload %+v", e)
+	}
+}
@@ -2,21 +2,189 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/event"
 	"github.com/in-jun/go-structure-example/internal/shared/query"
 )
 
+var (
+	ErrChargePending = errors.New("payment gateway: charge pending")
+	ErrRefundPending = errors.New("payment gateway: refund pending")
+	ErrDeclined      = errors.New("payment gateway: transaction declined")
+
+	ErrUnknownPaymentMethod = errors.New("payment gateway: unknown payment method")
+)
+
 type PaymentRepository interface {
 	Save(ctx context.Context, payment *entity.Payment) error
 	FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Payment, error)
 	Update(ctx context.Context, payment *entity.Payment) error
+	FindStaleProcessing(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error)
+	FindPendingDueBefore(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error)
+	FindByWinnerID(ctx context.Context, winnerID, status string, page, limit int) ([]*entity.Payment, int64, error)
+	FindByAuctionID(ctx context.Context, auctionID string) ([]*entity.Payment, error)
+	FindUpdatedBetween(ctx context.Context, from, to time.Time) ([]*entity.Payment, error)
+	FindEscrowDue(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error)
+	SaveAttempt(ctx context.Context, attempt entity.PaymentAttempt) error
+}
+
+type RefundRepository interface {
+	Save(ctx context.Context, refund *entity.Refund) error
+	FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Refund, error)
+	FindByPaymentID(ctx context.Context, paymentID string) ([]*entity.Refund, error)
+	FindDue(ctx context.Context, before time.Time, limit int) ([]*entity.Refund, error)
+	Update(ctx context.Context, refund *entity.Refund) error
+	SaveAttempt(ctx context.Context, attempt entity.RefundAttempt) error
+}
+
+// LedgerRepository stores balanced journal entries. Post reports false when
+// an entry with the same kind and reference was already posted.
+type LedgerRepository interface {
+	Post(ctx context.Context, entry *entity.JournalEntry) (bool, error)
+	Balance(ctx context.Context, account, ownerID string) (int64, error)
+	LockAccount(ctx context.Context, account, ownerID string) error
+}
+
+type PayoutRepository interface {
+	Save(ctx context.Context, payout *entity.Payout) error
+	FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Payout, error)
+	Update(ctx context.Context, payout *entity.Payout) error
+	FindPendingBySeller(ctx context.Context, sellerID string) (*entity.Payout, error)
+}
+
+// ReconciliationRepository stores detected drift. Save reports false when
+// the same issue was already recorded by an earlier run.
+type ReconciliationRepository interface {
+	Save(ctx context.Context, issue *entity.ReconciliationIssue) (bool, error)
+	List(ctx context.Context, kind string, page, limit int) ([]*entity.ReconciliationIssue, int64, error)
+}
+
+// InvoiceRepository numbers invoices without gaps: NextSequence locks the
+// counter until the transaction ends, so a rolled back issue frees its number.
+type InvoiceRepository interface {
+	NextSequence(ctx context.Context) (int64, error)
+	Save(ctx context.Context, invoice *entity.Invoice) error
+	FindByPaymentID(ctx context.Context, paymentID string) (*entity.Invoice, error)
+}
+
+// DisputeRepository keeps at most one active dispute per payment.
+// HasUnsettledForSeller reports whether a seller has an active dispute or a
+// resolved one whose refund is still pending. FindDeadlineDue returns active
+// disputes whose seller or review deadline has passed.
+type DisputeRepository interface {
+	Save(ctx context.Context, dispute *entity.Dispute) error
+	FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Dispute, error)
+	Update(ctx context.Context, dispute *entity.Dispute) error
+	FindActiveByPaymentID(ctx context.Context, paymentID string) (*entity.Dispute, error)
+	HasUnsettledForSeller(ctx context.Context, sellerID string) (bool, error)
+	FindDeadlineDue(ctx context.Context, now time.Time, limit int) ([]*entity.Dispute, error)
+	List(ctx context.Context, status string, page, limit int) ([]*entity.Dispute, int64, error)
+}
+
+// PaymentMethodRepository lists only methods that were not removed; FindByID
+// also returns removed ones so past payments can be traced. A user has at
+// most one default method.
+type PaymentMethodRepository interface {
+	Save(ctx context.Context, method *entity.PaymentMethod) error
+	FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.PaymentMethod, error)
+	FindByUserID(ctx context.Context, userID string, opts ...query.Option) ([]*entity.PaymentMethod, error)
+	FindDefaultByUserID(ctx context.Context, userID string) (*entity.PaymentMethod, error)
+	Update(ctx context.Context, method *entity.PaymentMethod) error
+}
+
+type AuctionClient interface {
+	GetAuction(ctx context.Context, auctionID string) (*AuctionInfo, error)
+}
+
+type AuctionInfo struct {
+	ID       string
+	SellerID string
+	Status   string
+	Category string
 }
 
+type UserClient interface {
+	GetUser(ctx context.Context, userID string) (*UserInfo, error)
+}
+
+type UserInfo struct {
+	ID    string
+	Email string
+	Name  string
+}
+
+// PaymentGateway reports declines as ErrDeclined and asynchronous results
+// as ErrChargePending or ErrRefundPending. Any other error leaves the
+// outcome unknown.
+// Authorize and Capture take the charge attempt's idempotency key, so a
+// repeated call with the same key returns the original result instead of
+// charging again. The payment method is the gateway's token for a saved
+// instrument, or empty to let the provider collect one.
 type PaymentGateway interface {
-	Charge(ctx context.Context, paymentID string, amount int64) error
-	Refund(ctx context.Context, paymentID string, amount int64) error
+	Authorize(ctx context.Context, idempotencyKey, paymentID, paymentMethod string, amount int64) (string, error)
+	Capture(ctx context.Context, idempotencyKey, authorizationID string, amount int64) error
+	Refund(ctx context.Context, paymentID, refundID string, amount int64) error
+	ListTransactions(ctx context.Context, from, to time.Time) ([]GatewayTransaction, error)
+}
+
+const (
+	TransactionCapture = "capture"
+	TransactionRefund  = "refund"
+)
+
+// GatewayTransaction is money the gateway actually moved, as reported by
+// the provider rather than recorded by us.
+type GatewayTransaction struct {
+	ID        string
+	Type      string
+	PaymentID string
+	RefundID  string
+	Amount    int64
+	CreatedAt time.Time
+}
+
+// PaymentMethodGateway looks up a saved card by the token the gateway issued
+// for it, so card details come from the gateway rather than the client.
+// Unknown tokens are ErrUnknownPaymentMethod.
+type PaymentMethodGateway interface {
+	LookupPaymentMethod(ctx context.Context, token string) (*GatewayPaymentMethod, error)
+}
+
+// GatewayPaymentMethod is a tokenized card as the gateway knows it. The
+// customer ID is the user the token was issued to.
+type GatewayPaymentMethod struct {
+	Token      string
+	CustomerID string
+	Brand      string
+	Last4      string
+	ExpMonth   int
+	ExpYear    int
+}
+
+// PayoutGateway sends money to a seller. The payout ID is the idempotency
+// key, and a successful transfer returns the provider's transfer ID. Like
+// PaymentGateway, rejections are ErrDeclined and other errors are unknown.
+type PayoutGateway interface {
+	Transfer(ctx context.Context, payoutID, sellerID string, amount int64) (string, error)
+}
+
+const (
+	WebhookChargeSucceeded = "charge.succeeded"
+	WebhookChargeFailed    = "charge.failed"
+)
+
+type WebhookEvent struct {
+	ID        string
+	Type      string
+	PaymentID string
+	Reason    string
+}
+
+type WebhookVerifier interface {
+	Verify(payload []byte, signature string) (*WebhookEvent, error)
 }
 
 type EventPublisher interface {
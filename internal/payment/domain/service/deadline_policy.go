@@ -0,0 +1,58 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+const DeadlineExpiredReason = "payment deadline expired"
+
+// DeadlinePolicy decides when a winner has to pay and when to remind them.
+// Reminder offsets are measured back from the due date; reminder n is the
+// n-th offset in descending order.
+type DeadlinePolicy struct {
+	window  time.Duration
+	offsets []time.Duration
+}
+
+func NewDeadlinePolicy(window time.Duration, reminderOffsets []time.Duration) *DeadlinePolicy {
+	offsets := make([]time.Duration, 0, len(reminderOffsets))
+	for _, o := range reminderOffsets {
+		if o > 0 && o < window {
+			offsets = append(offsets, o)
+		}
+	}
+	slices.Sort(offsets)
+	slices.Reverse(offsets)
+	return &DeadlinePolicy{window: window, offsets: slices.Compact(offsets)}
+}
+
+func (p *DeadlinePolicy) DueAt(now time.Time) time.Time { return now.Add(p.window) }
+
+// Horizon is how far ahead of a due date payments need to be looked at.
+func (p *DeadlinePolicy) Horizon() time.Duration {
+	if len(p.offsets) == 0 {
+		return 0
+	}
+	return p.offsets[0]
+}
+
+// DueReminder returns the latest reminder that has come due but was not sent.
+// Earlier reminders that were missed are skipped rather than sent late.
+func (p *DeadlinePolicy) DueReminder(payment *entity.Payment, now time.Time) (int, bool) {
+	if payment.Status() != entity.StatusPending || payment.IsOverdue(now) {
+		return 0, false
+	}
+	due := 0
+	for i, offset := range p.offsets {
+		if !now.Before(payment.DueAt().Add(-offset)) {
+			due = i + 1
+		}
+	}
+	if due <= payment.RemindersSent() {
+		return 0, false
+	}
+	return due, true
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+func newPendingPayment(dueAt time.Time, reminders int) *entity.Payment {
+	now := time.Now()
+	return entity.ReconstructPayment("payment-1", "auction-1", "winner-1", 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		entity.StatusPending, "", dueAt, reminders, 0, 0, now, now)
+}
+
+func TestDeadlinePolicy_NormalizesOffsets(t *testing.T) {
+	policy := NewDeadlinePolicy(48*time.Hour, []time.Duration{time.Hour, 24 * time.Hour, time.Hour, 72 * time.Hour, 0})
+
+	if policy.Horizon() != 24*time.Hour {
+		t.Errorf("Horizon() = %v, want 24h", policy.Horizon())
+	}
+	if len(policy.offsets) != 2 {
+		t.Errorf("offsets = %v, want [24h 1h]", policy.offsets)
+	}
+}
+
+func TestDeadlinePolicy_DueReminder(t *testing.T) {
+	policy := NewDeadlinePolicy(48*time.Hour, []time.Duration{24 * time.Hour, time.Hour})
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		dueIn     time.Duration
+		reminders int
+		want      int
+		wantOK    bool
+	}{
+		{"before first offset", 30 * time.Hour, 0, 0, false},
+		{"inside first offset", 12 * time.Hour, 0, 1, true},
+		{"first already sent", 12 * time.Hour, 1, 0, false},
+		{"inside last offset", 30 * time.Minute, 1, 2, true},
+		{"missed first reminder", 30 * time.Minute, 0, 2, true},
+		{"overdue", -time.Minute, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policy.DueReminder(newPendingPayment(now.Add(tt.dueIn), tt.reminders), now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DueReminder() = (%d, %v), want (%d, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+// DisputePolicy sets each party's deadline: the buyer may open a dispute
+// within the open window of paying, or for as long as the funds are held in
+// escrow; the seller then has the response window to answer, and an admin
+// the review window to resolve it.
+type DisputePolicy struct {
+	openWindow     time.Duration
+	responseWindow time.Duration
+	reviewWindow   time.Duration
+}
+
+func NewDisputePolicy(openWindow, responseWindow, reviewWindow time.Duration) *DisputePolicy {
+	return &DisputePolicy{openWindow: openWindow, responseWindow: responseWindow, reviewWindow: reviewWindow}
+}
+
+func (p *DisputePolicy) CanOpen(payment *entity.Payment, now time.Time) bool {
+	if payment.Status() == entity.StatusEscrowHeld {
+		return true
+	}
+	return now.Before(payment.CreatedAt().Add(p.openWindow))
+}
+
+func (p *DisputePolicy) RespondBy(now time.Time) time.Time { return now.Add(p.responseWindow) }
+func (p *DisputePolicy) ReviewBy(now time.Time) time.Time  { return now.Add(p.reviewWindow) }
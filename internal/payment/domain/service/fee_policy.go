@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+const (
+	FeeKindPercentage = "percentage"
+	FeeKindFlat       = "flat"
+	FeeKindTiered     = "tiered"
+)
+
+var errInvalidFeeRule = errors.New("invalid fee rule")
+
+type feeTier struct {
+	upTo        int64 // 0 for the open-ended top tier
+	basisPoints int64
+}
+
+// FeeRule calculates the platform commission on a sale amount. Percentages
+// are in basis points; tiers apply marginally, like tax brackets.
+type FeeRule struct {
+	kind        string
+	basisPoints int64
+	flat        int64
+	tiers       []feeTier
+	spec        string
+}
+
+// ParseFeeRule reads "percentage:<bps>", "flat:<amount>" or
+// "tiered:<up to>=<bps>,...,*=<bps>" with ascending bounds.
+func ParseFeeRule(s string) (FeeRule, error) {
+	s = strings.TrimSpace(s)
+	kind, params, ok := strings.Cut(s, ":")
+	if !ok {
+		return FeeRule{}, fmt.Errorf("%w: %q", errInvalidFeeRule, s)
+	}
+	rule := FeeRule{kind: kind, spec: s}
+	switch kind {
+	case FeeKindPercentage:
+		bps, err := parseFeeNumber(params)
+		if err != nil || bps > 10000 {
+			return FeeRule{}, fmt.Errorf("%w: %q", errInvalidFeeRule, s)
+		}
+		rule.basisPoints = bps
+	case FeeKindFlat:
+		flat, err := parseFeeNumber(params)
+		if err != nil {
+			return FeeRule{}, fmt.Errorf("%w: %q", errInvalidFeeRule, s)
+		}
+		rule.flat = flat
+	case FeeKindTiered:
+		tiers, err := parseFeeTiers(params)
+		if err != nil {
+			return FeeRule{}, fmt.Errorf("%w: %q", errInvalidFeeRule, s)
+		}
+		rule.tiers = tiers
+	default:
+		return FeeRule{}, fmt.Errorf("%w: %q", errInvalidFeeRule, s)
+	}
+	return rule, nil
+}
+
+func parseFeeTiers(s string) ([]feeTier, error) {
+	var tiers []feeTier
+	var prev int64
+	parts := strings.Split(s, ",")
+	for i, part := range parts {
+		bound, rate, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, errInvalidFeeRule
+		}
+		bps, err := parseFeeNumber(rate)
+		if err != nil || bps > 10000 {
+			return nil, errInvalidFeeRule
+		}
+		last := i == len(parts)-1
+		if bound == "*" {
+			if !last {
+				return nil, errInvalidFeeRule
+			}
+			tiers = append(tiers, feeTier{basisPoints: bps})
+			continue
+		}
+		upTo, err := parseFeeNumber(bound)
+		if err != nil || upTo <= prev || last {
+			return nil, errInvalidFeeRule
+		}
+		tiers = append(tiers, feeTier{upTo: upTo, basisPoints: bps})
+		prev = upTo
+	}
+	return tiers, nil
+}
+
+func parseFeeNumber(s string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n < 0 {
+		return 0, errInvalidFeeRule
+	}
+	return n, nil
+}
+
+func (r FeeRule) String() string { return r.spec }
+
+// Fee never exceeds the amount it is taken from.
+func (r FeeRule) Fee(amount int64) int64 {
+	var fee int64
+	switch r.kind {
+	case FeeKindPercentage:
+		fee = amount * r.basisPoints / 10000
+	case FeeKindFlat:
+		fee = r.flat
+	case FeeKindTiered:
+		var lower int64
+		for _, t := range r.tiers {
+			upper := t.upTo
+			if upper == 0 || upper > amount {
+				upper = amount
+			}
+			if upper > lower {
+				fee += (upper - lower) * t.basisPoints / 10000
+			}
+			if upper == amount {
+				break
+			}
+			lower = upper
+		}
+	}
+	return min(fee, amount)
+}
+
+// ParseFeeCategories reads per-category overrides as
+// "<category>=<rule>;<category>=<rule>".
+func ParseFeeCategories(s string) (map[string]FeeRule, error) {
+	categories := make(map[string]FeeRule)
+	for pair := range strings.SplitSeq(s, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		category, spec, ok := strings.Cut(pair, "=")
+		category = strings.TrimSpace(category)
+		if !ok || category == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidFeeRule, pair)
+		}
+		rule, err := ParseFeeRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		categories[category] = rule
+	}
+	return categories, nil
+}
+
+// FeePolicy picks the commission rule for a sale: the category override
+// when one is configured, the default rule otherwise.
+type FeePolicy struct {
+	defaultRule FeeRule
+	categories  map[string]FeeRule
+}
+
+func NewFeePolicy(defaultRule FeeRule, categories map[string]FeeRule) *FeePolicy {
+	return &FeePolicy{defaultRule: defaultRule, categories: categories}
+}
+
+func (p *FeePolicy) Calculate(amount int64, category string) entity.FeeBreakdown {
+	rule, ok := p.categories[category]
+	if !ok {
+		rule, category = p.defaultRule, ""
+	}
+	return entity.FeeBreakdown{Amount: rule.Fee(amount), Rule: rule.String(), Category: category}
+}
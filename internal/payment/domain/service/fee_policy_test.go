@@ -0,0 +1,61 @@
+package service
+
+import "testing"
+
+func mustFeeRule(t *testing.T, spec string) FeeRule {
+	t.Helper()
+	rule, err := ParseFeeRule(spec)
+	if err != nil {
+		t.Fatalf("ParseFeeRule(%q) error = %v", spec, err)
+	}
+	return rule
+}
+
+func TestFeeRule_Fee(t *testing.T) {
+	tests := []struct {
+		spec   string
+		amount int64
+		want   int64
+	}{
+		{"percentage:500", 10000, 500},
+		{"percentage:250", 999, 24},
+		{"flat:300", 10000, 300},
+		{"flat:300", 200, 200},
+		{"tiered:100000=1000,*=500", 50000, 5000},
+		{"tiered:100000=1000,*=500", 300000, 20000},
+		{"tiered:1000=0,5000=1000,*=500", 7000, 500},
+	}
+	for _, tt := range tests {
+		if got := mustFeeRule(t, tt.spec).Fee(tt.amount); got != tt.want {
+			t.Errorf("%s.Fee(%d) = %d, want %d", tt.spec, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestParseFeeRule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"", "percentage", "percentage:-1", "percentage:10001", "flat:abc", "bogus:1",
+		"tiered:*=500,1000=100", "tiered:5000=100,1000=50,*=10", "tiered:1000=100",
+	} {
+		if _, err := ParseFeeRule(spec); err == nil {
+			t.Errorf("ParseFeeRule(%q) expected error", spec)
+		}
+	}
+}
+
+func TestFeePolicy_CategoryOverride(t *testing.T) {
+	categories, err := ParseFeeCategories("art=flat:1000; cars=tiered:100000=300,*=100")
+	if err != nil {
+		t.Fatalf("ParseFeeCategories() error = %v", err)
+	}
+	policy := NewFeePolicy(mustFeeRule(t, "percentage:500"), categories)
+
+	fee := policy.Calculate(20000, "art")
+	if fee.Amount != 1000 || fee.Rule != "flat:1000" || fee.Category != "art" {
+		t.Errorf("art fee = %+v, want flat 1000", fee)
+	}
+	fee = policy.Calculate(20000, "books")
+	if fee.Amount != 1000 || fee.Rule != "percentage:500" || fee.Category != "" {
+		t.Errorf("default fee = %+v, want percentage 1000", fee)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+var (
+	ErrNotSettleable    = errors.New("payment has not been captured")
+	ErrRefundIncomplete = errors.New("refund has not succeeded")
+)
+
+// LedgerPolicy turns settled sales, their refunds and seller payouts into
+// balanced journal entries.
+type LedgerPolicy struct{}
+
+func NewLedgerPolicy() *LedgerPolicy {
+	return &LedgerPolicy{}
+}
+
+// SaleEntry records the buyer's charge as funds held at the gateway, split
+// into the platform fee stored on the payment and what the platform owes
+// the seller.
+func (p *LedgerPolicy) SaleEntry(payment *entity.Payment, sellerID string) (*entity.JournalEntry, error) {
+	if !payment.IsSettleable() {
+		return nil, ErrNotSettleable
+	}
+	return entity.NewJournalEntry(entity.EntryKindSale, payment.ID(),
+		entity.Debit(entity.AccountGatewayClearing, "", payment.Amount()),
+		entity.Credit(entity.AccountPlatformRevenue, "", payment.Fee().Amount),
+		entity.Credit(entity.AccountSellerPayable, sellerID, payment.SellerAmount()),
+	)
+}
+
+// RefundEntry reverses a refunded share of a settled sale: the seller's
+// payable balance and the platform fee give up their proportional parts of
+// the money returned through the gateway. The fee share is rounded down.
+func (p *LedgerPolicy) RefundEntry(payment *entity.Payment, refund *entity.Refund, sellerID string) (*entity.JournalEntry, error) {
+	if !payment.IsSettleable() {
+		return nil, ErrNotSettleable
+	}
+	if !refund.IsSucceeded() {
+		return nil, ErrRefundIncomplete
+	}
+	fee := refund.Amount() * payment.Fee().Amount / payment.Amount()
+	return entity.NewJournalEntry(entity.EntryKindRefund, refund.ID(),
+		entity.Debit(entity.AccountSellerPayable, sellerID, refund.Amount()-fee),
+		entity.Debit(entity.AccountPlatformRevenue, "", fee),
+		entity.Credit(entity.AccountGatewayClearing, "", refund.Amount()),
+	)
+}
+
+// PayoutEntry moves a paid-out amount from the seller's payable balance.
+func (p *LedgerPolicy) PayoutEntry(payout *entity.Payout) (*entity.JournalEntry, error) {
+	return entity.NewJournalEntry(entity.EntryKindPayout, payout.ID(),
+		entity.Debit(entity.AccountSellerPayable, payout.SellerID(), payout.Amount()),
+		entity.Credit(entity.AccountSellerPaidOut, payout.SellerID(), payout.Amount()),
+	)
+}
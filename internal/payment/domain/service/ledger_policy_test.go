@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+func TestLedgerPolicy_SaleEntry(t *testing.T) {
+	now := time.Now()
+	payment := entity.ReconstructPayment("payment-1", "auction-1", "winner-1", 10001, entity.FeeBreakdown{Amount: 500}, entity.Escrow{},
+		entity.StatusCompleted, "", now, 0, 1, 0, now, now)
+
+	entry, err := NewLedgerPolicy().SaleEntry(payment, "seller-1")
+	if err != nil {
+		t.Fatalf("SaleEntry() error = %v", err)
+	}
+	want := map[string]int64{
+		entity.AccountGatewayClearing: 10001,
+		entity.AccountPlatformRevenue: -500,
+		entity.AccountSellerPayable:   -9501,
+	}
+	for _, p := range entry.Postings() {
+		if p.Amount != want[p.Account] {
+			t.Errorf("%s = %d, want %d", p.Account, p.Amount, want[p.Account])
+		}
+	}
+	if entry.Reference() != "payment-1" {
+		t.Errorf("Reference() = %q, want payment-1", entry.Reference())
+	}
+}
+
+func TestLedgerPolicy_SaleEntry_NotCompleted(t *testing.T) {
+	payment := newPendingPayment(time.Now().Add(time.Hour), 0)
+
+	if _, err := NewLedgerPolicy().SaleEntry(payment, "seller-1"); err != ErrNotSettleable {
+		t.Errorf("expected ErrNotSettleable, got %v", err)
+	}
+}
+
+func TestLedgerPolicy_RefundEntry(t *testing.T) {
+	now := time.Now()
+	payment := entity.ReconstructPayment("payment-1", "auction-1", "winner-1", 10000, entity.FeeBreakdown{Amount: 500}, entity.Escrow{},
+		entity.StatusPartiallyRefunded, "", now, 0, 1, 3001, now, now)
+	refund := entity.ReconstructRefund("refund-1", "payment-1", 3001, "damaged", entity.RefundStatusSucceeded, 1, "", now, now, now)
+
+	entry, err := NewLedgerPolicy().RefundEntry(payment, refund, "seller-1")
+	if err != nil {
+		t.Fatalf("RefundEntry() error = %v", err)
+	}
+	want := map[string]int64{
+		entity.AccountGatewayClearing: -3001,
+		entity.AccountPlatformRevenue: 150,
+		entity.AccountSellerPayable:   2851,
+	}
+	for _, p := range entry.Postings() {
+		if p.Amount != want[p.Account] {
+			t.Errorf("%s = %d, want %d", p.Account, p.Amount, want[p.Account])
+		}
+	}
+	if entry.Kind() != entity.EntryKindRefund || entry.Reference() != "refund-1" {
+		t.Errorf("entry = %s/%s, want refund/refund-1", entry.Kind(), entry.Reference())
+	}
+}
+
+func TestLedgerPolicy_RefundEntry_Rejects(t *testing.T) {
+	now := time.Now()
+	held := entity.ReconstructPayment("payment-1", "auction-1", "winner-1", 10000, entity.FeeBreakdown{Amount: 500},
+		entity.Escrow{Hold: time.Hour, ReleaseAt: now.Add(time.Hour)}, entity.StatusRefunded, "", now, 0, 1, 10000, now, now)
+	settled := entity.ReconstructPayment("payment-2", "auction-2", "winner-1", 10000, entity.FeeBreakdown{Amount: 500}, entity.Escrow{},
+		entity.StatusRefundPending, "", now, 0, 1, 0, now, now)
+	succeeded := entity.ReconstructRefund("refund-1", "payment-1", 10000, "", entity.RefundStatusSucceeded, 1, "", now, now, now)
+	pending := entity.ReconstructRefund("refund-2", "payment-2", 10000, "", entity.RefundStatusPending, 0, "", now, now, now)
+
+	if _, err := NewLedgerPolicy().RefundEntry(held, succeeded, "seller-1"); err != ErrNotSettleable {
+		t.Errorf("held escrow: expected ErrNotSettleable, got %v", err)
+	}
+	if _, err := NewLedgerPolicy().RefundEntry(settled, pending, "seller-1"); err != ErrRefundIncomplete {
+		t.Errorf("pending refund: expected ErrRefundIncomplete, got %v", err)
+	}
+}
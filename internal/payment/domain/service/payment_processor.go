@@ -2,11 +2,32 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 )
 
+var ErrUnknownWebhookType = errors.New("unknown webhook event type")
+
+type ChargeOutcome string
+
+const (
+	ChargeCaptured ChargeOutcome = "captured"
+	ChargeDeclined ChargeOutcome = "declined"
+	ChargePending  ChargeOutcome = "pending"
+)
+
+type ChargeResult struct {
+	Outcome  ChargeOutcome
+	Reason   string
+	Attempts []entity.PaymentAttempt
+}
+
 type PaymentProcessor struct {
 	gateway domain.PaymentGateway
 }
@@ -15,17 +36,190 @@ func NewPaymentProcessor(gateway domain.PaymentGateway) *PaymentProcessor {
 	return &PaymentProcessor{gateway: gateway}
 }
 
-func (p *PaymentProcessor) Process(ctx context.Context, payment *entity.Payment) error {
-	err := p.gateway.Charge(ctx, payment.ID(), payment.Amount())
+// Charge authorizes and captures against the gateway and must not be called
+// while holding a database lock. An error means the outcome is unknown and
+// the charge is safe to retry with the same attempt number: the gateway
+// calls are keyed by payment and attempt, so a retry cannot charge twice.
+// The result lists the gateway calls made, even when the outcome is unknown.
+func (p *PaymentProcessor) Charge(
+	ctx context.Context, paymentID, paymentMethod string, attempt int, amount int64,
+) (ChargeResult, error) {
+	key := chargeKey(paymentID, attempt)
+	var attempts []entity.PaymentAttempt
+
+	start := time.Now()
+	authorizationID, err := p.gateway.Authorize(ctx, key, paymentID, paymentMethod, amount)
+	attempts = append(attempts, newPaymentAttempt(paymentID, attempt, entity.AttemptAuthorize, key,
+		gatewayExchange{PaymentID: paymentID, PaymentMethod: paymentMethod, Amount: amount}, authorizationID, err, start))
+	if result, done, err := classifyGatewayError(err); done {
+		result.Attempts = attempts
+		return result, err
+	}
+
+	start = time.Now()
+	err = p.gateway.Capture(ctx, key, authorizationID, amount)
+	attempts = append(attempts, newPaymentAttempt(paymentID, attempt, entity.AttemptCapture, key,
+		gatewayExchange{AuthorizationID: authorizationID, Amount: amount}, authorizationID, err, start))
+	if result, done, err := classifyGatewayError(err); done {
+		result.Attempts = attempts
+		return result, err
+	}
+	return ChargeResult{Outcome: ChargeCaptured, Attempts: attempts}, nil
+}
+
+// chargeKey is the idempotency key of one charge attempt. It depends only on
+// the payment and attempt number, so every retry of the attempt reuses it.
+func chargeKey(paymentID string, attempt int) string {
+	return paymentID + ":" + strconv.Itoa(attempt)
+}
+
+type gatewayExchange struct {
+	PaymentID       string `json:"payment_id,omitempty"`
+	PaymentMethod   string `json:"payment_method,omitempty"`
+	AuthorizationID string `json:"authorization_id,omitempty"`
+	Amount          int64  `json:"amount,omitempty"`
+	Outcome         string `json:"outcome,omitempty"`
+}
+
+func newPaymentAttempt(
+	paymentID string, attempt int, operation, key string,
+	request gatewayExchange, authorizationID string, err error, start time.Time,
+) entity.PaymentAttempt {
+	response := gatewayExchange{AuthorizationID: authorizationID, Outcome: attemptOutcome(err)}
+	a := entity.PaymentAttempt{
+		PaymentID:      paymentID,
+		Attempt:        attempt,
+		Operation:      operation,
+		IdempotencyKey: key,
+		Request:        encodeExchange(request),
+		Response:       encodeExchange(response),
+		Latency:        time.Since(start),
+		AttemptedAt:    start,
+	}
+	if err != nil {
+		a.Error = err.Error()
+	}
+	return a
+}
+
+func attemptOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "succeeded"
+	case errors.Is(err, domain.ErrChargePending):
+		return string(ChargePending)
+	case errors.Is(err, domain.ErrDeclined):
+		return string(ChargeDeclined)
+	}
+	return "unknown"
+}
+
+func encodeExchange(e gatewayExchange) string {
+	b, _ := json.Marshal(e)
+	return string(b)
+}
+
+func classifyGatewayError(err error) (ChargeResult, bool, error) {
+	switch {
+	case err == nil:
+		return ChargeResult{}, false, nil
+	case errors.Is(err, domain.ErrChargePending):
+		return ChargeResult{Outcome: ChargePending}, true, nil
+	case errors.Is(err, domain.ErrDeclined):
+		return ChargeResult{Outcome: ChargeDeclined, Reason: err.Error()}, true, nil
+	}
+	return ChargeResult{}, true, err
+}
+
+// Settle applies a charge result to the payment. Pending results leave the
+// payment processing until the provider reports back.
+func (p *PaymentProcessor) Settle(payment *entity.Payment, result ChargeResult) error {
+	switch result.Outcome {
+	case ChargeCaptured:
+		return payment.Complete()
+	case ChargeDeclined:
+		return payment.Fail(result.Reason)
+	}
+	return nil
+}
+
+// ApplyWebhook settles a pending payment from an asynchronous provider
+// notification. Redelivered notifications for an already settled payment
+// are ignored.
+func (p *PaymentProcessor) ApplyWebhook(payment *entity.Payment, evt *domain.WebhookEvent) error {
+	switch evt.Type {
+	case domain.WebhookChargeSucceeded:
+		if payment.IsCaptured() {
+			return nil
+		}
+		return payment.Complete()
+	case domain.WebhookChargeFailed:
+		if payment.Status() == entity.StatusFailed {
+			return nil
+		}
+		reason := evt.Reason
+		if reason == "" {
+			reason = domain.ErrDeclined.Error()
+		}
+		return payment.Fail(reason)
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownWebhookType, evt.Type)
+}
+
+// Captured asks the gateway whether it captured a charge for the payment at
+// any point since the payment was created. Like Charge, it must not be
+// called while holding a database lock.
+func (p *PaymentProcessor) Captured(ctx context.Context, payment *entity.Payment, now time.Time) (bool, error) {
+	txs, err := p.gateway.ListTransactions(ctx, payment.CreatedAt(), now)
 	if err != nil {
-		return payment.Fail(err.Error())
+		return false, err
+	}
+	for _, tx := range txs {
+		if tx.Type == domain.TransactionCapture && tx.PaymentID == payment.ID() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Refund calls the gateway for a pending refund and must not be called while
+// holding a database lock. The refund ID is the provider's idempotency key,
+// so retrying after an unknown outcome cannot refund twice. Repeating the
+// call only replays the provider's first answer, so a later attempt first
+// looks for the refund among the gateway's transactions: that is how a
+// refund the provider accepted as pending is seen to complete.
+func (p *PaymentProcessor) Refund(ctx context.Context, refund *entity.Refund, now time.Time) error {
+	if refund.Attempts() > 0 {
+		txs, err := p.gateway.ListTransactions(ctx, refund.CreatedAt(), now)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if tx.Type == domain.TransactionRefund && tx.RefundID == refund.ID() {
+				return nil
+			}
+		}
 	}
-	return payment.Complete()
+	return p.gateway.Refund(ctx, refund.PaymentID(), refund.ID(), refund.Amount())
 }
 
-func (p *PaymentProcessor) ProcessRefund(ctx context.Context, payment *entity.Payment, reason string) error {
-	if err := p.gateway.Refund(ctx, payment.ID(), payment.Amount()); err != nil {
-		return err
+// SettleRefund records a gateway attempt and applies its outcome. Declines
+// and exhausted retries fail the refund; other errors, including a refund
+// the provider still reports pending, schedule another attempt.
+func (p *PaymentProcessor) SettleRefund(
+	payment *entity.Payment, refund *entity.Refund, gatewayErr error, retry *RefundRetryPolicy, now time.Time,
+) (entity.RefundAttempt, error) {
+	attempt, err := refund.RecordAttempt(gatewayErr)
+	if err != nil {
+		return attempt, err
+	}
+	if gatewayErr == nil {
+		return attempt, payment.CompleteRefund(refund)
+	}
+	if !errors.Is(gatewayErr, domain.ErrDeclined) {
+		if next, ok := retry.NextAttempt(refund.Attempts(), now); ok {
+			return attempt, refund.RetryAt(next)
+		}
 	}
-	return payment.Refund(reason)
+	return attempt, payment.FailRefund(refund, gatewayErr.Error())
 }
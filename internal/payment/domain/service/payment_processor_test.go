@@ -3,30 +3,67 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
 	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 )
 
+var testDueAt = time.Now().Add(48 * time.Hour)
+
 type mockGatewaySuccess struct{}
 
-func (m *mockGatewaySuccess) Charge(_ context.Context, _ string, _ int64) error { return nil }
-func (m *mockGatewaySuccess) Refund(_ context.Context, _ string, _ int64) error { return nil }
+func (m *mockGatewaySuccess) Authorize(_ context.Context, _, paymentID, _ string, _ int64) (string, error) {
+	return "auth-" + paymentID, nil
+}
+func (m *mockGatewaySuccess) Capture(_ context.Context, _, _ string, _ int64) error { return nil }
+func (m *mockGatewaySuccess) Refund(_ context.Context, _, _ string, _ int64) error  { return nil }
+func (m *mockGatewaySuccess) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return nil, nil
+}
 
 type mockGatewayFail struct{}
 
-func (m *mockGatewayFail) Charge(_ context.Context, _ string, _ int64) error {
-	return errors.New("declined")
+func (m *mockGatewayFail) Authorize(_ context.Context, _, _, _ string, _ int64) (string, error) {
+	return "", fmt.Errorf("%w: insufficient_funds", domain.ErrDeclined)
 }
-func (m *mockGatewayFail) Refund(_ context.Context, _ string, _ int64) error {
+func (m *mockGatewayFail) Capture(_ context.Context, _, _ string, _ int64) error { return nil }
+func (m *mockGatewayFail) Refund(_ context.Context, _, _ string, _ int64) error {
 	return errors.New("refund failed")
 }
+func (m *mockGatewayFail) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return nil, nil
+}
+
+type mockGatewayUnavailable struct {
+	captureErr error
+}
+
+func (m *mockGatewayUnavailable) Authorize(_ context.Context, _, _, _ string, _ int64) (string, error) {
+	if m.captureErr != nil {
+		return "auth-1", nil
+	}
+	return "", errors.New("connection refused")
+}
+func (m *mockGatewayUnavailable) Capture(_ context.Context, _, _ string, _ int64) error {
+	return m.captureErr
+}
+func (m *mockGatewayUnavailable) Refund(_ context.Context, _, _ string, _ int64) error { return nil }
+func (m *mockGatewayUnavailable) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return nil, nil
+}
 
-func TestPaymentProcessor_Process_Success(t *testing.T) {
+func TestPaymentProcessor_Charge_Success(t *testing.T) {
 	processor := NewPaymentProcessor(&mockGatewaySuccess{})
-	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000)
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
 
-	if err := processor.Process(context.Background(), payment); err != nil {
+	result, err := processor.Charge(context.Background(), payment.ID(), "", payment.ChargeAttempts(), payment.Amount())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := processor.Settle(payment, result); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if payment.Status() != entity.StatusCompleted {
@@ -34,11 +71,18 @@ func TestPaymentProcessor_Process_Success(t *testing.T) {
 	}
 }
 
-func TestPaymentProcessor_Process_Failure(t *testing.T) {
+func TestPaymentProcessor_Charge_Declined(t *testing.T) {
 	processor := NewPaymentProcessor(&mockGatewayFail{})
-	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000)
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
 
-	if err := processor.Process(context.Background(), payment); err != nil {
+	result, err := processor.Charge(context.Background(), payment.ID(), "", payment.ChargeAttempts(), payment.Amount())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Outcome != ChargeDeclined {
+		t.Fatalf("expected outcome '%s', got '%s'", ChargeDeclined, result.Outcome)
+	}
+	if err := processor.Settle(payment, result); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if payment.Status() != entity.StatusFailed {
@@ -46,27 +90,307 @@ func TestPaymentProcessor_Process_Failure(t *testing.T) {
 	}
 }
 
-func TestPaymentProcessor_ProcessRefund_Success(t *testing.T) {
-	processor := NewPaymentProcessor(&mockGatewaySuccess{})
-	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000)
-	_ = processor.Process(context.Background(), payment)
+func TestPaymentProcessor_Charge_UnknownOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		gateway *mockGatewayUnavailable
+	}{
+		{"authorize", &mockGatewayUnavailable{}},
+		{"capture", &mockGatewayUnavailable{captureErr: errors.New("timeout")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewPaymentProcessor(tt.gateway)
+			result, err := processor.Charge(context.Background(), "payment-id", "", 1, 5000)
+			if err == nil {
+				t.Error("expected error when the gateway outcome is unknown")
+			}
+			last := result.Attempts[len(result.Attempts)-1]
+			if last.Operation != tt.name || last.Error == "" {
+				t.Errorf("expected the failed %s call to be recorded, got %+v", tt.name, last)
+			}
+		})
+	}
+}
+
+type mockGatewayKeys struct {
+	keys []string
+}
+
+func (m *mockGatewayKeys) Authorize(_ context.Context, key, _, _ string, _ int64) (string, error) {
+	m.keys = append(m.keys, key)
+	return "auth-1", nil
+}
+func (m *mockGatewayKeys) Capture(_ context.Context, key, _ string, _ int64) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+func (m *mockGatewayKeys) Refund(_ context.Context, _, _ string, _ int64) error { return nil }
+func (m *mockGatewayKeys) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return nil, nil
+}
+
+func TestPaymentProcessor_Charge_IdempotencyKeys(t *testing.T) {
+	gateway := &mockGatewayKeys{}
+	processor := NewPaymentProcessor(gateway)
 
-	if err := processor.ProcessRefund(context.Background(), payment, "test"); err != nil {
+	result, err := processor.Charge(context.Background(), "payment-id", "", 1, 5000)
+	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if payment.Status() != entity.StatusRefunded {
-		t.Errorf("expected status '%s', got '%s'", entity.StatusRefunded, payment.Status())
+	if _, err := processor.Charge(context.Background(), "payment-id", "", 1, 5000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := processor.Charge(context.Background(), "payment-id", "", 2, 5000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gateway.keys[0] != gateway.keys[2] || gateway.keys[1] != gateway.keys[3] {
+		t.Errorf("retrying an attempt changed its keys: %v", gateway.keys)
+	}
+	if gateway.keys[0] == gateway.keys[4] {
+		t.Errorf("a new attempt reused the previous key %q", gateway.keys[0])
+	}
+
+	if len(result.Attempts) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %d", len(result.Attempts))
+	}
+	authorize, capture := result.Attempts[0], result.Attempts[1]
+	if authorize.Operation != entity.AttemptAuthorize || capture.Operation != entity.AttemptCapture {
+		t.Errorf("unexpected operations %q, %q", authorize.Operation, capture.Operation)
+	}
+	if authorize.IdempotencyKey != gateway.keys[0] || authorize.Attempt != 1 || authorize.PaymentID != "payment-id" {
+		t.Errorf("unexpected authorize attempt %+v", authorize)
+	}
+	if authorize.Request != `{"payment_id":"payment-id","amount":5000}` || capture.Response != `{"authorization_id":"auth-1","outcome":"succeeded"}` {
+		t.Errorf("unexpected request/response %s, %s", authorize.Request, capture.Response)
 	}
 }
 
-func TestPaymentProcessor_ProcessRefund_GatewayFail(t *testing.T) {
-	processor := NewPaymentProcessor(&mockGatewayFail{})
-	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000)
+func newRefundingPayment(t *testing.T) (*entity.Payment, *entity.Refund) {
+	t.Helper()
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
 	if err := payment.Complete(); err != nil {
 		t.Fatal(err)
 	}
+	refund, err := payment.Refund(2000, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payment, refund
+}
+
+func TestPaymentProcessor_Refund_GatewayFail(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayFail{})
+	_, refund := newRefundingPayment(t)
 
-	if err := processor.ProcessRefund(context.Background(), payment, "test"); err == nil {
+	if err := processor.Refund(context.Background(), refund, time.Now()); err == nil {
 		t.Error("expected error from gateway refund failure")
 	}
 }
+
+func TestPaymentProcessor_SettleRefund(t *testing.T) {
+	retry := NewRefundRetryPolicy(time.Minute, time.Hour, 2)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		payment, refund := newRefundingPayment(t)
+		attempt, err := NewPaymentProcessor(&mockGatewaySuccess{}).SettleRefund(payment, refund, nil, retry, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attempt.Number != 1 || attempt.Error != "" {
+			t.Errorf("unexpected attempt %+v", attempt)
+		}
+		if payment.Status() != entity.StatusPartiallyRefunded || refund.Status() != entity.RefundStatusSucceeded {
+			t.Errorf("payment %q, refund %q", payment.Status(), refund.Status())
+		}
+	})
+
+	t.Run("transient error retries", func(t *testing.T) {
+		payment, refund := newRefundingPayment(t)
+		_, err := NewPaymentProcessor(&mockGatewaySuccess{}).SettleRefund(payment, refund, errors.New("timeout"), retry, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payment.Status() != entity.StatusRefundPending || !refund.IsPending() {
+			t.Errorf("payment %q, refund %q", payment.Status(), refund.Status())
+		}
+		if !refund.NextAttemptAt().Equal(now.Add(time.Minute)) {
+			t.Errorf("next attempt %v, want %v", refund.NextAttemptAt(), now.Add(time.Minute))
+		}
+	})
+
+	t.Run("decline fails immediately", func(t *testing.T) {
+		payment, refund := newRefundingPayment(t)
+		_, err := NewPaymentProcessor(&mockGatewaySuccess{}).SettleRefund(payment, refund, domain.ErrDeclined, retry, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payment.Status() != entity.StatusCompleted || refund.Status() != entity.RefundStatusFailed {
+			t.Errorf("payment %q, refund %q", payment.Status(), refund.Status())
+		}
+	})
+}
+
+func TestRefundRetryPolicy(t *testing.T) {
+	policy := NewRefundRetryPolicy(30*time.Second, 5*time.Minute, 5)
+	now := time.Now()
+
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for i, d := range want {
+		next, ok := policy.NextAttempt(i+1, now)
+		if !ok || next.Sub(now) != d {
+			t.Errorf("NextAttempt(%d) = (%v, %v), want %v", i+1, next.Sub(now), ok, d)
+		}
+	}
+	if policy.Delay(10) != 5*time.Minute {
+		t.Errorf("Delay(10) = %v, want capped at 5m", policy.Delay(10))
+	}
+	if _, ok := policy.NextAttempt(5, now); ok {
+		t.Error("expected no retry after max attempts")
+	}
+}
+
+type mockGatewayPending struct {
+	transactions []domain.GatewayTransaction
+	refunds      int
+}
+
+func (m *mockGatewayPending) Authorize(_ context.Context, _, _, _ string, _ int64) (string, error) {
+	return "", domain.ErrChargePending
+}
+func (m *mockGatewayPending) Capture(_ context.Context, _, _ string, _ int64) error { return nil }
+func (m *mockGatewayPending) Refund(_ context.Context, _, _ string, _ int64) error {
+	m.refunds++
+	return domain.ErrRefundPending
+}
+func (m *mockGatewayPending) ListTransactions(_ context.Context, _, _ time.Time) ([]domain.GatewayTransaction, error) {
+	return m.transactions, nil
+}
+
+func TestPaymentProcessor_Charge_Pending(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayPending{})
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	_ = payment.StartProcessing("")
+
+	result, err := processor.Charge(context.Background(), payment.ID(), "", payment.ChargeAttempts(), payment.Amount())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := processor.Settle(payment, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !payment.IsProcessing() {
+		t.Errorf("expected status '%s', got '%s'", entity.StatusProcessing, payment.Status())
+	}
+	if len(payment.Events()) != 1 {
+		t.Errorf("expected only the created event, got %d events", len(payment.Events()))
+	}
+}
+
+func TestPaymentProcessor_Refund_Pending(t *testing.T) {
+	retry := NewRefundRetryPolicy(time.Minute, time.Hour, 5)
+	now := time.Now()
+	gateway := &mockGatewayPending{}
+	processor := NewPaymentProcessor(gateway)
+	payment, refund := newRefundingPayment(t)
+
+	gatewayErr := processor.Refund(context.Background(), refund, now)
+	if !errors.Is(gatewayErr, domain.ErrRefundPending) {
+		t.Fatalf("expected ErrRefundPending, got %v", gatewayErr)
+	}
+	if _, err := processor.SettleRefund(payment, refund, gatewayErr, retry, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != entity.StatusRefundPending || !refund.IsPending() {
+		t.Fatalf("payment %q, refund %q", payment.Status(), refund.Status())
+	}
+
+	gateway.transactions = []domain.GatewayTransaction{
+		{Type: domain.TransactionRefund, PaymentID: payment.ID(), RefundID: "other-refund", Amount: 1000},
+	}
+	if err := processor.Refund(context.Background(), refund, now); !errors.Is(err, domain.ErrRefundPending) {
+		t.Fatalf("another refund's transaction must not settle this one, got %v", err)
+	}
+
+	gateway.transactions = append(gateway.transactions, domain.GatewayTransaction{
+		Type: domain.TransactionRefund, PaymentID: payment.ID(), RefundID: refund.ID(), Amount: refund.Amount(),
+	})
+	gatewayErr = processor.Refund(context.Background(), refund, now)
+	if gatewayErr != nil {
+		t.Fatalf("expected the settled refund to be found, got %v", gatewayErr)
+	}
+	if gateway.refunds != 2 {
+		t.Errorf("expected 2 refund calls, got %d", gateway.refunds)
+	}
+	if _, err := processor.SettleRefund(payment, refund, gatewayErr, retry, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Status() != entity.StatusPartiallyRefunded || refund.Status() != entity.RefundStatusSucceeded {
+		t.Errorf("payment %q, refund %q", payment.Status(), refund.Status())
+	}
+}
+
+func TestPaymentProcessor_ApplyWebhook(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayPending{})
+
+	tests := []struct {
+		name      string
+		eventType string
+		want      string
+	}{
+		{"succeeded", domain.WebhookChargeSucceeded, entity.StatusCompleted},
+		{"failed", domain.WebhookChargeFailed, entity.StatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
+			evt := &domain.WebhookEvent{Type: tt.eventType, PaymentID: payment.ID()}
+
+			if err := processor.ApplyWebhook(payment, evt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if payment.Status() != tt.want {
+				t.Errorf("expected status '%s', got '%s'", tt.want, payment.Status())
+			}
+			if err := processor.ApplyWebhook(payment, evt); err != nil {
+				t.Errorf("redelivery should be ignored, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPaymentProcessor_ApplyWebhook_SucceededAfterEscrowHold(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayPending{})
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, time.Hour, testDueAt)
+	_ = payment.Complete()
+
+	if err := processor.ApplyWebhook(payment, &domain.WebhookEvent{Type: domain.WebhookChargeSucceeded}); err != nil {
+		t.Errorf("redelivery for a captured payment should be ignored, got %v", err)
+	}
+	if payment.Status() != entity.StatusEscrowHeld {
+		t.Errorf("expected status '%s', got '%s'", entity.StatusEscrowHeld, payment.Status())
+	}
+}
+
+func TestPaymentProcessor_ApplyWebhook_Conflict(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayPending{})
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
+	_ = payment.Complete()
+
+	err := processor.ApplyWebhook(payment, &domain.WebhookEvent{Type: domain.WebhookChargeFailed})
+	if !errors.Is(err, entity.ErrNotPending) {
+		t.Errorf("expected ErrNotPending, got %v", err)
+	}
+}
+
+func TestPaymentProcessor_ApplyWebhook_UnknownType(t *testing.T) {
+	processor := NewPaymentProcessor(&mockGatewayPending{})
+	payment, _ := entity.NewPayment("auction-id", "winner-id", 5000, entity.FeeBreakdown{}, 0, testDueAt)
+
+	err := processor.ApplyWebhook(payment, &domain.WebhookEvent{Type: "charge.disputed"})
+	if !errors.Is(err, ErrUnknownWebhookType) {
+		t.Errorf("expected ErrUnknownWebhookType, got %v", err)
+	}
+}
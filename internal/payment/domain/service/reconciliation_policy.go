@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+// ReconciliationPolicy compares what the gateway moved with what we
+// recorded. Payments still processing and refunds still pending are left
+// to recovery and the refund retrier rather than reported as drift.
+type ReconciliationPolicy struct{}
+
+func NewReconciliationPolicy() *ReconciliationPolicy {
+	return &ReconciliationPolicy{}
+}
+
+// Compare checks every gateway transaction against the local records it
+// references, and every captured payment in window for a matching capture.
+func (p *ReconciliationPolicy) Compare(
+	window []*entity.Payment,
+	payments map[string]*entity.Payment,
+	refunds map[string]*entity.Refund,
+	txs []domain.GatewayTransaction,
+) []*entity.ReconciliationIssue {
+	var issues []*entity.ReconciliationIssue
+	captured := make(map[string]bool)
+
+	for _, tx := range txs {
+		switch tx.Type {
+		case domain.TransactionCapture:
+			captured[tx.PaymentID] = true
+			payment := payments[tx.PaymentID]
+			if payment != nil && payment.IsProcessing() {
+				continue
+			}
+			if payment == nil || !payment.IsCaptured() {
+				issues = append(issues, entity.NewReconciliationIssue(entity.IssueOrphanCharge, tx.PaymentID, tx.ID, 0, tx.Amount))
+				continue
+			}
+			if tx.Amount != payment.Amount() {
+				issues = append(issues, entity.NewReconciliationIssue(entity.IssueAmountMismatch, tx.PaymentID, tx.ID, payment.Amount(), tx.Amount))
+			}
+		case domain.TransactionRefund:
+			refund := refunds[tx.RefundID]
+			if refund != nil && refund.Status() == entity.RefundStatusPending {
+				continue
+			}
+			if refund == nil || refund.Status() != entity.RefundStatusSucceeded {
+				issues = append(issues, entity.NewReconciliationIssue(entity.IssueOrphanRefund, tx.PaymentID, tx.ID, 0, tx.Amount))
+				continue
+			}
+			if tx.Amount != refund.Amount() {
+				issues = append(issues, entity.NewReconciliationIssue(entity.IssueAmountMismatch, tx.PaymentID, tx.ID, refund.Amount(), tx.Amount))
+			}
+		}
+	}
+
+	for _, payment := range window {
+		if payment.IsCaptured() && !captured[payment.ID()] {
+			issues = append(issues, entity.NewReconciliationIssue(entity.IssueMissingCapture, payment.ID(), "", payment.Amount(), 0))
+		}
+	}
+	return issues
+}
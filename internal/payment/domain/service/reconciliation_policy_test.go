@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+)
+
+func newReconcilePayment(id, status string) *entity.Payment {
+	now := time.Now()
+	return entity.ReconstructPayment(id, "auction-1", "winner-1", 5000, entity.FeeBreakdown{}, entity.Escrow{},
+		status, "", now, 0, 1, 0, now, now)
+}
+
+func TestReconciliationPolicy_Compare(t *testing.T) {
+	now := time.Now()
+	matched := newReconcilePayment("payment-1", entity.StatusCompleted)
+	mismatched := newReconcilePayment("payment-2", entity.StatusCompleted)
+	missing := newReconcilePayment("payment-3", entity.StatusCompleted)
+	failed := newReconcilePayment("payment-4", entity.StatusFailed)
+	refund := entity.ReconstructRefund("refund-1", "payment-1", 1000, "", entity.RefundStatusSucceeded, 1, "", now, now, now)
+
+	window := []*entity.Payment{matched, mismatched, missing, failed}
+	payments := map[string]*entity.Payment{
+		matched.ID(): matched, mismatched.ID(): mismatched, missing.ID(): missing, failed.ID(): failed,
+	}
+	refunds := map[string]*entity.Refund{refund.ID(): refund}
+	txs := []domain.GatewayTransaction{
+		{ID: "tx-1", Type: domain.TransactionCapture, PaymentID: "payment-1", Amount: 5000},
+		{ID: "tx-2", Type: domain.TransactionCapture, PaymentID: "payment-2", Amount: 4000},
+		{ID: "tx-3", Type: domain.TransactionCapture, PaymentID: "payment-4", Amount: 5000},
+		{ID: "tx-4", Type: domain.TransactionCapture, PaymentID: "elsewhere", Amount: 700},
+		{ID: "tx-5", Type: domain.TransactionRefund, PaymentID: "payment-1", RefundID: "refund-1", Amount: 1000},
+		{ID: "tx-6", Type: domain.TransactionRefund, PaymentID: "payment-1", RefundID: "refund-9", Amount: 200},
+	}
+
+	issues := NewReconciliationPolicy().Compare(window, payments, refunds, txs)
+
+	got := make(map[string]string)
+	for _, issue := range issues {
+		got[issue.PaymentID()+"/"+issue.TransactionID()] = issue.Kind()
+	}
+	want := map[string]string{
+		"payment-2/tx-2": entity.IssueAmountMismatch,
+		"payment-4/tx-3": entity.IssueOrphanCharge,
+		"elsewhere/tx-4": entity.IssueOrphanCharge,
+		"payment-1/tx-6": entity.IssueOrphanRefund,
+		"payment-3/":     entity.IssueMissingCapture,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %v, want %v", got, want)
+	}
+	for key, kind := range want {
+		if got[key] != kind {
+			t.Errorf("issue %s = %q, want %q", key, got[key], kind)
+		}
+	}
+}
+
+func TestReconciliationPolicy_SkipsInFlight(t *testing.T) {
+	now := time.Now()
+	processing := newReconcilePayment("payment-1", entity.StatusProcessing)
+	refund := entity.ReconstructRefund("refund-1", "payment-1", 1000, "", entity.RefundStatusPending, 1, "", now, now, now)
+	txs := []domain.GatewayTransaction{
+		{ID: "tx-1", Type: domain.TransactionCapture, PaymentID: "payment-1", Amount: 5000},
+		{ID: "tx-2", Type: domain.TransactionRefund, PaymentID: "payment-1", RefundID: "refund-1", Amount: 1000},
+	}
+
+	issues := NewReconciliationPolicy().Compare([]*entity.Payment{processing},
+		map[string]*entity.Payment{processing.ID(): processing}, map[string]*entity.Refund{refund.ID(): refund}, txs)
+	if len(issues) != 0 {
+		t.Errorf("expected no issues for in-flight records, got %d", len(issues))
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import "time"
+
+// RefundRetryPolicy spaces out gateway attempts for a pending refund with
+// exponential backoff and decides when to stop trying.
+type RefundRetryPolicy struct {
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	maxAttempts int
+}
+
+func NewRefundRetryPolicy(baseDelay, maxDelay time.Duration, maxAttempts int) *RefundRetryPolicy {
+	return &RefundRetryPolicy{baseDelay: baseDelay, maxDelay: maxDelay, maxAttempts: maxAttempts}
+}
+
+// Delay is the wait after the given attempt number, starting at 1.
+func (p *RefundRetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay)
+}
+
+// NextAttempt returns when to retry after attempts failed calls, or false
+// once the attempts are exhausted.
+func (p *RefundRetryPolicy) NextAttempt(attempts int, now time.Time) (time.Time, bool) {
+	if attempts >= p.maxAttempts {
+		return time.Time{}, false
+	}
+	return now.Add(p.Delay(attempts)), true
+}
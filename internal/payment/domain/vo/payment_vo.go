@@ -25,3 +25,27 @@ func NewAuctionIDVO(id string) (*AuctionIDVO, error) {
 	}
 	return &AuctionIDVO{ID: parsed}, nil
 }
+
+type DisputeIDVO struct {
+	ID string
+}
+
+func NewDisputeIDVO(id string) (*DisputeIDVO, error) {
+	parsed, err := validation.ParseUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeIDVO{ID: parsed}, nil
+}
+
+type PaymentMethodIDVO struct {
+	ID string
+}
+
+func NewPaymentMethodIDVO(id string) (*PaymentMethodIDVO, error) {
+	parsed, err := validation.ParseUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentMethodIDVO{ID: parsed}, nil
+}
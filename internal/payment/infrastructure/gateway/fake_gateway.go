@@ -0,0 +1,351 @@
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+// FakeProviderName is the webhook provider the in-process fake settles
+// pending charges through.
+const FakeProviderName = "fake"
+
+const fakeAuthorizationPrefix = "fake_auth_"
+
+type Outcome string
+
+const (
+	OutcomeApprove Outcome = "approve"
+	OutcomeDecline Outcome = "decline"
+	OutcomeTimeout Outcome = "timeout"
+	OutcomePending Outcome = "pending"
+)
+
+var errUnknownOutcome = errors.New("unknown fake gateway outcome")
+
+// DefaultFakeScenarios are the magic amounts FakeGateway recognises out of
+// the box. Every other amount is approved.
+var DefaultFakeScenarios = map[int64]Outcome{
+	4002: OutcomeDecline,
+	4008: OutcomeTimeout,
+	4009: OutcomePending,
+}
+
+// DefaultFakeTokenScenarios are reserved payment method tokens that force
+// an outcome regardless of the amount.
+var DefaultFakeTokenScenarios = map[string]Outcome{
+	"tok_approve": OutcomeApprove,
+	"tok_decline": OutcomeDecline,
+	"tok_timeout": OutcomeTimeout,
+	"tok_pending": OutcomePending,
+}
+
+func ParseOutcome(s string) (Outcome, error) {
+	switch o := Outcome(strings.ToLower(strings.TrimSpace(s))); o {
+	case OutcomeApprove, OutcomeDecline, OutcomeTimeout, OutcomePending:
+		return o, nil
+	}
+	return "", fmt.Errorf("%w: %q", errUnknownOutcome, s)
+}
+
+// ParseFakeScenarios reads "amount=outcome" pairs separated by commas and
+// layers them over DefaultFakeScenarios.
+func ParseFakeScenarios(s string) (map[int64]Outcome, error) {
+	scenarios := make(map[int64]Outcome, len(DefaultFakeScenarios))
+	for amount, outcome := range DefaultFakeScenarios {
+		scenarios[amount] = outcome
+	}
+	for pair := range strings.SplitSeq(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		rawAmount, rawOutcome, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fake gateway scenario %q", pair)
+		}
+		amount, err := strconv.ParseInt(strings.TrimSpace(rawAmount), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fake gateway scenario amount %q", rawAmount)
+		}
+		outcome, err := ParseOutcome(rawOutcome)
+		if err != nil {
+			return nil, err
+		}
+		scenarios[amount] = outcome
+	}
+	return scenarios, nil
+}
+
+type FakeCall struct {
+	Op            string    `json:"op"`
+	PaymentID     string    `json:"payment_id"`
+	PaymentMethod string    `json:"payment_method,omitempty"`
+	Amount        int64     `json:"amount"`
+	Outcome       Outcome   `json:"outcome"`
+	At            time.Time `json:"at"`
+}
+
+type FakeGatewayConfig struct {
+	Scenarios      map[int64]Outcome
+	TokenScenarios map[string]Outcome
+	TimeoutAfter   time.Duration
+	SettleAfter    time.Duration
+}
+
+type WebhookDeliverer func(ctx context.Context, payload []byte, signature string) error
+
+var (
+	_ domain.PaymentGateway       = (*FakeGateway)(nil)
+	_ domain.PaymentMethodGateway = (*FakeGateway)(nil)
+)
+
+// FakeGateway is a deterministic PaymentGateway for local runs and tests.
+// The outcome of a call is taken from the per-payment script first, then
+// from the payment method token, then from the amount scenarios, and
+// defaults to approve. Pending charges are settled through a signed webhook
+// handed to the registered deliverer. Payment method tokens are only known
+// once issued with Tokenize.
+type FakeGateway struct {
+	cfg      FakeGatewayConfig
+	secret   []byte
+	verifier *HMACWebhookVerifier
+
+	mu           sync.Mutex
+	deliver      WebhookDeliverer
+	scripts      map[string][]Outcome
+	calls        []FakeCall
+	transactions []domain.GatewayTransaction
+	captured     map[string]bool
+	methods      map[string]domain.GatewayPaymentMethod
+}
+
+func NewFakeGateway(cfg FakeGatewayConfig) *FakeGateway {
+	if cfg.Scenarios == nil {
+		cfg.Scenarios = DefaultFakeScenarios
+	}
+	if cfg.TokenScenarios == nil {
+		cfg.TokenScenarios = DefaultFakeTokenScenarios
+	}
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return &FakeGateway{
+		cfg:      cfg,
+		secret:   secret,
+		verifier: NewHMACWebhookVerifier(string(secret), time.Minute),
+		scripts:  make(map[string][]Outcome),
+		captured: make(map[string]bool),
+		methods:  make(map[string]domain.GatewayPaymentMethod),
+	}
+}
+
+func (g *FakeGateway) Verifier() domain.WebhookVerifier { return g.verifier }
+
+func (g *FakeGateway) OnWebhook(deliver WebhookDeliverer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.deliver = deliver
+}
+
+// Script queues outcomes for the next calls made for paymentID.
+func (g *FakeGateway) Script(paymentID string, outcomes ...Outcome) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.scripts[paymentID] = append(g.scripts[paymentID], outcomes...)
+}
+
+func (g *FakeGateway) Calls() []FakeCall {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	calls := make([]FakeCall, len(g.calls))
+	copy(calls, g.calls)
+	return calls
+}
+
+// Tokenize issues a token for a card on behalf of a customer, standing in
+// for the provider's client-side tokenization. An empty token gets a
+// generated one, so reserved scenario tokens can be issued by name.
+func (g *FakeGateway) Tokenize(method domain.GatewayPaymentMethod) string {
+	if method.Token == "" {
+		method.Token = "tok_" + uuid.New().String()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.methods[method.Token] = method
+	return method.Token
+}
+
+func (g *FakeGateway) LookupPaymentMethod(_ context.Context, token string) (*domain.GatewayPaymentMethod, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	method, ok := g.methods[token]
+	if !ok {
+		return nil, domain.ErrUnknownPaymentMethod
+	}
+	return &method, nil
+}
+
+// Reset forgets scripts, calls and transactions. Issued tokens are kept
+// because saved payment methods still refer to them.
+func (g *FakeGateway) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.scripts = make(map[string][]Outcome)
+	g.calls = nil
+	g.transactions = nil
+	g.captured = make(map[string]bool)
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, _, paymentID, paymentMethod string, amount int64) (string, error) {
+	switch outcome := g.next("authorize", paymentID, paymentMethod, amount); outcome {
+	case OutcomeDecline:
+		return "", domain.ErrDeclined
+	case OutcomeTimeout:
+		return "", g.timeout(ctx)
+	case OutcomePending:
+		go g.settle(paymentID, amount)
+		return "", domain.ErrChargePending
+	}
+	return fakeAuthorizationPrefix + paymentID, nil
+}
+
+// Capture always succeeds; outcomes are decided at authorization time. A
+// repeated capture with the same idempotency key moves no money again.
+func (g *FakeGateway) Capture(_ context.Context, idempotencyKey, authorizationID string, amount int64) error {
+	paymentID := strings.TrimPrefix(authorizationID, fakeAuthorizationPrefix)
+	g.record("capture", paymentID, "", amount, OutcomeApprove)
+
+	g.mu.Lock()
+	duplicate := g.captured[idempotencyKey]
+	g.captured[idempotencyKey] = true
+	g.mu.Unlock()
+	if !duplicate {
+		g.transact(domain.TransactionCapture, paymentID, "", amount)
+	}
+	return nil
+}
+
+// Refund moves a pending refund's money after the settle delay, without a
+// webhook: the refund only shows up in ListTransactions.
+func (g *FakeGateway) Refund(ctx context.Context, paymentID, refundID string, amount int64) error {
+	switch outcome := g.next("refund", paymentID, "", amount); outcome {
+	case OutcomeDecline:
+		return domain.ErrDeclined
+	case OutcomeTimeout:
+		return g.timeout(ctx)
+	case OutcomePending:
+		go func() {
+			time.Sleep(g.cfg.SettleAfter)
+			g.transact(domain.TransactionRefund, paymentID, refundID, amount)
+		}()
+		return domain.ErrRefundPending
+	}
+	g.transact(domain.TransactionRefund, paymentID, refundID, amount)
+	return nil
+}
+
+// ListTransactions returns the captures and refunds the fake approved.
+func (g *FakeGateway) ListTransactions(_ context.Context, from, to time.Time) ([]domain.GatewayTransaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var txs []domain.GatewayTransaction
+	for _, tx := range g.transactions {
+		if !tx.CreatedAt.Before(from) && tx.CreatedAt.Before(to) {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (g *FakeGateway) transact(txType, paymentID, refundID string, amount int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.transactions = append(g.transactions, domain.GatewayTransaction{
+		ID: "fake_tx_" + uuid.New().String(), Type: txType, PaymentID: paymentID,
+		RefundID: refundID, Amount: amount, CreatedAt: time.Now(),
+	})
+}
+
+func (g *FakeGateway) next(op, paymentID, paymentMethod string, amount int64) Outcome {
+	outcome := g.resolve(paymentID, paymentMethod, amount)
+	g.record(op, paymentID, paymentMethod, amount, outcome)
+	return outcome
+}
+
+func (g *FakeGateway) resolve(paymentID, paymentMethod string, amount int64) Outcome {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if scripted := g.scripts[paymentID]; len(scripted) > 0 {
+		if len(scripted) == 1 {
+			delete(g.scripts, paymentID)
+		} else {
+			g.scripts[paymentID] = scripted[1:]
+		}
+		return scripted[0]
+	}
+	if outcome, ok := g.cfg.TokenScenarios[paymentMethod]; ok {
+		return outcome
+	}
+	if outcome, ok := g.cfg.Scenarios[amount]; ok {
+		return outcome
+	}
+	return OutcomeApprove
+}
+
+func (g *FakeGateway) record(op, paymentID, paymentMethod string, amount int64, outcome Outcome) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls = append(g.calls, FakeCall{
+		Op: op, PaymentID: paymentID, PaymentMethod: paymentMethod,
+		Amount: amount, Outcome: outcome, At: time.Now(),
+	})
+}
+
+func (g *FakeGateway) timeout(ctx context.Context) error {
+	timer := time.NewTimer(g.cfg.TimeoutAfter)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	return errors.New("payment gateway: request timed out")
+}
+
+func (g *FakeGateway) settle(paymentID string, amount int64) {
+	time.Sleep(g.cfg.SettleAfter)
+	g.transact(domain.TransactionCapture, paymentID, "", amount)
+
+	g.mu.Lock()
+	deliver := g.deliver
+	g.mu.Unlock()
+	if deliver == nil {
+		slog.Warn("fake gateway has no webhook deliverer, pending charge left unsettled", "payment_id", paymentID)
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:        "evt_" + uuid.New().String(),
+		Type:      domain.WebhookChargeSucceeded,
+		PaymentID: paymentID,
+	})
+	if err != nil {
+		slog.Error("failed to encode fake webhook", "error", err)
+		return
+	}
+
+	signature := SignWebhook(g.secret, time.Now(), payload)
+	if err := deliver(context.Background(), payload, signature); err != nil {
+		slog.Warn("fake gateway failed to settle pending charge", "payment_id", paymentID, "error", err)
+	}
+}
@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/middleware"
+	"github.com/in-jun/go-structure-example/internal/shared/server"
+)
+
+type scriptRequest struct {
+	PaymentID string   `json:"payment_id"`
+	Outcomes  []string `json:"outcomes"`
+}
+
+type tokenizeRequest struct {
+	CustomerID string `json:"customer_id"`
+	Token      string `json:"token"`
+	Brand      string `json:"brand"`
+	Last4      string `json:"last4"`
+	ExpMonth   int    `json:"exp_month"`
+	ExpYear    int    `json:"exp_year"`
+}
+
+type tokenizeResponse struct {
+	Token string `json:"token"`
+}
+
+type callsResponse struct {
+	Calls []FakeCall `json:"calls"`
+}
+
+// FakeGatewayAdmin exposes scripting and call inspection of a FakeGateway
+// so integration tests can drive and assert on payment outcomes.
+type FakeGatewayAdmin struct {
+	gateway  *FakeGateway
+	identity *middleware.IdentityVerifier
+}
+
+func NewFakeGatewayAdmin(gateway *FakeGateway, identity *middleware.IdentityVerifier) *FakeGatewayAdmin {
+	return &FakeGatewayAdmin{gateway: gateway, identity: identity}
+}
+
+func (a *FakeGatewayAdmin) RegisterRoutes(mux *server.Router, mw server.Middleware) {
+	gatewayAuth := middleware.GatewayAuth(a.identity)
+	admin := middleware.RequireRole(middleware.RoleAdmin)
+
+	mux.Handle("POST /api/v1/admin/fake-gateway/scripts", mw(gatewayAuth(admin(http.HandlerFunc(a.Script)))))
+	mux.Handle("POST /api/v1/admin/fake-gateway/payment-methods", mw(gatewayAuth(admin(http.HandlerFunc(a.Tokenize)))))
+	mux.Handle("GET /api/v1/admin/fake-gateway/calls", mw(gatewayAuth(admin(http.HandlerFunc(a.ListCalls)))))
+	mux.Handle("DELETE /api/v1/admin/fake-gateway/calls", mw(gatewayAuth(admin(http.HandlerFunc(a.Reset)))))
+}
+
+func (a *FakeGatewayAdmin) Script(w http.ResponseWriter, r *http.Request) {
+	var req scriptRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+	if req.PaymentID == "" || len(req.Outcomes) == 0 {
+		middleware.HandleError(w, errors.ValidationError("payment_id and outcomes are required"))
+		return
+	}
+
+	outcomes := make([]Outcome, len(req.Outcomes))
+	for i, raw := range req.Outcomes {
+		outcome, err := ParseOutcome(raw)
+		if err != nil {
+			middleware.HandleError(w, errors.ValidationError(err.Error()))
+			return
+		}
+		outcomes[i] = outcome
+	}
+
+	a.gateway.Script(req.PaymentID, outcomes...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *FakeGatewayAdmin) Tokenize(w http.ResponseWriter, r *http.Request) {
+	var req tokenizeRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+	if req.CustomerID == "" {
+		middleware.HandleError(w, errors.ValidationError("customer_id is required"))
+		return
+	}
+
+	token := a.gateway.Tokenize(domain.GatewayPaymentMethod{
+		Token:      req.Token,
+		CustomerID: req.CustomerID,
+		Brand:      req.Brand,
+		Last4:      req.Last4,
+		ExpMonth:   req.ExpMonth,
+		ExpYear:    req.ExpYear,
+	})
+	server.JSON(w, http.StatusCreated, tokenizeResponse{Token: token})
+}
+
+func (a *FakeGatewayAdmin) ListCalls(w http.ResponseWriter, _ *http.Request) {
+	server.JSON(w, http.StatusOK, callsResponse{Calls: a.gateway.Calls()})
+}
+
+func (a *FakeGatewayAdmin) Reset(w http.ResponseWriter, _ *http.Request) {
+	a.gateway.Reset()
+	w.WriteHeader(http.StatusNoContent)
+}
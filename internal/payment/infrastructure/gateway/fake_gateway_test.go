@@ -0,0 +1,284 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/middleware"
+	"github.com/in-jun/go-structure-example/internal/shared/server"
+)
+
+func TestFakeGateway_AmountScenarios(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{TimeoutAfter: time.Millisecond})
+
+	tests := []struct {
+		name    string
+		amount  int64
+		wantErr bool
+		pending bool
+	}{
+		{"approve", 5000, false, false},
+		{"decline", 4002, true, false},
+		{"timeout", 4008, true, false},
+		{"pending", 4009, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.Authorize(context.Background(), "pay-"+tt.name+":1", "pay-"+tt.name, "", tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, domain.ErrChargePending) != tt.pending {
+				t.Errorf("Authorize() pending = %v, want %v", errors.Is(err, domain.ErrChargePending), tt.pending)
+			}
+		})
+	}
+
+	calls := g.Calls()
+	if len(calls) != len(tests) {
+		t.Fatalf("expected %d recorded calls, got %d", len(tests), len(calls))
+	}
+	if calls[1].Outcome != OutcomeDecline || calls[1].PaymentID != "pay-decline" || calls[1].Op != "authorize" {
+		t.Errorf("unexpected recorded call %+v", calls[1])
+	}
+}
+
+func TestFakeGateway_TokenScenarios(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{TimeoutAfter: time.Millisecond})
+
+	tests := []struct {
+		name    string
+		token   string
+		amount  int64
+		wantErr error
+	}{
+		{"decline", "tok_decline", 5000, domain.ErrDeclined},
+		{"pending", "tok_pending", 5000, domain.ErrChargePending},
+		{"approve overrides amount", "tok_approve", 4002, nil},
+		{"unknown token falls back to amount", "tok_visa_4242", 4002, domain.ErrDeclined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.Authorize(context.Background(), "pay-"+tt.name+":1", "pay-"+tt.name, tt.token, tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err := g.Authorize(context.Background(), "pay-timeout:1", "pay-timeout", "tok_timeout", 5000)
+	if err == nil || errors.Is(err, domain.ErrDeclined) || errors.Is(err, domain.ErrChargePending) {
+		t.Errorf("Authorize() error = %v, want a timeout", err)
+	}
+	if calls := g.Calls(); calls[0].PaymentMethod != "tok_decline" || calls[0].Outcome != OutcomeDecline {
+		t.Errorf("unexpected recorded call %+v", calls[0])
+	}
+}
+
+func TestFakeGateway_ScriptTakesPrecedence(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+	g.Script("pay-1", OutcomeDecline, OutcomeApprove)
+
+	if _, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000); err == nil {
+		t.Fatal("expected scripted decline")
+	}
+	if _, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 4002); err != nil {
+		t.Fatalf("expected scripted approve, got %v", err)
+	}
+	if _, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 4002); err == nil {
+		t.Fatal("expected amount scenario once the script is exhausted")
+	}
+}
+
+func TestFakeGateway_Capture(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+
+	authorizationID, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if err := g.Capture(context.Background(), "pay-1:1", authorizationID, 5000); err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+
+	calls := g.Calls()
+	if len(calls) != 2 || calls[1].Op != "capture" || calls[1].PaymentID != "pay-1" {
+		t.Errorf("unexpected recorded calls %+v", calls)
+	}
+
+	if err := g.Capture(context.Background(), "pay-1:1", authorizationID, 5000); err != nil {
+		t.Fatalf("repeated Capture() error = %v", err)
+	}
+	txs, _ := g.ListTransactions(context.Background(), time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	if len(txs) != 1 {
+		t.Errorf("repeated capture with the same key moved money %d times, want once", len(txs))
+	}
+}
+
+func TestFakeGateway_TimeoutHonoursContext(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{TimeoutAfter: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := g.Authorize(ctx, "pay-1:1", "pay-1", "", 4008)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline, got %v", err)
+	}
+}
+
+func TestFakeGateway_PendingSettlesThroughWebhook(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+	received := make(chan *domain.WebhookEvent, 1)
+	g.OnWebhook(func(_ context.Context, payload []byte, signature string) error {
+		evt, err := g.Verifier().Verify(payload, signature)
+		if err != nil {
+			return err
+		}
+		received <- evt
+		return nil
+	})
+
+	if _, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 4009); !errors.Is(err, domain.ErrChargePending) {
+		t.Fatalf("expected ErrChargePending, got %v", err)
+	}
+
+	select {
+	case evt := <-received:
+		if evt.Type != domain.WebhookChargeSucceeded || evt.PaymentID != "pay-1" {
+			t.Errorf("unexpected webhook event %+v", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pending charge was not settled")
+	}
+}
+
+func TestFakeGateway_PendingRefundSettlesLater(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+	g.Script("pay-1", OutcomePending)
+	from := time.Now()
+
+	if err := g.Refund(context.Background(), "pay-1", "re-1", 1000); !errors.Is(err, domain.ErrRefundPending) {
+		t.Fatalf("expected ErrRefundPending, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		txs, _ := g.ListTransactions(context.Background(), from, time.Now().Add(time.Minute))
+		if len(txs) == 1 && txs[0].Type == domain.TransactionRefund && txs[0].RefundID == "re-1" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("pending refund was not settled")
+}
+
+func TestParseFakeScenarios(t *testing.T) {
+	scenarios, err := ParseFakeScenarios("1500=decline, 2500=pending")
+	if err != nil {
+		t.Fatalf("ParseFakeScenarios() error = %v", err)
+	}
+	if scenarios[1500] != OutcomeDecline || scenarios[2500] != OutcomePending {
+		t.Errorf("configured scenarios missing: %v", scenarios)
+	}
+	if scenarios[4002] != OutcomeDecline {
+		t.Error("default scenarios should be kept")
+	}
+
+	for _, invalid := range []string{"1500", "abc=decline", "1500=explode"} {
+		if _, err := ParseFakeScenarios(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestFakeGatewayAdmin(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+	mux := server.NewRouter()
+	keys, err := middleware.ParseIdentityKeys("k1=test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	NewFakeGatewayAdmin(g, middleware.NewIdentityVerifier(keys, time.Minute)).
+		RegisterRoutes(mux, func(next http.Handler) http.Handler { return next })
+	signer := middleware.NewIdentitySigner(keys)
+	admin := middleware.Identity{UserID: uuid.New().String(), Roles: []string{middleware.RoleAdmin}}
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/fake-gateway/scripts",
+		strings.NewReader(`{"payment_id":"pay-1","outcomes":["decline"]}`))
+	signer.Sign(req, admin)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d; body: %s", w.Code, w.Body.String())
+	}
+
+	if _, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000); err == nil {
+		t.Fatal("expected scripted decline")
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/fake-gateway/calls", nil)
+	signer.Sign(req, admin)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"outcome":"decline"`) {
+		t.Errorf("unexpected calls response %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/fake-gateway/scripts",
+		strings.NewReader(`{"payment_id":"pay-1","outcomes":["explode"]}`))
+	signer.Sign(req, admin)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/fake-gateway/payment-methods",
+		strings.NewReader(`{"customer_id":"user-1","token":"tok_decline","brand":"visa","last4":"4242","exp_month":12,"exp_year":2030}`))
+	signer.Sign(req, admin)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+	method, err := g.LookupPaymentMethod(context.Background(), "tok_decline")
+	if err != nil || method.CustomerID != "user-1" || method.Last4 != "4242" {
+		t.Errorf("LookupPaymentMethod() = %+v, %v", method, err)
+	}
+
+	req = httptest.NewRequest("DELETE", "/api/v1/admin/fake-gateway/calls", nil)
+	signer.Sign(req, admin)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || len(g.Calls()) != 0 {
+		t.Errorf("expected calls to be reset, got %d and %d calls", w.Code, len(g.Calls()))
+	}
+}
+
+func TestFakeGateway_Tokenize(t *testing.T) {
+	g := NewFakeGateway(FakeGatewayConfig{})
+
+	token := g.Tokenize(domain.GatewayPaymentMethod{CustomerID: "user-1", Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030})
+	method, err := g.LookupPaymentMethod(context.Background(), token)
+	if err != nil {
+		t.Fatalf("LookupPaymentMethod() error = %v", err)
+	}
+	if method.Token != token || method.CustomerID != "user-1" || method.Brand != "visa" {
+		t.Errorf("unexpected payment method %+v", method)
+	}
+
+	g.Reset()
+	if _, err := g.LookupPaymentMethod(context.Background(), token); err != nil {
+		t.Errorf("expected issued tokens to survive Reset, got %v", err)
+	}
+	if _, err := g.LookupPaymentMethod(context.Background(), "tok_unknown"); !errors.Is(err, domain.ErrUnknownPaymentMethod) {
+		t.Errorf("expected ErrUnknownPaymentMethod, got %v", err)
+	}
+}
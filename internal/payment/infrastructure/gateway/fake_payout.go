@@ -0,0 +1,34 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+const fakeTransferPrefix = "fake_po_"
+
+var _ domain.PayoutGateway = (*FakePayoutGateway)(nil)
+
+// FakePayoutGateway accepts every transfer and remembers it by payout ID,
+// so retrying a payout returns the original transfer.
+type FakePayoutGateway struct {
+	mu        sync.Mutex
+	transfers map[string]int64
+}
+
+func NewFakePayoutGateway() *FakePayoutGateway {
+	return &FakePayoutGateway{transfers: make(map[string]int64)}
+}
+
+func (g *FakePayoutGateway) Transfer(_ context.Context, payoutID, sellerID string, amount int64) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.transfers[payoutID]; !ok {
+		g.transfers[payoutID] = amount
+		slog.Info("fake payout transferred", "payout_id", payoutID, "seller_id", sellerID, "amount", amount)
+	}
+	return fakeTransferPrefix + payoutID, nil
+}
@@ -0,0 +1,279 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+type FakeProviderConfig struct {
+	APIKey        string
+	WebhookSecret string
+	WebhookURL    string
+	WebhookDelay  time.Duration
+	DeclineAbove  int64
+}
+
+// FakeProvider is a local stand-in for the payment provider API spoken by
+// HTTPGateway. Authorizations are accepted as pending and captured later
+// with a signed webhook, or authorized synchronously when no webhook URL is
+// configured.
+type FakeProvider struct {
+	cfg    FakeProviderConfig
+	client *http.Client
+
+	mu             sync.Mutex
+	results        map[string]providerResponse
+	authorizations map[string]*fakeAuthorization
+	transactions   []providerTransaction
+	methods        map[string]providerPaymentMethod
+}
+
+type fakeAuthorization struct {
+	paymentID string
+	amount    int64
+	captured  bool
+}
+
+func NewFakeProvider(cfg FakeProviderConfig) *FakeProvider {
+	return &FakeProvider{
+		cfg:            cfg,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		results:        make(map[string]providerResponse),
+		authorizations: make(map[string]*fakeAuthorization),
+		methods:        make(map[string]providerPaymentMethod),
+	}
+}
+
+func (p *FakeProvider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/authorizations", p.authorized(p.authorize))
+	mux.HandleFunc("POST /v1/authorizations/{id}/capture", p.authorized(p.capture))
+	mux.HandleFunc("POST /v1/refunds", p.authorized(p.refund))
+	mux.HandleFunc("GET /v1/transactions", p.authorized(p.listTransactions))
+	mux.HandleFunc("POST /v1/payment-methods", p.authorized(p.tokenize))
+	mux.HandleFunc("GET /v1/payment-methods/{id}", p.authorized(p.getPaymentMethod))
+	return mux
+}
+
+func (p *FakeProvider) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+p.cfg.APIKey {
+			writeProviderJSON(w, http.StatusUnauthorized, providerResponse{Status: "error", Reason: "invalid_api_key"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (p *FakeProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	var req providerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PaymentID == "" || req.Amount <= 0 {
+		writeProviderJSON(w, http.StatusBadRequest, providerResponse{Status: "error", Reason: "invalid_request"})
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	if resp, ok := p.lookup(key); ok {
+		writeProviderJSON(w, providerStatusCode(resp), resp)
+		return
+	}
+
+	resp := providerResponse{ID: "auth_" + uuid.New().String()}
+	switch {
+	case p.cfg.DeclineAbove > 0 && req.Amount > p.cfg.DeclineAbove:
+		resp.Status = providerStatusDeclined
+		resp.Reason = "insufficient_funds"
+	case p.cfg.WebhookURL == "":
+		resp.Status = providerStatusAuthorized
+		p.mu.Lock()
+		p.authorizations[resp.ID] = &fakeAuthorization{paymentID: req.PaymentID, amount: req.Amount}
+		p.mu.Unlock()
+	default:
+		resp.Status = providerStatusPending
+		go p.notify(req.PaymentID, req.Amount)
+	}
+
+	p.store(key, resp)
+	writeProviderJSON(w, providerStatusCode(resp), resp)
+}
+
+func (p *FakeProvider) capture(w http.ResponseWriter, r *http.Request) {
+	var req providerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProviderJSON(w, http.StatusBadRequest, providerResponse{Status: "error", Reason: "invalid_request"})
+		return
+	}
+
+	id := r.PathValue("id")
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, ok := p.authorizations[id]
+	if !ok {
+		writeProviderJSON(w, http.StatusNotFound, providerResponse{ID: id, Status: "error", Reason: "unknown_authorization"})
+		return
+	}
+	if req.Amount > auth.amount {
+		writeProviderJSON(w, http.StatusPaymentRequired, providerResponse{ID: id, Status: providerStatusDeclined, Reason: "amount_exceeds_authorization"})
+		return
+	}
+	if !auth.captured {
+		auth.captured = true
+		p.transact(domain.TransactionCapture, auth.paymentID, "", req.Amount)
+	}
+	writeProviderJSON(w, http.StatusOK, providerResponse{ID: id, Status: providerStatusSucceeded})
+}
+
+func (p *FakeProvider) refund(w http.ResponseWriter, r *http.Request) {
+	var req providerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PaymentID == "" || req.Amount <= 0 {
+		writeProviderJSON(w, http.StatusBadRequest, providerResponse{Status: "error", Reason: "invalid_request"})
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	if resp, ok := p.lookup(key); ok {
+		writeProviderJSON(w, providerStatusCode(resp), resp)
+		return
+	}
+
+	resp := providerResponse{ID: "re_" + uuid.New().String(), Status: providerStatusSucceeded}
+	p.store(key, resp)
+	p.mu.Lock()
+	p.transact(domain.TransactionRefund, req.PaymentID, req.RefundID, req.Amount)
+	p.mu.Unlock()
+	writeProviderJSON(w, http.StatusOK, resp)
+}
+
+func (p *FakeProvider) listTransactions(w http.ResponseWriter, r *http.Request) {
+	from, errFrom := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	to, errTo := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		writeProviderJSON(w, http.StatusBadRequest, providerResponse{Status: "error", Reason: "invalid_request"})
+		return
+	}
+
+	p.mu.Lock()
+	list := providerTransactionList{Transactions: []providerTransaction{}}
+	for _, tx := range p.transactions {
+		if !tx.CreatedAt.Before(from) && tx.CreatedAt.Before(to) {
+			list.Transactions = append(list.Transactions, tx)
+		}
+	}
+	p.mu.Unlock()
+	writeProviderJSON(w, http.StatusOK, list)
+}
+
+// tokenize stands in for the provider's client-side card tokenization and
+// issues a token to the given customer.
+func (p *FakeProvider) tokenize(w http.ResponseWriter, r *http.Request) {
+	var method providerPaymentMethod
+	if err := json.NewDecoder(r.Body).Decode(&method); err != nil || method.CustomerID == "" {
+		writeProviderJSON(w, http.StatusBadRequest, providerResponse{Status: "error", Reason: "invalid_request"})
+		return
+	}
+
+	method.ID = "tok_" + uuid.New().String()
+	p.mu.Lock()
+	p.methods[method.ID] = method
+	p.mu.Unlock()
+	writeProviderJSON(w, http.StatusCreated, method)
+}
+
+func (p *FakeProvider) getPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	p.mu.Lock()
+	method, ok := p.methods[id]
+	p.mu.Unlock()
+	if !ok {
+		writeProviderJSON(w, http.StatusNotFound, providerResponse{ID: id, Status: "error", Reason: "unknown_payment_method"})
+		return
+	}
+	writeProviderJSON(w, http.StatusOK, method)
+}
+
+// transact must be called with p.mu held.
+func (p *FakeProvider) transact(txType, paymentID, refundID string, amount int64) {
+	p.transactions = append(p.transactions, providerTransaction{
+		ID: "tx_" + uuid.New().String(), Type: txType, PaymentID: paymentID,
+		RefundID: refundID, Amount: amount, CreatedAt: time.Now(),
+	})
+}
+
+func (p *FakeProvider) notify(paymentID string, amount int64) {
+	time.Sleep(p.cfg.WebhookDelay)
+	p.mu.Lock()
+	p.transact(domain.TransactionCapture, paymentID, "", amount)
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:        "evt_" + uuid.New().String(),
+		Type:      domain.WebhookChargeSucceeded,
+		PaymentID: paymentID,
+	})
+	if err != nil {
+		slog.Error("failed to encode webhook", "error", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.cfg.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		slog.Error("failed to build webhook request", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Signature", SignWebhook([]byte(p.cfg.WebhookSecret), time.Now(), payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		slog.Warn("failed to deliver webhook", "payment_id", paymentID, "error", err)
+		return
+	}
+	if err := resp.Body.Close(); err != nil {
+		slog.Warn("failed to close webhook response body", "error", err)
+	}
+	slog.Info("webhook delivered", "payment_id", paymentID, "status", resp.StatusCode)
+}
+
+func (p *FakeProvider) lookup(key string) (providerResponse, bool) {
+	if key == "" {
+		return providerResponse{}, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resp, ok := p.results[key]
+	return resp, ok
+}
+
+func (p *FakeProvider) store(key string, resp providerResponse) {
+	if key == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[key] = resp
+}
+
+func providerStatusCode(resp providerResponse) int {
+	switch resp.Status {
+	case providerStatusDeclined:
+		return http.StatusPaymentRequired
+	case providerStatusPending:
+		return http.StatusAccepted
+	}
+	return http.StatusOK
+}
+
+func writeProviderJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("failed to encode provider response", "error", err)
+	}
+}
@@ -0,0 +1,268 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+const (
+	providerStatusAuthorized = "authorized"
+	providerStatusSucceeded  = "succeeded"
+	providerStatusPending    = "pending"
+	providerStatusDeclined   = "declined"
+)
+
+var (
+	errProviderUnavailable = errors.New("payment gateway: provider unavailable")
+	errProviderNotFound    = errors.New("payment gateway: provider resource not found")
+)
+
+var (
+	_ domain.PaymentGateway       = (*HTTPGateway)(nil)
+	_ domain.PaymentMethodGateway = (*HTTPGateway)(nil)
+)
+
+type HTTPGatewayConfig struct {
+	BaseURL    string
+	APIKey     string
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+type HTTPGateway struct {
+	baseURL    string
+	apiKey     string
+	maxRetries int
+	baseDelay  time.Duration
+	client     *http.Client
+}
+
+func NewHTTPGateway(cfg HTTPGatewayConfig) *HTTPGateway {
+	return &HTTPGateway{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		maxRetries: cfg.MaxRetries,
+		baseDelay:  100 * time.Millisecond,
+		client:     &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type providerRequest struct {
+	PaymentID     string `json:"payment_id,omitempty"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+	RefundID      string `json:"refund_id,omitempty"`
+	Amount        int64  `json:"amount"`
+}
+
+type providerResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type providerTransaction struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	PaymentID string    `json:"payment_id"`
+	RefundID  string    `json:"refund_id,omitempty"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type providerTransactionList struct {
+	Transactions []providerTransaction `json:"transactions"`
+}
+
+type providerPaymentMethod struct {
+	ID         string `json:"id"`
+	CustomerID string `json:"customer_id"`
+	Brand      string `json:"brand"`
+	Last4      string `json:"last4"`
+	ExpMonth   int    `json:"exp_month"`
+	ExpYear    int    `json:"exp_year"`
+}
+
+func (g *HTTPGateway) Authorize(ctx context.Context, idempotencyKey, paymentID, paymentMethod string, amount int64) (string, error) {
+	req := providerRequest{PaymentID: paymentID, PaymentMethod: paymentMethod, Amount: amount}
+	resp, err := g.post(ctx, "/v1/authorizations", "authorize:"+idempotencyKey, req)
+	if err != nil {
+		return "", err
+	}
+	switch resp.Status {
+	case providerStatusAuthorized:
+		return resp.ID, nil
+	case providerStatusPending:
+		return "", domain.ErrChargePending
+	case providerStatusDeclined:
+		return "", declineError(resp.Reason)
+	}
+	return "", fmt.Errorf("payment gateway: unexpected authorization status %q", resp.Status)
+}
+
+func (g *HTTPGateway) Capture(ctx context.Context, idempotencyKey, authorizationID string, amount int64) error {
+	path := "/v1/authorizations/" + url.PathEscape(authorizationID) + "/capture"
+	resp, err := g.post(ctx, path, "capture:"+idempotencyKey, providerRequest{Amount: amount})
+	if err != nil {
+		return err
+	}
+	switch resp.Status {
+	case providerStatusSucceeded:
+		return nil
+	case providerStatusPending:
+		return domain.ErrChargePending
+	case providerStatusDeclined:
+		return declineError(resp.Reason)
+	}
+	return fmt.Errorf("payment gateway: unexpected capture status %q", resp.Status)
+}
+
+func (g *HTTPGateway) Refund(ctx context.Context, paymentID, refundID string, amount int64) error {
+	resp, err := g.post(ctx, "/v1/refunds", "refund:"+refundID, providerRequest{PaymentID: paymentID, RefundID: refundID, Amount: amount})
+	if err != nil {
+		return err
+	}
+	switch resp.Status {
+	case providerStatusSucceeded:
+		return nil
+	case providerStatusPending:
+		return domain.ErrRefundPending
+	case providerStatusDeclined:
+		return declineError(resp.Reason)
+	}
+	return fmt.Errorf("payment gateway: unexpected refund status %q", resp.Status)
+}
+
+func (g *HTTPGateway) ListTransactions(ctx context.Context, from, to time.Time) ([]domain.GatewayTransaction, error) {
+	params := url.Values{}
+	params.Set("from", from.UTC().Format(time.RFC3339))
+	params.Set("to", to.UTC().Format(time.RFC3339))
+
+	var list providerTransactionList
+	if err := g.send(ctx, http.MethodGet, "/v1/transactions?"+params.Encode(), "", nil, &list); err != nil {
+		return nil, err
+	}
+	txs := make([]domain.GatewayTransaction, len(list.Transactions))
+	for i, tx := range list.Transactions {
+		txs[i] = domain.GatewayTransaction{
+			ID: tx.ID, Type: tx.Type, PaymentID: tx.PaymentID,
+			RefundID: tx.RefundID, Amount: tx.Amount, CreatedAt: tx.CreatedAt,
+		}
+	}
+	return txs, nil
+}
+
+func (g *HTTPGateway) LookupPaymentMethod(ctx context.Context, token string) (*domain.GatewayPaymentMethod, error) {
+	var method providerPaymentMethod
+	err := g.send(ctx, http.MethodGet, "/v1/payment-methods/"+url.PathEscape(token), "", nil, &method)
+	if errors.Is(err, errProviderNotFound) {
+		return nil, domain.ErrUnknownPaymentMethod
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GatewayPaymentMethod{
+		Token: method.ID, CustomerID: method.CustomerID, Brand: method.Brand,
+		Last4: method.Last4, ExpMonth: method.ExpMonth, ExpYear: method.ExpYear,
+	}, nil
+}
+
+func declineError(reason string) error {
+	if reason == "" {
+		return domain.ErrDeclined
+	}
+	return fmt.Errorf("%w: %s", domain.ErrDeclined, reason)
+}
+
+func (g *HTTPGateway) post(ctx context.Context, path, idempotencyKey string, body any) (*providerResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var out providerResponse
+	if err := g.send(ctx, http.MethodPost, path, idempotencyKey, payload, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// send retries transport errors and 5xx responses with exponential backoff.
+// The idempotency key lets the provider deduplicate the retried attempts.
+func (g *HTTPGateway) send(ctx context.Context, method, path, idempotencyKey string, payload []byte, out any) error {
+	delay := g.baseDelay
+	for attempt := 0; ; attempt++ {
+		retryable, err := g.do(ctx, method, path, idempotencyKey, payload, out)
+		if err == nil || !retryable || attempt >= g.maxRetries {
+			return err
+		}
+
+		slog.Warn("payment provider request failed, retrying",
+			"path", path,
+			"attempt", attempt+1,
+			"max_retries", g.maxRetries,
+			"error", err,
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func (g *HTTPGateway) do(ctx context.Context, method, path, idempotencyKey string, payload []byte, out any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.apiKey)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("%w: %v", errProviderUnavailable, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Warn("failed to close provider response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return true, fmt.Errorf("%w: status %d", errProviderUnavailable, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("payment gateway: invalid provider response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusPaymentRequired:
+		if pr, ok := out.(*providerResponse); ok {
+			pr.Status = providerStatusDeclined
+		}
+	case resp.StatusCode == http.StatusNotFound:
+		return false, errProviderNotFound
+	case resp.StatusCode >= http.StatusBadRequest:
+		return false, fmt.Errorf("payment gateway: provider rejected request with status %d", resp.StatusCode)
+	}
+	return false, nil
+}
@@ -0,0 +1,285 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+const (
+	testAPIKey = "test-key"
+	testSecret = "test-secret"
+)
+
+func newTestGateway(baseURL string) *HTTPGateway {
+	g := NewHTTPGateway(HTTPGatewayConfig{BaseURL: baseURL, APIKey: testAPIKey, Timeout: time.Second, MaxRetries: 2})
+	g.baseDelay = time.Millisecond
+	return g
+}
+
+func TestHTTPGateway_AuthorizeAndCapture(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+	g := newTestGateway(srv.URL)
+
+	authorizationID, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if authorizationID == "" {
+		t.Fatal("expected an authorization ID")
+	}
+	if err := g.Capture(context.Background(), "pay-1:1", authorizationID, 5000); err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+
+	again, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err != nil || again != authorizationID {
+		t.Errorf("repeated Authorize() = %q, %v; want the original authorization %q", again, err, authorizationID)
+	}
+}
+
+func TestHTTPGateway_Capture_ExceedsAuthorization(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+	g := newTestGateway(srv.URL)
+
+	authorizationID, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if err := g.Capture(context.Background(), "pay-1:1", authorizationID, 6000); !errors.Is(err, domain.ErrDeclined) {
+		t.Errorf("expected ErrDeclined, got %v", err)
+	}
+}
+
+func TestHTTPGateway_Authorize_Declined(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey, DeclineAbove: 1000})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+
+	_, err := newTestGateway(srv.URL).Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if !errors.Is(err, domain.ErrDeclined) {
+		t.Fatalf("expected ErrDeclined, got %v", err)
+	}
+}
+
+func TestHTTPGateway_Authorize_InvalidAPIKey(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: "other-key"})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+
+	_, err := newTestGateway(srv.URL).Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err == nil || errors.Is(err, domain.ErrDeclined) {
+		t.Fatalf("expected credentials error, got %v", err)
+	}
+}
+
+func TestHTTPGateway_Authorize_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Idempotency-Key") != "authorize:pay-1:1" {
+			t.Errorf("unexpected idempotency key %q", r.Header.Get("Idempotency-Key"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Error("retried request was sent without a body")
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeProviderJSON(w, http.StatusOK, providerResponse{ID: "auth_1", Status: providerStatusAuthorized})
+	}))
+	defer srv.Close()
+
+	if _, err := newTestGateway(srv.URL).Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000); err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestHTTPGateway_Authorize_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := newTestGateway(srv.URL).Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if !errors.Is(err, errProviderUnavailable) {
+		t.Fatalf("expected errProviderUnavailable, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestHTTPGateway_Authorize_PendingThenWebhook(t *testing.T) {
+	verifier := NewHMACWebhookVerifier(testSecret, time.Minute)
+	received := make(chan *domain.WebhookEvent, 1)
+	webhooks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		evt, err := verifier.Verify(payload, r.Header.Get("X-Webhook-Signature"))
+		if err != nil {
+			t.Errorf("Verify() error = %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received <- evt
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhooks.Close()
+
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey, WebhookSecret: testSecret, WebhookURL: webhooks.URL})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+
+	_, err := newTestGateway(srv.URL).Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if !errors.Is(err, domain.ErrChargePending) {
+		t.Fatalf("expected ErrChargePending, got %v", err)
+	}
+
+	select {
+	case evt := <-received:
+		if evt.Type != domain.WebhookChargeSucceeded || evt.PaymentID != "pay-1" {
+			t.Errorf("unexpected webhook event %+v", evt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+}
+
+func TestHTTPGateway_Refund(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+
+	if err := newTestGateway(srv.URL).Refund(context.Background(), "pay-1", "re-1", 5000); err != nil {
+		t.Fatalf("Refund() error = %v", err)
+	}
+}
+
+func TestHTTPGateway_Refund_Pending(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"pending"}`))
+	}))
+	defer srv.Close()
+
+	err := newTestGateway(srv.URL).Refund(context.Background(), "pay-1", "re-1", 5000)
+	if !errors.Is(err, domain.ErrRefundPending) {
+		t.Fatalf("expected ErrRefundPending, got %v", err)
+	}
+}
+
+func TestHTTPGateway_ListTransactions(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+	g := newTestGateway(srv.URL)
+
+	authorizationID, err := g.Authorize(context.Background(), "pay-1:1", "pay-1", "", 5000)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if err := g.Capture(context.Background(), "pay-1:1", authorizationID, 5000); err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+	if err := g.Refund(context.Background(), "pay-1", "re-1", 1000); err != nil {
+		t.Fatalf("Refund() error = %v", err)
+	}
+
+	txs, err := g.ListTransactions(context.Background(), time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ListTransactions() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("got %d transactions, want 2", len(txs))
+	}
+	if txs[0].Type != domain.TransactionCapture || txs[0].PaymentID != "pay-1" || txs[0].Amount != 5000 {
+		t.Errorf("capture = %+v", txs[0])
+	}
+	if txs[1].Type != domain.TransactionRefund || txs[1].RefundID != "re-1" || txs[1].Amount != 1000 {
+		t.Errorf("refund = %+v", txs[1])
+	}
+}
+
+func TestHMACWebhookVerifier(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"charge.failed","payment_id":"pay-1","reason":"card_declined"}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signedAt  time.Time
+		signature string
+		wantErr   error
+	}{
+		{"valid", testSecret, now, "", nil},
+		{"wrong secret", "other-secret", now, "", errSignatureMismatch},
+		{"expired", testSecret, now.Add(-10 * time.Minute), "", errSignatureExpired},
+		{"malformed", testSecret, now, "v1=deadbeef", errMalformedSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewHMACWebhookVerifier(testSecret, 5*time.Minute)
+			verifier.now = func() time.Time { return now }
+
+			signature := tt.signature
+			if signature == "" {
+				signature = SignWebhook([]byte(tt.secret), tt.signedAt, payload)
+			}
+
+			evt, err := verifier.Verify(payload, signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (evt.Type != domain.WebhookChargeFailed || evt.Reason != "card_declined") {
+				t.Errorf("unexpected event %+v", evt)
+			}
+		})
+	}
+}
+
+func TestHTTPGateway_LookupPaymentMethod(t *testing.T) {
+	provider := NewFakeProvider(FakeProviderConfig{APIKey: testAPIKey})
+	srv := httptest.NewServer(provider.Handler())
+	defer srv.Close()
+	g := newTestGateway(srv.URL)
+
+	body, err := json.Marshal(providerPaymentMethod{CustomerID: "user-1", Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var issued providerPaymentMethod
+	if err := g.send(context.Background(), http.MethodPost, "/v1/payment-methods", "", body, &issued); err != nil {
+		t.Fatalf("tokenize error = %v", err)
+	}
+
+	method, err := g.LookupPaymentMethod(context.Background(), issued.ID)
+	if err != nil {
+		t.Fatalf("LookupPaymentMethod() error = %v", err)
+	}
+	want := domain.GatewayPaymentMethod{Token: issued.ID, CustomerID: "user-1", Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030}
+	if *method != want {
+		t.Errorf("LookupPaymentMethod() = %+v, want %+v", *method, want)
+	}
+
+	if _, err := g.LookupPaymentMethod(context.Background(), "tok_unknown"); !errors.Is(err, domain.ErrUnknownPaymentMethod) {
+		t.Errorf("expected ErrUnknownPaymentMethod, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+)
+
+var (
+	errMalformedSignature = errors.New("malformed webhook signature")
+	errSignatureMismatch  = errors.New("webhook signature mismatch")
+	errSignatureExpired   = errors.New("webhook timestamp outside tolerance")
+	errMalformedPayload   = errors.New("malformed webhook payload")
+)
+
+var _ domain.WebhookVerifier = (*HMACWebhookVerifier)(nil)
+
+type webhookPayload struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	PaymentID string `json:"payment_id"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SignWebhook produces a "t=<unix>,v1=<hex>" header value where v1 is the
+// HMAC-SHA256 of "<unix>.<payload>" under the shared secret.
+func SignWebhook(secret []byte, ts time.Time, payload []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return "t=" + unix + ",v1=" + computeSignature(secret, unix, payload)
+}
+
+func computeSignature(secret []byte, unix string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type HMACWebhookVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+func NewHMACWebhookVerifier(secret string, tolerance time.Duration) *HMACWebhookVerifier {
+	return &HMACWebhookVerifier{secret: []byte(secret), tolerance: tolerance, now: time.Now}
+}
+
+func (v *HMACWebhookVerifier) Verify(payload []byte, signature string) (*domain.WebhookEvent, error) {
+	var unix, sig string
+	for part := range strings.SplitSeq(signature, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, errMalformedSignature
+		}
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			sig = value
+		}
+	}
+	if unix == "" || sig == "" {
+		return nil, errMalformedSignature
+	}
+
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return nil, errMalformedSignature
+	}
+	if age := v.now().Sub(time.Unix(ts, 0)); age > v.tolerance || age < -v.tolerance {
+		return nil, errSignatureExpired
+	}
+
+	expected := computeSignature(v.secret, unix, payload)
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return nil, errSignatureMismatch
+	}
+
+	var p webhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedPayload, err)
+	}
+	if p.Type == "" || p.PaymentID == "" {
+		return nil, errMalformedPayload
+	}
+
+	return &domain.WebhookEvent{ID: p.ID, Type: p.Type, PaymentID: p.PaymentID, Reason: p.Reason}, nil
+}
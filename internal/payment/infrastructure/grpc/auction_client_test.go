@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	auctionv1 "github.com/in-jun/go-structure-example/proto/auction/v1"
+)
+
+type stubAuctionServer struct {
+	auctionv1.UnimplementedAuctionServiceServer
+}
+
+func (s *stubAuctionServer) GetAuction(_ context.Context, req *auctionv1.GetAuctionRequest) (*auctionv1.GetAuctionResponse, error) {
+	return &auctionv1.GetAuctionResponse{
+		Id: req.AuctionId, SellerId: "seller-1", StartPrice: 1000, Status: "closed", Category: "electronics",
+	}, nil
+}
+
+func TestAuctionClient_GetAuction(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	auctionv1.RegisterAuctionServiceServer(srv, &stubAuctionServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	client, err := NewAuctionClient(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	auction, err := client.GetAuction(context.Background(), "auction-1")
+	if err != nil {
+		t.Fatalf("GetAuction() error = %v", err)
+	}
+	if auction.ID != "auction-1" || auction.SellerID != "seller-1" || auction.Category != "electronics" {
+		t.Errorf("unexpected auction %+v", auction)
+	}
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/logging"
+	"github.com/in-jun/go-structure-example/internal/shared/middleware"
+	"github.com/sony/gobreaker/v2"
+)
+
+var _ domain.UserClient = (*userClient)(nil)
+
+type userClient struct {
+	baseURL    string
+	signer     *middleware.IdentitySigner
+	httpClient *http.Client
+	cb         *gobreaker.CircuitBreaker[*domain.UserInfo]
+}
+
+// NewUserClient calls the auth service's internal user endpoint, signing
+// each request as a service call with signer.
+func NewUserClient(baseURL string, signer *middleware.IdentitySigner) domain.UserClient {
+	cb := gobreaker.NewCircuitBreaker[*domain.UserInfo](gobreaker.Settings{
+		Name:        "auth-service",
+		MaxRequests: 1,
+		Interval:    60 * time.Second,
+		Timeout:     60 * time.Second,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 5
+		},
+	})
+
+	return &userClient{
+		baseURL:    baseURL,
+		signer:     signer,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cb:         cb,
+	}
+}
+
+type userResponse struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+func (c *userClient) GetUser(ctx context.Context, userID string) (*domain.UserInfo, error) {
+	result, err := c.cb.Execute(func() (*domain.UserInfo, error) {
+		return c.getUserWithRetry(ctx, userID)
+	})
+	if err != nil {
+		if stderrors.Is(err, gobreaker.ErrOpenState) || stderrors.Is(err, gobreaker.ErrTooManyRequests) {
+			return nil, errors.Internal("Auth service temporarily unavailable")
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *userClient) getUserWithRetry(ctx context.Context, userID string) (*domain.UserInfo, error) {
+	var lastErr error
+	for attempt := range 3 {
+		if attempt > 0 {
+			delay := time.Duration(100<<(attempt-1)) * time.Millisecond
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		result, err := c.doRequest(ctx, userID)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if isClientError(err) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *userClient) doRequest(ctx context.Context, userID string) (*domain.UserInfo, error) {
+	url := fmt.Sprintf("%s/internal/v1/users/%s", c.baseURL, userID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Internal("Failed to create request")
+	}
+	c.signer.Sign(req, middleware.Identity{
+		Roles:     []string{middleware.RoleService},
+		RequestID: logging.RequestIDFromContext(ctx),
+	})
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Internal("Failed to call auth service")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Warn("failed to close auth response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NotFound("User not found")
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, errors.BadRequest("Auth service client error")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Internal("Auth service returned unexpected status")
+	}
+
+	var ur userResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
+		return nil, errors.Internal("Failed to decode user response")
+	}
+
+	return &domain.UserInfo{ID: ur.ID, Email: ur.Email, Name: ur.Name}, nil
+}
+
+func isClientError(err error) bool {
+	var ce errors.CustomError
+	if stderrors.As(err, &ce) {
+		return ce.Status >= 400 && ce.Status < 500
+	}
+	return false
+}
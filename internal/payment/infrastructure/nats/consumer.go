@@ -15,6 +15,9 @@ import (
 type Consumer struct {
 	nc                   *nats.Conn
 	createPaymentHandler *command.CreatePaymentHandler
+	recordSaleHandler    *command.RecordSaleHandler
+	recordRefundHandler  *command.RecordRefundHandler
+	issueInvoiceHandler  *command.IssueInvoiceHandler
 	dbGetter             func(ctx context.Context) transaction.DBTX
 	transactor           transaction.Transactor
 	subs                 []*nats.Subscription
@@ -23,11 +26,15 @@ type Consumer struct {
 func NewConsumer(
 	nc *nats.Conn,
 	createPaymentHandler *command.CreatePaymentHandler,
+	recordSaleHandler *command.RecordSaleHandler,
+	recordRefundHandler *command.RecordRefundHandler,
+	issueInvoiceHandler *command.IssueInvoiceHandler,
 	dbGetter func(ctx context.Context) transaction.DBTX,
 	transactor transaction.Transactor,
 ) *Consumer {
 	return &Consumer{
-		nc: nc, createPaymentHandler: createPaymentHandler,
+		nc: nc, createPaymentHandler: createPaymentHandler, recordSaleHandler: recordSaleHandler,
+		recordRefundHandler: recordRefundHandler, issueInvoiceHandler: issueInvoiceHandler,
 		dbGetter: dbGetter, transactor: transactor,
 	}
 }
@@ -38,6 +45,19 @@ type bidWonEvent struct {
 	Amount    int64  `json:"amount"`
 }
 
+type auctionSettledEvent struct {
+	AuctionID string `json:"auction_id"`
+}
+
+type paymentCompletedEvent struct {
+	PaymentID string `json:"payment_id"`
+}
+
+type paymentRefundedEvent struct {
+	PaymentID string `json:"payment_id"`
+	RefundID  string `json:"refund_id"`
+}
+
 func (c *Consumer) Start(_ context.Context) error {
 	sub, err := sharedNats.SubscribeIdempotent(c.nc, "bid.won", "payment", c.dbGetter, c.transactor,
 		func(ctx context.Context, env *sharedEvent.Envelope) error {
@@ -58,7 +78,53 @@ func (c *Consumer) Start(_ context.Context) error {
 	}
 	c.subs = append(c.subs, sub)
 
-	slog.Info("NATS consumer started", "service", "payment", "subjects", "bid.won")
+	sub, err = sharedNats.SubscribeIdempotent(c.nc, "auction.settled", "payment", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			var ae auctionSettledEvent
+			if err := json.Unmarshal(env.Payload, &ae); err != nil {
+				return err
+			}
+			slog.Info("received auction.settled", "service", "payment", "auction_id", ae.AuctionID)
+			return c.recordSaleHandler.Handle(ctx, command.RecordSale{AuctionID: ae.AuctionID})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	sub, err = sharedNats.SubscribeIdempotent(c.nc, "payment.completed", "payment", c.dbGetter, c.transactor,
+		func(ctx context.Context, env *sharedEvent.Envelope) error {
+			var pe paymentCompletedEvent
+			if err := json.Unmarshal(env.Payload, &pe); err != nil {
+				return err
+			}
+			slog.Info("received payment.completed", "service", "payment", "payment_id", pe.PaymentID)
+			return c.issueInvoiceHandler.Handle(ctx, command.IssueInvoice{PaymentID: pe.PaymentID})
+		})
+	if err != nil {
+		return err
+	}
+	c.subs = append(c.subs, sub)
+
+	// Full and partial refunds both reverse their share of the sale.
+	for _, subject := range []string{"payment.refunded", "payment.partially_refunded"} {
+		sub, err = sharedNats.SubscribeIdempotent(c.nc, subject, "payment", c.dbGetter, c.transactor,
+			func(ctx context.Context, env *sharedEvent.Envelope) error {
+				var re paymentRefundedEvent
+				if err := json.Unmarshal(env.Payload, &re); err != nil {
+					return err
+				}
+				slog.Info("received "+env.Type, "service", "payment", "payment_id", re.PaymentID, "refund_id", re.RefundID)
+				return c.recordRefundHandler.Handle(ctx, command.RecordRefund{PaymentID: re.PaymentID, RefundID: re.RefundID})
+			})
+		if err != nil {
+			return err
+		}
+		c.subs = append(c.subs, sub)
+	}
+
+	slog.Info("NATS consumer started", "service", "payment", "subjects",
+		"bid.won, auction.settled, payment.completed, payment.refunded, payment.partially_refunded")
 	return nil
 }
 
@@ -0,0 +1,195 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.DisputeRepository = (*disputeRepository)(nil)
+
+const disputeColumns = `id, payment_id, buyer_id, seller_id, reason, response, status, resolution, refund_amount,
+	respond_by, review_by, review_overdue, created_at, updated_at`
+
+type disputeRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewDisputeRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.DisputeRepository {
+	return &disputeRepository{dbGetter: dbGetter}
+}
+
+func (r *disputeRepository) Save(ctx context.Context, dispute *entity.Dispute) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO disputes ("+disputeColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+		dispute.ID(), dispute.PaymentID(), dispute.BuyerID(), dispute.SellerID(), dispute.Reason(),
+		dispute.Response(), dispute.Status(), dispute.Resolution(), dispute.RefundAmount(),
+		dispute.RespondBy(), nullTime(dispute.ReviewBy()), dispute.ReviewOverdue(),
+		dispute.CreatedAt(), dispute.UpdatedAt(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to create dispute")
+	}
+	return nil
+}
+
+func scanDispute(row rowScanner, extra ...any) (*entity.Dispute, error) {
+	var id, paymentID, buyerID, sellerID, reason, response, status, resolution string
+	var refundAmount int64
+	var respondBy, createdAt, updatedAt time.Time
+	var reviewBy sql.NullTime
+	var overdue bool
+	dest := []any{
+		&id, &paymentID, &buyerID, &sellerID, &reason, &response, &status, &resolution, &refundAmount,
+		&respondBy, &reviewBy, &overdue, &createdAt, &updatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructDispute(
+		id, paymentID, buyerID, sellerID, reason, response, status, resolution, refundAmount,
+		respondBy, reviewBy.Time, overdue, createdAt, updatedAt,
+	), nil
+}
+
+func (r *disputeRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Dispute, error) {
+	cfg := query.ApplyOptions(opts)
+	q := "SELECT " + disputeColumns + " FROM disputes WHERE id = $1"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+	return r.find(ctx, q, id)
+}
+
+func (r *disputeRepository) FindActiveByPaymentID(ctx context.Context, paymentID string) (*entity.Dispute, error) {
+	return r.find(ctx,
+		"SELECT "+disputeColumns+" FROM disputes WHERE payment_id = $1 AND status <> $2",
+		paymentID, entity.DisputeStatusResolved,
+	)
+}
+
+func (r *disputeRepository) HasUnsettledForSeller(ctx context.Context, sellerID string) (bool, error) {
+	db := r.dbGetter(ctx)
+	var exists bool
+	err := db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM disputes d JOIN payments p ON p.id = d.payment_id
+		WHERE d.seller_id = $1 AND (d.status <> $2 OR (d.refund_amount > 0 AND p.status = $3)))`,
+		sellerID, entity.DisputeStatusResolved, entity.StatusRefundPending,
+	).Scan(&exists)
+	if err != nil {
+		return false, errors.Internal("Failed to check disputes")
+	}
+	return exists, nil
+}
+
+func (r *disputeRepository) FindDeadlineDue(ctx context.Context, now time.Time, limit int) ([]*entity.Dispute, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx,
+		"SELECT "+disputeColumns+` FROM disputes
+		WHERE (status = $1 AND respond_by <= $3) OR (status = $2 AND NOT review_overdue AND review_by <= $3)
+		ORDER BY updated_at LIMIT $4`,
+		entity.DisputeStatusOpen, entity.DisputeStatusUnderReview, now, limit,
+	)
+	if err != nil {
+		return nil, errors.Internal("Failed to list due disputes")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var disputes []*entity.Dispute
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, errors.Internal("Failed to scan dispute")
+		}
+		disputes = append(disputes, dispute)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Failed to list due disputes")
+	}
+	return disputes, nil
+}
+
+func (r *disputeRepository) List(ctx context.Context, status string, page, limit int) ([]*entity.Dispute, int64, error) {
+	db := r.dbGetter(ctx)
+	offset := (page - 1) * limit
+
+	q := "SELECT " + disputeColumns + ", COUNT(*) OVER() FROM disputes"
+	var args []any
+	if status != "" {
+		q += " WHERE status = $1"
+		args = append(args, status)
+	}
+	q += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, errors.Internal("Failed to list disputes")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var disputes []*entity.Dispute
+	var total int64
+	for rows.Next() {
+		dispute, err := scanDispute(rows, &total)
+		if err != nil {
+			return nil, 0, errors.Internal("Failed to scan dispute")
+		}
+		disputes = append(disputes, dispute)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Internal("Error iterating disputes")
+	}
+	return disputes, total, nil
+}
+
+func (r *disputeRepository) Update(ctx context.Context, dispute *entity.Dispute) error {
+	db := r.dbGetter(ctx)
+	result, err := db.ExecContext(ctx,
+		`UPDATE disputes SET response = $1, status = $2, resolution = $3, refund_amount = $4,
+		review_by = $5, review_overdue = $6, updated_at = $7 WHERE id = $8`,
+		dispute.Response(), dispute.Status(), dispute.Resolution(), dispute.RefundAmount(),
+		nullTime(dispute.ReviewBy()), dispute.ReviewOverdue(), dispute.UpdatedAt(), dispute.ID(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to update dispute")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("Dispute not found")
+	}
+	return nil
+}
+
+func (r *disputeRepository) find(ctx context.Context, q string, args ...any) (*entity.Dispute, error) {
+	db := r.dbGetter(ctx)
+	dispute, err := scanDispute(db.QueryRowContext(ctx, q, args...))
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get dispute")
+	}
+	return dispute, nil
+}
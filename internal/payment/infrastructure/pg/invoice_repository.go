@@ -0,0 +1,106 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.InvoiceRepository = (*invoiceRepository)(nil)
+
+const invoiceColumns = "id, sequence, payment_id, auction_id, buyer_id, buyer_name, buyer_email, " +
+	"seller_id, seller_name, seller_email, lines, total, seller_net, issued_at"
+
+type invoiceLine struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+type invoiceRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewInvoiceRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.InvoiceRepository {
+	return &invoiceRepository{dbGetter: dbGetter}
+}
+
+func (r *invoiceRepository) NextSequence(ctx context.Context) (int64, error) {
+	db := r.dbGetter(ctx)
+	var seq int64
+	err := db.QueryRowContext(ctx,
+		"UPDATE invoice_counter SET value = value + 1 RETURNING value",
+	).Scan(&seq)
+	if err != nil {
+		return 0, errors.Internal("Failed to allocate invoice number")
+	}
+	return seq, nil
+}
+
+func (r *invoiceRepository) Save(ctx context.Context, invoice *entity.Invoice) error {
+	lines := make([]invoiceLine, 0, len(invoice.Lines()))
+	for _, l := range invoice.Lines() {
+		lines = append(lines, invoiceLine{Kind: l.Kind, Description: l.Description, Amount: l.Amount})
+	}
+	encoded, err := json.Marshal(lines)
+	if err != nil {
+		return errors.Internal("Failed to encode invoice lines")
+	}
+
+	db := r.dbGetter(ctx)
+	buyer, seller := invoice.Buyer(), invoice.Seller()
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO invoices ("+invoiceColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+		invoice.ID(), invoice.Sequence(), invoice.PaymentID(), invoice.AuctionID(),
+		buyer.ID, buyer.Name, buyer.Email, seller.ID, seller.Name, seller.Email,
+		encoded, invoice.Total(), invoice.SellerNet(), invoice.IssuedAt(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to create invoice")
+	}
+	return nil
+}
+
+func (r *invoiceRepository) FindByPaymentID(ctx context.Context, paymentID string) (*entity.Invoice, error) {
+	db := r.dbGetter(ctx)
+	invoice, err := scanInvoice(db.QueryRowContext(ctx,
+		"SELECT "+invoiceColumns+" FROM invoices WHERE payment_id = $1", paymentID,
+	))
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get invoice")
+	}
+	return invoice, nil
+}
+
+func scanInvoice(row rowScanner) (*entity.Invoice, error) {
+	var id, paymentID, auctionID string
+	var buyer, seller entity.InvoiceParty
+	var sequence, total, sellerNet int64
+	var encoded []byte
+	var issuedAt time.Time
+	if err := row.Scan(&id, &sequence, &paymentID, &auctionID,
+		&buyer.ID, &buyer.Name, &buyer.Email, &seller.ID, &seller.Name, &seller.Email,
+		&encoded, &total, &sellerNet, &issuedAt); err != nil {
+		return nil, err
+	}
+
+	var stored []invoiceLine
+	if err := json.Unmarshal(encoded, &stored); err != nil {
+		return nil, err
+	}
+	lines := make([]entity.InvoiceLine, 0, len(stored))
+	for _, l := range stored {
+		lines = append(lines, entity.InvoiceLine{Kind: l.Kind, Description: l.Description, Amount: l.Amount})
+	}
+	return entity.ReconstructInvoice(id, sequence, paymentID, auctionID, buyer, seller, lines, total, sellerNet, issuedAt), nil
+}
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.LedgerRepository = (*ledgerRepository)(nil)
+
+type ledgerRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewLedgerRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.LedgerRepository {
+	return &ledgerRepository{dbGetter: dbGetter}
+}
+
+// Post must run inside a transaction so the entry and its postings land
+// together.
+func (r *ledgerRepository) Post(ctx context.Context, entry *entity.JournalEntry) (bool, error) {
+	db := r.dbGetter(ctx)
+	result, err := db.ExecContext(ctx,
+		"INSERT INTO journal_entries (id, kind, reference, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (kind, reference) DO NOTHING",
+		entry.ID(), entry.Kind(), entry.Reference(), entry.CreatedAt(),
+	)
+	if err != nil {
+		return false, errors.Internal("Failed to post journal entry")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	for _, p := range entry.Postings() {
+		accountID, err := r.account(ctx, p.Account, p.OwnerID)
+		if err != nil {
+			return false, err
+		}
+		if _, err := db.ExecContext(ctx,
+			"INSERT INTO journal_postings (entry_id, account_id, amount) VALUES ($1, $2, $3)",
+			entry.ID(), accountID, p.Amount,
+		); err != nil {
+			return false, errors.Internal("Failed to post journal entry")
+		}
+	}
+	return true, nil
+}
+
+func (r *ledgerRepository) Balance(ctx context.Context, account, ownerID string) (int64, error) {
+	db := r.dbGetter(ctx)
+	var balance int64
+	err := db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(p.amount), 0) FROM journal_postings p
+		JOIN ledger_accounts a ON a.id = p.account_id
+		WHERE a.type = $1 AND a.owner_id = $2`,
+		account, ownerID,
+	).Scan(&balance)
+	if err != nil {
+		return 0, errors.Internal("Failed to get balance")
+	}
+	return balance, nil
+}
+
+func (r *ledgerRepository) LockAccount(ctx context.Context, account, ownerID string) error {
+	accountID, err := r.account(ctx, account, ownerID)
+	if err != nil {
+		return err
+	}
+	db := r.dbGetter(ctx)
+	if _, err := db.ExecContext(ctx, "SELECT id FROM ledger_accounts WHERE id = $1 FOR UPDATE", accountID); err != nil {
+		return errors.Internal("Failed to lock account")
+	}
+	return nil
+}
+
+func (r *ledgerRepository) account(ctx context.Context, account, ownerID string) (int64, error) {
+	db := r.dbGetter(ctx)
+	var id int64
+	err := db.QueryRowContext(ctx,
+		"INSERT INTO ledger_accounts (type, owner_id) VALUES ($1, $2) ON CONFLICT (type, owner_id) DO UPDATE SET type = EXCLUDED.type RETURNING id",
+		account, ownerID,
+	).Scan(&id)
+	if err != nil {
+		return 0, errors.Internal("Failed to get ledger account")
+	}
+	return id, nil
+}
@@ -0,0 +1,135 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.PaymentMethodRepository = (*paymentMethodRepository)(nil)
+
+const paymentMethodColumns = "id, user_id, token, brand, last4, exp_month, exp_year, is_default, created_at, updated_at, removed_at"
+
+type paymentMethodRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewPaymentMethodRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.PaymentMethodRepository {
+	return &paymentMethodRepository{dbGetter: dbGetter}
+}
+
+func (r *paymentMethodRepository) Save(ctx context.Context, method *entity.PaymentMethod) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO payment_methods ("+paymentMethodColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+		method.ID(), method.UserID(), method.Token(), method.Brand(), method.Last4(),
+		method.ExpMonth(), method.ExpYear(), method.IsDefault(),
+		method.CreatedAt(), method.UpdatedAt(), nullTime(method.RemovedAt()),
+	)
+	if err != nil {
+		return errors.Internal("Failed to save payment method")
+	}
+	return nil
+}
+
+func scanPaymentMethod(row rowScanner) (*entity.PaymentMethod, error) {
+	var id, userID, token, brand, last4 string
+	var expMonth, expYear int
+	var isDefault bool
+	var createdAt, updatedAt time.Time
+	var removedAt sql.NullTime
+	if err := row.Scan(&id, &userID, &token, &brand, &last4, &expMonth, &expYear, &isDefault,
+		&createdAt, &updatedAt, &removedAt); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructPaymentMethod(
+		id, userID, token, brand, last4, expMonth, expYear, isDefault, createdAt, updatedAt, removedAt.Time,
+	), nil
+}
+
+func (r *paymentMethodRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.PaymentMethod, error) {
+	cfg := query.ApplyOptions(opts)
+	q := "SELECT " + paymentMethodColumns + " FROM payment_methods WHERE id = $1"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+	return r.find(ctx, q, id)
+}
+
+func (r *paymentMethodRepository) FindDefaultByUserID(ctx context.Context, userID string) (*entity.PaymentMethod, error) {
+	return r.find(ctx,
+		"SELECT "+paymentMethodColumns+" FROM payment_methods WHERE user_id = $1 AND is_default AND removed_at IS NULL",
+		userID,
+	)
+}
+
+func (r *paymentMethodRepository) FindByUserID(ctx context.Context, userID string, opts ...query.Option) ([]*entity.PaymentMethod, error) {
+	cfg := query.ApplyOptions(opts)
+	q := "SELECT " + paymentMethodColumns + " FROM payment_methods WHERE user_id = $1 AND removed_at IS NULL ORDER BY created_at DESC"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, errors.Internal("Failed to list payment methods")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var methods []*entity.PaymentMethod
+	for rows.Next() {
+		method, err := scanPaymentMethod(rows)
+		if err != nil {
+			return nil, errors.Internal("Failed to scan payment method")
+		}
+		methods = append(methods, method)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Failed to list payment methods")
+	}
+	return methods, nil
+}
+
+func (r *paymentMethodRepository) Update(ctx context.Context, method *entity.PaymentMethod) error {
+	db := r.dbGetter(ctx)
+	result, err := db.ExecContext(ctx,
+		"UPDATE payment_methods SET is_default = $1, updated_at = $2, removed_at = $3 WHERE id = $4",
+		method.IsDefault(), method.UpdatedAt(), nullTime(method.RemovedAt()), method.ID(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to update payment method")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("Payment method not found")
+	}
+	return nil
+}
+
+func (r *paymentMethodRepository) find(ctx context.Context, q string, args ...any) (*entity.PaymentMethod, error) {
+	db := r.dbGetter(ctx)
+	method, err := scanPaymentMethod(db.QueryRowContext(ctx, q, args...))
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get payment method")
+	}
+	return method, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	stderrors "errors"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/in-jun/go-structure-example/internal/payment/domain"
@@ -15,6 +17,13 @@ import (
 
 var _ domain.PaymentRepository = (*paymentRepository)(nil)
 
+const paymentColumns = "id, auction_id, winner_id, amount, fee_amount, fee_rule, fee_category, " +
+	"escrow_hold_seconds, escrow_release_at, escrow_released_at, status, payment_method_id, due_at, reminders_sent, charge_attempts, refunded_amount, created_at, updated_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 type paymentRepository struct {
 	dbGetter func(ctx context.Context) transaction.DBTX
 }
@@ -26,8 +35,10 @@ func NewPaymentRepository(dbGetter func(ctx context.Context) transaction.DBTX) d
 func (r *paymentRepository) Save(ctx context.Context, payment *entity.Payment) error {
 	db := r.dbGetter(ctx)
 	_, err := db.ExecContext(ctx,
-		"INSERT INTO payments (id, auction_id, winner_id, amount, status) VALUES ($1, $2, $3, $4, $5)",
-		payment.ID(), payment.AuctionID(), payment.WinnerID(), payment.Amount(), payment.Status(),
+		"INSERT INTO payments (id, auction_id, winner_id, amount, fee_amount, fee_rule, fee_category, escrow_hold_seconds, status, due_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		payment.ID(), payment.AuctionID(), payment.WinnerID(), payment.Amount(),
+		payment.Fee().Amount, payment.Fee().Rule, payment.Fee().Category,
+		int64(payment.Escrow().Hold/time.Second), payment.Status(), payment.DueAt(),
 	)
 	if err != nil {
 		return errors.Internal("Failed to create payment")
@@ -35,34 +46,54 @@ func (r *paymentRepository) Save(ctx context.Context, payment *entity.Payment) e
 	return nil
 }
 
+// scanPayment reads paymentColumns followed by any extra selected columns.
+func scanPayment(row rowScanner, extra ...any) (*entity.Payment, error) {
+	var pid, auctionID, winnerID, status, methodID string
+	var amount int64
+	var fee entity.FeeBreakdown
+	var holdSeconds int64
+	var releaseAt, releasedAt sql.NullTime
+	var reminders, attempts int
+	var refunded int64
+	var dueAt, createdAt, updatedAt time.Time
+	dest := []any{&pid, &auctionID, &winnerID, &amount, &fee.Amount, &fee.Rule, &fee.Category,
+		&holdSeconds, &releaseAt, &releasedAt, &status, &methodID, &dueAt, &reminders, &attempts, &refunded, &createdAt, &updatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	escrow := entity.Escrow{
+		Hold:       time.Duration(holdSeconds) * time.Second,
+		ReleaseAt:  releaseAt.Time,
+		ReleasedAt: releasedAt.Time,
+	}
+	return entity.ReconstructPayment(pid, auctionID, winnerID, amount, fee, escrow, status, methodID, dueAt, reminders, attempts, refunded, createdAt, updatedAt), nil
+}
+
 func (r *paymentRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Payment, error) {
 	cfg := query.ApplyOptions(opts)
 	db := r.dbGetter(ctx)
-	var pid, auctionID, winnerID, status string
-	var amount int64
-	var createdAt, updatedAt time.Time
 
-	q := "SELECT id, auction_id, winner_id, amount, status, created_at, updated_at FROM payments WHERE id = $1"
+	q := "SELECT " + paymentColumns + " FROM payments WHERE id = $1"
 	if cfg.ForUpdate {
 		q += " FOR UPDATE"
 	}
 
-	err := db.QueryRowContext(ctx, q, id).Scan(&pid, &auctionID, &winnerID, &amount, &status, &createdAt, &updatedAt)
+	payment, err := scanPayment(db.QueryRowContext(ctx, q, id))
 	if stderrors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, errors.Internal("Failed to get payment")
 	}
-
-	return entity.ReconstructPayment(pid, auctionID, winnerID, amount, status, createdAt, updatedAt), nil
+	return payment, nil
 }
 
 func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
 	db := r.dbGetter(ctx)
 	result, err := db.ExecContext(ctx,
-		"UPDATE payments SET status = $1, updated_at = $2 WHERE id = $3",
-		payment.Status(), payment.UpdatedAt(), payment.ID(),
+		"UPDATE payments SET status = $1, payment_method_id = $2, reminders_sent = $3, charge_attempts = $4, refunded_amount = $5, escrow_release_at = $6, escrow_released_at = $7, updated_at = $8 WHERE id = $9",
+		payment.Status(), payment.PaymentMethodID(), payment.RemindersSent(), payment.ChargeAttempts(), payment.Refunded(),
+		nullTime(payment.Escrow().ReleaseAt), nullTime(payment.Escrow().ReleasedAt), payment.UpdatedAt(), payment.ID(),
 	)
 	if err != nil {
 		return errors.Internal("Failed to update payment")
@@ -76,3 +107,122 @@ func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment)
 	}
 	return nil
 }
+
+func (r *paymentRepository) SaveAttempt(ctx context.Context, attempt entity.PaymentAttempt) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO payment_attempts (payment_id, attempt, operation, idempotency_key, request, response, error, latency_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		attempt.PaymentID, attempt.Attempt, attempt.Operation, attempt.IdempotencyKey,
+		attempt.Request, attempt.Response, attempt.Error, attempt.Latency.Milliseconds(), attempt.AttemptedAt,
+	)
+	if err != nil {
+		return errors.Internal("Failed to record payment attempt")
+	}
+	return nil
+}
+
+func (r *paymentRepository) FindStaleProcessing(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error) {
+	return r.list(ctx, "Failed to list processing payments",
+		"SELECT "+paymentColumns+" FROM payments WHERE status = $1 AND updated_at < $2 ORDER BY updated_at LIMIT $3",
+		entity.StatusProcessing, before, limit,
+	)
+}
+
+func (r *paymentRepository) FindPendingDueBefore(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error) {
+	return r.list(ctx, "Failed to list payments due",
+		"SELECT "+paymentColumns+" FROM payments WHERE status = $1 AND due_at <= $2 ORDER BY due_at LIMIT $3",
+		entity.StatusPending, before, limit,
+	)
+}
+
+func (r *paymentRepository) FindByWinnerID(ctx context.Context, winnerID, status string, page, limit int) ([]*entity.Payment, int64, error) {
+	db := r.dbGetter(ctx)
+	offset := (page - 1) * limit
+
+	q := "SELECT " + paymentColumns + ", COUNT(*) OVER() FROM payments WHERE winner_id = $1"
+	args := []any{winnerID}
+	if status != "" {
+		q += " AND status = $2"
+		args = append(args, status)
+	}
+	q += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, errors.Internal("Failed to list payments")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var payments []*entity.Payment
+	var total int64
+	for rows.Next() {
+		payment, err := scanPayment(rows, &total)
+		if err != nil {
+			return nil, 0, errors.Internal("Failed to scan payment")
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Internal("Error iterating payments")
+	}
+	return payments, total, nil
+}
+
+func (r *paymentRepository) FindByAuctionID(ctx context.Context, auctionID string) ([]*entity.Payment, error) {
+	return r.list(ctx, "Failed to list auction payments",
+		"SELECT "+paymentColumns+" FROM payments WHERE auction_id = $1 ORDER BY created_at DESC",
+		auctionID,
+	)
+}
+
+func (r *paymentRepository) FindEscrowDue(ctx context.Context, before time.Time, limit int) ([]*entity.Payment, error) {
+	return r.list(ctx, "Failed to list escrow due",
+		"SELECT "+paymentColumns+` FROM payments p WHERE status = $1 AND escrow_release_at <= $2
+		AND NOT EXISTS (SELECT 1 FROM disputes d WHERE d.payment_id = p.id AND d.status <> $3)
+		ORDER BY escrow_release_at LIMIT $4`,
+		entity.StatusEscrowHeld, before, entity.DisputeStatusResolved, limit,
+	)
+}
+
+func (r *paymentRepository) FindUpdatedBetween(ctx context.Context, from, to time.Time) ([]*entity.Payment, error) {
+	return r.list(ctx, "Failed to list payments",
+		"SELECT "+paymentColumns+" FROM payments WHERE updated_at >= $1 AND updated_at < $2",
+		from, to,
+	)
+}
+
+func (r *paymentRepository) list(ctx context.Context, failure, q string, args ...any) ([]*entity.Payment, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Internal(failure)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var payments []*entity.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, errors.Internal("Failed to scan payment")
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal(failure)
+	}
+	return payments, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.PayoutRepository = (*payoutRepository)(nil)
+
+const payoutColumns = "id, seller_id, amount, status, transfer_id, created_at, updated_at"
+
+type payoutRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewPayoutRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.PayoutRepository {
+	return &payoutRepository{dbGetter: dbGetter}
+}
+
+func (r *payoutRepository) Save(ctx context.Context, payout *entity.Payout) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO payouts ("+payoutColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		payout.ID(), payout.SellerID(), payout.Amount(), payout.Status(),
+		payout.TransferID(), payout.CreatedAt(), payout.UpdatedAt(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to create payout")
+	}
+	return nil
+}
+
+func scanPayout(row rowScanner) (*entity.Payout, error) {
+	var id, sellerID, status, transferID string
+	var amount int64
+	var createdAt, updatedAt time.Time
+	if err := row.Scan(&id, &sellerID, &amount, &status, &transferID, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructPayout(id, sellerID, amount, status, transferID, createdAt, updatedAt), nil
+}
+
+func (r *payoutRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Payout, error) {
+	cfg := query.ApplyOptions(opts)
+	q := "SELECT " + payoutColumns + " FROM payouts WHERE id = $1"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+	return r.find(ctx, q, id)
+}
+
+func (r *payoutRepository) FindPendingBySeller(ctx context.Context, sellerID string) (*entity.Payout, error) {
+	return r.find(ctx,
+		"SELECT "+payoutColumns+" FROM payouts WHERE seller_id = $1 AND status = $2",
+		sellerID, entity.PayoutStatusPending,
+	)
+}
+
+func (r *payoutRepository) Update(ctx context.Context, payout *entity.Payout) error {
+	db := r.dbGetter(ctx)
+	result, err := db.ExecContext(ctx,
+		"UPDATE payouts SET status = $1, transfer_id = $2, updated_at = $3 WHERE id = $4",
+		payout.Status(), payout.TransferID(), payout.UpdatedAt(), payout.ID(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to update payout")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("Payout not found")
+	}
+	return nil
+}
+
+func (r *payoutRepository) find(ctx context.Context, q string, args ...any) (*entity.Payout, error) {
+	db := r.dbGetter(ctx)
+	payout, err := scanPayout(db.QueryRowContext(ctx, q, args...))
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get payout")
+	}
+	return payout, nil
+}
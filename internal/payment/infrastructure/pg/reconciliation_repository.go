@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.ReconciliationRepository = (*reconciliationRepository)(nil)
+
+type reconciliationRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewReconciliationRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.ReconciliationRepository {
+	return &reconciliationRepository{dbGetter: dbGetter}
+}
+
+func (r *reconciliationRepository) Save(ctx context.Context, issue *entity.ReconciliationIssue) (bool, error) {
+	db := r.dbGetter(ctx)
+	res, err := db.ExecContext(ctx,
+		`INSERT INTO reconciliation_issues (id, kind, payment_id, transaction_id, expected_amount, actual_amount, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (kind, payment_id, transaction_id) DO NOTHING`,
+		issue.ID(), issue.Kind(), issue.PaymentID(), issue.TransactionID(),
+		issue.Expected(), issue.Actual(), issue.DetectedAt(),
+	)
+	if err != nil {
+		return false, errors.Internal("Failed to create reconciliation issue")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Internal("Failed to create reconciliation issue")
+	}
+	return n > 0, nil
+}
+
+func (r *reconciliationRepository) List(ctx context.Context, kind string, page, limit int) ([]*entity.ReconciliationIssue, int64, error) {
+	db := r.dbGetter(ctx)
+	offset := (page - 1) * limit
+
+	q := `SELECT id, kind, payment_id, transaction_id, expected_amount, actual_amount, detected_at, COUNT(*) OVER()
+		FROM reconciliation_issues`
+	var args []any
+	if kind != "" {
+		q += " WHERE kind = $1"
+		args = append(args, kind)
+	}
+	q += fmt.Sprintf(" ORDER BY detected_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, errors.Internal("Failed to list reconciliation issues")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var issues []*entity.ReconciliationIssue
+	var total int64
+	for rows.Next() {
+		var id, issueKind, paymentID, transactionID string
+		var expected, actual int64
+		var detectedAt time.Time
+		if err := rows.Scan(&id, &issueKind, &paymentID, &transactionID, &expected, &actual, &detectedAt, &total); err != nil {
+			return nil, 0, errors.Internal("Failed to scan reconciliation issue")
+		}
+		issues = append(issues, entity.ReconstructReconciliationIssue(id, issueKind, paymentID, transactionID, expected, actual, detectedAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Internal("Error iterating reconciliation issues")
+	}
+	return issues, total, nil
+}
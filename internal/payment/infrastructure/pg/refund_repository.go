@@ -0,0 +1,143 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/domain"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/query"
+	"github.com/in-jun/go-structure-example/internal/shared/transaction"
+)
+
+var _ domain.RefundRepository = (*refundRepository)(nil)
+
+const refundColumns = "id, payment_id, amount, reason, status, attempts, last_error, next_attempt_at, created_at, updated_at"
+
+type refundRepository struct {
+	dbGetter func(ctx context.Context) transaction.DBTX
+}
+
+func NewRefundRepository(dbGetter func(ctx context.Context) transaction.DBTX) domain.RefundRepository {
+	return &refundRepository{dbGetter: dbGetter}
+}
+
+func (r *refundRepository) Save(ctx context.Context, refund *entity.Refund) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO payment_refunds ("+refundColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		refund.ID(), refund.PaymentID(), refund.Amount(), refund.Reason(), refund.Status(),
+		refund.Attempts(), refund.LastError(), refund.NextAttemptAt(), refund.CreatedAt(), refund.UpdatedAt(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to create refund")
+	}
+	return nil
+}
+
+func scanRefund(row rowScanner) (*entity.Refund, error) {
+	var id, paymentID, reason, status, lastError string
+	var amount int64
+	var attempts int
+	var nextAttemptAt, createdAt, updatedAt time.Time
+	if err := row.Scan(&id, &paymentID, &amount, &reason, &status, &attempts, &lastError,
+		&nextAttemptAt, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructRefund(id, paymentID, amount, reason, status,
+		attempts, lastError, nextAttemptAt, createdAt, updatedAt), nil
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id string, opts ...query.Option) (*entity.Refund, error) {
+	cfg := query.ApplyOptions(opts)
+	db := r.dbGetter(ctx)
+
+	q := "SELECT " + refundColumns + " FROM payment_refunds WHERE id = $1"
+	if cfg.ForUpdate {
+		q += " FOR UPDATE"
+	}
+
+	refund, err := scanRefund(db.QueryRowContext(ctx, q, id))
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Internal("Failed to get refund")
+	}
+	return refund, nil
+}
+
+func (r *refundRepository) FindByPaymentID(ctx context.Context, paymentID string) ([]*entity.Refund, error) {
+	return r.list(ctx,
+		"SELECT "+refundColumns+" FROM payment_refunds WHERE payment_id = $1 ORDER BY created_at",
+		paymentID,
+	)
+}
+
+func (r *refundRepository) FindDue(ctx context.Context, before time.Time, limit int) ([]*entity.Refund, error) {
+	return r.list(ctx,
+		"SELECT "+refundColumns+" FROM payment_refunds WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3",
+		entity.RefundStatusPending, before, limit,
+	)
+}
+
+func (r *refundRepository) Update(ctx context.Context, refund *entity.Refund) error {
+	db := r.dbGetter(ctx)
+	result, err := db.ExecContext(ctx,
+		"UPDATE payment_refunds SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, updated_at = $5 WHERE id = $6",
+		refund.Status(), refund.Attempts(), refund.LastError(), refund.NextAttemptAt(), refund.UpdatedAt(), refund.ID(),
+	)
+	if err != nil {
+		return errors.Internal("Failed to update refund")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Internal("Failed to get affected rows")
+	}
+	if rows == 0 {
+		return errors.NotFound("Refund not found")
+	}
+	return nil
+}
+
+func (r *refundRepository) SaveAttempt(ctx context.Context, attempt entity.RefundAttempt) error {
+	db := r.dbGetter(ctx)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO payment_refund_attempts (refund_id, attempt, error, attempted_at) VALUES ($1, $2, $3, $4)",
+		attempt.RefundID, attempt.Number, attempt.Error, attempt.AttemptedAt,
+	)
+	if err != nil {
+		return errors.Internal("Failed to record refund attempt")
+	}
+	return nil
+}
+
+func (r *refundRepository) list(ctx context.Context, q string, args ...any) ([]*entity.Refund, error) {
+	db := r.dbGetter(ctx)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Internal("Failed to list refunds")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", "error", err)
+		}
+	}()
+
+	var refunds []*entity.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, errors.Internal("Failed to scan refund")
+		}
+		refunds = append(refunds, refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Internal("Failed to list refunds")
+	}
+	return refunds, nil
+}
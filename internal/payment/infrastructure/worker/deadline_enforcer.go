@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+const deadlineBatchSize = 100
+
+type DeadlineEnforcer struct {
+	handler  *command.EnforceDeadlinesHandler
+	interval time.Duration
+}
+
+func NewDeadlineEnforcer(handler *command.EnforceDeadlinesHandler, interval time.Duration) *DeadlineEnforcer {
+	return &DeadlineEnforcer{handler: handler, interval: interval}
+}
+
+func (w *DeadlineEnforcer) Start(ctx context.Context) {
+	slog.Info("deadline enforcer started", "component", "deadline-enforcer", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("deadline enforcer stopped", "component", "deadline-enforcer")
+			return
+		case <-ticker.C:
+			result, err := w.handler.Handle(ctx, command.EnforceDeadlines{Now: time.Now(), Limit: deadlineBatchSize})
+			if err != nil {
+				slog.Error("deadline enforcement failed", "component", "deadline-enforcer", "error", err)
+				continue
+			}
+			if result.Expired+result.Reminded > 0 {
+				slog.Info("payment deadlines enforced", "component", "deadline-enforcer",
+					"expired", result.Expired, "reminded", result.Reminded)
+			}
+		}
+	}
+}
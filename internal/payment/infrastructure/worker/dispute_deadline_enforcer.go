@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+const disputeBatchSize = 100
+
+type DisputeDeadlineEnforcer struct {
+	handler  *command.EnforceDisputeDeadlinesHandler
+	interval time.Duration
+}
+
+func NewDisputeDeadlineEnforcer(handler *command.EnforceDisputeDeadlinesHandler, interval time.Duration) *DisputeDeadlineEnforcer {
+	return &DisputeDeadlineEnforcer{handler: handler, interval: interval}
+}
+
+func (w *DisputeDeadlineEnforcer) Start(ctx context.Context) {
+	slog.Info("dispute deadline enforcer started", "component", "dispute-deadlines", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("dispute deadline enforcer stopped", "component", "dispute-deadlines")
+			return
+		case <-ticker.C:
+			result, err := w.handler.Handle(ctx, command.EnforceDisputeDeadlines{Now: time.Now(), Limit: disputeBatchSize})
+			if err != nil {
+				slog.Error("dispute deadline enforcement failed", "component", "dispute-deadlines", "error", err)
+				continue
+			}
+			if result.Escalated+result.Overdue > 0 {
+				slog.Info("dispute deadlines enforced", "component", "dispute-deadlines",
+					"escalated", result.Escalated, "overdue", result.Overdue)
+			}
+		}
+	}
+}
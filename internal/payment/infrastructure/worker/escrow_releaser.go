@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+const escrowBatchSize = 100
+
+type EscrowReleaser struct {
+	handler  *command.ReleaseEscrowsHandler
+	interval time.Duration
+}
+
+func NewEscrowReleaser(handler *command.ReleaseEscrowsHandler, interval time.Duration) *EscrowReleaser {
+	return &EscrowReleaser{handler: handler, interval: interval}
+}
+
+func (w *EscrowReleaser) Start(ctx context.Context) {
+	slog.Info("escrow releaser started", "component", "escrow-releaser", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("escrow releaser stopped", "component", "escrow-releaser")
+			return
+		case <-ticker.C:
+			result, err := w.handler.Handle(ctx, command.ReleaseEscrows{Now: time.Now(), Limit: escrowBatchSize})
+			if err != nil {
+				slog.Error("escrow release failed", "component", "escrow-releaser", "error", err)
+				continue
+			}
+			if result.Released > 0 {
+				slog.Info("expired escrow holds released", "component", "escrow-releaser", "released", result.Released)
+			}
+		}
+	}
+}
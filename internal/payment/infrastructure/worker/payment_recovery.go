@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+const recoveryBatchSize = 100
+
+type PaymentRecovery struct {
+	handler     *command.RecoverPaymentsHandler
+	interval    time.Duration
+	staleAfter  time.Duration
+	giveUpAfter time.Duration
+}
+
+func NewPaymentRecovery(handler *command.RecoverPaymentsHandler, interval, staleAfter, giveUpAfter time.Duration) *PaymentRecovery {
+	return &PaymentRecovery{handler: handler, interval: interval, staleAfter: staleAfter, giveUpAfter: giveUpAfter}
+}
+
+func (w *PaymentRecovery) Start(ctx context.Context) {
+	slog.Info("payment recovery started", "component", "payment-recovery", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("payment recovery stopped", "component", "payment-recovery")
+			return
+		case <-ticker.C:
+			now := time.Now()
+			result, err := w.handler.Handle(ctx, command.RecoverPayments{
+				StaleBefore:  now.Add(-w.staleAfter),
+				GiveUpBefore: now.Add(-w.giveUpAfter),
+				Limit:        recoveryBatchSize,
+			})
+			if err != nil {
+				slog.Error("payment recovery failed", "component", "payment-recovery", "error", err)
+				continue
+			}
+			if result.Resolved+result.Abandoned+result.Unresolved > 0 {
+				slog.Info("processing payments recovered", "component", "payment-recovery",
+					"resolved", result.Resolved, "abandoned", result.Abandoned, "unresolved", result.Unresolved)
+			}
+		}
+	}
+}
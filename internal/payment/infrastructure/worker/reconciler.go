@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+type Reconciler struct {
+	handler  *command.ReconcilePaymentsHandler
+	interval time.Duration
+	window   time.Duration
+	lag      time.Duration
+}
+
+// NewReconciler checks the window ending lag ago on every tick, so recent
+// payments have a chance to settle first. A window longer than the interval
+// makes consecutive runs overlap.
+func NewReconciler(handler *command.ReconcilePaymentsHandler, interval, window, lag time.Duration) *Reconciler {
+	return &Reconciler{handler: handler, interval: interval, window: window, lag: lag}
+}
+
+func (w *Reconciler) Start(ctx context.Context) {
+	slog.Info("payment reconciler started", "component", "payment-reconciler",
+		"interval", w.interval, "window", w.window, "lag", w.lag)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("payment reconciler stopped", "component", "payment-reconciler")
+			return
+		case <-ticker.C:
+			to := time.Now().Add(-w.lag)
+			result, err := w.handler.Handle(ctx, command.ReconcilePayments{From: to.Add(-w.window), To: to})
+			if err != nil {
+				slog.Error("payment reconciliation failed", "component", "payment-reconciler", "error", err)
+				continue
+			}
+			if result.Issues > 0 {
+				slog.Warn("payment reconciliation found issues", "component", "payment-reconciler",
+					"issues", result.Issues, "transactions", result.Transactions, "payments", result.Payments)
+			}
+		}
+	}
+}
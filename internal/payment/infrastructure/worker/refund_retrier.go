@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
+)
+
+const refundBatchSize = 100
+
+type RefundRetrier struct {
+	handler  *command.RetryRefundsHandler
+	interval time.Duration
+}
+
+func NewRefundRetrier(handler *command.RetryRefundsHandler, interval time.Duration) *RefundRetrier {
+	return &RefundRetrier{handler: handler, interval: interval}
+}
+
+func (w *RefundRetrier) Start(ctx context.Context) {
+	slog.Info("refund retrier started", "component", "refund-retrier", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("refund retrier stopped", "component", "refund-retrier")
+			return
+		case <-ticker.C:
+			result, err := w.handler.Handle(ctx, command.RetryRefunds{Now: time.Now(), Limit: refundBatchSize})
+			if err != nil {
+				slog.Error("refund retry failed", "component", "refund-retrier", "error", err)
+				continue
+			}
+			if result.Refunded+result.Failed+result.Pending > 0 {
+				slog.Info("pending refunds retried", "component", "refund-retrier",
+					"refunded", result.Refunded, "failed", result.Failed, "pending", result.Pending)
+			}
+		}
+	}
+}
@@ -1,34 +1,60 @@
 package http
 
 import (
+	"bytes"
 	stderrors "errors"
 	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/in-jun/go-structure-example/internal/payment/application"
 	"github.com/in-jun/go-structure-example/internal/payment/application/command"
 	"github.com/in-jun/go-structure-example/internal/payment/application/query"
+	"github.com/in-jun/go-structure-example/internal/payment/domain/entity"
 	"github.com/in-jun/go-structure-example/internal/shared/errors"
 	"github.com/in-jun/go-structure-example/internal/shared/middleware"
 	"github.com/in-jun/go-structure-example/internal/shared/server"
 )
 
+const webhookSignatureHeader = "X-Webhook-Signature"
+
 type Handler struct {
 	commands application.CommandUseCase
 	queries  application.QueryUseCase
+	identity *middleware.IdentityVerifier
 }
 
-func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase) *Handler {
-	return &Handler{commands: commands, queries: queries}
+func NewHandler(commands application.CommandUseCase, queries application.QueryUseCase, identity *middleware.IdentityVerifier) *Handler {
+	return &Handler{commands: commands, queries: queries, identity: identity}
 }
 
 func (h *Handler) RegisterRoutes(mux *server.Router, mw server.Middleware) {
-	gatewayAuth := middleware.GatewayAuth()
+	gatewayAuth := middleware.GatewayAuth(h.identity)
+	admin := middleware.RequireRole(middleware.RoleAdmin)
 
 	mux.Handle("GET /api/v1/payments/{id}", mw(gatewayAuth(http.HandlerFunc(h.GetPayment))))
 	mux.Handle("GET /api/v1/payments/{id}/events", mw(gatewayAuth(http.HandlerFunc(h.GetEvents))))
+	mux.Handle("GET /api/v1/payments/{id}/invoice", mw(gatewayAuth(http.HandlerFunc(h.GetInvoice))))
 	mux.Handle("POST /api/v1/payments/{id}/confirm", mw(gatewayAuth(http.HandlerFunc(h.ConfirmPayment))))
+	mux.Handle("POST /api/v1/payments/{id}/confirm-delivery", mw(gatewayAuth(http.HandlerFunc(h.ConfirmDelivery))))
 	mux.Handle("POST /api/v1/payments/{id}/refund", mw(gatewayAuth(http.HandlerFunc(h.RefundPayment))))
+	mux.Handle("POST /api/v1/payments/webhooks/{provider}", mw(http.HandlerFunc(h.HandleWebhook)))
+	mux.Handle("GET /api/v1/me/payments", mw(gatewayAuth(http.HandlerFunc(h.ListMyPayments))))
+	mux.Handle("GET /api/v1/auctions/{id}/payment", mw(gatewayAuth(http.HandlerFunc(h.GetAuctionPayment))))
+	mux.Handle("GET /api/v1/me/balance", mw(gatewayAuth(http.HandlerFunc(h.GetBalance))))
+	mux.Handle("POST /api/v1/me/payouts", mw(gatewayAuth(http.HandlerFunc(h.PayoutSeller))))
+	mux.Handle("GET /api/v1/admin/reconciliation-issues", mw(gatewayAuth(admin(http.HandlerFunc(h.ListReconciliationIssues)))))
+	mux.Handle("POST /api/v1/admin/payments/{id}/refund", mw(gatewayAuth(admin(http.HandlerFunc(h.AdminRefundPayment)))))
+	mux.Handle("POST /api/v1/payments/{id}/disputes", mw(gatewayAuth(http.HandlerFunc(h.OpenDispute))))
+	mux.Handle("GET /api/v1/disputes/{id}", mw(gatewayAuth(http.HandlerFunc(h.GetDispute))))
+	mux.Handle("POST /api/v1/disputes/{id}/respond", mw(gatewayAuth(http.HandlerFunc(h.RespondDispute))))
+	mux.Handle("GET /api/v1/admin/disputes", mw(gatewayAuth(admin(http.HandlerFunc(h.ListDisputes)))))
+	mux.Handle("POST /api/v1/admin/disputes/{id}/resolve", mw(gatewayAuth(admin(http.HandlerFunc(h.ResolveDispute)))))
+	mux.Handle("POST /api/v1/me/payment-methods", mw(gatewayAuth(http.HandlerFunc(h.AddPaymentMethod))))
+	mux.Handle("GET /api/v1/me/payment-methods", mw(gatewayAuth(http.HandlerFunc(h.ListPaymentMethods))))
+	mux.Handle("POST /api/v1/me/payment-methods/{id}/default", mw(gatewayAuth(http.HandlerFunc(h.SetDefaultPaymentMethod))))
+	mux.Handle("DELETE /api/v1/me/payment-methods/{id}", mw(gatewayAuth(http.HandlerFunc(h.RemovePaymentMethod))))
 }
 
 func (h *Handler) GetPayment(w http.ResponseWriter, r *http.Request) {
@@ -47,6 +73,100 @@ func (h *Handler) GetPayment(w http.ResponseWriter, r *http.Request) {
 	server.JSON(w, http.StatusOK, toGetResponse(result))
 }
 
+func (h *Handler) ListMyPayments(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(server.QueryDefault(r, "page", "1"))
+	limit, _ := strconv.Atoi(server.QueryDefault(r, "limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.queries.ListPayments(r.Context(), query.ListPayments{
+		UserID: server.UserID(r),
+		Status: r.URL.Query().Get("status"),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toListResponse(result))
+}
+
+func (h *Handler) GetAuctionPayment(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queries.GetAuctionPayment(r.Context(), query.GetAuctionPayment{
+		AuctionID: r.PathValue("id"),
+		UserID:    server.UserID(r),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toGetResponse(result))
+}
+
+func (h *Handler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queries.GetBalance(r.Context(), query.GetBalance{
+		UserID: server.UserID(r),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toBalanceResponse(result))
+}
+
+func (h *Handler) PayoutSeller(w http.ResponseWriter, r *http.Request) {
+	result, err := h.commands.PayoutSeller(r.Context(), command.PayoutSeller{
+		SellerID: server.UserID(r),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Status == entity.PayoutStatusPending {
+		status = http.StatusAccepted
+	}
+	server.JSON(w, status, toPayoutResponse(result))
+}
+
+func (h *Handler) ListReconciliationIssues(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(server.QueryDefault(r, "page", "1"))
+	limit, _ := strconv.Atoi(server.QueryDefault(r, "limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.queries.ListReconciliationIssues(r.Context(), query.ListReconciliationIssues{
+		Kind:  r.URL.Query().Get("kind"),
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toReconciliationIssueListResponse(result))
+}
+
 func (h *Handler) GetEvents(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
@@ -61,22 +181,94 @@ func (h *Handler) GetEvents(w http.ResponseWriter, r *http.Request) {
 	server.JSON(w, http.StatusOK, toEventHistoryResponse(result))
 }
 
+// GetInvoice renders HTML by default and plain text with ?format=text.
+func (h *Handler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	format := server.QueryDefault(r, "format", "html")
+	if format != "html" && format != "text" {
+		middleware.HandleError(w, errors.BadRequest("format must be html or text"))
+		return
+	}
+
+	result, err := h.queries.GetInvoice(r.Context(), query.GetInvoice{
+		PaymentID: r.PathValue("id"),
+		UserID:    server.UserID(r),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/html; charset=utf-8"
+	render := renderInvoiceHTML
+	if format == "text" {
+		contentType = "text/plain; charset=utf-8"
+		render = renderInvoiceText
+	}
+	if err := render(&buf, result); err != nil {
+		middleware.HandleError(w, errors.Internal("Failed to render invoice"))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Warn("failed to write invoice response", "error", err)
+	}
+}
+
 func (h *Handler) ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	userID := server.UserID(r)
 
-	if err := h.commands.ConfirmPayment(r.Context(), command.ConfirmPayment{
+	var req ConfirmRequest
+	if err := server.Bind(r, &req); err != nil && !stderrors.Is(err, io.EOF) {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	result, err := h.commands.ConfirmPayment(r.Context(), command.ConfirmPayment{
+		UserID:          userID,
+		PaymentID:       id,
+		PaymentMethodID: req.PaymentMethodID,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	if result.Pending {
+		server.JSON(w, http.StatusAccepted, toConfirmResponse(result))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ConfirmDelivery(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	userID := server.UserID(r)
+
+	result, err := h.commands.ConfirmDelivery(r.Context(), command.ConfirmDelivery{
 		UserID:    userID,
 		PaymentID: id,
-	}); err != nil {
+	})
+	if err != nil {
 		middleware.HandleError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	server.JSON(w, http.StatusOK, toConfirmDeliveryResponse(result))
 }
 
 func (h *Handler) RefundPayment(w http.ResponseWriter, r *http.Request) {
+	h.refundPayment(w, r, false)
+}
+
+// AdminRefundPayment refunds any payment, not only the caller's own.
+func (h *Handler) AdminRefundPayment(w http.ResponseWriter, r *http.Request) {
+	h.refundPayment(w, r, true)
+}
+
+func (h *Handler) refundPayment(w http.ResponseWriter, r *http.Request, admin bool) {
 	id := r.PathValue("id")
 	userID := server.UserID(r)
 
@@ -86,10 +278,135 @@ func (h *Handler) RefundPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.commands.RefundPayment(r.Context(), command.RefundPayment{
+	result, err := h.commands.RefundPayment(r.Context(), command.RefundPayment{
 		UserID:    userID,
+		Admin:     admin,
 		PaymentID: id,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	if result.Pending {
+		server.JSON(w, http.StatusAccepted, toRefundResponse(result))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	var req OpenDisputeRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	result, err := h.commands.OpenDispute(r.Context(), command.OpenDispute{
+		UserID:    server.UserID(r),
+		PaymentID: r.PathValue("id"),
 		Reason:    req.Reason,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusCreated, toDisputeStatusResponse(result))
+}
+
+func (h *Handler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queries.GetDispute(r.Context(), query.GetDispute{
+		DisputeID: r.PathValue("id"),
+		UserID:    server.UserID(r),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toDisputeResponse(result))
+}
+
+func (h *Handler) RespondDispute(w http.ResponseWriter, r *http.Request) {
+	var req RespondDisputeRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	result, err := h.commands.RespondDispute(r.Context(), command.RespondDispute{
+		UserID:    server.UserID(r),
+		DisputeID: r.PathValue("id"),
+		Response:  req.Response,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toDisputeStatusResponse(result))
+}
+
+func (h *Handler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(server.QueryDefault(r, "page", "1"))
+	limit, _ := strconv.Atoi(server.QueryDefault(r, "limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.queries.ListDisputes(r.Context(), query.ListDisputes{
+		Status: r.URL.Query().Get("status"),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toDisputeListResponse(result))
+}
+
+func (h *Handler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	var req ResolveDisputeRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	result, err := h.commands.ResolveDispute(r.Context(), command.ResolveDispute{
+		DisputeID:  r.PathValue("id"),
+		Resolution: req.Resolution,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toResolveDisputeResponse(result))
+}
+
+func (h *Handler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	if err := h.commands.HandleWebhook(r.Context(), command.HandleWebhook{
+		Provider:  r.PathValue("provider"),
+		Payload:   payload,
+		Signature: r.Header.Get(webhookSignatureHeader),
 	}); err != nil {
 		middleware.HandleError(w, err)
 		return
@@ -97,3 +414,59 @@ func (h *Handler) RefundPayment(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *Handler) AddPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	var req AddPaymentMethodRequest
+	if err := server.Bind(r, &req); err != nil {
+		middleware.HandleError(w, errors.BadRequest("Invalid request format"))
+		return
+	}
+
+	result, err := h.commands.AddPaymentMethod(r.Context(), command.AddPaymentMethod{
+		UserID:      server.UserID(r),
+		Token:       req.Token,
+		MakeDefault: req.Default,
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusCreated, toPaymentMethodStatusResponse(result))
+}
+
+func (h *Handler) ListPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queries.ListPaymentMethods(r.Context(), query.ListPaymentMethods{UserID: server.UserID(r)})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toPaymentMethodListResponse(result))
+}
+
+func (h *Handler) SetDefaultPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	result, err := h.commands.SetDefaultPaymentMethod(r.Context(), command.SetDefaultPaymentMethod{
+		UserID:          server.UserID(r),
+		PaymentMethodID: r.PathValue("id"),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	server.JSON(w, http.StatusOK, toPaymentMethodStatusResponse(result))
+}
+
+func (h *Handler) RemovePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	err := h.commands.RemovePaymentMethod(r.Context(), command.RemovePaymentMethod{
+		UserID:          server.UserID(r),
+		PaymentMethodID: r.PathValue("id"),
+	})
+	if err != nil {
+		middleware.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,23 +16,106 @@ import (
 )
 
 type mockCommandUseCase struct {
-	createResp *command.CreatePaymentResult
-	err        error
+	createResp  *command.CreatePaymentResult
+	confirmResp *command.ConfirmPaymentResult
+	refundResp  *command.RefundPaymentResult
+	refund      command.RefundPayment
+	webhook     command.HandleWebhook
+	resolve     command.ResolveDispute
+	confirm     command.ConfirmPayment
+	addMethod   command.AddPaymentMethod
+	err         error
 }
 
 func (m *mockCommandUseCase) CreatePayment(_ context.Context, _ command.CreatePayment) (*command.CreatePaymentResult, error) {
 	return m.createResp, m.err
 }
-func (m *mockCommandUseCase) ConfirmPayment(_ context.Context, _ command.ConfirmPayment) error {
+func (m *mockCommandUseCase) ConfirmPayment(_ context.Context, cmd command.ConfirmPayment) (*command.ConfirmPaymentResult, error) {
+	m.confirm = cmd
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.confirmResp == nil {
+		return &command.ConfirmPaymentResult{PaymentID: testPaymentID, Status: "completed"}, nil
+	}
+	return m.confirmResp, nil
+}
+func (m *mockCommandUseCase) RefundPayment(_ context.Context, cmd command.RefundPayment) (*command.RefundPaymentResult, error) {
+	m.refund = cmd
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.refundResp == nil {
+		return &command.RefundPaymentResult{RefundID: "refund-1", Status: "succeeded"}, nil
+	}
+	return m.refundResp, nil
+}
+func (m *mockCommandUseCase) HandleWebhook(_ context.Context, cmd command.HandleWebhook) error {
+	m.webhook = cmd
 	return m.err
 }
-func (m *mockCommandUseCase) RefundPayment(_ context.Context, _ command.RefundPayment) error {
+
+func (m *mockCommandUseCase) PayoutSeller(_ context.Context, _ command.PayoutSeller) (*command.PayoutSellerResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.PayoutSellerResult{ID: "payout-id", Amount: 9500, Status: "paid"}, nil
+}
+
+func (m *mockCommandUseCase) OpenDispute(_ context.Context, _ command.OpenDispute) (*command.DisputeResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.DisputeResult{DisputeID: "dispute-id", Status: "open"}, nil
+}
+
+func (m *mockCommandUseCase) RespondDispute(_ context.Context, _ command.RespondDispute) (*command.DisputeResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.DisputeResult{DisputeID: "dispute-id", Status: "under_review"}, nil
+}
+
+func (m *mockCommandUseCase) ResolveDispute(_ context.Context, cmd command.ResolveDispute) (*command.ResolveDisputeResult, error) {
+	m.resolve = cmd
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.ResolveDisputeResult{DisputeID: cmd.DisputeID, Status: "resolved", Resolution: cmd.Resolution}, nil
+}
+
+func (m *mockCommandUseCase) ConfirmDelivery(_ context.Context, cmd command.ConfirmDelivery) (*command.ConfirmDeliveryResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.ConfirmDeliveryResult{PaymentID: cmd.PaymentID, Status: "escrow_released"}, nil
+}
+
+func (m *mockCommandUseCase) AddPaymentMethod(_ context.Context, cmd command.AddPaymentMethod) (*command.PaymentMethodResult, error) {
+	m.addMethod = cmd
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.PaymentMethodResult{PaymentMethodID: "method-id", IsDefault: cmd.MakeDefault}, nil
+}
+
+func (m *mockCommandUseCase) SetDefaultPaymentMethod(_ context.Context, cmd command.SetDefaultPaymentMethod) (*command.PaymentMethodResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &command.PaymentMethodResult{PaymentMethodID: cmd.PaymentMethodID, IsDefault: true}, nil
+}
+
+func (m *mockCommandUseCase) RemovePaymentMethod(_ context.Context, _ command.RemovePaymentMethod) error {
 	return m.err
 }
 
 type mockQueryUseCase struct {
-	getResp *query.Result
-	err     error
+	getResp     *query.Result
+	listResp    *query.ListResult
+	list        query.ListPayments
+	invoiceResp *query.InvoiceResult
+	err         error
 }
 
 func (m *mockQueryUseCase) GetPayment(_ context.Context, _ query.GetPayment) (*query.Result, error) {
@@ -40,12 +125,38 @@ func (m *mockQueryUseCase) GetEvents(_ context.Context, _ query.EventHistory) (*
 	return &query.EventHistoryResult{Events: []query.EventHistoryItem{}}, m.err
 }
 
+func (m *mockQueryUseCase) ListPayments(_ context.Context, qry query.ListPayments) (*query.ListResult, error) {
+	m.list = qry
+	return m.listResp, m.err
+}
+func (m *mockQueryUseCase) GetAuctionPayment(_ context.Context, _ query.GetAuctionPayment) (*query.Result, error) {
+	return m.getResp, m.err
+}
+func (m *mockQueryUseCase) ListReconciliationIssues(_ context.Context, _ query.ListReconciliationIssues) (*query.ReconciliationIssueListResult, error) {
+	return &query.ReconciliationIssueListResult{}, m.err
+}
+func (m *mockQueryUseCase) GetInvoice(_ context.Context, _ query.GetInvoice) (*query.InvoiceResult, error) {
+	return m.invoiceResp, m.err
+}
+func (m *mockQueryUseCase) GetBalance(_ context.Context, _ query.GetBalance) (*query.BalanceResult, error) {
+	return &query.BalanceResult{}, m.err
+}
+func (m *mockQueryUseCase) GetDispute(_ context.Context, qry query.GetDispute) (*query.DisputeResult, error) {
+	return &query.DisputeResult{ID: qry.DisputeID, Status: "open"}, m.err
+}
+func (m *mockQueryUseCase) ListDisputes(_ context.Context, _ query.ListDisputes) (*query.DisputeListResult, error) {
+	return &query.DisputeListResult{}, m.err
+}
+func (m *mockQueryUseCase) ListPaymentMethods(_ context.Context, _ query.ListPaymentMethods) (*query.PaymentMethodListResult, error) {
+	return &query.PaymentMethodListResult{}, m.err
+}
+
 const testUserID = "550e8400-e29b-41d4-a716-446655440000"
 const testPaymentID = "660e8400-e29b-41d4-a716-446655440000"
 
 func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server.Router {
 	mux := server.NewRouter()
-	h := NewHandler(cmdMock, qryMock)
+	h := NewHandler(cmdMock, qryMock, nil)
 	noopMw := server.Middleware(func(next http.Handler) http.Handler { return next })
 
 	injectUser := func(next http.Handler) http.Handler {
@@ -57,8 +168,18 @@ func setupRouter(cmdMock *mockCommandUseCase, qryMock *mockQueryUseCase) *server
 
 	mux.Handle("GET /api/v1/payments/{id}", noopMw(injectUser(http.HandlerFunc(h.GetPayment))))
 	mux.Handle("GET /api/v1/payments/{id}/events", noopMw(injectUser(http.HandlerFunc(h.GetEvents))))
+	mux.Handle("GET /api/v1/payments/{id}/invoice", noopMw(injectUser(http.HandlerFunc(h.GetInvoice))))
 	mux.Handle("POST /api/v1/payments/{id}/confirm", noopMw(injectUser(http.HandlerFunc(h.ConfirmPayment))))
+	mux.Handle("POST /api/v1/payments/{id}/confirm-delivery", noopMw(injectUser(http.HandlerFunc(h.ConfirmDelivery))))
 	mux.Handle("POST /api/v1/payments/{id}/refund", noopMw(injectUser(http.HandlerFunc(h.RefundPayment))))
+	mux.Handle("POST /api/v1/payments/webhooks/{provider}", noopMw(http.HandlerFunc(h.HandleWebhook)))
+	mux.Handle("GET /api/v1/me/payments", noopMw(injectUser(http.HandlerFunc(h.ListMyPayments))))
+	mux.Handle("GET /api/v1/auctions/{id}/payment", noopMw(injectUser(http.HandlerFunc(h.GetAuctionPayment))))
+	mux.Handle("POST /api/v1/me/payouts", noopMw(injectUser(http.HandlerFunc(h.PayoutSeller))))
+	mux.Handle("POST /api/v1/payments/{id}/disputes", noopMw(injectUser(http.HandlerFunc(h.OpenDispute))))
+	mux.Handle("POST /api/v1/admin/disputes/{id}/resolve", noopMw(injectUser(http.HandlerFunc(h.ResolveDispute))))
+	mux.Handle("POST /api/v1/me/payment-methods", noopMw(injectUser(http.HandlerFunc(h.AddPaymentMethod))))
+	mux.Handle("DELETE /api/v1/me/payment-methods/{id}", noopMw(injectUser(http.HandlerFunc(h.RemovePaymentMethod))))
 
 	return mux
 }
@@ -116,6 +237,95 @@ func TestHandler_ConfirmPayment(t *testing.T) {
 	}
 }
 
+func TestHandler_ConfirmPayment_WithPaymentMethod(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	body := strings.NewReader(`{"payment_method_id":"770e8400-e29b-41d4-a716-446655440000"}`)
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/confirm", body)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.confirm.PaymentMethodID != "770e8400-e29b-41d4-a716-446655440000" {
+		t.Errorf("payment method was not passed on: %+v", cmdMock.confirm)
+	}
+}
+
+func TestHandler_ConfirmPayment_Pending(t *testing.T) {
+	cmdMock := &mockCommandUseCase{
+		confirmResp: &command.ConfirmPaymentResult{PaymentID: testPaymentID, Status: "pending", Pending: true},
+	}
+
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/confirm", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d; body: %s", w.Code, w.Body.String())
+	}
+	var body ConfirmResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Status != "pending" {
+		t.Errorf("expected status 'pending', got %q", body.Status)
+	}
+}
+
+func TestHandler_ConfirmDelivery(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/confirm-delivery", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	var body ConfirmResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.ID != testPaymentID || body.Status != "escrow_released" {
+		t.Errorf("unexpected response %+v", body)
+	}
+}
+
+func TestHandler_OpenDispute(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	body := strings.NewReader(`{"reason":"item not as described"}`)
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/disputes", body)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_ResolveDispute(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	body := strings.NewReader(`{"resolution":"partial","amount":1000}`)
+	req := httptest.NewRequest("POST", "/api/v1/admin/disputes/dispute-id/resolve", body)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.resolve.Resolution != "partial" || cmdMock.resolve.Amount != 1000 {
+		t.Errorf("unexpected command %+v", cmdMock.resolve)
+	}
+}
+
 func TestHandler_ConfirmPayment_Error(t *testing.T) {
 	cmdMock := &mockCommandUseCase{
 		err: errors.Forbidden("Not authorized"),
@@ -144,6 +354,45 @@ func TestHandler_RefundPayment(t *testing.T) {
 	}
 }
 
+func TestHandler_RefundPayment_Partial(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	body := strings.NewReader(`{"amount":1500,"reason":"damaged item"}`)
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/refund", body)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.refund.Amount != 1500 || cmdMock.refund.Reason != "damaged item" {
+		t.Errorf("unexpected refund command %+v", cmdMock.refund)
+	}
+}
+
+func TestHandler_RefundPayment_Pending(t *testing.T) {
+	cmdMock := &mockCommandUseCase{
+		refundResp: &command.RefundPaymentResult{RefundID: "refund-1", Status: "pending", Pending: true},
+	}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/payments/"+testPaymentID+"/refund", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d; body: %s", w.Code, w.Body.String())
+	}
+	var resp RefundResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.ID != "refund-1" || resp.Status != "pending" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
 func TestHandler_RefundPayment_NotOwner(t *testing.T) {
 	cmdMock := &mockCommandUseCase{
 		err: errors.Forbidden("Not authorized"),
@@ -199,3 +448,179 @@ func TestHandler_GetEvents(t *testing.T) {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
 }
+
+func TestHandler_HandleWebhook(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+
+	payload := `{"type":"charge.succeeded","payment_id":"` + testPaymentID + `"}`
+	req := httptest.NewRequest("POST", "/api/v1/payments/webhooks/fakepay", strings.NewReader(payload))
+	req.Header.Set("X-Webhook-Signature", "t=1,v1=abc")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if cmdMock.webhook.Provider != "fakepay" {
+		t.Errorf("expected provider 'fakepay', got %q", cmdMock.webhook.Provider)
+	}
+	if string(cmdMock.webhook.Payload) != payload {
+		t.Errorf("payload was not passed through verbatim: %q", cmdMock.webhook.Payload)
+	}
+	if cmdMock.webhook.Signature != "t=1,v1=abc" {
+		t.Errorf("expected signature header to be forwarded, got %q", cmdMock.webhook.Signature)
+	}
+}
+
+func TestHandler_HandleWebhook_InvalidSignature(t *testing.T) {
+	cmdMock := &mockCommandUseCase{err: errors.Unauthorized("Invalid webhook signature")}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+
+	req := httptest.NewRequest("POST", "/api/v1/payments/webhooks/fakepay", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListMyPayments(t *testing.T) {
+	qryMock := &mockQueryUseCase{
+		listResp: &query.ListResult{
+			Payments: []query.Result{{ID: testPaymentID, WinnerID: testUserID, Amount: 5000, Status: "completed"}},
+			Total:    11,
+		},
+	}
+
+	router := setupRouter(&mockCommandUseCase{}, qryMock)
+	req := httptest.NewRequest("GET", "/api/v1/me/payments?status=completed&page=2&limit=500", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if qryMock.list.UserID != testUserID || qryMock.list.Status != "completed" ||
+		qryMock.list.Page != 2 || qryMock.list.Limit != 100 {
+		t.Errorf("unexpected query %+v", qryMock.list)
+	}
+	var resp ListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 11 || len(resp.Payments) != 1 || resp.Payments[0].ID != testPaymentID {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestHandler_GetAuctionPayment_Forbidden(t *testing.T) {
+	qryMock := &mockQueryUseCase{err: errors.Forbidden("Not authorized")}
+
+	router := setupRouter(&mockCommandUseCase{}, qryMock)
+	req := httptest.NewRequest("GET", "/api/v1/auctions/"+testPaymentID+"/payment", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestHandler_PayoutSeller(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("POST", "/api/v1/me/payouts", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_GetInvoice(t *testing.T) {
+	qryMock := &mockQueryUseCase{invoiceResp: &query.InvoiceResult{
+		Number:    "INV-00000001",
+		PaymentID: testPaymentID,
+		Buyer:     query.InvoiceParty{ID: testUserID, Name: "<Buyer>", Email: "buyer@example.com"},
+		Seller:    query.InvoiceParty{Name: "Seller", Email: "seller@example.com"},
+		Lines:     []query.InvoiceLine{{Kind: "hammer_price", Description: "Hammer price", Amount: 5000}},
+		Total:     5000,
+		SellerNet: 4750,
+		IssuedAt:  time.Now(),
+	}}
+	router := setupRouter(&mockCommandUseCase{}, qryMock)
+
+	req := httptest.NewRequest("GET", "/api/v1/payments/"+testPaymentID+"/invoice", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected text/html, got %q", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "INV-00000001") || !strings.Contains(body, "&lt;Buyer&gt;") {
+		t.Errorf("unexpected HTML invoice: %s", body)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/payments/"+testPaymentID+"/invoice?format=text", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain, got %q", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "<Buyer> <buyer@example.com>") {
+		t.Errorf("unexpected text invoice: %s", body)
+	}
+}
+
+func TestHandler_GetInvoice_BadFormat(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("GET", "/api/v1/payments/"+testPaymentID+"/invoice?format=pdf", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_AddPaymentMethod(t *testing.T) {
+	cmdMock := &mockCommandUseCase{}
+	router := setupRouter(cmdMock, &mockQueryUseCase{})
+	body := strings.NewReader(`{"token":"tok_visa","default":true}`)
+	req := httptest.NewRequest("POST", "/api/v1/me/payment-methods", body)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+	want := command.AddPaymentMethod{UserID: testUserID, Token: "tok_visa", MakeDefault: true}
+	if cmdMock.addMethod != want {
+		t.Errorf("unexpected command %+v", cmdMock.addMethod)
+	}
+}
+
+func TestHandler_RemovePaymentMethod(t *testing.T) {
+	router := setupRouter(&mockCommandUseCase{}, &mockQueryUseCase{})
+	req := httptest.NewRequest("DELETE", "/api/v1/me/payment-methods/method-id", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d; body: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+
+	"github.com/in-jun/go-structure-example/internal/payment/application/query"
+)
+
+const invoiceHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Number}}</title>
+</head>
+<body>
+<h1>Invoice {{.Number}}</h1>
+<p>Issued {{.IssuedAt.Format "2006-01-02"}} for payment {{.PaymentID}} (auction {{.AuctionID}})</p>
+<table>
+<tr><th>Buyer</th><th>Seller</th></tr>
+<tr><td>{{.Buyer.Name}}<br>{{.Buyer.Email}}</td><td>{{.Seller.Name}}<br>{{.Seller.Email}}</td></tr>
+</table>
+<table>
+<tr><th>Item</th><th>Amount</th></tr>
+{{- range .Lines}}
+<tr><td>{{.Description}}</td><td>{{.Amount}}</td></tr>
+{{- end}}
+</table>
+<p>Total paid by buyer: {{.Total}}</p>
+<p>Net to seller: {{.SellerNet}}</p>
+</body>
+</html>
+`
+
+const invoiceText = `INVOICE {{.Number}}
+Issued:  {{.IssuedAt.Format "2006-01-02"}}
+Payment: {{.PaymentID}}
+Auction: {{.AuctionID}}
+
+Buyer:   {{.Buyer.Name}} <{{.Buyer.Email}}>
+Seller:  {{.Seller.Name}} <{{.Seller.Email}}>
+{{range .Lines}}
+{{printf "%-50s %12d" .Description .Amount}}
+{{- end}}
+
+{{printf "%-50s %12d" "Total paid by buyer" .Total}}
+{{printf "%-50s %12d" "Net to seller" .SellerNet}}
+`
+
+var (
+	invoiceHTMLTemplate = htmltemplate.Must(htmltemplate.New("invoice.html").Parse(invoiceHTML))
+	invoiceTextTemplate = texttemplate.Must(texttemplate.New("invoice.txt").Parse(invoiceText))
+)
+
+func renderInvoiceHTML(w io.Writer, invoice *query.InvoiceResult) error {
+	return invoiceHTMLTemplate.Execute(w, invoice)
+}
+
+func renderInvoiceText(w io.Writer, invoice *query.InvoiceResult) error {
+	return invoiceTextTemplate.Execute(w, invoice)
+}
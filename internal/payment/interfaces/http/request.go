@@ -1,5 +1,30 @@
 package http
 
+type ConfirmRequest struct {
+	PaymentMethodID string `json:"payment_method_id"`
+}
+
 type RefundRequest struct {
+	Amount int64  `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+type OpenDisputeRequest struct {
 	Reason string `json:"reason"`
 }
+
+type RespondDisputeRequest struct {
+	Response string `json:"response"`
+}
+
+type ResolveDisputeRequest struct {
+	Resolution string `json:"resolution"`
+	Amount     int64  `json:"amount"`
+}
+
+// AddPaymentMethodRequest carries a token the client obtained from the
+// gateway; card numbers and details are never sent here.
+type AddPaymentMethodRequest struct {
+	Token   string `json:"token"`
+	Default bool   `json:"default"`
+}
@@ -4,17 +4,127 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/in-jun/go-structure-example/internal/payment/application/command"
 	"github.com/in-jun/go-structure-example/internal/payment/application/query"
 )
 
 type Response struct {
+	ID        string      `json:"id"`
+	AuctionID string      `json:"auction_id"`
+	WinnerID  string      `json:"winner_id"`
+	Amount    int64       `json:"amount"`
+	Fee       FeeResponse `json:"fee"`
+	Refunded  int64       `json:"refunded_amount"`
+	Status    string      `json:"status"`
+	DueAt     time.Time   `json:"due_at"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+
+	EscrowReleaseAt *time.Time `json:"escrow_release_at,omitempty"`
+	PaymentMethodID string     `json:"payment_method_id,omitempty"`
+}
+
+type FeeResponse struct {
+	Amount       int64  `json:"amount"`
+	Rule         string `json:"rule"`
+	Category     string `json:"category,omitempty"`
+	SellerAmount int64  `json:"seller_amount"`
+}
+
+type ListResponse struct {
+	Payments []Response `json:"payments"`
+	Total    int64      `json:"total"`
+}
+
+type ConfirmResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type RefundResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type PayoutResponse struct {
+	ID     string `json:"id"`
+	Amount int64  `json:"amount"`
+	Status string `json:"status"`
+}
+
+type BalanceResponse struct {
+	Payable int64 `json:"payable"`
+	PaidOut int64 `json:"paid_out"`
+}
+
+type ReconciliationIssueResponse struct {
+	ID            string    `json:"id"`
+	Kind          string    `json:"kind"`
+	PaymentID     string    `json:"payment_id"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Expected      int64     `json:"expected_amount"`
+	Actual        int64     `json:"actual_amount"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+type ReconciliationIssueListResponse struct {
+	Issues []ReconciliationIssueResponse `json:"issues"`
+	Total  int64                         `json:"total"`
+}
+
+type DisputeResponse struct {
+	ID            string     `json:"id"`
+	PaymentID     string     `json:"payment_id"`
+	BuyerID       string     `json:"buyer_id"`
+	SellerID      string     `json:"seller_id"`
+	Reason        string     `json:"reason"`
+	Response      string     `json:"response,omitempty"`
+	Status        string     `json:"status"`
+	Resolution    string     `json:"resolution,omitempty"`
+	RefundAmount  int64      `json:"refund_amount"`
+	RespondBy     time.Time  `json:"respond_by"`
+	ReviewBy      *time.Time `json:"review_by,omitempty"`
+	ReviewOverdue bool       `json:"review_overdue"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type DisputeListResponse struct {
+	Disputes []DisputeResponse `json:"disputes"`
+	Total    int64             `json:"total"`
+}
+
+type DisputeStatusResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type ResolveDisputeResponse struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Resolution   string `json:"resolution"`
+	RefundID     string `json:"refund_id,omitempty"`
+	RefundStatus string `json:"refund_status,omitempty"`
+}
+
+type PaymentMethodResponse struct {
 	ID        string    `json:"id"`
-	AuctionID string    `json:"auction_id"`
-	WinnerID  string    `json:"winner_id"`
-	Amount    int64     `json:"amount"`
-	Status    string    `json:"status"`
+	Brand     string    `json:"brand"`
+	Last4     string    `json:"last4"`
+	ExpMonth  int       `json:"exp_month"`
+	ExpYear   int       `json:"exp_year"`
+	IsDefault bool      `json:"default"`
+	IsExpired bool      `json:"expired"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PaymentMethodListResponse struct {
+	PaymentMethods []PaymentMethodResponse `json:"payment_methods"`
+}
+
+type PaymentMethodStatusResponse struct {
+	ID        string `json:"id"`
+	IsDefault bool   `json:"default"`
 }
 
 type EventResponse struct {
@@ -37,13 +147,113 @@ func toEventHistoryResponse(r *query.EventHistoryResult) *EventHistoryResponse {
 }
 
 func toGetResponse(r *query.Result) *Response {
-	return &Response{
+	resp := &Response{
 		ID:        r.ID,
 		AuctionID: r.AuctionID,
 		WinnerID:  r.WinnerID,
 		Amount:    r.Amount,
+		Fee: FeeResponse{
+			Amount: r.Fee.Amount, Rule: r.Fee.Rule,
+			Category: r.Fee.Category, SellerAmount: r.Fee.SellerAmount,
+		},
+		Refunded:  r.Refunded,
 		Status:    r.Status,
+		DueAt:     r.DueAt,
 		CreatedAt: r.CreatedAt,
 		UpdatedAt: r.UpdatedAt,
+
+		PaymentMethodID: r.PaymentMethodID,
+	}
+	if !r.EscrowReleaseAt.IsZero() {
+		resp.EscrowReleaseAt = &r.EscrowReleaseAt
 	}
+	return resp
+}
+
+func toListResponse(r *query.ListResult) *ListResponse {
+	payments := make([]Response, len(r.Payments))
+	for i := range r.Payments {
+		payments[i] = *toGetResponse(&r.Payments[i])
+	}
+	return &ListResponse{Payments: payments, Total: r.Total}
+}
+
+func toConfirmResponse(r *command.ConfirmPaymentResult) *ConfirmResponse {
+	return &ConfirmResponse{ID: r.PaymentID, Status: r.Status}
+}
+
+func toConfirmDeliveryResponse(r *command.ConfirmDeliveryResult) *ConfirmResponse {
+	return &ConfirmResponse{ID: r.PaymentID, Status: r.Status}
+}
+
+func toRefundResponse(r *command.RefundPaymentResult) *RefundResponse {
+	return &RefundResponse{ID: r.RefundID, Status: r.Status}
+}
+
+func toPayoutResponse(r *command.PayoutSellerResult) *PayoutResponse {
+	return &PayoutResponse{ID: r.ID, Amount: r.Amount, Status: r.Status}
+}
+
+func toBalanceResponse(r *query.BalanceResult) *BalanceResponse {
+	return &BalanceResponse{Payable: r.Payable, PaidOut: r.PaidOut}
+}
+
+func toReconciliationIssueListResponse(r *query.ReconciliationIssueListResult) *ReconciliationIssueListResponse {
+	issues := make([]ReconciliationIssueResponse, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = ReconciliationIssueResponse{
+			ID: issue.ID, Kind: issue.Kind, PaymentID: issue.PaymentID,
+			TransactionID: issue.TransactionID, Expected: issue.Expected,
+			Actual: issue.Actual, DetectedAt: issue.DetectedAt,
+		}
+	}
+	return &ReconciliationIssueListResponse{Issues: issues, Total: r.Total}
+}
+
+func toDisputeResponse(r *query.DisputeResult) *DisputeResponse {
+	resp := &DisputeResponse{
+		ID: r.ID, PaymentID: r.PaymentID, BuyerID: r.BuyerID, SellerID: r.SellerID,
+		Reason: r.Reason, Response: r.Response, Status: r.Status,
+		Resolution: r.Resolution, RefundAmount: r.RefundAmount,
+		RespondBy: r.RespondBy, ReviewOverdue: r.ReviewOverdue,
+		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+	}
+	if !r.ReviewBy.IsZero() {
+		resp.ReviewBy = &r.ReviewBy
+	}
+	return resp
+}
+
+func toDisputeListResponse(r *query.DisputeListResult) *DisputeListResponse {
+	disputes := make([]DisputeResponse, len(r.Disputes))
+	for i := range r.Disputes {
+		disputes[i] = *toDisputeResponse(&r.Disputes[i])
+	}
+	return &DisputeListResponse{Disputes: disputes, Total: r.Total}
+}
+
+func toDisputeStatusResponse(r *command.DisputeResult) *DisputeStatusResponse {
+	return &DisputeStatusResponse{ID: r.DisputeID, Status: r.Status}
+}
+
+func toResolveDisputeResponse(r *command.ResolveDisputeResult) *ResolveDisputeResponse {
+	return &ResolveDisputeResponse{
+		ID: r.DisputeID, Status: r.Status, Resolution: r.Resolution,
+		RefundID: r.RefundID, RefundStatus: r.RefundStatus,
+	}
+}
+
+func toPaymentMethodListResponse(r *query.PaymentMethodListResult) *PaymentMethodListResponse {
+	methods := make([]PaymentMethodResponse, len(r.PaymentMethods))
+	for i, m := range r.PaymentMethods {
+		methods[i] = PaymentMethodResponse{
+			ID: m.ID, Brand: m.Brand, Last4: m.Last4, ExpMonth: m.ExpMonth, ExpYear: m.ExpYear,
+			IsDefault: m.IsDefault, IsExpired: m.IsExpired, CreatedAt: m.CreatedAt,
+		}
+	}
+	return &PaymentMethodListResponse{PaymentMethods: methods}
+}
+
+func toPaymentMethodStatusResponse(r *command.PaymentMethodResult) *PaymentMethodStatusResponse {
+	return &PaymentMethodStatusResponse{ID: r.PaymentMethodID, IsDefault: r.IsDefault}
 }
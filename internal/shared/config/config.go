@@ -3,14 +3,17 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
 	AppPort            string
-	JWTSecret          string
+	JWTSigningKeys     string
 	JWTAccessExpiry    string
 	JWTRefreshExpiry   string
+	JWKSURL            string
+	JWKSCacheTTL       time.Duration
 	RedisURL           string
 	PGHost             string
 	PGPort             string
@@ -34,6 +37,70 @@ type Config struct {
 	ShutdownTimeout    time.Duration
 	RateLimitRPS       float64
 	RateLimitBurst     int
+	FraudScanInterval  time.Duration
+	BidRateLimit       int
+	BidRateLimitWindow time.Duration
+	BidPseudonymSecret string
+	HighestBidCacheTTL time.Duration
+	SecondChanceOffers int
+
+	InternalIdentityKeys      string
+	InternalIdentityTolerance time.Duration
+
+	Mailer               string
+	MailFrom             string
+	MailDir              string
+	MailDispatchInterval time.Duration
+	SMTPAddr             string
+	SMTPUsername         string
+	SMTPPassword         string
+
+	EmailVerifyURL       string
+	EmailVerificationTTL time.Duration
+	EmailResendCooldown  time.Duration
+
+	PaymentGateway          string
+	PaymentProvider         string
+	PaymentProviderURL      string
+	PaymentProviderAPIKey   string
+	PaymentProviderTimeout  time.Duration
+	PaymentProviderRetries  int
+	PaymentWebhookSecret    string
+	PaymentWebhookTolerance time.Duration
+	FakePayWebhookURL       string
+	FakePayWebhookDelay     time.Duration
+	FakePayDeclineAbove     int
+	FakeGatewayScenarios    string
+	FakeGatewayTimeout      time.Duration
+	FakeGatewaySettleDelay  time.Duration
+
+	PaymentRecoveryInterval    time.Duration
+	PaymentRecoveryStaleAfter  time.Duration
+	PaymentRecoveryGiveUpAfter time.Duration
+	PaymentDueAfter            time.Duration
+	PaymentReminderOffsets     []time.Duration
+	PaymentDeadlineInterval    time.Duration
+
+	PaymentRefundRetryInterval  time.Duration
+	PaymentRefundRetryBaseDelay time.Duration
+	PaymentRefundRetryMaxDelay  time.Duration
+	PaymentRefundMaxAttempts    int
+
+	PaymentReconcileInterval time.Duration
+	PaymentReconcileWindow   time.Duration
+	PaymentReconcileLag      time.Duration
+
+	PaymentEscrowHold            time.Duration
+	PaymentEscrowReleaseInterval time.Duration
+
+	DisputeOpenWindow       time.Duration
+	DisputeResponseWindow   time.Duration
+	DisputeReviewWindow     time.Duration
+	DisputeDeadlineInterval time.Duration
+
+	PlatformFee           string
+	PlatformFeeCategories string
+	InvoiceTaxBasisPoints int
 }
 
 var AppConfig Config
@@ -41,9 +108,11 @@ var AppConfig Config
 func Load() {
 	AppConfig = Config{
 		AppPort:            getEnv("APP_PORT", "8080"),
-		JWTSecret:          getEnv("JWT_SECRET", ""),
+		JWTSigningKeys:     getEnv("JWT_SIGNING_KEYS", ""),
 		JWTAccessExpiry:    getEnv("JWT_ACCESS_EXPIRY", "15m"),
 		JWTRefreshExpiry:   getEnv("JWT_REFRESH_EXPIRY", "168h"),
+		JWKSURL:            getEnv("JWKS_URL", ""),
+		JWKSCacheTTL:       parseDuration(getEnv("JWKS_CACHE_TTL", "5m")),
 		RedisURL:           getEnv("REDIS_URL", "localhost:6379"),
 		PGHost:             getEnv("PG_HOST", "localhost"),
 		PGPort:             getEnv("PG_PORT", "5432"),
@@ -67,6 +136,70 @@ func Load() {
 		ShutdownTimeout:    parseDuration(getEnv("SHUTDOWN_TIMEOUT", "10s")),
 		RateLimitRPS:       parseFloat(getEnv("RATE_LIMIT_RPS", "100")),
 		RateLimitBurst:     parseInt(getEnv("RATE_LIMIT_BURST", "200")),
+		FraudScanInterval:  parseDuration(getEnv("FRAUD_SCAN_INTERVAL", "5m")),
+		BidRateLimit:       parseInt(getEnv("BID_RATE_LIMIT", "5")),
+		BidRateLimitWindow: parseDuration(getEnv("BID_RATE_LIMIT_WINDOW", "10s")),
+		BidPseudonymSecret: getEnv("BID_PSEUDONYM_SECRET", ""),
+		HighestBidCacheTTL: parseDuration(getEnv("HIGHEST_BID_CACHE_TTL", "10m")),
+		SecondChanceOffers: parseInt(getEnv("SECOND_CHANCE_OFFERS", "2")),
+
+		InternalIdentityKeys:      getEnv("INTERNAL_IDENTITY_KEYS", ""),
+		InternalIdentityTolerance: parseDuration(getEnv("INTERNAL_IDENTITY_TOLERANCE", "30s")),
+
+		Mailer:               getEnv("MAILER", "log"),
+		MailFrom:             getEnv("MAIL_FROM", "no-reply@localhost"),
+		MailDir:              getEnv("MAIL_DIR", ""),
+		MailDispatchInterval: parseDuration(getEnv("MAIL_DISPATCH_INTERVAL", "5s")),
+		SMTPAddr:             getEnv("SMTP_ADDR", "localhost:25"),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+
+		EmailVerifyURL:       getEnv("EMAIL_VERIFY_URL", "http://localhost:8080/verify-email"),
+		EmailVerificationTTL: parseDuration(getEnv("EMAIL_VERIFICATION_TTL", "24h")),
+		EmailResendCooldown:  parseDuration(getEnv("EMAIL_RESEND_COOLDOWN", "1m")),
+
+		PaymentGateway:          getEnv("PAYMENT_GATEWAY", "mock"),
+		PaymentProvider:         getEnv("PAYMENT_PROVIDER", "fakepay"),
+		PaymentProviderURL:      getEnv("PAYMENT_PROVIDER_URL", "http://localhost:8090"),
+		PaymentProviderAPIKey:   getEnv("PAYMENT_PROVIDER_API_KEY", ""),
+		PaymentProviderTimeout:  parseDuration(getEnv("PAYMENT_PROVIDER_TIMEOUT", "10s")),
+		PaymentProviderRetries:  parseInt(getEnv("PAYMENT_PROVIDER_RETRIES", "2")),
+		PaymentWebhookSecret:    getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		PaymentWebhookTolerance: parseDuration(getEnv("PAYMENT_WEBHOOK_TOLERANCE", "5m")),
+		FakePayWebhookURL:       getEnv("FAKEPAY_WEBHOOK_URL", ""),
+		FakePayWebhookDelay:     parseDuration(getEnv("FAKEPAY_WEBHOOK_DELAY", "1s")),
+		FakePayDeclineAbove:     parseInt(getEnv("FAKEPAY_DECLINE_ABOVE", "0")),
+		FakeGatewayScenarios:    getEnv("FAKE_GATEWAY_SCENARIOS", ""),
+		FakeGatewayTimeout:      parseDuration(getEnv("FAKE_GATEWAY_TIMEOUT", "5s")),
+		FakeGatewaySettleDelay:  parseDuration(getEnv("FAKE_GATEWAY_SETTLE_DELAY", "2s")),
+
+		PaymentRecoveryInterval:    parseDuration(getEnv("PAYMENT_RECOVERY_INTERVAL", "1m")),
+		PaymentRecoveryStaleAfter:  parseDuration(getEnv("PAYMENT_RECOVERY_STALE_AFTER", "2m")),
+		PaymentRecoveryGiveUpAfter: parseDuration(getEnv("PAYMENT_RECOVERY_GIVE_UP_AFTER", "30m")),
+		PaymentDueAfter:            parseDuration(getEnv("PAYMENT_DUE_AFTER", "48h")),
+		PaymentReminderOffsets:     parseDurations(getEnv("PAYMENT_REMINDER_OFFSETS", "24h,1h")),
+		PaymentDeadlineInterval:    parseDuration(getEnv("PAYMENT_DEADLINE_INTERVAL", "1m")),
+
+		PaymentRefundRetryInterval:  parseDuration(getEnv("PAYMENT_REFUND_RETRY_INTERVAL", "30s")),
+		PaymentRefundRetryBaseDelay: parseDuration(getEnv("PAYMENT_REFUND_RETRY_BASE_DELAY", "30s")),
+		PaymentRefundRetryMaxDelay:  parseDuration(getEnv("PAYMENT_REFUND_RETRY_MAX_DELAY", "1h")),
+		PaymentRefundMaxAttempts:    parseInt(getEnv("PAYMENT_REFUND_MAX_ATTEMPTS", "8")),
+
+		PaymentReconcileInterval: parseDuration(getEnv("PAYMENT_RECONCILE_INTERVAL", "1h")),
+		PaymentReconcileWindow:   parseDuration(getEnv("PAYMENT_RECONCILE_WINDOW", "24h")),
+		PaymentReconcileLag:      parseDuration(getEnv("PAYMENT_RECONCILE_LAG", "10m")),
+
+		PaymentEscrowHold:            parseDuration(getEnv("PAYMENT_ESCROW_HOLD", "0s")),
+		PaymentEscrowReleaseInterval: parseDuration(getEnv("PAYMENT_ESCROW_RELEASE_INTERVAL", "1m")),
+
+		DisputeOpenWindow:       parseDuration(getEnv("DISPUTE_OPEN_WINDOW", "720h")),
+		DisputeResponseWindow:   parseDuration(getEnv("DISPUTE_RESPONSE_WINDOW", "72h")),
+		DisputeReviewWindow:     parseDuration(getEnv("DISPUTE_REVIEW_WINDOW", "120h")),
+		DisputeDeadlineInterval: parseDuration(getEnv("DISPUTE_DEADLINE_INTERVAL", "1m")),
+
+		PlatformFee:           getEnv("PLATFORM_FEE", "percentage:500"),
+		PlatformFeeCategories: getEnv("PLATFORM_FEE_CATEGORIES", ""),
+		InvoiceTaxBasisPoints: parseInt(getEnv("INVOICE_TAX_BASIS_POINTS", "0")),
 	}
 }
 
@@ -93,6 +226,16 @@ func parseInt(s string) int {
 	return v
 }
 
+func parseDurations(s string) []time.Duration {
+	var ds []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		if d, err := time.ParseDuration(strings.TrimSpace(part)); err == nil {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
 func parseDuration(s string) time.Duration {
 	d, err := time.ParseDuration(s)
 	if err != nil {
@@ -8,7 +8,6 @@ import (
 
 func TestLoad_Defaults(t *testing.T) {
 	os.Clearenv()
-	t.Setenv("JWT_SECRET", "test-secret")
 	Load()
 
 	if AppConfig.AppPort != "8080" {
@@ -35,6 +34,9 @@ func TestLoad_Defaults(t *testing.T) {
 	if AppConfig.JWTRefreshExpiry != "168h" {
 		t.Errorf("expected default JWTRefreshExpiry '168h', got %q", AppConfig.JWTRefreshExpiry)
 	}
+	if AppConfig.JWKSCacheTTL != 5*time.Minute {
+		t.Errorf("expected default JWKSCacheTTL 5m, got %v", AppConfig.JWKSCacheTTL)
+	}
 	if AppConfig.NATSURL != "nats://localhost:4222" {
 		t.Errorf("expected default NATSURL 'nats://localhost:4222', got %q", AppConfig.NATSURL)
 	}
@@ -50,11 +52,59 @@ func TestLoad_Defaults(t *testing.T) {
 	if AppConfig.PaymentServiceURL != "http://localhost:8084" {
 		t.Errorf("expected default PaymentServiceURL 'http://localhost:8084', got %q", AppConfig.PaymentServiceURL)
 	}
+	if AppConfig.FraudScanInterval != 5*time.Minute {
+		t.Errorf("expected default FraudScanInterval 5m, got %v", AppConfig.FraudScanInterval)
+	}
+	if AppConfig.BidRateLimit != 5 {
+		t.Errorf("expected default BidRateLimit 5, got %d", AppConfig.BidRateLimit)
+	}
+	if AppConfig.Mailer != "log" {
+		t.Errorf("expected default Mailer 'log', got %q", AppConfig.Mailer)
+	}
+	if AppConfig.EmailVerificationTTL != 24*time.Hour {
+		t.Errorf("expected default EmailVerificationTTL 24h, got %v", AppConfig.EmailVerificationTTL)
+	}
+	if AppConfig.PaymentGateway != "mock" {
+		t.Errorf("expected default PaymentGateway 'mock', got %q", AppConfig.PaymentGateway)
+	}
+	if AppConfig.PaymentWebhookTolerance != 5*time.Minute {
+		t.Errorf("expected default PaymentWebhookTolerance 5m, got %v", AppConfig.PaymentWebhookTolerance)
+	}
+	if AppConfig.PaymentRecoveryGiveUpAfter != 30*time.Minute {
+		t.Errorf("expected default PaymentRecoveryGiveUpAfter 30m, got %v", AppConfig.PaymentRecoveryGiveUpAfter)
+	}
+	if AppConfig.PaymentDueAfter != 48*time.Hour {
+		t.Errorf("expected default PaymentDueAfter 48h, got %v", AppConfig.PaymentDueAfter)
+	}
+	if len(AppConfig.PaymentReminderOffsets) != 2 || AppConfig.PaymentReminderOffsets[0] != 24*time.Hour {
+		t.Errorf("expected default PaymentReminderOffsets [24h 1h], got %v", AppConfig.PaymentReminderOffsets)
+	}
+	if AppConfig.PaymentRefundRetryBaseDelay != 30*time.Second {
+		t.Errorf("expected default PaymentRefundRetryBaseDelay 30s, got %v", AppConfig.PaymentRefundRetryBaseDelay)
+	}
+	if AppConfig.PaymentRefundMaxAttempts != 8 {
+		t.Errorf("expected default PaymentRefundMaxAttempts 8, got %d", AppConfig.PaymentRefundMaxAttempts)
+	}
+	if AppConfig.PaymentReconcileWindow != 24*time.Hour {
+		t.Errorf("expected default PaymentReconcileWindow 24h, got %v", AppConfig.PaymentReconcileWindow)
+	}
+	if AppConfig.PaymentEscrowHold != 0 {
+		t.Errorf("expected default PaymentEscrowHold 0, got %v", AppConfig.PaymentEscrowHold)
+	}
+	if AppConfig.DisputeResponseWindow != 72*time.Hour {
+		t.Errorf("expected default DisputeResponseWindow 72h, got %v", AppConfig.DisputeResponseWindow)
+	}
+	if AppConfig.PlatformFee != "percentage:500" {
+		t.Errorf("expected default PlatformFee percentage:500, got %q", AppConfig.PlatformFee)
+	}
+	if AppConfig.InvoiceTaxBasisPoints != 0 {
+		t.Errorf("expected default InvoiceTaxBasisPoints 0, got %d", AppConfig.InvoiceTaxBasisPoints)
+	}
 }
 
 func TestLoad_CustomEnv(t *testing.T) {
 	os.Clearenv()
-	t.Setenv("JWT_SECRET", "custom-secret")
+	t.Setenv("JWT_SIGNING_KEYS", "k1=/run/secrets/jwt-k1.pem")
 	t.Setenv("APP_PORT", "9090")
 	t.Setenv("PG_HOST", "myhost")
 	t.Setenv("SHUTDOWN_TIMEOUT", "30s")
@@ -78,6 +128,9 @@ func TestLoad_CustomEnv(t *testing.T) {
 	if AppConfig.CORSAllowOrigins != "https://example.com" {
 		t.Errorf("expected CORSAllowOrigins 'https://example.com', got %q", AppConfig.CORSAllowOrigins)
 	}
+	if AppConfig.JWTSigningKeys != "k1=/run/secrets/jwt-k1.pem" {
+		t.Errorf("expected JWTSigningKeys to be read, got %q", AppConfig.JWTSigningKeys)
+	}
 }
 
 func TestParseDuration_Invalid(t *testing.T) {
@@ -93,3 +146,10 @@ func TestParseInt_Invalid(t *testing.T) {
 		t.Errorf("expected fallback 0, got %d", v)
 	}
 }
+
+func TestParseDurations_SkipsInvalid(t *testing.T) {
+	ds := parseDurations("24h, nope,1h")
+	if len(ds) != 2 || ds[0] != 24*time.Hour || ds[1] != time.Hour {
+		t.Errorf("expected [24h 1h], got %v", ds)
+	}
+}
@@ -30,6 +30,7 @@ var (
 	ErrNotFound        = CustomError{Status: http.StatusNotFound, Code: "NOT_FOUND"}
 	ErrConflict        = CustomError{Status: http.StatusConflict, Code: "CONFLICT"}
 	ErrTooManyRequests = CustomError{Status: http.StatusTooManyRequests, Code: "RATE_LIMIT_EXCEEDED"}
+	ErrBidRateLimited  = CustomError{Status: http.StatusTooManyRequests, Code: "BID_RATE_LIMIT_EXCEEDED"}
 	ErrInternal        = CustomError{Status: http.StatusInternalServerError, Code: "INTERNAL_ERROR"}
 )
 
@@ -61,6 +62,10 @@ func TooManyRequests(message string) CustomError {
 	return CustomError{Status: http.StatusTooManyRequests, Code: "RATE_LIMIT_EXCEEDED", Message: message}
 }
 
+func BidRateLimitExceeded(message string) CustomError {
+	return CustomError{Status: http.StatusTooManyRequests, Code: "BID_RATE_LIMIT_EXCEEDED", Message: message}
+}
+
 func Internal(message string) CustomError {
 	return CustomError{Status: http.StatusInternalServerError, Code: "INTERNAL_ERROR", Message: message}
 }
@@ -27,6 +27,7 @@ func TestErrorFactories(t *testing.T) {
 		{"NotFound", NotFound, http.StatusNotFound, "NOT_FOUND"},
 		{"Conflict", Conflict, http.StatusConflict, "CONFLICT"},
 		{"TooManyRequests", TooManyRequests, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED"},
+		{"BidRateLimitExceeded", BidRateLimitExceeded, http.StatusTooManyRequests, "BID_RATE_LIMIT_EXCEEDED"},
 		{"Internal", Internal, http.StatusInternalServerError, "INTERNAL_ERROR"},
 	}
 
@@ -0,0 +1,141 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrUnknownKey = errors.New("jwks: unknown key ID")
+
+// minRefreshInterval throttles refetches for key IDs the cached set does
+// not know, so tokens with made-up kids cannot be used to hammer the
+// issuer.
+const minRefreshInterval = 30 * time.Second
+
+// Cache fetches a JWKS document and keeps its keys for ttl. An unknown key
+// ID triggers an early refetch, which is how keys rotated in by the issuer
+// are picked up. When a refetch fails the keys already held keep being
+// used.
+//
+// Fetches happen outside the lock and concurrent ones are collapsed into
+// one. A known key is served from the cached set while it is refreshed, so
+// only callers with an unknown key ID wait for the issuer.
+type Cache struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+	now    func() time.Time
+	group  singleflight.Group
+
+	mu          sync.RWMutex
+	keys        map[string]ed25519.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewCache(url string, ttl time.Duration, client *http.Client) *Cache {
+	return &Cache{url: url, ttl: ttl, client: client, now: time.Now}
+}
+
+func (c *Cache) Key(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	key, known, stale := c.lookup(kid)
+	if known && !stale {
+		return key, nil
+	}
+
+	// The refresh is shared with concurrent callers and may outlive this
+	// one, so it runs without the caller's cancellation; the client timeout
+	// bounds it.
+	done := c.group.DoChan("refresh", func() (any, error) {
+		c.refresh(context.WithoutCancel(ctx))
+		return nil, nil
+	})
+	if known {
+		return key, nil
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if key, known, _ = c.lookup(kid); !known {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// lookup reports the cached key for kid and whether the set is stale
+// enough to start a refresh for it.
+func (c *Cache) lookup(kid string) (ed25519.PublicKey, bool, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := c.now()
+	key, known := c.keys[kid]
+	return key, known, now.Sub(c.fetchedAt) >= c.ttl && now.Sub(c.attemptedAt) >= minRefreshInterval
+}
+
+// refresh refetches the set unless an attempt was made within
+// minRefreshInterval, so unknown key IDs cannot force a fetch each.
+func (c *Cache) refresh(ctx context.Context) {
+	c.mu.Lock()
+	now := c.now()
+	if now.Sub(c.attemptedAt) < minRefreshInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.attemptedAt = now
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		slog.Warn("failed to refresh JWKS", "url", c.url, "error", err)
+		return
+	}
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = now
+	c.mu.Unlock()
+}
+
+func (c *Cache) fetch(ctx context.Context) (map[string]ed25519.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Warn("failed to close JWKS response body", "error", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]ed25519.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		public, err := k.PublicKey()
+		if err != nil {
+			slog.Warn("skipping JWKS key", "kid", k.KeyID, "error", err)
+			continue
+		}
+		keys[k.KeyID] = public
+	}
+	return keys, nil
+}
@@ -0,0 +1,189 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/in-jun/go-structure-example/internal/shared/server"
+)
+
+type testIssuer struct {
+	mu      sync.Mutex
+	keys    []Key
+	fail    bool
+	fetches atomic.Int32
+	block   chan struct{}
+}
+
+func (i *testIssuer) add(t *testing.T, kid string) ed25519.PublicKey {
+	t.Helper()
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = append(i.keys, NewKey(kid, public))
+	return public
+}
+
+func (i *testIssuer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	i.fetches.Add(1)
+	if i.block != nil {
+		<-i.block
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	server.JSON(w, http.StatusOK, Set{Keys: i.keys})
+}
+
+func newTestCache(t *testing.T, issuer *testIssuer) (*Cache, *time.Time) {
+	t.Helper()
+	srv := httptest.NewServer(issuer)
+	t.Cleanup(srv.Close)
+	now := time.Unix(1_700_000_000, 0)
+	c := NewCache(srv.URL, 5*time.Minute, srv.Client())
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCache_Key(t *testing.T) {
+	issuer := &testIssuer{}
+	want := issuer.add(t, "k1")
+	c, _ := newTestCache(t, issuer)
+
+	for range 3 {
+		got, err := c.Key(context.Background(), "k1")
+		if err != nil {
+			t.Fatalf("Key() error = %v", err)
+		}
+		if !got.Equal(want) {
+			t.Fatal("Key() returned the wrong key")
+		}
+	}
+	if issuer.fetches.Load() != 1 {
+		t.Errorf("fetched %d times, want 1", issuer.fetches.Load())
+	}
+}
+
+func TestCache_RotatedKeyIsFetched(t *testing.T) {
+	issuer := &testIssuer{}
+	issuer.add(t, "k1")
+	c, now := newTestCache(t, issuer)
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer.add(t, "k2")
+	if _, err := c.Key(context.Background(), "k2"); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected the refetch to be throttled, got %v", err)
+	}
+	*now = now.Add(minRefreshInterval)
+	if _, err := c.Key(context.Background(), "k2"); err != nil {
+		t.Fatalf("Key() after rotation error = %v", err)
+	}
+	if issuer.fetches.Load() != 2 {
+		t.Errorf("fetched %d times, want 2", issuer.fetches.Load())
+	}
+}
+
+func TestCache_KeepsKeysWhenRefreshFails(t *testing.T) {
+	issuer := &testIssuer{}
+	issuer.add(t, "k1")
+	c, now := newTestCache(t, issuer)
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer.mu.Lock()
+	issuer.fail = true
+	issuer.mu.Unlock()
+	*now = now.Add(10 * time.Minute)
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatalf("expected the stale key to be served, got %v", err)
+	}
+	waitForFetches(t, issuer, 2)
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatalf("expected the key to survive the failed refresh, got %v", err)
+	}
+	if issuer.fetches.Load() != 2 {
+		t.Errorf("fetched %d times, want 2", issuer.fetches.Load())
+	}
+}
+
+func TestCache_ServesCachedKeysDuringRefresh(t *testing.T) {
+	issuer := &testIssuer{}
+	issuer.add(t, "k1")
+	c, now := newTestCache(t, issuer)
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer.block = make(chan struct{})
+	issuer.add(t, "k2")
+	*now = now.Add(10 * time.Minute)
+
+	unknown := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := c.Key(context.Background(), "k2")
+			unknown <- err
+		}()
+	}
+	waitForFetches(t, issuer, 2)
+
+	if _, err := c.Key(context.Background(), "k1"); err != nil {
+		t.Fatalf("expected the cached key while refreshing, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Key(ctx, "k2"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled wait, got %v", err)
+	}
+
+	close(issuer.block)
+	for range 2 {
+		if err := <-unknown; err != nil {
+			t.Errorf("Key() after refresh error = %v", err)
+		}
+	}
+	if issuer.fetches.Load() != 2 {
+		t.Errorf("fetched %d times, want 2", issuer.fetches.Load())
+	}
+}
+
+func waitForFetches(t *testing.T, issuer *testIssuer, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for issuer.fetches.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("fetched %d times, want %d", issuer.fetches.Load(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestKey_PublicKey_Unsupported(t *testing.T) {
+	tests := []Key{
+		{KeyType: "RSA", KeyID: "k1"},
+		{KeyType: keyTypeOKP, Curve: "X25519", X: "AAAA", KeyID: "k1"},
+		{KeyType: keyTypeOKP, Curve: curveEd25519, X: "short", KeyID: "k1"},
+	}
+	for _, k := range tests {
+		if _, err := k.PublicKey(); err == nil {
+			t.Errorf("expected %+v to be rejected", k)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package jwks
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+)
+
+const (
+	keyTypeOKP   = "OKP"
+	curveEd25519 = "Ed25519"
+	algEdDSA     = "EdDSA"
+)
+
+var errUnsupportedKey = errors.New("jwks: only Ed25519 keys are supported")
+
+// Key is the JSON Web Key form of an Ed25519 public key (RFC 8037).
+type Key struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+}
+
+type Set struct {
+	Keys []Key `json:"keys"`
+}
+
+func NewKey(kid string, public ed25519.PublicKey) Key {
+	return Key{
+		KeyType:   keyTypeOKP,
+		Curve:     curveEd25519,
+		X:         base64.RawURLEncoding.EncodeToString(public),
+		KeyID:     kid,
+		Use:       "sig",
+		Algorithm: algEdDSA,
+	}
+}
+
+func (k Key) PublicKey() (ed25519.PublicKey, error) {
+	if k.KeyType != keyTypeOKP || k.Curve != curveEd25519 {
+		return nil, errUnsupportedKey
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil || len(x) != ed25519.PublicKeySize {
+		return nil, errUnsupportedKey
+	}
+	return ed25519.PublicKey(x), nil
+}
@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -10,10 +12,21 @@ import (
 	"github.com/in-jun/go-structure-example/internal/shared/server"
 )
 
+const (
+	RoleAdmin  = "admin"
+	RoleSeller = "seller"
+	RoleBidder = "bidder"
+
+	// RoleService marks a call one service makes to another on its own
+	// behalf rather than for a user.
+	RoleService = "service"
+)
+
 type ValidateTokenResult struct {
 	UserID   string
 	JTI      string
 	IssuedAt int64
+	Roles    []string
 }
 
 type TokenValidator func(ctx context.Context, tokenString string) (*ValidateTokenResult, error)
@@ -41,12 +54,30 @@ func Auth(validateToken TokenValidator) func(http.Handler) http.Handler {
 
 			ctx := server.ContextWithUserID(r.Context(), result.UserID)
 			ctx = server.ContextWithTokenClaims(ctx, result.JTI, result.IssuedAt)
+			ctx = server.ContextWithRoles(ctx, result.Roles)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func GatewayAuth() func(http.Handler) http.Handler {
+func OptionalAuth(validateToken TokenValidator) func(http.Handler) http.Handler {
+	auth := Auth(validateToken)
+	return func(next http.Handler) http.Handler {
+		authed := auth(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authed.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GatewayAuth trusts the identity headers only when the gateway's
+// signature over them verifies, so reaching a service port directly is not
+// enough to act as another user.
+func GatewayAuth(identity *IdentityVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userID := r.Header.Get("X-User-ID")
@@ -58,8 +89,79 @@ func GatewayAuth() func(http.Handler) http.Handler {
 				HandleError(w, errors.Unauthorized("Invalid X-User-ID header"))
 				return
 			}
-			ctx := server.ContextWithUserID(r.Context(), userID)
+			id, err := identity.Verify(r)
+			if err != nil {
+				slog.Warn("rejected gateway identity", "path", r.URL.Path, "error", err)
+				HandleError(w, errors.Unauthorized("Invalid gateway identity"))
+				return
+			}
+			ctx := server.ContextWithUserID(r.Context(), id.UserID)
+			ctx = server.ContextWithTokenClaims(ctx, id.JTI, id.IssuedAt)
+			ctx = server.ContextWithRoles(ctx, id.Roles)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// ServiceAuth admits only internal calls signed with the identity keys for
+// a service rather than forwarded for a user, so internal endpoints stay
+// closed to anyone who merely reaches the service port.
+func ServiceAuth(identity *IdentityVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := identity.Verify(r)
+			if err != nil {
+				slog.Warn("rejected service identity", "path", r.URL.Path, "error", err)
+				HandleError(w, errors.Unauthorized("Invalid service identity"))
+				return
+			}
+			if id.UserID != "" || !slices.Contains(id.Roles, RoleService) {
+				HandleError(w, errors.Forbidden("Service identity required"))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(server.ContextWithRoles(r.Context(), id.Roles)))
+		})
+	}
+}
+
+// ParseRoles reads the comma-separated X-User-Roles header the gateway sets
+// from the token's roles claim.
+func ParseRoles(header string) []string {
+	var roles []string
+	for _, role := range strings.Split(header, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// RequireRole lets the request through only when the authenticated user
+// holds at least one of roles. It must run after Auth or GatewayAuth.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, role := range roles {
+				if server.HasRole(r, role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			HandleError(w, errors.Forbidden("Insufficient role"))
+		})
+	}
+}
+
+func OptionalGatewayAuth(identity *IdentityVerifier) func(http.Handler) http.Handler {
+	gatewayAuth := GatewayAuth(identity)
+	return func(next http.Handler) http.Handler {
+		authed := gatewayAuth(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-User-ID") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authed.ServeHTTP(w, r)
+		})
+	}
+}
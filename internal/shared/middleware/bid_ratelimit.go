@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/in-jun/go-structure-example/internal/shared/errors"
+	"github.com/in-jun/go-structure-example/internal/shared/server"
+)
+
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+if redis.call('ZCARD', key) >= limit then
+    local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+    return tonumber(oldest[2]) + window - now
+end
+redis.call('ZADD', key, now, ARGV[4])
+redis.call('PEXPIRE', key, window)
+return 0
+`)
+
+func BidRateLimit(redisClient *redis.Client, limit int, window time.Duration) func(http.Handler) http.Handler {
+	windowMs := window.Milliseconds()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := "bid_ratelimit:" + server.UserID(r) + ":" + r.PathValue("id")
+			now := time.Now().UnixMilli()
+
+			retryAfterMs, err := slidingWindowScript.Run(r.Context(), redisClient, []string{key},
+				now, windowMs, limit, uuid.NewString()).Int64()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if retryAfterMs > 0 {
+				retryAfter := (retryAfterMs + 999) / 1000
+				w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+				server.JSON(w, http.StatusTooManyRequests, errors.BidRateLimitExceeded("Too many bids on this auction, retry later"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
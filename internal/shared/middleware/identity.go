@@ -0,0 +1,241 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const identitySignatureHeader = "X-Identity-Signature"
+
+var (
+	errNoIdentityKeys         = errors.New("at least one identity key is required")
+	errIdentityMissing        = errors.New("missing identity signature")
+	errIdentityMalformed      = errors.New("malformed identity signature")
+	errIdentityExpired        = errors.New("identity signature is outside the replay window")
+	errIdentityReplayed       = errors.New("identity signature was already used")
+	errIdentityUnknownKey     = errors.New("identity signed with an unknown key")
+	errIdentitySignatureWrong = errors.New("identity signature mismatch")
+)
+
+type identityKey struct {
+	id     string
+	secret []byte
+}
+
+// IdentityKeys are the secrets the gateway signs forwarded identities with.
+// The first key signs; all of them verify. To rotate, add the new key last
+// everywhere, then move it first on the gateway, then drop the old one.
+type IdentityKeys struct {
+	keys []identityKey
+}
+
+// ParseIdentityKeys reads a comma-separated list of kid=secret entries.
+func ParseIdentityKeys(spec string) (*IdentityKeys, error) {
+	k := &IdentityKeys{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid identity key entry for %q, want kid=secret", kid)
+		}
+		if k.find(kid) != nil {
+			return nil, fmt.Errorf("duplicate identity key ID %q", kid)
+		}
+		k.keys = append(k.keys, identityKey{id: kid, secret: []byte(secret)})
+	}
+	if len(k.keys) == 0 {
+		return nil, errNoIdentityKeys
+	}
+	return k, nil
+}
+
+func (k *IdentityKeys) find(kid string) *identityKey {
+	for i := range k.keys {
+		if k.keys[i].id == kid {
+			return &k.keys[i]
+		}
+	}
+	return nil
+}
+
+// Identity is what the gateway vouches for on a request it forwards.
+type Identity struct {
+	UserID    string
+	JTI       string
+	IssuedAt  int64
+	Roles     []string
+	RequestID string
+}
+
+type IdentitySigner struct {
+	keys *IdentityKeys
+	now  func() time.Time
+}
+
+func NewIdentitySigner(keys *IdentityKeys) *IdentitySigner {
+	return &IdentitySigner{keys: keys, now: time.Now}
+}
+
+// Sign sets the identity headers on r, replacing whatever the client sent,
+// and signs them together with the method, path, a timestamp and a nonce.
+func (s *IdentitySigner) Sign(r *http.Request, id Identity) {
+	r.Header.Set("X-User-ID", id.UserID)
+	r.Header.Set("X-Token-JTI", id.JTI)
+	r.Header.Set("X-Token-IAT", strconv.FormatInt(id.IssuedAt, 10))
+	r.Header.Set("X-User-Roles", strings.Join(id.Roles, ","))
+	r.Header.Set("X-Request-ID", id.RequestID)
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+
+	key := s.keys.keys[0]
+	unix := strconv.FormatInt(s.now().Unix(), 10)
+	n := hex.EncodeToString(nonce)
+	r.Header.Set(identitySignatureHeader,
+		"t="+unix+",kid="+key.id+",n="+n+",v1="+identityMAC(key.secret, unix, n, r))
+}
+
+type forwardedIdentityKey struct{}
+
+// ContextWithIdentity marks the identity to forward for a request proxied
+// through a Transport.
+func ContextWithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, forwardedIdentityKey{}, id)
+}
+
+// Transport signs each attempt of a request carrying an identity from
+// ContextWithIdentity, so a retrying transport above it sends a fresh nonce
+// instead of one the upstream has already seen. Other requests pass through
+// unsigned.
+func (s *IdentitySigner) Transport(base http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		id, ok := r.Context().Value(forwardedIdentityKey{}).(Identity)
+		if !ok {
+			return base.RoundTrip(r)
+		}
+		r = r.Clone(r.Context())
+		s.Sign(r, id)
+		return base.RoundTrip(r)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// IdentityVerifier checks identity headers signed by an IdentitySigner.
+// Signatures older or newer than the tolerance are refused, and each nonce
+// is accepted once while its signature is within the tolerance; binding the
+// method, path and request ID keeps a request from being replayed against
+// another endpoint. The seen nonces are kept per process, so a captured
+// request can still be replayed once against each other replica of the
+// service until the tolerance runs out.
+type IdentityVerifier struct {
+	keys      *IdentityKeys
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextSweep time.Time
+}
+
+func NewIdentityVerifier(keys *IdentityKeys, tolerance time.Duration) *IdentityVerifier {
+	return &IdentityVerifier{keys: keys, tolerance: tolerance, now: time.Now, seen: make(map[string]time.Time)}
+}
+
+func (v *IdentityVerifier) Verify(r *http.Request) (*Identity, error) {
+	header := r.Header.Get(identitySignatureHeader)
+	if header == "" {
+		return nil, errIdentityMissing
+	}
+	var unix, kid, nonce, sig string
+	for _, part := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "t":
+			unix = value
+		case "kid":
+			kid = value
+		case "n":
+			nonce = value
+		case "v1":
+			sig = value
+		}
+	}
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || kid == "" || nonce == "" || sig == "" {
+		return nil, errIdentityMalformed
+	}
+	now := v.now()
+	signedAt := time.Unix(ts, 0)
+	if age := now.Sub(signedAt); age > v.tolerance || age < -v.tolerance {
+		return nil, errIdentityExpired
+	}
+	key := v.keys.find(kid)
+	if key == nil {
+		return nil, errIdentityUnknownKey
+	}
+	if !hmac.Equal([]byte(sig), []byte(identityMAC(key.secret, unix, nonce, r))) {
+		return nil, errIdentitySignatureWrong
+	}
+	if !v.remember(kid+":"+nonce, signedAt.Add(v.tolerance), now) {
+		return nil, errIdentityReplayed
+	}
+
+	issuedAt, _ := strconv.ParseInt(r.Header.Get("X-Token-IAT"), 10, 64)
+	return &Identity{
+		UserID:    r.Header.Get("X-User-ID"),
+		JTI:       r.Header.Get("X-Token-JTI"),
+		IssuedAt:  issuedAt,
+		Roles:     ParseRoles(r.Header.Get("X-User-Roles")),
+		RequestID: r.Header.Get("X-Request-ID"),
+	}, nil
+}
+
+// remember records a verified nonce until its signature expires and reports
+// false if it was already recorded. Expired nonces are swept at most once
+// per tolerance.
+func (v *IdentityVerifier) remember(nonce string, expires, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if now.After(v.nextSweep) {
+		for n, exp := range v.seen {
+			if now.After(exp) {
+				delete(v.seen, n)
+			}
+		}
+		v.nextSweep = now.Add(v.tolerance)
+	}
+	if _, ok := v.seen[nonce]; ok {
+		return false
+	}
+	v.seen[nonce] = expires
+	return true
+}
+
+func identityMAC(secret []byte, unix, nonce string, r *http.Request) string {
+	mac := hmac.New(sha256.New, secret)
+	for _, field := range []string{
+		unix, nonce, r.Method, r.URL.Path,
+		r.Header.Get("X-User-ID"), r.Header.Get("X-Token-JTI"), r.Header.Get("X-Token-IAT"),
+		r.Header.Get("X-User-Roles"), r.Header.Get("X-Request-ID"),
+	} {
+		mac.Write([]byte(field))
+		mac.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testIdentityUser = "550e8400-e29b-41d4-a716-446655440000"
+
+func newTestIdentity(t *testing.T) (*IdentitySigner, *IdentityVerifier) {
+	t.Helper()
+	keys, err := ParseIdentityKeys("k1=test-secret")
+	if err != nil {
+		t.Fatalf("ParseIdentityKeys() error = %v", err)
+	}
+	return NewIdentitySigner(keys), NewIdentityVerifier(keys, 30*time.Second)
+}
+
+func TestIdentity_SignAndVerify(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	r := httptest.NewRequest("POST", "/api/v1/auctions", nil)
+	r.Header.Set("X-User-ID", "spoofed")
+	signer.Sign(r, Identity{
+		UserID: testIdentityUser, JTI: "jti-1", IssuedAt: 1_700_000_000,
+		Roles: []string{RoleBidder, RoleAdmin}, RequestID: "req-1",
+	})
+
+	id, err := verifier.Verify(r)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if id.UserID != testIdentityUser || id.JTI != "jti-1" || id.IssuedAt != 1_700_000_000 || id.RequestID != "req-1" {
+		t.Errorf("unexpected identity %+v", id)
+	}
+	if len(id.Roles) != 2 || id.Roles[1] != RoleAdmin {
+		t.Errorf("Roles = %v, want [bidder admin]", id.Roles)
+	}
+}
+
+func TestIdentity_Verify_Rejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name    string
+		tamper  func(r *http.Request)
+		at      time.Time
+		wantErr error
+	}{
+		{"unsigned", func(r *http.Request) { r.Header.Del(identitySignatureHeader) }, now, errIdentityMissing},
+		{"malformed", func(r *http.Request) { r.Header.Set(identitySignatureHeader, "v1=abc") }, now, errIdentityMalformed},
+		{"other user", func(r *http.Request) { r.Header.Set("X-User-ID", "6ba7b810-9dad-11d1-80b4-00c04fd430c8") }, now, errIdentitySignatureWrong},
+		{"added role", func(r *http.Request) { r.Header.Set("X-User-Roles", "bidder,admin") }, now, errIdentitySignatureWrong},
+		{"other path", func(r *http.Request) { r.URL.Path = "/api/v1/admin/disputes" }, now, errIdentitySignatureWrong},
+		{"other method", func(r *http.Request) { r.Method = http.MethodDelete }, now, errIdentitySignatureWrong},
+		{"replayed late", func(*http.Request) {}, now.Add(time.Minute), errIdentityExpired},
+		{"from the future", func(*http.Request) {}, now.Add(-time.Minute), errIdentityExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, verifier := newTestIdentity(t)
+			signer.now = func() time.Time { return now }
+			verifier.now = func() time.Time { return tt.at }
+
+			r := httptest.NewRequest("GET", "/api/v1/me/payments", nil)
+			signer.Sign(r, Identity{UserID: testIdentityUser, Roles: []string{RoleBidder}, RequestID: "req-1"})
+			tt.tamper(r)
+
+			if _, err := verifier.Verify(r); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdentity_Verify_RejectsReplay(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	r := httptest.NewRequest("GET", "/api/v1/me/payments", nil)
+	signer.Sign(r, Identity{UserID: testIdentityUser, RequestID: "req-1"})
+
+	if _, err := verifier.Verify(r); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if _, err := verifier.Verify(r); !errors.Is(err, errIdentityReplayed) {
+		t.Errorf("expected errIdentityReplayed, got %v", err)
+	}
+
+	signer.Sign(r, Identity{UserID: testIdentityUser, RequestID: "req-1"})
+	if _, err := verifier.Verify(r); err != nil {
+		t.Errorf("expected a re-signed request to verify, got %v", err)
+	}
+}
+
+func TestIdentity_Verify_ForgetsExpiredNonces(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	signer, verifier := newTestIdentity(t)
+	signer.now = func() time.Time { return now }
+	verifier.now = func() time.Time { return now }
+
+	r := httptest.NewRequest("GET", "/", nil)
+	signer.Sign(r, Identity{UserID: testIdentityUser})
+	if _, err := verifier.Verify(r); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	signer.Sign(r, Identity{UserID: testIdentityUser})
+	if _, err := verifier.Verify(r); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(verifier.seen) != 1 {
+		t.Errorf("expected the expired nonce to be swept, %d remembered", len(verifier.seen))
+	}
+}
+
+func TestIdentitySigner_Transport(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	var calls int
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		id, err := verifier.Verify(r)
+		if calls == 1 {
+			if err != nil || id.UserID != testIdentityUser {
+				t.Errorf("Verify() = %+v, %v", id, err)
+			}
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+		}
+		if err != nil {
+			t.Errorf("expected the retry to be signed afresh, got %v", err)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	retry := NewRetryTransport(signer.Transport(base), 1)
+	retry.BaseDelay = time.Millisecond
+
+	r := httptest.NewRequest("GET", "/api/v1/me/payments", nil)
+	r = r.WithContext(ContextWithIdentity(r.Context(), Identity{UserID: testIdentityUser, RequestID: "req-1"}))
+	resp, err := retry.RoundTrip(r)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("RoundTrip() = %v, %v", resp, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+
+	unsigned := httptest.NewRequest("GET", "/api/v1/auctions", nil)
+	unsigned.Header.Set(identitySignatureHeader, "t=1,kid=k1,n=x,v1=forged")
+	base = func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get(identitySignatureHeader) != "t=1,kid=k1,n=x,v1=forged" {
+			t.Error("a request without a forwarded identity must not be signed")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}
+	if _, err := signer.Transport(base).RoundTrip(unsigned); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIdentity_KeyRotation(t *testing.T) {
+	oldKeys, _ := ParseIdentityKeys("k1=old-secret")
+	rotated, _ := ParseIdentityKeys("k2=new-secret,k1=old-secret")
+	newOnly, _ := ParseIdentityKeys("k2=new-secret")
+
+	r := httptest.NewRequest("GET", "/", nil)
+	NewIdentitySigner(oldKeys).Sign(r, Identity{UserID: testIdentityUser})
+	if _, err := NewIdentityVerifier(rotated, time.Minute).Verify(r); err != nil {
+		t.Errorf("expected the old key to verify during rotation, got %v", err)
+	}
+	if _, err := NewIdentityVerifier(newOnly, time.Minute).Verify(r); !errors.Is(err, errIdentityUnknownKey) {
+		t.Errorf("expected errIdentityUnknownKey once the old key is dropped, got %v", err)
+	}
+}
+
+func TestParseIdentityKeys_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "secret-without-kid", "k1=", "k1=a,k1=b"} {
+		if _, err := ParseIdentityKeys(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestGatewayAuth_Unsigned(t *testing.T) {
+	_, verifier := newTestIdentity(t)
+	handler := GatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not be called")
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-User-ID", testIdentityUser)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestServiceAuth(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	handler := ServiceAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name string
+		sign func(r *http.Request)
+		want int
+	}{
+		{"service", func(r *http.Request) { signer.Sign(r, Identity{Roles: []string{RoleService}, RequestID: "req-1"}) }, http.StatusOK},
+		{"unsigned", func(*http.Request) {}, http.StatusUnauthorized},
+		{"forwarded user", func(r *http.Request) {
+			signer.Sign(r, Identity{UserID: testIdentityUser, Roles: []string{RoleService}, RequestID: "req-1"})
+		}, http.StatusForbidden},
+		{"no service role", func(r *http.Request) { signer.Sign(r, Identity{RequestID: "req-1"}) }, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/internal/v1/users/"+testIdentityUser, nil)
+			tt.sign(r)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -145,6 +145,93 @@ func TestRateLimit(t *testing.T) {
 	}
 }
 
+func TestBidRateLimit(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rc.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /auctions/{id}/bids", BidRateLimit(rc, 2, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})))
+
+	send := func(userID, auctionID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/auctions/"+auctionID+"/bids", nil)
+		r = r.WithContext(server.ContextWithUserID(r.Context(), userID))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := send("user-1", "auction-1"); w.Code != http.StatusCreated {
+			t.Fatalf("request %d: expected 201, got %d", i+1, w.Code)
+		}
+	}
+
+	w := send("user-1", "auction-1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+	var body errors.CustomError
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Code != "BID_RATE_LIMIT_EXCEEDED" {
+		t.Errorf("expected code BID_RATE_LIMIT_EXCEEDED, got %q", body.Code)
+	}
+
+	if w := send("user-1", "auction-2"); w.Code != http.StatusCreated {
+		t.Errorf("other auction: expected 201, got %d", w.Code)
+	}
+	if w := send("user-2", "auction-1"); w.Code != http.StatusCreated {
+		t.Errorf("other user: expected 201, got %d", w.Code)
+	}
+}
+
+func TestBidRateLimit_IdempotentReplaysNotCounted(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rc.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /auctions/{id}/bids", Idempotency(rc)(BidRateLimit(rc, 2, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))))
+
+	send := func(key string) int {
+		r := httptest.NewRequest("POST", "/auctions/auction-1/bids", nil)
+		r.Header.Set("Idempotency-Key", key)
+		r = r.WithContext(server.ContextWithUserID(r.Context(), "user-1"))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := send("key-1"); code != http.StatusCreated {
+			t.Fatalf("replay %d: expected 201, got %d", i+1, code)
+		}
+	}
+	if code := send("key-2"); code != http.StatusCreated {
+		t.Fatalf("second bid: expected 201, got %d", code)
+	}
+	if code := send("key-3"); code != http.StatusTooManyRequests {
+		t.Errorf("third bid: expected 429, got %d", code)
+	}
+}
+
 func TestRecovery(t *testing.T) {
 	handler := Recovery()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		panic("test panic")
@@ -161,7 +248,7 @@ func TestRecovery(t *testing.T) {
 
 func TestAuth_ValidToken(t *testing.T) {
 	validator := TokenValidator(func(ctx context.Context, token string) (*ValidateTokenResult, error) {
-		return &ValidateTokenResult{UserID: "user-123"}, nil
+		return &ValidateTokenResult{UserID: "user-123", Roles: []string{RoleBidder}}, nil
 	})
 
 	handler := Auth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -169,6 +256,9 @@ func TestAuth_ValidToken(t *testing.T) {
 		if uid != "user-123" {
 			t.Errorf("expected user-123, got %q", uid)
 		}
+		if !server.HasRole(r, RoleBidder) {
+			t.Errorf("expected bidder role, got %v", server.Roles(r))
+		}
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -220,7 +310,8 @@ func TestAuth_InvalidFormat(t *testing.T) {
 }
 
 func TestGatewayAuth_Valid(t *testing.T) {
-	handler := GatewayAuth()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	signer, verifier := newTestIdentity(t)
+	handler := GatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		uid := server.UserID(r)
 		if uid != "550e8400-e29b-41d4-a716-446655440000" {
 			t.Errorf("expected valid uuid, got %q", uid)
@@ -229,7 +320,7 @@ func TestGatewayAuth_Valid(t *testing.T) {
 	}))
 
 	r := httptest.NewRequest("GET", "/", nil)
-	r.Header.Set("X-User-ID", "550e8400-e29b-41d4-a716-446655440000")
+	signer.Sign(r, Identity{UserID: "550e8400-e29b-41d4-a716-446655440000"})
 	w := httptest.NewRecorder()
 	handler.ServeHTTP(w, r)
 
@@ -239,7 +330,8 @@ func TestGatewayAuth_Valid(t *testing.T) {
 }
 
 func TestGatewayAuth_Missing(t *testing.T) {
-	handler := GatewayAuth()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, verifier := newTestIdentity(t)
+	handler := GatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("should not be called")
 	}))
 
@@ -252,8 +344,43 @@ func TestGatewayAuth_Missing(t *testing.T) {
 	}
 }
 
+func TestOptionalGatewayAuth_Anonymous(t *testing.T) {
+	_, verifier := newTestIdentity(t)
+	handler := OptionalGatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if uid := server.UserID(r); uid != "" {
+			t.Errorf("expected no user, got %q", uid)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestOptionalGatewayAuth_InvalidUUID(t *testing.T) {
+	_, verifier := newTestIdentity(t)
+	handler := OptionalGatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not be called")
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-User-ID", "not-a-uuid")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
 func TestGatewayAuth_InvalidUUID(t *testing.T) {
-	handler := GatewayAuth()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, verifier := newTestIdentity(t)
+	handler := GatewayAuth(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("should not be called")
 	}))
 
@@ -267,6 +394,52 @@ func TestGatewayAuth_InvalidUUID(t *testing.T) {
 	}
 }
 
+func TestGatewayAuth_Roles(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	handler := GatewayAuth(verifier)(RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !server.HasRole(r, RoleSeller) {
+			t.Errorf("expected seller role, got %v", server.Roles(r))
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	signer.Sign(r, Identity{UserID: "550e8400-e29b-41d4-a716-446655440000", Roles: []string{RoleSeller, RoleAdmin}})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestRequireRole_Forbidden(t *testing.T) {
+	signer, verifier := newTestIdentity(t)
+	tests := []struct {
+		name  string
+		roles string
+	}{
+		{"no roles", ""},
+		{"other roles", "bidder,seller"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := GatewayAuth(verifier)(RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("should not be called")
+			})))
+
+			r := httptest.NewRequest("GET", "/", nil)
+			signer.Sign(r, Identity{UserID: "550e8400-e29b-41d4-a716-446655440000", Roles: ParseRoles(tt.roles)})
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusForbidden {
+				t.Errorf("expected 403, got %d", w.Code)
+			}
+		})
+	}
+}
+
 func TestHandleError_CustomError(t *testing.T) {
 	w := httptest.NewRecorder()
 	HandleError(w, errors.NotFound("not found"))
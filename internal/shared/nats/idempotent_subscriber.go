@@ -42,12 +42,14 @@ func SubscribeIdempotent(
 		msgCtx, span := tracer.Start(msgCtx, "consume:"+subject)
 		defer span.End()
 
+		eventKey := processedEventKey(subject, env.ID)
+
 		err := transactor.WithinTransaction(msgCtx, func(txCtx context.Context) error {
 			db := dbGetter(txCtx)
 
 			var exists bool
 			if err := db.QueryRowContext(txCtx,
-				"SELECT EXISTS(SELECT 1 FROM processed_events WHERE event_id = $1)", env.ID,
+				"SELECT EXISTS(SELECT 1 FROM processed_events WHERE event_id = $1)", eventKey,
 			).Scan(&exists); err != nil {
 				return err
 			}
@@ -61,7 +63,7 @@ func SubscribeIdempotent(
 			}
 
 			_, err := db.ExecContext(txCtx,
-				"INSERT INTO processed_events (event_id) VALUES ($1)", env.ID)
+				"INSERT INTO processed_events (event_id) VALUES ($1)", eventKey)
 			return err
 		})
 
@@ -74,3 +76,10 @@ func SubscribeIdempotent(
 		}
 	})
 }
+
+// processedEventKey scopes an outbox ID by the service that published it,
+// since each service numbers its outbox independently. Subjects are
+// prefixed with their publisher's namespace (auction., bid., payment., ...).
+func processedEventKey(subject, eventID string) string {
+	return serviceFromSubject(subject) + ":" + eventID
+}
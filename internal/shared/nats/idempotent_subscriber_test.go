@@ -0,0 +1,21 @@
+package nats
+
+import "testing"
+
+func TestProcessedEventKey(t *testing.T) {
+	tests := []struct {
+		subject string
+		id      string
+		want    string
+	}{
+		{"payment.completed", "42", "payment:42"},
+		{"payment.failed", "42", "payment:42"},
+		{"auction.closed", "42", "auction:42"},
+		{"user.registered", "42", "user:42"},
+	}
+	for _, tt := range tests {
+		if got := processedEventKey(tt.subject, tt.id); got != tt.want {
+			t.Errorf("processedEventKey(%q, %q) = %q, want %q", tt.subject, tt.id, got, tt.want)
+		}
+	}
+}
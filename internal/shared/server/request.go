@@ -39,6 +39,7 @@ func QueryDefault(r *http.Request, key, def string) string {
 type userIDKey struct{}
 type tokenJTIKey struct{}
 type tokenIssuedAtKey struct{}
+type rolesKey struct{}
 
 func ContextWithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userIDKey{}, userID)
@@ -71,6 +72,26 @@ func TokenIssuedAt(r *http.Request) int64 {
 	return 0
 }
 
+func ContextWithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+func Roles(r *http.Request) []string {
+	if roles, ok := r.Context().Value(rolesKey{}).([]string); ok {
+		return roles
+	}
+	return nil
+}
+
+func HasRole(r *http.Request, role string) bool {
+	for _, have := range Roles(r) {
+		if have == role {
+			return true
+		}
+	}
+	return false
+}
+
 func ClientIP(r *http.Request) string {
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
 		if i := strings.Index(xff, ","); i > 0 {
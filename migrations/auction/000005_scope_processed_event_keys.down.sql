@@ -0,0 +1,3 @@
+UPDATE processed_events
+SET event_id = substr(event_id, length('payment:') + 1)
+WHERE event_id LIKE 'payment:%';
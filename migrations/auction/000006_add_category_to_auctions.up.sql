@@ -0,0 +1 @@
+ALTER TABLE auctions ADD COLUMN category VARCHAR(50) NOT NULL DEFAULT '';
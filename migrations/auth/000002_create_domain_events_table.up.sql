@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS domain_events (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    published BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_domain_events_aggregate ON domain_events(aggregate_type, aggregate_id);
+CREATE INDEX idx_domain_events_type ON domain_events(event_type);
+CREATE INDEX idx_domain_events_unpublished ON domain_events(published) WHERE published = FALSE;
@@ -0,0 +1,3 @@
+-- Everyone may bid and sell; admins are promoted by hand, e.g.
+-- UPDATE users SET roles = array_append(roles, 'admin') WHERE email = '...';
+ALTER TABLE users ADD COLUMN roles TEXT[] NOT NULL DEFAULT ARRAY['bidder', 'seller']::TEXT[];
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bids_created_at;
+DROP TABLE IF EXISTS bid_flags;
+DROP TABLE IF EXISTS bidder_accounts;
+DROP TABLE IF EXISTS auction_sellers;
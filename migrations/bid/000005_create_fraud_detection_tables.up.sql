@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS auction_sellers (
+    auction_id UUID PRIMARY KEY,
+    seller_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auction_sellers_seller_id ON auction_sellers(seller_id);
+
+CREATE TABLE IF NOT EXISTS bidder_accounts (
+    user_id UUID PRIMARY KEY,
+    registered_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS bid_flags (
+    id UUID PRIMARY KEY,
+    auction_id UUID NOT NULL,
+    bidder_id UUID NOT NULL,
+    rule VARCHAR(50) NOT NULL,
+    evidence JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (auction_id, bidder_id, rule)
+);
+
+CREATE INDEX idx_bid_flags_auction_id ON bid_flags(auction_id);
+CREATE INDEX idx_bid_flags_created_at ON bid_flags(created_at DESC);
+CREATE INDEX idx_bids_created_at ON bids(created_at);
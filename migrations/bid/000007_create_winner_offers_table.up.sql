@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS winner_offers (
+    auction_id UUID NOT NULL,
+    attempt INT NOT NULL,
+    bid_id UUID NOT NULL REFERENCES bids(id),
+    bidder_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (auction_id, attempt),
+    UNIQUE (auction_id, bidder_id)
+);
@@ -0,0 +1,3 @@
+UPDATE processed_events
+SET event_id = substr(event_id, length('bid:') + 1)
+WHERE event_id LIKE 'bid:%';
@@ -0,0 +1,14 @@
+-- Processed event keys are scoped by the publishing service. Keys written
+-- as "<subject>:<id>" keep only the subject's namespace; bare IDs predate
+-- scoping and all came from the bid service.
+UPDATE processed_events
+SET event_id = split_part(split_part(event_id, ':', 1), '.', 1) || ':' || split_part(event_id, ':', 2)
+WHERE event_id LIKE '%.%:%';
+
+DELETE FROM processed_events legacy
+WHERE legacy.event_id NOT LIKE '%:%'
+  AND EXISTS (SELECT 1 FROM processed_events WHERE event_id = 'bid:' || legacy.event_id);
+
+UPDATE processed_events
+SET event_id = 'bid:' || event_id
+WHERE event_id NOT LIKE '%:%';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_status_due_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS reminders_sent;
+ALTER TABLE payments DROP COLUMN IF EXISTS due_at;
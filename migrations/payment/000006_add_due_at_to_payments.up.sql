@@ -0,0 +1,5 @@
+ALTER TABLE payments ADD COLUMN due_at TIMESTAMPTZ;
+ALTER TABLE payments ADD COLUMN reminders_sent INT NOT NULL DEFAULT 0;
+UPDATE payments SET due_at = created_at + INTERVAL '48 hours';
+ALTER TABLE payments ALTER COLUMN due_at SET NOT NULL;
+CREATE INDEX idx_payments_status_due_at ON payments(status, due_at);
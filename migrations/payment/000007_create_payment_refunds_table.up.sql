@@ -0,0 +1,14 @@
+ALTER TABLE payments ADD COLUMN refunded_amount BIGINT NOT NULL DEFAULT 0;
+UPDATE payments SET refunded_amount = amount WHERE status = 'refunded';
+
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_refunds_payment_id ON payment_refunds(payment_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS payment_refund_attempts;
+DROP INDEX IF EXISTS idx_payment_refunds_status_next_attempt_at;
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS last_error;
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,13 @@
+ALTER TABLE payment_refunds ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE payment_refunds ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE payment_refunds ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+CREATE INDEX idx_payment_refunds_status_next_attempt_at ON payment_refunds(status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS payment_refund_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    refund_id UUID NOT NULL REFERENCES payment_refunds(id),
+    attempt INT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (refund_id, attempt)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS journal_postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
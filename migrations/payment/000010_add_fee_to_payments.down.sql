@@ -0,0 +1,3 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS fee_category;
+ALTER TABLE payments DROP COLUMN IF EXISTS fee_rule;
+ALTER TABLE payments DROP COLUMN IF EXISTS fee_amount;
@@ -0,0 +1,3 @@
+ALTER TABLE payments ADD COLUMN fee_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN fee_rule VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN fee_category VARCHAR(50) NOT NULL DEFAULT '';
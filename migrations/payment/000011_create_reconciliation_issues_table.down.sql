@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_updated_at;
+DROP TABLE IF EXISTS reconciliation_issues;
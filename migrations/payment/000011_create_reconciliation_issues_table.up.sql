@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reconciliation_issues (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL,
+    payment_id VARCHAR(64) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(100) NOT NULL DEFAULT '',
+    expected_amount BIGINT NOT NULL DEFAULT 0,
+    actual_amount BIGINT NOT NULL DEFAULT 0,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, payment_id, transaction_id)
+);
+
+CREATE INDEX idx_reconciliation_issues_detected_at ON reconciliation_issues(detected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payments_updated_at ON payments(updated_at);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS invoice_counter (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    value BIGINT NOT NULL
+);
+
+INSERT INTO invoice_counter (id, value) VALUES (TRUE, 0) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY,
+    sequence BIGINT NOT NULL UNIQUE,
+    payment_id UUID NOT NULL UNIQUE REFERENCES payments(id),
+    auction_id UUID NOT NULL,
+    buyer_id UUID NOT NULL,
+    buyer_name VARCHAR(255) NOT NULL,
+    buyer_email VARCHAR(255) NOT NULL,
+    seller_id UUID NOT NULL,
+    seller_name VARCHAR(255) NOT NULL,
+    seller_email VARCHAR(255) NOT NULL,
+    lines JSONB NOT NULL,
+    total BIGINT NOT NULL,
+    seller_net BIGINT NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_escrow_release_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS escrow_released_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS escrow_release_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS escrow_hold_seconds;
@@ -0,0 +1,5 @@
+ALTER TABLE payments ADD COLUMN escrow_hold_seconds BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN escrow_release_at TIMESTAMPTZ;
+ALTER TABLE payments ADD COLUMN escrow_released_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payments_escrow_release_at ON payments(escrow_release_at) WHERE status = 'escrow_held';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS disputes (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    buyer_id UUID NOT NULL,
+    seller_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    response TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    resolution VARCHAR(20) NOT NULL DEFAULT '',
+    refund_amount BIGINT NOT NULL DEFAULT 0,
+    respond_by TIMESTAMPTZ NOT NULL,
+    review_by TIMESTAMPTZ,
+    review_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_disputes_payment_active ON disputes(payment_id) WHERE status <> 'resolved';
+CREATE INDEX idx_disputes_seller_active ON disputes(seller_id) WHERE status <> 'resolved';
+CREATE INDEX idx_disputes_created_at ON disputes(created_at);
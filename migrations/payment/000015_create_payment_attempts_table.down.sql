@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payment_attempts;
+ALTER TABLE payments DROP COLUMN IF EXISTS charge_attempts;
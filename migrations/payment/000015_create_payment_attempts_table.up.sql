@@ -0,0 +1,17 @@
+ALTER TABLE payments ADD COLUMN charge_attempts INT NOT NULL DEFAULT 0;
+UPDATE payments SET charge_attempts = 1 WHERE status <> 'pending';
+
+CREATE TABLE IF NOT EXISTS payment_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    attempt INT NOT NULL,
+    operation VARCHAR(20) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request JSONB NOT NULL,
+    response JSONB NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    latency_ms BIGINT NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_attempts_payment_id ON payment_attempts(payment_id, attempted_at);
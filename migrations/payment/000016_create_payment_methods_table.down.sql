@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS payment_method_id;
+DROP TABLE IF EXISTS payment_methods;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    brand VARCHAR(50) NOT NULL,
+    last4 CHAR(4) NOT NULL,
+    exp_month SMALLINT NOT NULL CHECK (exp_month BETWEEN 1 AND 12),
+    exp_year SMALLINT NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    removed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_user_id ON payment_methods(user_id, created_at) WHERE removed_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_methods_one_default ON payment_methods(user_id) WHERE is_default;
+
+ALTER TABLE payments ADD COLUMN payment_method_id VARCHAR(36) NOT NULL DEFAULT '';
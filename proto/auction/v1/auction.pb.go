@@ -71,6 +71,7 @@ type GetAuctionResponse struct {
 	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
 	StartPrice    int64                  `protobuf:"varint,3,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
 	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +134,13 @@ func (x *GetAuctionResponse) GetStatus() string {
 	return ""
 }
 
+func (x *GetAuctionResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 var File_proto_auction_v1_auction_proto protoreflect.FileDescriptor
 
 const file_proto_auction_v1_auction_proto_rawDesc = "" +
@@ -141,13 +149,14 @@ const file_proto_auction_v1_auction_proto_rawDesc = "" +
 	"auction.v1\"2\n" +
 	"\x11GetAuctionRequest\x12\x1d\n" +
 	"\n" +
-	"auction_id\x18\x01 \x01(\tR\tauctionId\"z\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\"\x96\x01\n" +
 	"\x12GetAuctionResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x1f\n" +
 	"\vstart_price\x18\x03 \x01(\x03R\n" +
 	"startPrice\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status2]\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory2]\n" +
 	"\x0eAuctionService\x12K\n" +
 	"\n" +
 	"GetAuction\x12\x1d.auction.v1.GetAuctionRequest\x1a\x1e.auction.v1.GetAuctionResponseBCZAgithub.com/in-jun/go-structure-example/proto/auction/v1;auctionv1b\x06proto3"